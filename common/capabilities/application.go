@@ -19,6 +19,9 @@ const (
 	// ApplicationV1_2 is the capabilties string for standard new non-backwards compatible fabric v1.2 application capabilities.
 	ApplicationV1_2 = "V1_2"
 
+	// ApplicationV1_3 is the capabilties string for standard new non-backwards compatible fabric v1.3 application capabilities.
+	ApplicationV1_3 = "V1_3"
+
 	// ApplicationPvtDataExperimental is the capabilties string for private data using the experimental feature of collections/sideDB.
 	ApplicationPvtDataExperimental = "V1_1_PVTDATA_EXPERIMENTAL"
 
@@ -37,6 +40,7 @@ type ApplicationProvider struct {
 	*registry
 	v11                          bool
 	v12                          bool
+	v13                          bool
 	v11PvtDataExperimental       bool
 	v11ResourcesTreeExperimental bool
 	v12LifecycleExperimental     bool
@@ -48,6 +52,7 @@ func NewApplicationProvider(capabilities map[string]*cb.Capability) *Application
 	ap.registry = newRegistry(ap, capabilities)
 	_, ap.v11 = capabilities[ApplicationV1_1]
 	_, ap.v12 = capabilities[ApplicationV1_2]
+	_, ap.v13 = capabilities[ApplicationV1_3]
 	_, ap.v11PvtDataExperimental = capabilities[ApplicationPvtDataExperimental]
 	_, ap.v11ResourcesTreeExperimental = capabilities[ApplicationResourcesTreeExperimental]
 	_, ap.v12LifecycleExperimental = capabilities[ApplicationChaincodeLifecycleExperimental]
@@ -67,7 +72,7 @@ func (ap *ApplicationProvider) ResourcesTree() bool {
 // ForbidDuplicateTXIdInBlock specifies whether two transactions with the same TXId are permitted
 // in the same block or whether we mark the second one as TxValidationCode_DUPLICATE_TXID
 func (ap *ApplicationProvider) ForbidDuplicateTXIdInBlock() bool {
-	return ap.v11 || ap.v12
+	return ap.v11 || ap.v12 || ap.v13
 }
 
 // PrivateChannelData returns true if support for private channel data (a.k.a. collections) is enabled.
@@ -78,7 +83,20 @@ func (ap *ApplicationProvider) PrivateChannelData() bool {
 // V1_1Validation returns true is this channel is configured to perform stricter validation
 // of transactions (as introduced in v1.1).
 func (ap *ApplicationProvider) V1_1Validation() bool {
-	return ap.v11 || ap.v12
+	return ap.v11 || ap.v12 || ap.v13
+}
+
+// V1_3Validation returns true if this channel is configured to perform stricter validation
+// of transactions (as introduced in v1.3), such as rejecting collection configurations
+// with duplicate collection names or names reserved for implicit collections.
+func (ap *ApplicationProvider) V1_3Validation() bool {
+	return ap.v13
+}
+
+// CollectionEndorsementPolicies returns true if the endorsement policies of collections are evaluated
+// against the endorsements of transactions writing private data to them.
+func (ap *ApplicationProvider) CollectionEndorsementPolicies() bool {
+	return ap.v13
 }
 
 // MetadataLifecycle indicates whether the peer should use the deprecated and problematic
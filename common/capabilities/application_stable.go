@@ -16,6 +16,8 @@ func (ap *ApplicationProvider) HasCapability(capability string) bool {
 		return true
 	case ApplicationV1_2:
 		return true
+	case ApplicationV1_3:
+		return true
 	default:
 		return false
 	}
@@ -37,6 +37,17 @@ func TestApplicationV12(t *testing.T) {
 	assert.True(t, op.V1_1Validation())
 }
 
+func TestApplicationV13(t *testing.T) {
+	op := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.ForbidDuplicateTXIdInBlock())
+	assert.True(t, op.V1_1Validation())
+	assert.True(t, op.V1_3Validation())
+	assert.True(t, op.CollectionEndorsementPolicies())
+}
+
 func TestApplicationPvtDataExperimental(t *testing.T) {
 	op := NewApplicationProvider(map[string]*cb.Capability{
 		ApplicationPvtDataExperimental: {},
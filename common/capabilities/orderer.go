@@ -15,12 +15,17 @@ const (
 
 	// OrdererV1_1 is the capabilties string for standard new non-backwards compatible fabric v1.1 orderer capabilities.
 	OrdererV1_1 = "V1_1"
+
+	// OrdererV1_2 is the capabilties string for standard new non-backwards compatible fabric v1.2 orderer capabilities,
+	// it implies OrdererV1_1.
+	OrdererV1_2 = "V1_2"
 )
 
 // OrdererProvider provides capabilities information for orderer level config.
 type OrdererProvider struct {
 	*registry
 	v11BugFixes bool
+	v12         bool
 }
 
 // NewOrdererProvider creates an orderer capabilities provider.
@@ -28,6 +33,7 @@ func NewOrdererProvider(capabilities map[string]*cb.Capability) *OrdererProvider
 	cp := &OrdererProvider{}
 	cp.registry = newRegistry(cp, capabilities)
 	_, cp.v11BugFixes = capabilities[OrdererV1_1]
+	_, cp.v12 = capabilities[OrdererV1_2]
 	return cp
 }
 
@@ -42,6 +48,8 @@ func (cp *OrdererProvider) HasCapability(capability string) bool {
 	// Add new capability names here
 	case OrdererV1_1:
 		return true
+	case OrdererV1_2:
+		return true
 	default:
 		return false
 	}
@@ -50,17 +58,29 @@ func (cp *OrdererProvider) HasCapability(capability string) bool {
 // PredictableChannelTemplate specifies whether the v1.0 undesirable behavior of setting the /Channel
 // group's mod_policy to "" and copying versions from the channel config should be fixed or not.
 func (cp *OrdererProvider) PredictableChannelTemplate() bool {
-	return cp.v11BugFixes
+	return cp.v11BugFixes || cp.v12
 }
 
 // Resubmission specifies whether the v1.0 non-deterministic commitment of tx should be fixed by re-submitting
 // the re-validated tx.
 func (cp *OrdererProvider) Resubmission() bool {
-	return cp.v11BugFixes
+	return cp.v11BugFixes || cp.v12
 }
 
 // ExpirationCheck specifies whether the orderer checks for identity expiration checks
 // when validating messages
 func (cp *OrdererProvider) ExpirationCheck() bool {
-	return cp.v11BugFixes
+	return cp.v11BugFixes || cp.v12
+}
+
+// ConsensusTypeMigration specifies whether the orderer permits putting a channel into maintenance
+// mode and changing its consensus type while in that mode.
+func (cp *OrdererProvider) ConsensusTypeMigration() bool {
+	return cp.v12
+}
+
+// BatchingStrategies specifies whether the orderer permits configuring the strategy
+// the block cutter uses to group ordered messages into batches.
+func (cp *OrdererProvider) BatchingStrategies() bool {
+	return cp.v12
 }
@@ -26,4 +26,18 @@ func TestOrdererV11(t *testing.T) {
 	})
 	assert.NoError(t, op.Supported())
 	assert.True(t, op.PredictableChannelTemplate())
+	assert.False(t, op.ConsensusTypeMigration())
+	assert.False(t, op.BatchingStrategies())
+}
+
+func TestOrdererV12(t *testing.T) {
+	op := NewOrdererProvider(map[string]*cb.Capability{
+		OrdererV1_2: {},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.PredictableChannelTemplate())
+	assert.True(t, op.Resubmission())
+	assert.True(t, op.ExpirationCheck())
+	assert.True(t, op.ConsensusTypeMigration())
+	assert.True(t, op.BatchingStrategies())
 }
@@ -80,12 +80,22 @@ type Orderer interface {
 	// ConsensusType returns the configured consensus type
 	ConsensusType() string
 
+	// ConsensusMetadata returns the metadata associated with the consensus type.
+	ConsensusMetadata() []byte
+
+	// ConsensusState returns the consensus-type state, which is either normal
+	// operation or maintenance mode, used for consensus-type migration.
+	ConsensusState() ab.ConsensusType_State
+
 	// BatchSize returns the maximum number of messages to include in a block
 	BatchSize() *ab.BatchSize
 
 	// BatchTimeout returns the amount of time to wait before creating a batch
 	BatchTimeout() time.Duration
 
+	// BatchingStrategy returns the strategy used to group ordered messages into batches
+	BatchingStrategy() *ab.BatchingStrategy
+
 	// MaxChannelsCount returns the maximum count of channels to allow for an ordering network
 	MaxChannelsCount() uint64
 
@@ -130,6 +140,14 @@ type ApplicationCapabilities interface {
 	// of transactions (as introduced in v1.1).
 	V1_1Validation() bool
 
+	// V1_3Validation returns true if this channel is configured to perform stricter validation
+	// of transactions (as introduced in v1.3).
+	V1_3Validation() bool
+
+	// CollectionEndorsementPolicies returns true if the endorsement policies of collections are evaluated
+	// against the endorsements of transactions writing private data to them.
+	CollectionEndorsementPolicies() bool
+
 	// MetadataLifecycle indicates whether the peer should use the deprecated and problematic
 	// v1.0/v1.1 lifecycle, or whether it should use the newer per channel peer local chaincode
 	// metadata package approach planned for release with Fabric v1.2
@@ -152,6 +170,14 @@ type OrdererCapabilities interface {
 	// ExpirationCheck specifies whether the orderer checks for identity expiration checks
 	// when validating messages
 	ExpirationCheck() bool
+
+	// ConsensusTypeMigration specifies whether the orderer permits putting a channel into maintenance
+	// mode and changing its consensus type while in that mode.
+	ConsensusTypeMigration() bool
+
+	// BatchingStrategies specifies whether the orderer permits configuring the strategy
+	// the block cutter uses to group ordered messages into batches.
+	BatchingStrategies() bool
 }
 
 // Resources is the common set of config resources for all channels
@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
 
 	"github.com/pkg/errors"
@@ -86,7 +87,7 @@ func (b *Bundle) ValidateNew(nb Resources) error {
 			return errors.New("Current config has orderer section, but new config does not")
 		}
 
-		if oc.ConsensusType() != noc.ConsensusType() {
+		if oc.ConsensusType() != noc.ConsensusType() && !consensusTypeMigration(oc, noc) {
 			return errors.Errorf("Attempted to change consensus type from %s to %s", oc.ConsensusType(), noc.ConsensusType())
 		}
 
@@ -239,3 +240,12 @@ func preValidate(config *cb.Config) error {
 
 	return nil
 }
+
+// consensusTypeMigration returns whether the consensus type may be changed from the current to the new
+// orderer config, which requires the channel to be in maintenance mode, and to remain in it, with the
+// consensus-type migration capability enabled.
+func consensusTypeMigration(oc, noc Orderer) bool {
+	return oc.ConsensusState() == ab.ConsensusType_STATE_MAINTENANCE &&
+		noc.ConsensusState() == ab.ConsensusType_STATE_MAINTENANCE &&
+		oc.Capabilities().ConsensusTypeMigration()
+}
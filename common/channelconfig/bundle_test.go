@@ -9,6 +9,7 @@ package channelconfig
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/capabilities"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 
@@ -94,6 +95,45 @@ func TestValidateNew(t *testing.T) {
 		assert.Regexp(t, "Attempted to change consensus type from", err.Error())
 	})
 
+	t.Run("ConsensusTypeMigration", func(t *testing.T) {
+		bundle := func(consensusType string, state ab.ConsensusType_State, capabilities map[string]*cb.Capability) *Bundle {
+			return &Bundle{
+				channelConfig: &ChannelConfig{
+					ordererConfig: &OrdererConfig{
+						protos: &OrdererProtos{
+							ConsensusType: &ab.ConsensusType{
+								Type:  consensusType,
+								State: state,
+							},
+							Capabilities: &cb.Capabilities{Capabilities: capabilities},
+						},
+					},
+				},
+			}
+		}
+		v12 := map[string]*cb.Capability{capabilities.OrdererV1_2: {}}
+		v11 := map[string]*cb.Capability{capabilities.OrdererV1_1: {}}
+		maintenance := ab.ConsensusType_STATE_MAINTENANCE
+		normal := ab.ConsensusType_STATE_NORMAL
+
+		err := bundle("type1", maintenance, v12).ValidateNew(bundle("type2", maintenance, v12))
+		assert.NoError(t, err)
+
+		for _, test := range []struct {
+			name string
+			cb   *Bundle
+			nb   *Bundle
+		}{
+			{"NoCapability", bundle("type1", maintenance, v11), bundle("type2", maintenance, v11)},
+			{"NotInMaintenance", bundle("type1", normal, v12), bundle("type2", maintenance, v12)},
+			{"ExitingMaintenance", bundle("type1", maintenance, v12), bundle("type2", normal, v12)},
+		} {
+			err := test.cb.ValidateNew(test.nb)
+			assert.Error(t, err, test.name)
+			assert.Regexp(t, "Attempted to change consensus type from type1 to type2", err.Error())
+		}
+	})
+
 	t.Run("OrdererOrgMSPIDChange", func(t *testing.T) {
 		cb := &Bundle{
 			channelConfig: &ChannelConfig{
@@ -35,6 +35,9 @@ const (
 	// BatchTimeoutKey is the cb.ConfigItem type key name for the BatchTimeout message
 	BatchTimeoutKey = "BatchTimeout"
 
+	// BatchingStrategyKey is the cb.ConfigItem type key name for the BatchingStrategy message
+	BatchingStrategyKey = "BatchingStrategy"
+
 	// ChannelRestrictions is the key name for the ChannelRestrictions message
 	ChannelRestrictionsKey = "ChannelRestrictions"
 
@@ -47,6 +50,7 @@ type OrdererProtos struct {
 	ConsensusType       *ab.ConsensusType
 	BatchSize           *ab.BatchSize
 	BatchTimeout        *ab.BatchTimeout
+	BatchingStrategy    *ab.BatchingStrategy
 	KafkaBrokers        *ab.KafkaBrokers
 	ChannelRestrictions *ab.ChannelRestrictions
 	Capabilities        *cb.Capabilities
@@ -89,6 +93,16 @@ func (oc *OrdererConfig) ConsensusType() string {
 	return oc.protos.ConsensusType.Type
 }
 
+// ConsensusMetadata returns the metadata associated with the consensus type.
+func (oc *OrdererConfig) ConsensusMetadata() []byte {
+	return oc.protos.ConsensusType.Metadata
+}
+
+// ConsensusState returns the consensus-type state.
+func (oc *OrdererConfig) ConsensusState() ab.ConsensusType_State {
+	return oc.protos.ConsensusType.State
+}
+
 // BatchSize returns the maximum number of messages to include in a block
 func (oc *OrdererConfig) BatchSize() *ab.BatchSize {
 	return oc.protos.BatchSize
@@ -99,6 +113,11 @@ func (oc *OrdererConfig) BatchTimeout() time.Duration {
 	return oc.batchTimeout
 }
 
+// BatchingStrategy returns the strategy used to group ordered messages into batches
+func (oc *OrdererConfig) BatchingStrategy() *ab.BatchingStrategy {
+	return oc.protos.BatchingStrategy
+}
+
 // KafkaBrokers returns the addresses (IP:port notation) of a set of "bootstrap"
 // Kafka brokers, i.e. this is not necessarily the entire set of Kafka brokers
 // used for ordering
@@ -125,6 +144,7 @@ func (oc *OrdererConfig) Validate() error {
 	for _, validator := range []func() error{
 		oc.validateBatchSize,
 		oc.validateBatchTimeout,
+		oc.validateBatchingStrategy,
 		oc.validateKafkaBrokers,
 	} {
 		if err := validator(); err != nil {
@@ -163,6 +183,30 @@ func (oc *OrdererConfig) validateBatchTimeout() error {
 	return nil
 }
 
+func (oc *OrdererConfig) validateBatchingStrategy() error {
+	strategy := oc.protos.BatchingStrategy
+	if strategy.Type == ab.BatchingStrategy_DEFAULT {
+		return nil
+	}
+	if _, ok := ab.BatchingStrategy_Type_name[int32(strategy.Type)]; !ok {
+		return fmt.Errorf("Attempted to set the batching strategy to an unknown type: %d", strategy.Type)
+	}
+	if !oc.Capabilities().BatchingStrategies() {
+		return fmt.Errorf("Attempted to set the batching strategy to %s without the required orderer capability", strategy.Type)
+	}
+	if strategy.MinBatchTimeout == "" {
+		return nil
+	}
+	minBatchTimeout, err := time.ParseDuration(strategy.MinBatchTimeout)
+	if err != nil {
+		return fmt.Errorf("Attempted to set the min batch timeout to a invalid value: %s", err)
+	}
+	if minBatchTimeout <= 0 || minBatchTimeout > oc.batchTimeout {
+		return fmt.Errorf("Attempted to set the min batch timeout (%s) outside of the range (0, %s]", minBatchTimeout, oc.batchTimeout)
+	}
+	return nil
+}
+
 func (oc *OrdererConfig) validateKafkaBrokers() error {
 	for _, broker := range oc.protos.KafkaBrokers.Brokers {
 		if !brokerEntrySeemsValid(broker) {
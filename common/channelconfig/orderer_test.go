@@ -8,7 +8,10 @@ package channelconfig
 
 import (
 	"testing"
+	"time"
 
+	"github.com/hyperledger/fabric/common/capabilities"
+	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 
 	logging "github.com/op/go-logging"
@@ -48,6 +51,37 @@ func TestBatchTimeout(t *testing.T) {
 	assert.Error(t, oc.validateBatchTimeout(), "Zero batch timeout")
 }
 
+func TestBatchingStrategy(t *testing.T) {
+	v12 := &cb.Capabilities{Capabilities: map[string]*cb.Capability{capabilities.OrdererV1_2: {}}}
+	newConfig := func(strategy *ab.BatchingStrategy, caps *cb.Capabilities) *OrdererConfig {
+		return &OrdererConfig{
+			protos:       &OrdererProtos{BatchingStrategy: strategy, Capabilities: caps},
+			batchTimeout: time.Second,
+		}
+	}
+
+	oc := newConfig(&ab.BatchingStrategy{}, &cb.Capabilities{})
+	assert.NoError(t, oc.validateBatchingStrategy(), "Default strategy does not require the capability")
+
+	oc = newConfig(&ab.BatchingStrategy{Type: ab.BatchingStrategy_TYPE_ISOLATION}, v12)
+	assert.NoError(t, oc.validateBatchingStrategy(), "Valid batching strategy")
+
+	oc = newConfig(&ab.BatchingStrategy{Type: ab.BatchingStrategy_ADAPTIVE_TIMEOUT, MinBatchTimeout: "100ms"}, v12)
+	assert.NoError(t, oc.validateBatchingStrategy(), "Valid min batch timeout")
+
+	oc = newConfig(&ab.BatchingStrategy{Type: ab.BatchingStrategy_TYPE_ISOLATION}, &cb.Capabilities{})
+	assert.Error(t, oc.validateBatchingStrategy(), "Missing capability")
+
+	oc = newConfig(&ab.BatchingStrategy{Type: ab.BatchingStrategy_Type(42)}, v12)
+	assert.Error(t, oc.validateBatchingStrategy(), "Unknown strategy")
+
+	oc = newConfig(&ab.BatchingStrategy{Type: ab.BatchingStrategy_ADAPTIVE_TIMEOUT, MinBatchTimeout: "foo"}, v12)
+	assert.Error(t, oc.validateBatchingStrategy(), "Unparseable min batch timeout")
+
+	oc = newConfig(&ab.BatchingStrategy{Type: ab.BatchingStrategy_ADAPTIVE_TIMEOUT, MinBatchTimeout: "2s"}, v12)
+	assert.Error(t, oc.validateBatchingStrategy(), "Min batch timeout larger than the batch timeout")
+}
+
 func TestKafkaBrokers(t *testing.T) {
 	oc := &OrdererConfig{protos: &OrdererProtos{KafkaBrokers: &ab.KafkaBrokers{Brokers: []string{"127.0.0.1:9092", "foo.bar:9092"}}}}
 	assert.NoError(t, oc.validateKafkaBrokers(), "Valid kafka brokers")
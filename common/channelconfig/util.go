@@ -137,6 +137,18 @@ func BatchTimeoutValue(timeout string) *StandardConfigValue {
 	}
 }
 
+// BatchingStrategyValue returns the config definition for the orderer batching strategy.
+// It is a value for the /Channel/Orderer group.
+func BatchingStrategyValue(strategyType ab.BatchingStrategy_Type, minBatchTimeout string) *StandardConfigValue {
+	return &StandardConfigValue{
+		key: BatchingStrategyKey,
+		value: &ab.BatchingStrategy{
+			Type:            strategyType,
+			MinBatchTimeout: minBatchTimeout,
+		},
+	}
+}
+
 // ChannelRestrictionsValue returns the config definition for the orderer channel restrictions.
 // It is a value for the /Channel/Orderer group.
 func ChannelRestrictionsValue(maxChannelCount uint64) *StandardConfigValue {
@@ -18,6 +18,7 @@ package blkstorage
 
 import (
 	"errors"
+	"time"
 
 	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/protos/common"
@@ -35,6 +36,7 @@ const (
 	IndexableAttrBlockNumTranNum  = IndexableAttr("BlockNumTranNum")
 	IndexableAttrBlockTxID        = IndexableAttr("BlockTxID")
 	IndexableAttrTxValidationCode = IndexableAttr("TxValidationCode")
+	IndexableAttrTxTime           = IndexableAttr("TxTime")
 )
 
 // IndexConfig - a configuration that includes a list of attributes that should be indexed
@@ -42,11 +44,23 @@ type IndexConfig struct {
 	AttrsToIndex []IndexableAttr
 }
 
+// Contains returns true iff the supplied parameter is present in the IndexConfig.AttrsToIndex
+func (c *IndexConfig) Contains(indexableAttr IndexableAttr) bool {
+	for _, a := range c.AttrsToIndex {
+		if a == indexableAttr {
+			return true
+		}
+	}
+	return false
+}
+
 var (
 	// ErrNotFoundInIndex is used to indicate missing entry in the index
 	ErrNotFoundInIndex = errors.New("Entry not found in index")
 	// ErrAttrNotIndexed is used to indicate that an attribute is not indexed
 	ErrAttrNotIndexed = errors.New("Attribute not indexed")
+	// ErrBlockArchived is used to indicate that a block has been moved out of the ledger to an archive
+	ErrBlockArchived = errors.New("Block has been archived")
 )
 
 // BlockStoreProvider provides an handle to a BlockStore
@@ -71,5 +85,9 @@ type BlockStore interface {
 	RetrieveTxByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error)
 	RetrieveBlockByTxID(txID string) (*common.Block, error)
 	RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	// RetrieveTxsByTime returns a page of the transactions whose timestamp is in [from, to), in the order
+	// of their timestamps, restricted to the transactions created by the identity of hash creatorHash
+	// if creatorHash is not nil. A zero from or to leaves the range unbounded on that side.
+	RetrieveTxsByTime(creatorHash []byte, from, to time.Time, bookmark string, limit int) (*peer.TxQueryResponse, error)
 	Shutdown()
 }
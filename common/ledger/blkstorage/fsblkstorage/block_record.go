@@ -0,0 +1,184 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+)
+
+// A block is stored in a block file as a record. The records written by older versions of the block
+// store consist of the varint encoded length of the serialized block followed by the serialized block.
+// As a serialized block is never empty, a record starting with a zero length is a framed record, which
+// is laid out as follows
+//
+//	0x00 | varint length of the stored bytes | encoding (1 byte) | checksum (4 bytes) | stored bytes
+//
+// The stored bytes are the serialized block encoded as per the encoding, and the checksum is the
+// CRC-32C (Castagnoli) of the encoding byte followed by the stored bytes. Blocks are stored as framed
+// records only if they are compressed or checksums are enabled, so that the block files stay readable
+// by older versions of the block store otherwise. The checksum of a block stored as a legacy record is
+// computed alike, with no encoding, and recorded only in the block index
+const (
+	framedRecordMarker = 0x00
+	checksumLen        = 4
+	// maxRecordHeaderLen is the length of the longest header that precedes the stored bytes of a record
+	maxRecordHeaderLen = 1 + binary.MaxVarintLen64 + 1 + checksumLen
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// blockEncoding identifies how a serialized block is encoded in a block file
+type blockEncoding byte
+
+const (
+	blockEncodingNone blockEncoding = iota
+	blockEncodingSnappy
+)
+
+var blockEncodingNames = map[blockEncoding]string{
+	blockEncodingNone:   "none",
+	blockEncodingSnappy: "snappy",
+}
+
+func (e blockEncoding) String() string {
+	if name, ok := blockEncodingNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", byte(e))
+}
+
+// blockEncodingByName returns the encoding with the given name, an empty name standing for no compression
+func blockEncodingByName(name string) (blockEncoding, error) {
+	if name == "" {
+		return blockEncodingNone, nil
+	}
+	for e, n := range blockEncodingNames {
+		if strings.EqualFold(n, name) {
+			return e, nil
+		}
+	}
+	return blockEncodingNone, fmt.Errorf("unknown block compression [%s], supported values are none and snappy", name)
+}
+
+func (e blockEncoding) encode(serializedBlock []byte) []byte {
+	switch e {
+	case blockEncodingSnappy:
+		return snappy.Encode(nil, serializedBlock)
+	default:
+		return serializedBlock
+	}
+}
+
+func (e blockEncoding) decode(storedBytes []byte) ([]byte, error) {
+	switch e {
+	case blockEncodingNone:
+		return storedBytes, nil
+	case blockEncodingSnappy:
+		serializedBlock, err := snappy.Decode(nil, storedBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error while decompressing block: %s", err)
+		}
+		return serializedBlock, nil
+	default:
+		return nil, fmt.Errorf("unknown block encoding [%d]", byte(e))
+	}
+}
+
+// recordChecksum computes the checksum of a framed record
+func recordChecksum(encoding blockEncoding, storedBytes []byte) []byte {
+	crc := crc32.Update(crc32.Checksum([]byte{byte(encoding)}, crcTable), crcTable, storedBytes)
+	checksum := make([]byte, checksumLen)
+	binary.BigEndian.PutUint32(checksum, crc)
+	return checksum
+}
+
+// ChecksumMismatchError is returned when the bytes of a block read from a block file
+// do not match the checksum recorded along with them
+type ChecksumMismatchError struct {
+	FileNum  int
+	Offset   int64
+	Expected []byte
+	Actual   []byte
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("checksum mismatch for the block stored at offset [%d] of block file [%d]: expected [%x], computed [%x]",
+		e.Offset, e.FileNum, e.Expected, e.Actual)
+}
+
+// encodedBlock is a serialized block encoded for being appended to a block file.
+// The checksum is part of the header unless the block is stored as a legacy record
+type encodedBlock struct {
+	header      []byte
+	storedBytes []byte
+	encoding    blockEncoding
+	checksum    []byte
+}
+
+// encodeBlock encodes a serialized block as a framed record if it is compressed or checksums are enabled,
+// and as a legacy record otherwise
+func encodeBlock(serializedBlock []byte, encoding blockEncoding, checksums bool) *encodedBlock {
+	if encoding == blockEncodingNone && !checksums {
+		checksum := recordChecksum(blockEncodingNone, serializedBlock)
+		return &encodedBlock{proto.EncodeVarint(uint64(len(serializedBlock))), serializedBlock, blockEncodingNone, checksum}
+	}
+	storedBytes := encoding.encode(serializedBlock)
+	checksum := recordChecksum(encoding, storedBytes)
+	header := append([]byte{framedRecordMarker}, proto.EncodeVarint(uint64(len(storedBytes)))...)
+	header = append(header, byte(encoding))
+	header = append(header, checksum...)
+	return &encodedBlock{header, storedBytes, encoding, checksum}
+}
+
+func (b *encodedBlock) len() int {
+	return len(b.header) + len(b.storedBytes)
+}
+
+// recordHeader is the header of a record read from a block file
+type recordHeader struct {
+	// headerLen is the number of bytes preceding the stored bytes
+	headerLen int
+	// storedLen is the number of stored bytes
+	storedLen int
+	// framed tells whether the record is a framed record, in which case the encoding and checksum are set
+	framed   bool
+	encoding blockEncoding
+	checksum []byte
+}
+
+// decodeRecordHeader decodes the header of a record from its first bytes. It returns
+// a nil header if the given bytes are not enough for decoding the header
+func decodeRecordHeader(b []byte) *recordHeader {
+	if len(b) == 0 {
+		return nil
+	}
+	if b[0] != framedRecordMarker {
+		length, n := proto.DecodeVarint(b)
+		if n == 0 {
+			return nil
+		}
+		return &recordHeader{headerLen: n, storedLen: int(length), encoding: blockEncodingNone}
+	}
+	length, n := proto.DecodeVarint(b[1:])
+	headerLen := 1 + n + 1 + checksumLen
+	if n == 0 || len(b) < headerLen {
+		return nil
+	}
+	return &recordHeader{
+		headerLen: headerLen,
+		storedLen: int(length),
+		framed:    true,
+		encoding:  blockEncoding(b[1+n]),
+		checksum:  append([]byte(nil), b[2+n:headerLen]...),
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockEncodingByName(t *testing.T) {
+	for name, expected := range map[string]blockEncoding{"": blockEncodingNone, "none": blockEncodingNone, "snappy": blockEncodingSnappy, "SNAPPY": blockEncodingSnappy} {
+		encoding, err := blockEncodingByName(name)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, encoding)
+	}
+	_, err := blockEncodingByName("lz4")
+	assert.EqualError(t, err, "unknown block compression [lz4], supported values are none and snappy")
+}
+
+func TestEncodeBlock(t *testing.T) {
+	block := testutil.ConstructTestBlock(t, 1, 10, 100)
+	serializedBlock, _, err := serializeBlock(block)
+	assert.NoError(t, err)
+
+	for _, encoding := range []blockEncoding{blockEncodingNone, blockEncodingSnappy} {
+		record := encodeBlock(serializedBlock, encoding, true)
+		recordBytes := append(append([]byte{}, record.header...), record.storedBytes...)
+		header := decodeRecordHeader(recordBytes)
+		assert.Equal(t, &recordHeader{
+			headerLen: len(record.header),
+			storedLen: len(record.storedBytes),
+			framed:    true,
+			encoding:  encoding,
+			checksum:  record.checksum,
+		}, header)
+		assert.Equal(t, recordChecksum(encoding, record.storedBytes), header.checksum)
+		decoded, err := header.encoding.decode(recordBytes[header.headerLen:])
+		assert.NoError(t, err)
+		assert.Equal(t, serializedBlock, decoded)
+		// a partial header is not decoded
+		assert.Nil(t, decodeRecordHeader(record.header[:len(record.header)-1]))
+	}
+
+	// a compressed block is stored as a framed record even if checksums are disabled
+	assert.NotNil(t, encodeBlock(serializedBlock, blockEncodingSnappy, false).checksum)
+
+	// the blocks written by older versions, and the uncompressed blocks when checksums are disabled,
+	// are stored without a record header, their checksum being recorded in the block index only
+	legacyRecord := append(proto.EncodeVarint(uint64(len(serializedBlock))), serializedBlock...)
+	record := encodeBlock(serializedBlock, blockEncodingNone, false)
+	assert.Equal(t, legacyRecord, append(append([]byte{}, record.header...), record.storedBytes...))
+	assert.Equal(t, recordChecksum(blockEncodingNone, serializedBlock), record.checksum)
+	header := decodeRecordHeader(legacyRecord)
+	assert.Equal(t, &recordHeader{headerLen: len(legacyRecord) - len(serializedBlock), storedLen: len(serializedBlock)}, header)
+
+	_, err = blockEncoding(10).decode(serializedBlock)
+	assert.EqualError(t, err, "unknown block encoding [10]")
+	_, err = blockEncodingSnappy.decode(serializedBlock)
+	assert.Error(t, err)
+}
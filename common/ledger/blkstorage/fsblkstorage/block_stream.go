@@ -18,12 +18,11 @@ package fsblkstorage
 
 import (
 	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"os"
-
-	"github.com/golang/protobuf/proto"
 )
 
 // ErrUnexpectedEndOfBlockfile error used to indicate an unexpected end of a file segment
@@ -44,7 +43,7 @@ type blockfileStream struct {
 // it starts from a given file offset and continues with the next
 // file segment until the end of the last segment (`endFileNum`)
 type blockStream struct {
-	rootDir           string
+	openBlockfile     blockfileOpener
 	currentFileNum    int
 	endFileNum        int
 	currentFileStream *blockfileStream
@@ -52,30 +51,47 @@ type blockStream struct {
 
 // blockPlacementInfo captures the information related
 // to block's placement in the file.
+// The checksum is nil for the blocks stored as legacy records, which carry no checksum
 type blockPlacementInfo struct {
 	fileNum          int
 	blockStartOffset int64
 	blockBytesOffset int64
+	encoding         blockEncoding
+	checksum         []byte
+}
+
+// blockfileOpener opens for reading the block file with the given suffix number
+type blockfileOpener func(fileNum int) (*os.File, error)
+
+// localBlockfileOpener opens the block files present in rootDir
+func localBlockfileOpener(rootDir string) blockfileOpener {
+	return func(fileNum int) (*os.File, error) {
+		return os.OpenFile(deriveBlockfilePath(rootDir, fileNum), os.O_RDONLY, 0600)
+	}
 }
 
 ///////////////////////////////////
 // blockfileStream functions
 ////////////////////////////////////
 func newBlockfileStream(rootDir string, fileNum int, startOffset int64) (*blockfileStream, error) {
-	filePath := deriveBlockfilePath(rootDir, fileNum)
-	logger.Debugf("newBlockfileStream(): filePath=[%s], startOffset=[%d]", filePath, startOffset)
+	return openBlockfileStream(localBlockfileOpener(rootDir), fileNum, startOffset)
+}
+
+func openBlockfileStream(openBlockfile blockfileOpener, fileNum int, startOffset int64) (*blockfileStream, error) {
 	var file *os.File
 	var err error
-	if file, err = os.OpenFile(filePath, os.O_RDONLY, 0600); err != nil {
+	if file, err = openBlockfile(fileNum); err != nil {
 		return nil, err
 	}
+	logger.Debugf("openBlockfileStream(): filePath=[%s], startOffset=[%d]", file.Name(), startOffset)
 	var newPosition int64
 	if newPosition, err = file.Seek(startOffset, 0); err != nil {
+		file.Close()
 		return nil, err
 	}
 	if newPosition != startOffset {
 		panic(fmt.Sprintf("Could not seek file [%s] to given startOffset [%d]. New position = [%d]",
-			filePath, startOffset, newPosition))
+			file.Name(), startOffset, newPosition))
 	}
 	s := &blockfileStream{fileNum, file, bufio.NewReader(file), startOffset}
 	return s, nil
@@ -91,7 +107,7 @@ func (s *blockfileStream) nextBlockBytes() ([]byte, error) {
 // An error `ErrUnexpectedEndOfBlockfile` is returned if a partial written data is detected
 // which is possible towards the tail of the file if a crash had taken place during appending of a block
 func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacementInfo, error) {
-	var lenBytes []byte
+	var headerBytes []byte
 	var err error
 	var fileInfo os.FileInfo
 	moreContentAvailable := true
@@ -104,46 +120,59 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 		return nil, nil, nil
 	}
 	remainingBytes := fileInfo.Size() - s.currentOffset
-	// Peek 8 or smaller number of bytes (if remaining bytes are less than 8)
-	// Assumption is that a block size would be small enough to be represented in 8 bytes varint
-	peekBytes := 8
+	// Peek the bytes of the longest possible record header or a smaller number of bytes
+	// (if remaining bytes are less than that)
+	peekBytes := maxRecordHeaderLen
 	if remainingBytes < int64(peekBytes) {
 		peekBytes = int(remainingBytes)
 		moreContentAvailable = false
 	}
 	logger.Debugf("Remaining bytes=[%d], Going to peek [%d] bytes", remainingBytes, peekBytes)
-	if lenBytes, err = s.reader.Peek(peekBytes); err != nil {
+	if headerBytes, err = s.reader.Peek(peekBytes); err != nil {
 		return nil, nil, err
 	}
-	length, n := proto.DecodeVarint(lenBytes)
-	if n == 0 {
-		// proto.DecodeVarint did not consume any byte at all which means that the bytes
-		// representing the size of the block are partial bytes
+	header := decodeRecordHeader(headerBytes)
+	if header == nil {
+		// the bytes representing the header of the record, which include the size of the block, are partial bytes
 		if !moreContentAvailable {
 			return nil, nil, ErrUnexpectedEndOfBlockfile
 		}
-		panic(fmt.Errorf("Error in decoding varint bytes [%#v]", lenBytes))
+		return nil, nil, fmt.Errorf("Error in decoding the header of the block stored at offset [%d] of file number [%d] from bytes [%#v]",
+			s.currentOffset, s.fileNum, headerBytes)
 	}
-	bytesExpected := int64(n) + int64(length)
+	bytesExpected := int64(header.headerLen) + int64(header.storedLen)
 	if bytesExpected > remainingBytes {
 		logger.Debugf("At least [%d] bytes expected. Remaining bytes = [%d]. Returning with error [%s]",
 			bytesExpected, remainingBytes, ErrUnexpectedEndOfBlockfile)
 		return nil, nil, ErrUnexpectedEndOfBlockfile
 	}
-	// skip the bytes representing the block size
-	if _, err = s.reader.Discard(n); err != nil {
+	// skip the bytes representing the record header
+	if _, err = s.reader.Discard(header.headerLen); err != nil {
 		return nil, nil, err
 	}
-	blockBytes := make([]byte, length)
-	if _, err = io.ReadAtLeast(s.reader, blockBytes, int(length)); err != nil {
-		logger.Debugf("Error while trying to read [%d] bytes from fileNum [%d]: %s", length, s.fileNum, err)
+	storedBytes := make([]byte, header.storedLen)
+	if _, err = io.ReadAtLeast(s.reader, storedBytes, header.storedLen); err != nil {
+		logger.Debugf("Error while trying to read [%d] bytes from fileNum [%d]: %s", header.storedLen, s.fileNum, err)
 		return nil, nil, err
 	}
 	blockPlacementInfo := &blockPlacementInfo{
 		fileNum:          s.fileNum,
 		blockStartOffset: s.currentOffset,
-		blockBytesOffset: s.currentOffset + int64(n)}
-	s.currentOffset += int64(n) + int64(length)
+		blockBytesOffset: s.currentOffset + int64(header.headerLen),
+		encoding:         header.encoding,
+		checksum:         header.checksum}
+	// the stream moves past a corrupted block, so that the blocks which follow it can still be read
+	s.currentOffset += bytesExpected
+	if header.framed {
+		if checksum := recordChecksum(header.encoding, storedBytes); !bytes.Equal(checksum, header.checksum) {
+			return nil, blockPlacementInfo, &ChecksumMismatchError{
+				FileNum: s.fileNum, Offset: blockPlacementInfo.blockStartOffset, Expected: header.checksum, Actual: checksum}
+		}
+	}
+	blockBytes, err := header.encoding.decode(storedBytes)
+	if err != nil {
+		return nil, blockPlacementInfo, err
+	}
 	logger.Debugf("Returning blockbytes - length=[%d], placementInfo={%s}", len(blockBytes), blockPlacementInfo)
 	return blockBytes, blockPlacementInfo, nil
 }
@@ -156,11 +185,15 @@ func (s *blockfileStream) close() error {
 // blockStream functions
 ////////////////////////////////////
 func newBlockStream(rootDir string, startFileNum int, startOffset int64, endFileNum int) (*blockStream, error) {
-	startFileStream, err := newBlockfileStream(rootDir, startFileNum, startOffset)
+	return openBlockStream(localBlockfileOpener(rootDir), startFileNum, startOffset, endFileNum)
+}
+
+func openBlockStream(openBlockfile blockfileOpener, startFileNum int, startOffset int64, endFileNum int) (*blockStream, error) {
+	startFileStream, err := openBlockfileStream(openBlockfile, startFileNum, startOffset)
 	if err != nil {
 		return nil, err
 	}
-	return &blockStream{rootDir, startFileNum, endFileNum, startFileStream}, nil
+	return &blockStream{openBlockfile, startFileNum, endFileNum, startFileStream}, nil
 }
 
 func (s *blockStream) moveToNextBlockfileStream() error {
@@ -169,7 +202,7 @@ func (s *blockStream) moveToNextBlockfileStream() error {
 		return err
 	}
 	s.currentFileNum++
-	if s.currentFileStream, err = newBlockfileStream(s.rootDir, s.currentFileNum, 0); err != nil {
+	if s.currentFileStream, err = openBlockfileStream(s.openBlockfile, s.currentFileNum, 0); err != nil {
 		return err
 	}
 	return nil
@@ -204,6 +237,6 @@ func (s *blockStream) close() error {
 }
 
 func (i *blockPlacementInfo) String() string {
-	return fmt.Sprintf("fileNum=[%d], startOffset=[%d], bytesOffset=[%d]",
-		i.fileNum, i.blockStartOffset, i.blockBytesOffset)
+	return fmt.Sprintf("fileNum=[%d], startOffset=[%d], bytesOffset=[%d], encoding=[%s]",
+		i.fileNum, i.blockStartOffset, i.blockBytesOffset, i.encoding)
 }
@@ -0,0 +1,320 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util"
+)
+
+const archiveFileSuffix = ".tar.gz"
+
+var (
+	archiveInfoKey = []byte("archiveInfo")
+)
+
+// ArchiveConf encapsulates the configuration for archiving old block files
+type ArchiveConf struct {
+	// ArchiveDir is the directory under which the archived block files are placed.
+	// Each ledger gets its own sub-directory named after the ledger id.
+	ArchiveDir string
+	// Compress, if set, stores every archived block file as a gzip compressed
+	// tarball instead of moving the file as is
+	Compress bool
+}
+
+// archiveInfo tracks the oldest block file, and the first block stored in it,
+// that is still present in the ledger directory. All the blocks with a lower
+// number have been moved to the archive and can no longer be retrieved.
+type archiveInfo struct {
+	firstAvailableFileNum  int
+	firstAvailableBlockNum uint64
+}
+
+func (i *archiveInfo) marshal() ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	var err error
+	if err = buffer.EncodeVarint(uint64(i.firstAvailableFileNum)); err != nil {
+		return nil, err
+	}
+	if err = buffer.EncodeVarint(i.firstAvailableBlockNum); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (i *archiveInfo) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	var val uint64
+	var err error
+
+	if val, err = buffer.DecodeVarint(); err != nil {
+		return err
+	}
+	i.firstAvailableFileNum = int(val)
+
+	if val, err = buffer.DecodeVarint(); err != nil {
+		return err
+	}
+	i.firstAvailableBlockNum = val
+	return nil
+}
+
+func (i *archiveInfo) String() string {
+	return fmt.Sprintf("firstAvailableFileNum=[%d], firstAvailableBlockNum=[%d]",
+		i.firstAvailableFileNum, i.firstAvailableBlockNum)
+}
+
+// loadArchiveInfo retrieves the archive info from the database. A ledger
+// that has never been archived gets an archive info pointing at the first file.
+func (mgr *blockfileMgr) loadArchiveInfo() (*archiveInfo, error) {
+	b, err := mgr.db.Get(archiveInfoKey)
+	if err != nil {
+		return nil, err
+	}
+	i := &archiveInfo{}
+	if b == nil {
+		return i, nil
+	}
+	if err = i.unmarshal(b); err != nil {
+		return nil, err
+	}
+	logger.Debugf("loaded archiveInfo:%s", i)
+	return i, nil
+}
+
+func (mgr *blockfileMgr) saveArchiveInfo(i *archiveInfo) error {
+	b, err := i.marshal()
+	if err != nil {
+		return err
+	}
+	return mgr.db.Put(archiveInfoKey, b, true)
+}
+
+func (mgr *blockfileMgr) getArchiveInfo() *archiveInfo {
+	return mgr.arInfo.Load().(*archiveInfo)
+}
+
+func (mgr *blockfileMgr) isArchived(fileNum int) bool {
+	return fileNum < mgr.getArchiveInfo().firstAvailableFileNum
+}
+
+// archiveBlockfiles moves the block files that contain only blocks with a number lower than
+// blockNum, and that were last modified before modifiedBefore, from the ledger directory to archiveDir.
+// A zero modifiedBefore disables the age check. The file holding the last block is never archived.
+// The block files offloaded to an archive backend are left in the backend, but are no longer fetched.
+// It returns the number of the oldest block that can still be retrieved from the ledger.
+func (mgr *blockfileMgr) archiveBlockfiles(blockNum uint64, modifiedBefore time.Time, archiveDir string, compress bool) (uint64, error) {
+	mgr.relocationLock.Lock()
+	defer mgr.relocationLock.Unlock()
+	current := mgr.getArchiveInfo()
+	height := mgr.getBlockchainInfo().Height
+	if height == 0 {
+		return current.firstAvailableBlockNum, nil
+	}
+	if blockNum > height-1 {
+		blockNum = height - 1
+	}
+	if blockNum <= current.firstAvailableBlockNum {
+		return current.firstAvailableBlockNum, nil
+	}
+
+	flp, err := mgr.index.getBlockLocByBlockNum(blockNum)
+	if err != nil {
+		return 0, err
+	}
+	fileNum := current.firstAvailableFileNum
+	for ; fileNum < flp.fileSuffixNum; fileNum++ {
+		// offloaded block files are older than any block file still present in the ledger directory
+		if modifiedBefore.IsZero() || mgr.isOffloaded(fileNum) {
+			continue
+		}
+		fileInfo, err := os.Stat(deriveBlockfilePath(mgr.rootDir, fileNum))
+		if err != nil {
+			return 0, err
+		}
+		if fileInfo.ModTime().After(modifiedBefore) {
+			break
+		}
+	}
+	if fileNum == current.firstAvailableFileNum {
+		logger.Debugf("No block file eligible for archiving below block [%d]", blockNum)
+		return current.firstAvailableBlockNum, nil
+	}
+
+	firstBlockNum, err := mgr.firstBlockNumInFile(fileNum)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = util.CreateDirIfMissing(archiveDir); err != nil {
+		return 0, err
+	}
+	newInfo := &archiveInfo{firstAvailableFileNum: fileNum, firstAvailableBlockNum: firstBlockNum}
+	// The new boundary is recorded before any file is moved so that a crash half way
+	// through never leaves the ledger claiming a block whose file is already gone.
+	// Files left behind by such a crash are picked up by the next archiving run.
+	if err = mgr.saveArchiveInfo(newInfo); err != nil {
+		return 0, err
+	}
+	mgr.arInfo.Store(newInfo)
+
+	fileNums, err := blockfileNumsBelow(mgr.rootDir, fileNum)
+	if err != nil {
+		return 0, err
+	}
+	for _, num := range fileNums {
+		if err = archiveBlockfile(deriveBlockfilePath(mgr.rootDir, num), archiveDir, compress); err != nil {
+			return 0, err
+		}
+	}
+	logger.Infof("Archived block files up to [%s] to [%s], oldest available block is now [%d]",
+		deriveBlockfilePath(mgr.rootDir, fileNum-1), archiveDir, firstBlockNum)
+	return firstBlockNum, nil
+}
+
+func (mgr *blockfileMgr) firstBlockNumInFile(fileNum int) (uint64, error) {
+	stream, err := openBlockfileStream(mgr.openBlockfile, fileNum, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer stream.close()
+	blockBytes, err := stream.nextBlockBytes()
+	if err != nil {
+		return 0, err
+	}
+	if blockBytes == nil {
+		return 0, fmt.Errorf("block file [%s] does not contain any block", deriveBlockfilePath(mgr.rootDir, fileNum))
+	}
+	info, err := extractSerializedBlockInfo(blockBytes)
+	if err != nil {
+		return 0, err
+	}
+	return info.blockHeader.Number, nil
+}
+
+// blockfileNumsBelow returns the suffix numbers of the block files present in rootDir
+// that are lower than fileNum
+func blockfileNumsBelow(rootDir string, fileNum int) ([]int, error) {
+	filesInfo, err := ioutil.ReadDir(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	var nums []int
+	for _, fileInfo := range filesInfo {
+		name := fileInfo.Name()
+		if fileInfo.IsDir() || !isBlockFileName(name) {
+			continue
+		}
+		num, err := strconv.Atoi(strings.TrimPrefix(name, blockfilePrefix))
+		if err != nil {
+			return nil, err
+		}
+		if num < fileNum {
+			nums = append(nums, num)
+		}
+	}
+	return nums, nil
+}
+
+// archiveBlockfile moves the block file at filePath to archiveDir, optionally
+// as a gzip compressed tarball, and removes it from the ledger directory
+func archiveBlockfile(filePath string, archiveDir string, compress bool) error {
+	logger.Debugf("Archiving block file [%s] to [%s], compress=[%t]", filePath, archiveDir, compress)
+	name := filepath.Base(filePath)
+	if !compress {
+		destPath := filepath.Join(archiveDir, name)
+		if err := os.Rename(filePath, destPath); err == nil {
+			return nil
+		}
+		// the archive may live on a different device, fall back to copying the file
+		if err := copyFile(filePath, destPath); err != nil {
+			return err
+		}
+		return os.Remove(filePath)
+	}
+
+	destPath := filepath.Join(archiveDir, name+archiveFileSuffix)
+	tmpPath := destPath + ".tmp"
+	if err := writeTarball(filePath, tmpPath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, destPath); err != nil {
+		return err
+	}
+	return os.Remove(filePath)
+}
+
+func writeTarball(srcPath string, destPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	fileInfo, err := src.Stat()
+	if err != nil {
+		return err
+	}
+	dest, err := os.OpenFile(destPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0640)
+	if err != nil {
+		return err
+	}
+	defer dest.Close()
+
+	gzipWriter := gzip.NewWriter(dest)
+	tarWriter := tar.NewWriter(gzipWriter)
+	header, err := tar.FileInfoHeader(fileInfo, "")
+	if err != nil {
+		return err
+	}
+	if err = tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	if _, err = io.Copy(tarWriter, src); err != nil {
+		return err
+	}
+	if err = tarWriter.Close(); err != nil {
+		return err
+	}
+	if err = gzipWriter.Close(); err != nil {
+		return err
+	}
+	return dest.Sync()
+}
+
+func copyFile(srcPath string, destPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	return writeFileFrom(destPath, src)
+}
+
+// writeFileFrom writes the content read from src to the file at destPath, and syncs the file
+func writeFileFrom(destPath string, src io.Reader) error {
+	dest, err := os.OpenFile(destPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0640)
+	if err != nil {
+		return err
+	}
+	defer dest.Close()
+	if _, err = io.Copy(dest, src); err != nil {
+		return err
+	}
+	return dest.Sync()
+}
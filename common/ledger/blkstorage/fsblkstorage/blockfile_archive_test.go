@@ -0,0 +1,142 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveInfoSerialization(t *testing.T) {
+	info := &archiveInfo{firstAvailableFileNum: 3, firstAvailableBlockNum: 42}
+	b, err := info.marshal()
+	assert.NoError(t, err)
+	infoDeserialized := &archiveInfo{}
+	assert.NoError(t, infoDeserialized.unmarshal(b))
+	assert.Equal(t, info, infoDeserialized)
+}
+
+func TestBlockfileMgrArchive(t *testing.T) {
+	testBlockfileMgrArchive(t, false)
+	testBlockfileMgrArchive(t, true)
+}
+
+func testBlockfileMgrArchive(t *testing.T, compress bool) {
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	env := newTestEnv(t, NewConf(testPath(), maxFileSizeForBlocks(t, blocks[:10])))
+	defer env.Cleanup()
+	archiveDir, err := ioutil.TempDir("", "fsblkstorage-archive-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(archiveDir)
+
+	ledgerid := "testLedger"
+	blkStore, err := env.provider.OpenBlockStore(ledgerid)
+	assert.NoError(t, err)
+	store := blkStore.(*fsBlockStore)
+	for _, block := range blocks {
+		assert.NoError(t, store.AddBlock(block))
+	}
+	assert.True(t, store.fileMgr.cpInfo.latestFileChunkSuffixNum >= 2, "Expected blocks to span several files")
+
+	archiveConf := &ArchiveConf{ArchiveDir: archiveDir, Compress: compress}
+
+	// files modified after the cut-off time are never archived
+	firstAvailable, err := store.ArchiveBlocks(25, time.Now().Add(-time.Hour), archiveConf)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), firstAvailable)
+
+	firstAvailable, err = store.ArchiveBlocks(25, time.Time{}, archiveConf)
+	assert.NoError(t, err)
+	assert.True(t, firstAvailable > 0 && firstAvailable <= 25, "Unexpected first available block %d", firstAvailable)
+
+	archived, err := ioutil.ReadDir(filepath.Join(archiveDir, ledgerid))
+	assert.NoError(t, err)
+	assert.Len(t, archived, store.fileMgr.getArchiveInfo().firstAvailableFileNum)
+	for _, fileInfo := range archived {
+		assert.Equal(t, compress, filepath.Ext(fileInfo.Name()) == ".gz")
+	}
+
+	assertArchived := func(store *fsBlockStore) {
+		for _, block := range blocks[:firstAvailable] {
+			_, err := store.RetrieveBlockByNumber(block.Header.Number)
+			assert.Equal(t, blkstorage.ErrBlockArchived, err)
+			_, err = store.RetrieveBlockByHash(block.Header.Hash())
+			assert.Equal(t, blkstorage.ErrBlockArchived, err)
+		}
+		for _, block := range blocks[firstAvailable:] {
+			b, err := store.RetrieveBlockByNumber(block.Header.Number)
+			assert.NoError(t, err)
+			assert.Equal(t, block, b)
+		}
+		assert.Equal(t, firstAvailable, store.FirstAvailableBlockNum())
+		_, err = store.RetrieveBlocks(0)
+		assert.Equal(t, blkstorage.ErrBlockArchived, err)
+		itr, err := store.RetrieveBlocks(firstAvailable)
+		assert.NoError(t, err)
+		defer itr.Close()
+		result, err := itr.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, blocks[firstAvailable], result.(*common.Block))
+
+		info, err := store.GetBlockchainInfo()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(len(blocks)), info.Height)
+	}
+	assertArchived(store)
+
+	// archiving below the oldest available block is a no-op
+	again, err := store.ArchiveBlocks(firstAvailable, time.Time{}, archiveConf)
+	assert.NoError(t, err)
+	assert.Equal(t, firstAvailable, again)
+
+	// the archive boundary survives a restart
+	store.Shutdown()
+	env.provider.Close()
+	env.provider = NewProvider(env.provider.conf, env.provider.indexConfig).(*FsBlockstoreProvider)
+	blkStore, err = env.provider.OpenBlockStore(ledgerid)
+	assert.NoError(t, err)
+	assertArchived(blkStore.(*fsBlockStore))
+}
+
+func TestBlockfileMgrArchiveKeepsLastFile(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	archiveDir, err := ioutil.TempDir("", "fsblkstorage-archive-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(archiveDir)
+
+	blkStore, err := env.provider.OpenBlockStore("testLedger")
+	assert.NoError(t, err)
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	for _, block := range blocks {
+		assert.NoError(t, blkStore.AddBlock(block))
+	}
+	firstAvailable, err := blkStore.(*fsBlockStore).ArchiveBlocks(100, time.Time{}, &ArchiveConf{ArchiveDir: archiveDir})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), firstAvailable)
+	_, err = blkStore.RetrieveBlockByNumber(0)
+	assert.NoError(t, err)
+}
+
+func maxFileSizeForBlocks(t *testing.T, blocks []*common.Block) int {
+	size := 0
+	for _, block := range blocks {
+		by, _, err := serializeBlock(block)
+		assert.NoError(t, err)
+		size += len(by) + len(proto.EncodeVarint(uint64(len(by))))
+	}
+	return size
+}
@@ -52,7 +52,14 @@ type blockfileMgr struct {
 	cpInfo            *checkpointInfo
 	cpInfoCond        *sync.Cond
 	currentFileWriter *blockfileWriter
+	encoding          blockEncoding
 	bcInfo            atomic.Value
+	arInfo            atomic.Value
+	tInfo             atomic.Value
+	// tier is the archive backend the old block files are offloaded to, nil if tiering is not configured
+	tier *tieredStorage
+	// relocationLock serializes the archiving and the offloading of block files
+	relocationLock sync.Mutex
 }
 
 /*
@@ -104,8 +111,12 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	if err != nil {
 		panic(fmt.Sprintf("Error: %s", err))
 	}
+	encoding, err := blockEncodingByName(conf.compression)
+	if err != nil {
+		panic(fmt.Sprintf("Error: %s", err))
+	}
 	// Instantiate the manager, i.e. blockFileMgr structure
-	mgr := &blockfileMgr{rootDir: rootDir, conf: conf, db: indexStore}
+	mgr := &blockfileMgr{rootDir: rootDir, conf: conf, db: indexStore, encoding: encoding}
 
 	// cp = checkpointInfo, retrieve from the database the file suffix or number of where blocks were stored.
 	// It also retrieves the current size of that file and the last block number that was written to that file.
@@ -141,8 +152,30 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		panic(fmt.Sprintf("Could not truncate current file to known size in db: %s", err))
 	}
 
+	// Load the information about the block files that have been moved to the archive, if any
+	arInfo, err := mgr.loadArchiveInfo()
+	if err != nil {
+		panic(fmt.Sprintf("Could not get archive info from db: %s", err))
+	}
+	mgr.arInfo.Store(arInfo)
+
+	// Load the information about the block files that have been offloaded to the archive backend, if any
+	tInfo, err := mgr.loadTierInfo()
+	if err != nil {
+		panic(fmt.Sprintf("Could not get tier info from db: %s", err))
+	}
+	mgr.tInfo.Store(tInfo)
+	if conf.tiering != nil {
+		if mgr.tier, err = newTieredStorage(id, conf); err != nil {
+			panic(fmt.Sprintf("Could not set up the offloading of block files: %s", err))
+		}
+	}
+
 	// Create a new KeyValue store database handler for the blocks index in the keyvalue database
 	mgr.index = newBlockIndex(indexConfig, indexStore)
+	if err = mgr.initTxTimeIndex(indexConfig); err != nil {
+		panic(fmt.Sprintf("Could not initialize the index of the transactions by time: %s", err))
+	}
 
 	// Update the manager with the checkpoint info and the file writer
 	mgr.cpInfo = cpInfo
@@ -172,6 +205,7 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 			PreviousBlockHash: previousBlockHash}
 	}
 	mgr.bcInfo.Store(bcInfo)
+	mgr.offloadInBackground()
 	return mgr
 }
 
@@ -216,10 +250,17 @@ func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
 }
 
 func deriveBlockfilePath(rootDir string, suffixNum int) string {
-	return rootDir + "/" + blockfilePrefix + fmt.Sprintf("%06d", suffixNum)
+	return rootDir + "/" + blockfileName(suffixNum)
+}
+
+func blockfileName(suffixNum int) string {
+	return blockfilePrefix + fmt.Sprintf("%06d", suffixNum)
 }
 
 func (mgr *blockfileMgr) close() {
+	if mgr.tier != nil {
+		mgr.tier.waitForOffloading()
+	}
 	mgr.currentFileWriter.close()
 }
 
@@ -242,6 +283,7 @@ func (mgr *blockfileMgr) moveToNextFile() {
 	}
 	mgr.currentFileWriter = nextFileWriter
 	mgr.updateCheckpoint(cpInfo)
+	mgr.offloadInBackground()
 }
 
 func (mgr *blockfileMgr) addBlock(block *common.Block) error {
@@ -256,12 +298,9 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	//Get the location / offset where each transaction starts in the block and where the block ends
 	txOffsets := info.txOffsets
 	currentOffset := mgr.cpInfo.latestFileChunksize
-	if err != nil {
-		return fmt.Errorf("Error while serializing block: %s", err)
-	}
-	blockBytesLen := len(blockBytes)
-	blockBytesEncodedLen := proto.EncodeVarint(uint64(blockBytesLen))
-	totalBytesToAppend := blockBytesLen + len(blockBytesEncodedLen)
+	//Compress the block bytes (if configured) and frame them with their length, encoding and checksum (if configured)
+	record := encodeBlock(blockBytes, mgr.encoding, mgr.conf.checksums)
+	totalBytesToAppend := record.len()
 
 	//Determine if we need to start a new file since the size of this block
 	//exceeds the amount of space left in the current file
@@ -269,11 +308,11 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 		mgr.moveToNextFile()
 		currentOffset = 0
 	}
-	//append blockBytesEncodedLen to the file
-	err = mgr.currentFileWriter.append(blockBytesEncodedLen, false)
+	//append the record header to the file
+	err = mgr.currentFileWriter.append(record.header, false)
 	if err == nil {
 		//append the actual block bytes to the file
-		err = mgr.currentFileWriter.append(blockBytes, true)
+		err = mgr.currentFileWriter.append(record.storedBytes, true)
 	}
 	if err != nil {
 		truncateErr := mgr.currentFileWriter.truncateFile(mgr.cpInfo.latestFileChunksize)
@@ -302,14 +341,18 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	//Index block file location pointer updated with file suffex and offset for the new block
 	blockFLP := &fileLocPointer{fileSuffixNum: newCPInfo.latestFileChunkSuffixNum}
 	blockFLP.offset = currentOffset
-	// shift the txoffset because we prepend length of bytes before block bytes
-	for _, txOffset := range txOffsets {
-		txOffset.loc.offset += len(blockBytesEncodedLen)
+	// shift the txoffset because we prepend the record header before block bytes,
+	// unless the block is compressed, in which case the txoffsets are relative to the uncompressed block bytes
+	if record.encoding == blockEncodingNone {
+		for _, txOffset := range txOffsets {
+			txOffset.loc.offset += len(record.header)
+		}
 	}
 	//save the index in the database
 	mgr.index.indexBlock(&blockIdxInfo{
 		blockNum: block.Header.Number, blockHash: blockHash,
-		flp: blockFLP, txOffsets: txOffsets, metadata: block.Metadata})
+		flp: blockFLP, txOffsets: txOffsets, metadata: block.Metadata,
+		encoding: record.encoding, checksum: record.checksum})
 
 	//update the checkpoint info (for storage) and the blockchain info (for APIs) in the manager
 	mgr.updateCheckpoint(newCPInfo)
@@ -354,13 +397,17 @@ func (mgr *blockfileMgr) syncIndex() error {
 		startingBlockNum = lastBlockIndexed + 1
 	} else {
 		logger.Debugf("No block indexed, Last block present in block files=[%d]", mgr.cpInfo.lastBlockNumber)
+		// block files that have been archived can no longer be indexed
+		arInfo := mgr.getArchiveInfo()
+		startFileNum = arInfo.firstAvailableFileNum
+		startingBlockNum = arInfo.firstAvailableBlockNum
 	}
 
 	logger.Infof("Start building index from block [%d] to last block [%d]", startingBlockNum, mgr.cpInfo.lastBlockNumber)
 
 	//open a blockstream to the file location that was stored in the index
 	var stream *blockStream
-	if stream, err = newBlockStream(mgr.rootDir, startFileNum, int64(startOffset), endFileNum); err != nil {
+	if stream, err = openBlockStream(mgr.openBlockfile, startFileNum, int64(startOffset), endFileNum); err != nil {
 		return err
 	}
 	var blockBytes []byte
@@ -393,10 +440,13 @@ func (mgr *blockfileMgr) syncIndex() error {
 		}
 
 		//The blockStartOffset will get applied to the txOffsets prior to indexing within indexBlock(),
-		//therefore just shift by the difference between blockBytesOffset and blockStartOffset
-		numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
-		for _, offset := range info.txOffsets {
-			offset.loc.offset += numBytesToShift
+		//therefore just shift by the difference between blockBytesOffset and blockStartOffset.
+		//The txOffsets of a compressed block remain relative to the uncompressed block bytes
+		if blockPlacementInfo.encoding == blockEncodingNone {
+			numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
+			for _, offset := range info.txOffsets {
+				offset.loc.offset += numBytesToShift
+			}
 		}
 
 		//Update the blockIndexInfo with what was actually stored in file system
@@ -406,6 +456,8 @@ func (mgr *blockfileMgr) syncIndex() error {
 			locPointer: locPointer{offset: int(blockPlacementInfo.blockStartOffset)}}
 		blockIdxInfo.txOffsets = info.txOffsets
 		blockIdxInfo.metadata = info.metadata
+		blockIdxInfo.encoding = blockPlacementInfo.encoding
+		blockIdxInfo.checksum = blockPlacementInfo.checksum
 
 		logger.Debugf("syncIndex() indexing block [%d]", blockIdxInfo.blockNum)
 		if err = mgr.index.indexBlock(blockIdxInfo); err != nil {
@@ -499,6 +551,9 @@ func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.B
 }
 
 func (mgr *blockfileMgr) retrieveBlocks(startNum uint64) (*blocksItr, error) {
+	if startNum < mgr.getArchiveInfo().firstAvailableBlockNum {
+		return nil, blkstorage.ErrBlockArchived
+	}
 	return newBlockItr(mgr, startNum), nil
 }
 
@@ -536,7 +591,12 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 	logger.Debugf("Entering fetchTransactionEnvelope() %v\n", lp)
 	var err error
 	var txEnvelopeBytes []byte
-	if txEnvelopeBytes, err = mgr.fetchRawBytes(lp); err != nil {
+	if lp.compressed {
+		txEnvelopeBytes, err = mgr.fetchCompressedTxBytes(lp)
+	} else {
+		txEnvelopeBytes, err = mgr.fetchRawBytes(lp)
+	}
+	if err != nil {
 		return nil, err
 	}
 	_, n := proto.DecodeVarint(txEnvelopeBytes)
@@ -544,7 +604,10 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 }
 
 func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
-	stream, err := newBlockfileStream(mgr.rootDir, lp.fileSuffixNum, int64(lp.offset))
+	if mgr.isArchived(lp.fileSuffixNum) {
+		return nil, blkstorage.ErrBlockArchived
+	}
+	stream, err := openBlockfileStream(mgr.openBlockfile, lp.fileSuffixNum, int64(lp.offset))
 	if err != nil {
 		return nil, err
 	}
@@ -557,11 +620,14 @@ func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
 }
 
 func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
-	filePath := deriveBlockfilePath(mgr.rootDir, lp.fileSuffixNum)
-	reader, err := newBlockfileReader(filePath)
+	if mgr.isArchived(lp.fileSuffixNum) {
+		return nil, blkstorage.ErrBlockArchived
+	}
+	file, err := mgr.openBlockfile(lp.fileSuffixNum)
 	if err != nil {
 		return nil, err
 	}
+	reader := &blockfileReader{file}
 	defer reader.close()
 	b, err := reader.read(lp.offset, lp.bytesLength)
 	if err != nil {
@@ -570,6 +636,19 @@ func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
 	return b, nil
 }
 
+// fetchCompressedTxBytes returns the bytes of a transaction of a compressed block,
+// which are extracted from the uncompressed bytes of the block
+func (mgr *blockfileMgr) fetchCompressedTxBytes(lp *fileLocPointer) ([]byte, error) {
+	blockBytes, err := mgr.fetchBlockBytes(lp)
+	if err != nil {
+		return nil, err
+	}
+	if lp.txOffset+lp.bytesLength > len(blockBytes) {
+		return nil, fmt.Errorf("transaction location [%s] is out of the bounds of the block of [%d] bytes", lp, len(blockBytes))
+	}
+	return blockBytes[lp.txOffset : lp.txOffset+lp.bytesLength], nil
+}
+
 //Get the current checkpoint information that is stored in the database
 func (mgr *blockfileMgr) loadCurrentInfo() (*checkpointInfo, error) {
 	var b []byte
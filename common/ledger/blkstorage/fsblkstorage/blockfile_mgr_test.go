@@ -18,9 +18,12 @@ package fsblkstorage
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 
 	"github.com/hyperledger/fabric/protos/common"
@@ -212,9 +215,7 @@ func TestBlockfileMgrFileRolling(t *testing.T) {
 	for _, block := range blocks[:100] {
 		by, _, err := serializeBlock(block)
 		testutil.AssertNoError(t, err, "Error while serializing block")
-		blockBytesSize := len(by)
-		encodedLen := proto.EncodeVarint(uint64(blockBytesSize))
-		size += blockBytesSize + len(encodedLen)
+		size += encodeBlock(by, blockEncodingNone, false).len()
 	}
 
 	maxFileSie := int(0.75 * float64(size))
@@ -253,3 +254,103 @@ func TestBlockfileMgrGetBlockByTxID(t *testing.T) {
 		}
 	}
 }
+
+func TestBlockfileMgrCompression(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	// the first blocks are stored as legacy records, the next ones are stored uncompressed along with
+	// a checksum, and the other ones are compressed, all of them in the same block file
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.addBlocks(blocks[:10])
+	blkfileMgrWrapper.close()
+	env.provider.conf.checksums = true
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.addBlocks(blocks[10:20])
+	blkfileMgrWrapper.close()
+	env.provider.conf.checksums = false
+	env.provider.conf.compression = "snappy"
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.addBlocks(blocks[20:])
+	testutil.AssertEquals(t, blkfileMgrWrapper.blockfileMgr.cpInfo.latestFileChunkSuffixNum, 0)
+	testBlockfileMgrRetrievals(t, blkfileMgrWrapper, blocks, 0)
+	blkfileMgrWrapper.close()
+
+	// the index is rebuilt from the block file, which records no checksum for the legacy records
+	env.provider.Close()
+	os.RemoveAll(env.provider.conf.getIndexDir())
+	env.provider = NewProvider(env.provider.conf, env.provider.indexConfig).(*FsBlockstoreProvider)
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	defer blkfileMgrWrapper.close()
+	testBlockfileMgrRetrievals(t, blkfileMgrWrapper, blocks, 10)
+}
+
+// testBlockfileMgrRetrievals retrieves the given blocks and their transactions, the given number of
+// first blocks being expected to be indexed without a checksum, and the other ones with a checksum
+func testBlockfileMgrRetrievals(t *testing.T, w *testBlockfileMgrWrapper, blocks []*common.Block, numWithoutChecksum int) {
+	w.testGetBlockByHash(blocks)
+	w.testGetBlockByNumber(blocks, 0)
+	testBlockfileMgrBlockIterator(t, w.blockfileMgr, 0, len(blocks)-1, blocks)
+	for blockIndex, blk := range blocks {
+		checksum, err := w.blockfileMgr.index.getBlockChecksum(uint64(blockIndex))
+		if blockIndex < numWithoutChecksum {
+			testutil.AssertSame(t, err, blkstorage.ErrNotFoundInIndex)
+		} else {
+			testutil.AssertNoError(t, err, "")
+			testutil.AssertEquals(t, len(checksum), checksumLen)
+		}
+		for tranIndex, txEnvelopeBytes := range blk.Data.Data {
+			txEnvelope, err := putil.GetEnvelopeFromBlock(txEnvelopeBytes)
+			testutil.AssertNoError(t, err, "Error while unmarshalling tx")
+			txID, err := extractTxID(txEnvelopeBytes)
+			testutil.AssertNoError(t, err, "")
+			txEnvelopeFromFileMgr, err := w.blockfileMgr.retrieveTransactionByID(txID)
+			testutil.AssertNoError(t, err, "Error while retrieving tx from blkfileMgr")
+			testutil.AssertEquals(t, txEnvelopeFromFileMgr, txEnvelope)
+			txEnvelopeFromFileMgr, err = w.blockfileMgr.retrieveTransactionByBlockNumTranNum(uint64(blockIndex), uint64(tranIndex))
+			testutil.AssertNoError(t, err, "Error while retrieving tx from blkfileMgr")
+			testutil.AssertEquals(t, txEnvelopeFromFileMgr, txEnvelope)
+		}
+	}
+}
+
+func TestBlockfileMgrLegacyBlockfiles(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	// the first blocks are stored as by older versions of the block store, without record header
+	writeLegacyBlockfile(t, env.provider.conf.getLedgerBlockDir(ledgerid), blocks[:5])
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	defer blkfileMgrWrapper.close()
+	testutil.AssertEquals(t, blkfileMgrWrapper.blockfileMgr.getBlockchainInfo().Height, uint64(5))
+	blkfileMgrWrapper.addBlocks(blocks[5:])
+	blkfileMgrWrapper.testGetBlockByHash(blocks)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0)
+	for _, blk := range blocks {
+		txID, err := extractTxID(blk.Data.Data[0])
+		testutil.AssertNoError(t, err, "")
+		txEnvelopeFromFileMgr, err := blkfileMgrWrapper.blockfileMgr.retrieveTransactionByID(txID)
+		testutil.AssertNoError(t, err, "Error while retrieving tx from blkfileMgr")
+		txEnvelope, err := putil.GetEnvelopeFromBlock(blk.Data.Data[0])
+		testutil.AssertNoError(t, err, "Error while unmarshalling tx")
+		testutil.AssertEquals(t, txEnvelopeFromFileMgr, txEnvelope)
+	}
+	_, err := blkfileMgrWrapper.blockfileMgr.index.getBlockChecksum(0)
+	testutil.AssertSame(t, err, blkstorage.ErrNotFoundInIndex)
+}
+
+// writeLegacyBlockfile writes the given blocks to the first block file of a ledger, each block being
+// stored as its serialized bytes preceded by their length
+func writeLegacyBlockfile(t *testing.T, rootDir string, blocks []*common.Block) {
+	testutil.AssertNoError(t, os.MkdirAll(rootDir, 0755), "")
+	var fileBytes []byte
+	for _, block := range blocks {
+		blockBytes, _, err := serializeBlock(block)
+		testutil.AssertNoError(t, err, "")
+		fileBytes = append(fileBytes, proto.EncodeVarint(uint64(len(blockBytes)))...)
+		fileBytes = append(fileBytes, blockBytes...)
+	}
+	testutil.AssertNoError(t, ioutil.WriteFile(deriveBlockfilePath(rootDir, 0), fileBytes, 0644), "")
+}
@@ -0,0 +1,429 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util"
+)
+
+const defaultTierCacheSize = 10
+
+var (
+	tierInfoKey = append([]byte{metadataKeyPrefix}, "tierInfo"...)
+)
+
+// ArchiveBackend stores the block files offloaded from the local disk of the peer, typically
+// in an object storage. A block file is immutable once offloaded, and is identified by the id
+// of its ledger and its file name
+type ArchiveBackend interface {
+	// Put stores the content of a block file
+	Put(ledgerID string, fileName string, content io.Reader) error
+	// Get returns a reader over the content of a block file previously stored with Put
+	Get(ledgerID string, fileName string) (io.ReadCloser, error)
+}
+
+// ArchiveBackendFactory constructs an ArchiveBackend that stores the block files at the given location
+type ArchiveBackendFactory func(location string) (ArchiveBackend, error)
+
+var (
+	archiveBackendsLock sync.RWMutex
+	archiveBackends     = make(map[string]ArchiveBackendFactory)
+)
+
+func init() {
+	RegisterArchiveBackend("local", func(location string) (ArchiveBackend, error) {
+		return NewLocalDirArchiveBackend(location)
+	})
+}
+
+// RegisterArchiveBackend makes an archive backend available under the given name, which is then
+// selected through the property `ledger.blockchain.tiering.backend` in core.yaml.
+// This function panics if the factory is nil or if a backend is already registered under the name
+func RegisterArchiveBackend(name string, factory ArchiveBackendFactory) {
+	archiveBackendsLock.Lock()
+	defer archiveBackendsLock.Unlock()
+	if factory == nil {
+		panic("nil factory registered for archive backend " + name)
+	}
+	if _, ok := archiveBackends[name]; ok {
+		panic("archive backend " + name + " registered twice")
+	}
+	archiveBackends[name] = factory
+}
+
+// NewArchiveBackend constructs the archive backend registered under the given name
+func NewArchiveBackend(name string, location string) (ArchiveBackend, error) {
+	archiveBackendsLock.RLock()
+	factory, ok := archiveBackends[name]
+	archiveBackendsLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown archive backend [%s], the registered archive backends are %v", name, RegisteredArchiveBackends())
+	}
+	return factory(location)
+}
+
+// RegisteredArchiveBackends returns the sorted names of the registered archive backends
+func RegisteredArchiveBackends() []string {
+	archiveBackendsLock.RLock()
+	defer archiveBackendsLock.RUnlock()
+	var names []string
+	for name := range archiveBackends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// localDirArchiveBackend is an ArchiveBackend storing the block files in a local directory,
+// with a sub-directory per ledger. It is mostly meant for testing, or for a directory
+// mounted from a cheaper storage
+type localDirArchiveBackend struct {
+	dir string
+}
+
+// NewLocalDirArchiveBackend constructs an ArchiveBackend that stores the block files under dir
+func NewLocalDirArchiveBackend(dir string) (ArchiveBackend, error) {
+	if dir == "" {
+		return nil, errors.New("no directory configured for the local archive backend")
+	}
+	if _, err := util.CreateDirIfMissing(dir); err != nil {
+		return nil, err
+	}
+	return &localDirArchiveBackend{dir}, nil
+}
+
+func (b *localDirArchiveBackend) Put(ledgerID string, fileName string, content io.Reader) error {
+	ledgerDir := filepath.Join(b.dir, ledgerID)
+	if _, err := util.CreateDirIfMissing(ledgerDir); err != nil {
+		return err
+	}
+	destPath := filepath.Join(ledgerDir, fileName)
+	tmpPath := destPath + ".tmp"
+	if err := writeFileFrom(tmpPath, content); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, destPath)
+}
+
+func (b *localDirArchiveBackend) Get(ledgerID string, fileName string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(b.dir, ledgerID, fileName))
+}
+
+// TieringConf encapsulates the configuration for offloading the old block files to an archive backend.
+// Unlike archived block files, the offloaded block files are fetched back on demand, so that
+// their blocks and transactions can still be retrieved
+type TieringConf struct {
+	// Backend is the archive backend the block files are offloaded to
+	Backend ArchiveBackend
+	// MaxAge is the time since a block file was last written to after which it is offloaded.
+	// The block files are checked on start-up and whenever the block store starts a new block file.
+	// A zero MaxAge disables the automatic offloading
+	MaxAge time.Duration
+	// CacheDir is the directory under which the block files fetched from the backend are cached.
+	// Each ledger gets its own sub-directory named after the ledger id.
+	// It defaults to a directory next to the block files
+	CacheDir string
+	// CacheSize is the maximum number of block files cached per ledger, 10 if not set
+	CacheSize int
+}
+
+// tierInfo tracks the oldest block file that is still present in the ledger directory.
+// The block files with a lower number that have not been archived have been offloaded
+// to the archive backend, and are fetched from there when their blocks are retrieved
+type tierInfo struct {
+	firstLocalFileNum int
+}
+
+func (i *tierInfo) marshal() ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeVarint(uint64(i.firstLocalFileNum)); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (i *tierInfo) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	val, err := buffer.DecodeVarint()
+	if err != nil {
+		return err
+	}
+	i.firstLocalFileNum = int(val)
+	return nil
+}
+
+func (i *tierInfo) String() string {
+	return fmt.Sprintf("firstLocalFileNum=[%d]", i.firstLocalFileNum)
+}
+
+// loadTierInfo retrieves the tier info from the database. A ledger whose
+// block files have never been offloaded gets a tier info pointing at the first file.
+func (mgr *blockfileMgr) loadTierInfo() (*tierInfo, error) {
+	b, err := mgr.db.Get(tierInfoKey)
+	if err != nil {
+		return nil, err
+	}
+	i := &tierInfo{}
+	if b == nil {
+		return i, nil
+	}
+	if err = i.unmarshal(b); err != nil {
+		return nil, err
+	}
+	logger.Debugf("loaded tierInfo:%s", i)
+	return i, nil
+}
+
+func (mgr *blockfileMgr) saveTierInfo(i *tierInfo) error {
+	b, err := i.marshal()
+	if err != nil {
+		return err
+	}
+	return mgr.db.Put(tierInfoKey, b, true)
+}
+
+func (mgr *blockfileMgr) getTierInfo() *tierInfo {
+	return mgr.tInfo.Load().(*tierInfo)
+}
+
+func (mgr *blockfileMgr) isOffloaded(fileNum int) bool {
+	return fileNum < mgr.getTierInfo().firstLocalFileNum
+}
+
+// tieredStorage is the archive backend of a ledger along with the cache of the block files fetched from it
+type tieredStorage struct {
+	ledgerID string
+	conf     *TieringConf
+	cache    *blockfileCache
+	// offloading is set while the block files are being offloaded in the background
+	offloading int32
+	wg         sync.WaitGroup
+}
+
+func newTieredStorage(ledgerID string, conf *Conf) (*tieredStorage, error) {
+	tiering := conf.tiering
+	if tiering.Backend == nil {
+		return nil, errors.New("no archive backend configured for offloading block files")
+	}
+	cacheSize := tiering.CacheSize
+	if cacheSize <= 0 {
+		cacheSize = defaultTierCacheSize
+	}
+	cache, err := newBlockfileCache(ledgerID, conf.getTierCacheDir(ledgerID), cacheSize, tiering.Backend)
+	if err != nil {
+		return nil, err
+	}
+	return &tieredStorage{ledgerID: ledgerID, conf: tiering, cache: cache}, nil
+}
+
+// openBlockfile opens for reading the block file with the given suffix number,
+// fetching it from the archive backend if it has been offloaded
+func (mgr *blockfileMgr) openBlockfile(fileNum int) (*os.File, error) {
+	if !mgr.isOffloaded(fileNum) {
+		file, err := os.OpenFile(deriveBlockfilePath(mgr.rootDir, fileNum), os.O_RDONLY, 0600)
+		if err == nil || !os.IsNotExist(err) || !mgr.isOffloaded(fileNum) {
+			return file, err
+		}
+		// the block file has been offloaded in the meantime
+	}
+	if mgr.tier == nil {
+		return nil, fmt.Errorf("block file [%s] has been offloaded to an archive backend, but no archive backend is configured",
+			deriveBlockfilePath(mgr.rootDir, fileNum))
+	}
+	return mgr.tier.cache.open(fileNum)
+}
+
+// offloadBlockfiles moves the block files that were last modified before modifiedBefore from the ledger directory
+// to the archive backend, starting from the oldest block file. A zero modifiedBefore disables the age check.
+// The block files already archived and the file holding the last block are never offloaded.
+// It returns the number of block files offloaded.
+func (mgr *blockfileMgr) offloadBlockfiles(modifiedBefore time.Time) (int, error) {
+	if mgr.tier == nil {
+		return 0, errors.New("no archive backend configured for offloading block files")
+	}
+	mgr.relocationLock.Lock()
+	defer mgr.relocationLock.Unlock()
+
+	current := mgr.getTierInfo()
+	firstAvailableFileNum := mgr.getArchiveInfo().firstAvailableFileNum
+	startFileNum := current.firstLocalFileNum
+	if startFileNum < firstAvailableFileNum {
+		startFileNum = firstAvailableFileNum
+	}
+	mgr.cpInfoCond.L.Lock()
+	currentFileNum := mgr.cpInfo.latestFileChunkSuffixNum
+	mgr.cpInfoCond.L.Unlock()
+
+	fileNum := startFileNum
+	for ; fileNum < currentFileNum; fileNum++ {
+		filePath := deriveBlockfilePath(mgr.rootDir, fileNum)
+		if !modifiedBefore.IsZero() {
+			fileInfo, err := os.Stat(filePath)
+			if err != nil {
+				return 0, err
+			}
+			if fileInfo.ModTime().After(modifiedBefore) {
+				break
+			}
+		}
+		if err := mgr.tier.put(fileNum, filePath); err != nil {
+			return 0, err
+		}
+	}
+	if fileNum > current.firstLocalFileNum {
+		// The new boundary is recorded once the block files are stored by the backend and before the local
+		// copies are removed. Local copies left behind by a crash are removed by the next offloading run.
+		newInfo := &tierInfo{firstLocalFileNum: fileNum}
+		if err := mgr.saveTierInfo(newInfo); err != nil {
+			return 0, err
+		}
+		mgr.tInfo.Store(newInfo)
+	}
+
+	fileNums, err := blockfileNumsBelow(mgr.rootDir, mgr.getTierInfo().firstLocalFileNum)
+	if err != nil {
+		return 0, err
+	}
+	for _, num := range fileNums {
+		// the block files below the archive boundary are left to the archiving
+		if num < firstAvailableFileNum {
+			continue
+		}
+		if err = os.Remove(deriveBlockfilePath(mgr.rootDir, num)); err != nil {
+			return 0, err
+		}
+	}
+	numOffloaded := fileNum - startFileNum
+	if numOffloaded > 0 {
+		logger.Infof("Offloaded block files up to [%s] to the archive backend", deriveBlockfilePath(mgr.rootDir, fileNum-1))
+	}
+	return numOffloaded, nil
+}
+
+// offloadInBackground offloads the block files older than the configured maximum age without
+// blocking the caller. Nothing is done if the previous run has not completed yet
+func (mgr *blockfileMgr) offloadInBackground() {
+	if mgr.tier == nil || mgr.tier.conf.MaxAge <= 0 {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&mgr.tier.offloading, 0, 1) {
+		return
+	}
+	mgr.tier.wg.Add(1)
+	go func() {
+		defer mgr.tier.wg.Done()
+		defer atomic.StoreInt32(&mgr.tier.offloading, 0)
+		if _, err := mgr.offloadBlockfiles(time.Now().Add(-mgr.tier.conf.MaxAge)); err != nil {
+			logger.Errorf("Error while offloading the block files of [%s]: %s", mgr.rootDir, err)
+		}
+	}()
+}
+
+func (t *tieredStorage) put(fileNum int, filePath string) error {
+	logger.Debugf("Offloading block file [%s] to the archive backend", filePath)
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return t.conf.Backend.Put(t.ledgerID, blockfileName(fileNum), file)
+}
+
+// waitForOffloading waits for the completion of the offloading running in the background, if any
+func (t *tieredStorage) waitForOffloading() {
+	t.wg.Wait()
+}
+
+// blockfileCache keeps in a local directory a bounded number of the block files
+// fetched from the archive backend, evicting the least recently used ones
+type blockfileCache struct {
+	ledgerID string
+	dir      string
+	maxFiles int
+	backend  ArchiveBackend
+	lock     sync.Mutex
+	// lru holds the numbers of the cached block files, the most recently used first
+	lru     *list.List
+	entries map[int]*list.Element
+}
+
+func newBlockfileCache(ledgerID string, dir string, maxFiles int, backend ArchiveBackend) (*blockfileCache, error) {
+	// the block files cached before a restart are not tracked, so they are dropped
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if _, err := util.CreateDirIfMissing(dir); err != nil {
+		return nil, err
+	}
+	return &blockfileCache{
+		ledgerID: ledgerID,
+		dir:      dir,
+		maxFiles: maxFiles,
+		backend:  backend,
+		lru:      list.New(),
+		entries:  make(map[int]*list.Element),
+	}, nil
+}
+
+// open opens for reading the cached copy of the block file with the given suffix number,
+// fetching it from the archive backend first if it is not cached. A cached block file
+// evicted while it is open remains readable until it is closed
+func (c *blockfileCache) open(fileNum int) (*os.File, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	filePath := deriveBlockfilePath(c.dir, fileNum)
+	if elem, ok := c.entries[fileNum]; ok {
+		c.lru.MoveToFront(elem)
+	} else {
+		if err := c.fetch(fileNum, filePath); err != nil {
+			return nil, err
+		}
+		c.entries[fileNum] = c.lru.PushFront(fileNum)
+		c.evict()
+	}
+	return os.OpenFile(filePath, os.O_RDONLY, 0600)
+}
+
+func (c *blockfileCache) fetch(fileNum int, filePath string) error {
+	logger.Debugf("Fetching block file [%d] of ledger [%s] from the archive backend", fileNum, c.ledgerID)
+	content, err := c.backend.Get(c.ledgerID, blockfileName(fileNum))
+	if err != nil {
+		return fmt.Errorf("error while fetching block file [%d] of ledger [%s] from the archive backend: %s", fileNum, c.ledgerID, err)
+	}
+	defer content.Close()
+	tmpPath := filePath + ".tmp"
+	if err = writeFileFrom(tmpPath, content); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
+}
+
+func (c *blockfileCache) evict() {
+	for c.lru.Len() > c.maxFiles {
+		elem := c.lru.Back()
+		fileNum := elem.Value.(int)
+		c.lru.Remove(elem)
+		delete(c.entries, fileNum)
+		if err := os.Remove(deriveBlockfilePath(c.dir, fileNum)); err != nil {
+			logger.Warningf("Could not remove block file [%d] from the cache of ledger [%s]: %s", fileNum, c.ledgerID, err)
+		}
+	}
+}
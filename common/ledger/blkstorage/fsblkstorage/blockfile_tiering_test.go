@@ -0,0 +1,203 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	putil "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTierInfoSerialization(t *testing.T) {
+	info := &tierInfo{firstLocalFileNum: 7}
+	b, err := info.marshal()
+	assert.NoError(t, err)
+	infoDeserialized := &tierInfo{}
+	assert.NoError(t, infoDeserialized.unmarshal(b))
+	assert.Equal(t, info, infoDeserialized)
+}
+
+func TestLocalDirArchiveBackend(t *testing.T) {
+	_, err := NewLocalDirArchiveBackend("")
+	assert.EqualError(t, err, "no directory configured for the local archive backend")
+
+	dir, err := ioutil.TempDir("", "fsblkstorage-tiering-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	backend, err := NewArchiveBackend("local", dir)
+	assert.NoError(t, err)
+
+	assert.NoError(t, backend.Put("ledger1", "blockfile_000000", bytes.NewReader([]byte("content"))))
+	reader, err := backend.Get("ledger1", "blockfile_000000")
+	assert.NoError(t, err)
+	content, err := ioutil.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.NoError(t, reader.Close())
+	assert.Equal(t, []byte("content"), content)
+
+	_, err = backend.Get("ledger2", "blockfile_000000")
+	assert.Error(t, err)
+
+	_, err = NewArchiveBackend("unknown", dir)
+	assert.EqualError(t, err, "unknown archive backend [unknown], the registered archive backends are [local]")
+	assert.Panics(t, func() { RegisterArchiveBackend("local", func(string) (ArchiveBackend, error) { return nil, nil }) })
+}
+
+func TestBlockfileMgrTiering(t *testing.T) {
+	testBlockfileMgrTiering(t, "none")
+	testBlockfileMgrTiering(t, "snappy")
+}
+
+func testBlockfileMgrTiering(t *testing.T, compression string) {
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	backendDir, err := ioutil.TempDir("", "fsblkstorage-tiering-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(backendDir)
+	backend, err := NewLocalDirArchiveBackend(backendDir)
+	assert.NoError(t, err)
+	encoding, err := blockEncodingByName(compression)
+	assert.NoError(t, err)
+	maxFileSize := 0
+	for _, block := range blocks[:3] {
+		serializedBlock, _, err := serializeBlock(block)
+		assert.NoError(t, err)
+		maxFileSize += encodeBlock(serializedBlock, encoding, false).len()
+	}
+	conf := NewConfWithCompression(testPath(), maxFileSize, compression).
+		WithTiering(&TieringConf{Backend: backend, CacheSize: 2})
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+
+	ledgerid := "testLedger"
+	blkStore, err := env.provider.OpenBlockStore(ledgerid)
+	assert.NoError(t, err)
+	store := blkStore.(*fsBlockStore)
+	for _, block := range blocks {
+		assert.NoError(t, store.AddBlock(block))
+	}
+	currentFileNum := store.fileMgr.cpInfo.latestFileChunkSuffixNum
+	assert.True(t, currentFileNum >= 4, "Expected blocks to span several files")
+
+	// files modified after the cut-off time are never offloaded
+	numOffloaded, err := store.OffloadBlocks(time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, numOffloaded)
+
+	numOffloaded, err = store.OffloadBlocks(time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, currentFileNum, numOffloaded)
+	assert.Equal(t, currentFileNum, store.fileMgr.getTierInfo().firstLocalFileNum)
+
+	localFileNums, err := blockfileNumsBelow(store.fileMgr.rootDir, currentFileNum+1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{currentFileNum}, localFileNums)
+	offloaded, err := ioutil.ReadDir(filepath.Join(backendDir, ledgerid))
+	assert.NoError(t, err)
+	assert.Len(t, offloaded, currentFileNum)
+
+	assertRetrievable := func(store *fsBlockStore) {
+		for _, block := range blocks {
+			b, err := store.RetrieveBlockByNumber(block.Header.Number)
+			assert.NoError(t, err)
+			assert.Equal(t, block, b)
+			b, err = store.RetrieveBlockByHash(block.Header.Hash())
+			assert.NoError(t, err)
+			assert.Equal(t, block, b)
+			txid, err := extractTxID(block.Data.Data[0])
+			assert.NoError(t, err)
+			txEnvelope, err := store.RetrieveTxByID(txid)
+			assert.NoError(t, err)
+			expectedTxEnvelope, err := putil.GetEnvelopeFromBlock(block.Data.Data[0])
+			assert.NoError(t, err)
+			assert.Equal(t, expectedTxEnvelope, txEnvelope)
+		}
+
+		itr, err := store.RetrieveBlocks(0)
+		assert.NoError(t, err)
+		defer itr.Close()
+		for _, block := range blocks {
+			result, err := itr.Next()
+			assert.NoError(t, err)
+			assert.Equal(t, block, result.(*common.Block))
+		}
+
+		cached, err := ioutil.ReadDir(env.provider.conf.getTierCacheDir(ledgerid))
+		assert.NoError(t, err)
+		assert.Len(t, cached, 2)
+	}
+	assertRetrievable(store)
+
+	// offloading again is a no-op
+	numOffloaded, err = store.OffloadBlocks(time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, numOffloaded)
+
+	// the offloading boundary survives a restart
+	store.Shutdown()
+	env.provider.Close()
+	env.provider = NewProvider(conf, env.provider.indexConfig).(*FsBlockstoreProvider)
+	blkStore, err = env.provider.OpenBlockStore(ledgerid)
+	assert.NoError(t, err)
+	store = blkStore.(*fsBlockStore)
+	assertRetrievable(store)
+
+	// archiving drops the offloaded blocks that fall below the archive boundary
+	firstAvailable, err := store.ArchiveBlocks(20, time.Time{}, &ArchiveConf{ArchiveDir: filepath.Join(backendDir, "archive")})
+	assert.NoError(t, err)
+	assert.True(t, firstAvailable > 0 && firstAvailable <= 20, "Unexpected first available block %d", firstAvailable)
+	_, err = store.RetrieveBlockByNumber(0)
+	assert.Equal(t, blkstorage.ErrBlockArchived, err)
+	b, err := store.RetrieveBlockByNumber(firstAvailable)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[firstAvailable], b)
+	store.Shutdown()
+
+	// the offloaded blocks cannot be retrieved without the archive backend
+	env.provider.Close()
+	env.provider = NewProvider(NewConf(conf.blockStorageDir, conf.maxBlockfileSize), env.provider.indexConfig).(*FsBlockstoreProvider)
+	blkStore, err = env.provider.OpenBlockStore(ledgerid)
+	assert.NoError(t, err)
+	_, err = blkStore.RetrieveBlockByNumber(firstAvailable)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "has been offloaded to an archive backend, but no archive backend is configured")
+	b, err = blkStore.RetrieveBlockByNumber(uint64(len(blocks) - 1))
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[len(blocks)-1], b)
+}
+
+func TestBlockfileMgrAutomaticOffloading(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	backendDir, err := ioutil.TempDir("", "fsblkstorage-tiering-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(backendDir)
+	backend, err := NewLocalDirArchiveBackend(backendDir)
+	assert.NoError(t, err)
+	conf := NewConf(testPath(), maxFileSizeForBlocks(t, blocks[:3])).
+		WithTiering(&TieringConf{Backend: backend, MaxAge: time.Nanosecond})
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	mgr := blkfileMgrWrapper.blockfileMgr
+	// closing waits for the offloading running in the background
+	blkfileMgrWrapper.close()
+	assert.True(t, mgr.getTierInfo().firstLocalFileNum > 0, "Expected block files to be offloaded")
+
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0)
+}
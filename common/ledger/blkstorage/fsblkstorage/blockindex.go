@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
@@ -37,7 +38,13 @@ const (
 	blockNumTranNumIdxKeyPrefix    = 'a'
 	blockTxIDIdxKeyPrefix          = 'b'
 	txValidationResultIdxKeyPrefix = 'v'
+	blockChecksumIdxKeyPrefix      = 'c'
+	txTimeIdxKeyPrefix             = 'm'
+	txCreatorTimeIdxKeyPrefix      = 'r'
 	indexCheckpointKeyStr          = "indexCheckpointKey"
+	// metadataKeyPrefix prefixes the keys of the block store metadata kept along
+	// with the index. No index key starts with it, so they can't collide
+	metadataKeyPrefix = 0x00
 )
 
 var indexCheckpointKey = []byte(indexCheckpointKeyStr)
@@ -52,6 +59,9 @@ type index interface {
 	getTXLocByBlockNumTranNum(blockNum uint64, tranNum uint64) (*fileLocPointer, error)
 	getBlockLocByTxID(txID string) (*fileLocPointer, error)
 	getTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	getBlockChecksum(blockNum uint64) ([]byte, error)
+	getTxsByTime(creatorHash []byte, from, to time.Time, bookmark string, limit int) (*peer.TxQueryResponse, error)
+	indexTxTimes(blockNum uint64, txOffsets []*txindexInfo, metadata *common.BlockMetadata) error
 }
 
 type blockIdxInfo struct {
@@ -60,6 +70,10 @@ type blockIdxInfo struct {
 	flp       *fileLocPointer
 	txOffsets []*txindexInfo
 	metadata  *common.BlockMetadata
+	encoding  blockEncoding
+	// checksum is nil for the blocks written by older versions of the block store,
+	// and for the legacy records indexed from the block files, which carry no checksum
+	checksum []byte
 }
 
 type blockIndex struct {
@@ -118,7 +132,7 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 	//Index3 Used to find a transaction by it's transaction id
 	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrTxID]; ok {
 		for _, txoffset := range txOffsets {
-			txFlp := newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc)
+			txFlp := blockIdxInfo.txLocation(txoffset)
 			logger.Debugf("Adding txLoc [%s] for tx ID: [%s] to index", txFlp, txoffset.txID)
 			txFlpBytes, marshalErr := txFlp.marshal()
 			if marshalErr != nil {
@@ -131,7 +145,7 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 	//Index4 - Store BlockNumTranNum will be used to query history data
 	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrBlockNumTranNum]; ok {
 		for txIterator, txoffset := range txOffsets {
-			txFlp := newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc)
+			txFlp := blockIdxInfo.txLocation(txoffset)
 			logger.Debugf("Adding txLoc [%s] for tx number:[%d] ID: [%s] to blockNumTranNum index", txFlp, txIterator, txoffset.txID)
 			txFlpBytes, marshalErr := txFlp.marshal()
 			if marshalErr != nil {
@@ -155,6 +169,19 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 		}
 	}
 
+	// Index7 - Store transactions by timestamp, and by creator and timestamp
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrTxTime]; ok {
+		if err := addTxTimeEntries(batch, blockIdxInfo.blockNum, txOffsets, txsfltr); err != nil {
+			return err
+		}
+	}
+
+	// Store the checksum of the block as recorded in the block file, or as computed when the block was stored
+	// as a legacy record, used to verify the block files
+	if blockIdxInfo.checksum != nil {
+		batch.Put(constructBlockChecksumKey(blockIdxInfo.blockNum), blockIdxInfo.checksum)
+	}
+
 	batch.Put(indexCheckpointKey, encodeBlockNum(blockIdxInfo.blockNum))
 	// Setting snyc to true as a precaution, false may be an ok optimization after further testing.
 	if err := index.db.WriteBatch(batch, true); err != nil {
@@ -263,6 +290,18 @@ func (index *blockIndex) getTxValidationCodeByTxID(txID string) (peer.TxValidati
 	return result, nil
 }
 
+// getBlockChecksum returns the checksum of the block as recorded in the block file
+func (index *blockIndex) getBlockChecksum(blockNum uint64) ([]byte, error) {
+	checksum, err := index.db.Get(constructBlockChecksumKey(blockNum))
+	if err != nil {
+		return nil, err
+	}
+	if checksum == nil {
+		return nil, blkstorage.ErrNotFoundInIndex
+	}
+	return checksum, nil
+}
+
 func constructBlockNumKey(blockNum uint64) []byte {
 	blkNumBytes := util.EncodeOrderPreservingVarUint64(blockNum)
 	return append([]byte{blockNumIdxKeyPrefix}, blkNumBytes...)
@@ -272,6 +311,11 @@ func constructBlockHashKey(blockHash []byte) []byte {
 	return append([]byte{blockHashIdxKeyPrefix}, blockHash...)
 }
 
+func constructBlockChecksumKey(blockNum uint64) []byte {
+	blkNumBytes := util.EncodeOrderPreservingVarUint64(blockNum)
+	return append([]byte{blockChecksumIdxKeyPrefix}, blkNumBytes...)
+}
+
 func constructTxIDKey(txID string) []byte {
 	return append([]byte{txIDIdxKeyPrefix}, []byte(txID)...)
 }
@@ -311,9 +355,13 @@ func (lp *locPointer) String() string {
 }
 
 // fileLocPointer
+// The location of a transaction of a compressed block is the location of the block, along with the
+// offset of the transaction within the uncompressed block bytes (txOffset) and its length
 type fileLocPointer struct {
 	fileSuffixNum int
 	locPointer
+	compressed bool
+	txOffset   int
 }
 
 func newFileLocationPointer(fileSuffixNum int, beginningOffset int, relativeLP *locPointer) *fileLocPointer {
@@ -323,6 +371,18 @@ func newFileLocationPointer(fileSuffixNum int, beginningOffset int, relativeLP *
 	return flp
 }
 
+// txLocation returns the location of a transaction of the block
+func (blockIdxInfo *blockIdxInfo) txLocation(txoffset *txindexInfo) *fileLocPointer {
+	flp := blockIdxInfo.flp
+	if blockIdxInfo.encoding == blockEncodingNone {
+		return newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc)
+	}
+	txFlp := &fileLocPointer{fileSuffixNum: flp.fileSuffixNum, compressed: true, txOffset: txoffset.loc.offset}
+	txFlp.offset = flp.offset
+	txFlp.bytesLength = txoffset.loc.bytesLength
+	return txFlp
+}
+
 func (flp *fileLocPointer) marshal() ([]byte, error) {
 	buffer := proto.NewBuffer([]byte{})
 	e := buffer.EncodeVarint(uint64(flp.fileSuffixNum))
@@ -337,6 +397,12 @@ func (flp *fileLocPointer) marshal() ([]byte, error) {
 	if e != nil {
 		return nil, e
 	}
+	if flp.compressed {
+		e = buffer.EncodeVarint(uint64(flp.txOffset))
+		if e != nil {
+			return nil, e
+		}
+	}
 	return buffer.Bytes(), nil
 }
 
@@ -358,10 +424,18 @@ func (flp *fileLocPointer) unmarshal(b []byte) error {
 		return e
 	}
 	flp.bytesLength = int(i)
+	// only the locations of the transactions of compressed blocks carry a fourth value
+	if i, e = buffer.DecodeVarint(); e == nil {
+		flp.compressed = true
+		flp.txOffset = int(i)
+	}
 	return nil
 }
 
 func (flp *fileLocPointer) String() string {
+	if flp.compressed {
+		return fmt.Sprintf("fileSuffixNum=%d, %s, txOffset=%d", flp.fileSuffixNum, flp.locPointer.String(), flp.txOffset)
+	}
 	return fmt.Sprintf("fileSuffixNum=%d, %s", flp.fileSuffixNum, flp.locPointer.String())
 }
 
@@ -19,6 +19,7 @@ package fsblkstorage
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
@@ -58,6 +59,18 @@ func (i *noopIndex) getTxValidationCodeByTxID(txID string) (peer.TxValidationCod
 	return peer.TxValidationCode(-1), nil
 }
 
+func (i *noopIndex) getBlockChecksum(blockNum uint64) ([]byte, error) {
+	return nil, nil
+}
+
+func (i *noopIndex) getTxsByTime(creatorHash []byte, from, to time.Time, bookmark string, limit int) (*peer.TxQueryResponse, error) {
+	return nil, nil
+}
+
+func (i *noopIndex) indexTxTimes(blockNum uint64, txOffsets []*txindexInfo, metadata *common.BlockMetadata) error {
+	return nil
+}
+
 func TestBlockIndexSync(t *testing.T) {
 	testBlockIndexSync(t, 10, 5, false)
 	testBlockIndexSync(t, 10, 5, true)
@@ -0,0 +1,299 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// The transactions are indexed by time under the key
+//
+//	'm' | timestamp | block number | transaction number
+//
+// and by creator and time under the key
+//
+//	'r' | SHA-256 hash of the creator | timestamp | block number | transaction number
+//
+// where the timestamp is the number of nanoseconds elapsed since the Unix epoch. The numbers are
+// encoded so as to preserve their order, and the value of both keys is a peer.IndexedTransaction.
+// As blocks carry no timestamp, the timestamp of a transaction is the one of its channel header.
+
+// txTimeIndexFromBlockKey holds the number of the first block whose transactions are indexed by time.
+// The transactions committed before the index was enabled are only indexed once the index is backfilled
+var txTimeIndexFromBlockKey = append([]byte{metadataKeyPrefix}, "txTimeIndexFromBlock"...)
+
+const creatorHashLen = sha256.Size
+
+// addTxTimeEntries adds to the batch the entries indexing the transactions of a block by time and by creator and time
+func addTxTimeEntries(batch *leveldbhelper.UpdateBatch, blockNum uint64, txOffsets []*txindexInfo, txsfltr ledgerUtil.TxValidationFlags) error {
+	for txNum, txoffset := range txOffsets {
+		ts, creator := extractTxTimeAndCreator(txoffset.txEnvBytes)
+		indexedTx := &peer.IndexedTransaction{
+			TxId:           txoffset.txID,
+			BlockNum:       blockNum,
+			TxNum:          uint64(txNum),
+			Timestamp:      ts,
+			ValidationCode: txsfltr.Flag(txNum),
+		}
+		if len(creator) > 0 {
+			creatorHash := sha256.Sum256(creator)
+			indexedTx.CreatorHash = creatorHash[:]
+		}
+		indexedTxBytes, err := proto.Marshal(indexedTx)
+		if err != nil {
+			return err
+		}
+		txTime := txTimestamp(ts)
+		batch.Put(constructTxTimeKey(nil, txTime, blockNum, uint64(txNum)), indexedTxBytes)
+		if indexedTx.CreatorHash != nil {
+			batch.Put(constructTxTimeKey(indexedTx.CreatorHash, txTime, blockNum, uint64(txNum)), indexedTxBytes)
+		}
+	}
+	return nil
+}
+
+// indexTxTimes indexes the transactions of a block by time without moving the index checkpoint,
+// which is used for backfilling the index with the blocks committed before it was enabled
+func (index *blockIndex) indexTxTimes(blockNum uint64, txOffsets []*txindexInfo, metadata *common.BlockMetadata) error {
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrTxTime]; !ok {
+		return blkstorage.ErrAttrNotIndexed
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	txsfltr := ledgerUtil.TxValidationFlags(metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	if err := addTxTimeEntries(batch, blockNum, txOffsets, txsfltr); err != nil {
+		return err
+	}
+	return index.db.WriteBatch(batch, false)
+}
+
+// getTxsByTime returns at most limit transactions whose timestamp is in [from, to), starting after the
+// transaction the bookmark points at. A non-positive limit returns all the transactions of the range.
+func (index *blockIndex) getTxsByTime(creatorHash []byte, from, to time.Time, bookmark string, limit int) (*peer.TxQueryResponse, error) {
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrTxTime]; !ok {
+		return nil, blkstorage.ErrAttrNotIndexed
+	}
+	if creatorHash != nil && len(creatorHash) != creatorHashLen {
+		return nil, fmt.Errorf("invalid creator hash [%x], a SHA-256 hash of %d bytes is expected", creatorHash, creatorHashLen)
+	}
+	startKey := append(constructTxTimeKeyPrefix(creatorHash), encodeTxTime(from)...)
+	endKey := append(constructTxTimeKeyPrefix(creatorHash), 0xff)
+	if !to.IsZero() {
+		endKey = append(constructTxTimeKeyPrefix(creatorHash), encodeTxTime(to)...)
+	}
+	if bookmark != "" {
+		lastKey, err := hex.DecodeString(bookmark)
+		if err != nil || bytes.Compare(lastKey, startKey) < 0 || bytes.Compare(lastKey, endKey) >= 0 {
+			return nil, fmt.Errorf("invalid bookmark [%s] for the requested time range", bookmark)
+		}
+		startKey = append(lastKey, 0x00)
+	}
+
+	itr := index.db.GetIterator(startKey, endKey)
+	defer itr.Release()
+	resp := &peer.TxQueryResponse{}
+	var lastKey []byte
+	for itr.Next() {
+		if limit > 0 && len(resp.Transactions) == limit {
+			resp.Bookmark = hex.EncodeToString(lastKey)
+			break
+		}
+		indexedTx := &peer.IndexedTransaction{}
+		if err := proto.Unmarshal(itr.Value(), indexedTx); err != nil {
+			return nil, err
+		}
+		resp.Transactions = append(resp.Transactions, indexedTx)
+		lastKey = append([]byte(nil), itr.Key()...)
+	}
+	if err := itr.Error(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func constructTxTimeKeyPrefix(creatorHash []byte) []byte {
+	if creatorHash == nil {
+		return []byte{txTimeIdxKeyPrefix}
+	}
+	return append([]byte{txCreatorTimeIdxKeyPrefix}, creatorHash...)
+}
+
+func constructTxTimeKey(creatorHash []byte, txTime time.Time, blockNum uint64, txNum uint64) []byte {
+	key := append(constructTxTimeKeyPrefix(creatorHash), encodeTxTime(txTime)...)
+	key = append(key, util.EncodeOrderPreservingVarUint64(blockNum)...)
+	return append(key, util.EncodeOrderPreservingVarUint64(txNum)...)
+}
+
+// encodeTxTime encodes a time in an order preserving way, the times before the Unix epoch being mapped to the epoch
+func encodeTxTime(t time.Time) []byte {
+	nanos := t.UnixNano()
+	if t.IsZero() || nanos < 0 {
+		nanos = 0
+	}
+	return util.EncodeOrderPreservingVarUint64(uint64(nanos))
+}
+
+// extractTxTimeAndCreator extracts the timestamp and the creator of a transaction from its headers.
+// Like for the ID of the transaction, headers which can't be parsed are tolerated,
+// and the transaction is then indexed with no timestamp or creator
+func extractTxTimeAndCreator(txEnvBytes []byte) (*timestamp.Timestamp, []byte) {
+	txEnvelope, err := utils.GetEnvelopeFromBlock(txEnvBytes)
+	if err != nil {
+		return nil, nil
+	}
+	txPayload, err := utils.GetPayload(txEnvelope)
+	if err != nil || txPayload.Header == nil {
+		return nil, nil
+	}
+	var ts *timestamp.Timestamp
+	if chdr, err := utils.UnmarshalChannelHeader(txPayload.Header.ChannelHeader); err == nil {
+		ts = chdr.Timestamp
+	}
+	var creator []byte
+	if shdr, err := utils.GetSignatureHeader(txPayload.Header.SignatureHeader); err == nil {
+		creator = shdr.Creator
+	}
+	return ts, creator
+}
+
+// txTimestamp returns the given timestamp of a transaction as a time, the zero time if it has none
+func txTimestamp(ts *timestamp.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// initTxTimeIndex records the first block whose transactions are indexed by time when the index gets enabled,
+// and forgets it when the index gets disabled so that it is recorded again if the index is enabled later on
+func (mgr *blockfileMgr) initTxTimeIndex(indexConfig *blkstorage.IndexConfig) error {
+	b, err := mgr.db.Get(txTimeIndexFromBlockKey)
+	if err != nil {
+		return err
+	}
+	if !indexConfig.Contains(blkstorage.IndexableAttrTxTime) {
+		if b == nil {
+			return nil
+		}
+		return mgr.db.Delete(txTimeIndexFromBlockKey, true)
+	}
+	if b != nil {
+		return nil
+	}
+	fromBlock := mgr.getArchiveInfo().firstAvailableBlockNum
+	lastBlockIndexed, err := mgr.index.getLastBlockIndexed()
+	if err != nil && err != errIndexEmpty {
+		return err
+	}
+	if err == nil {
+		// the blocks not indexed yet are indexed by syncIndex, and so are indexed by time
+		fromBlock = lastBlockIndexed + 1
+	}
+	logger.Infof("Indexing the transactions by time from block [%d]", fromBlock)
+	return mgr.saveTxTimeIndexFromBlock(fromBlock)
+}
+
+func (mgr *blockfileMgr) loadTxTimeIndexFromBlock() (uint64, error) {
+	b, err := mgr.db.Get(txTimeIndexFromBlockKey)
+	if err != nil {
+		return 0, err
+	}
+	if b == nil {
+		return 0, blkstorage.ErrAttrNotIndexed
+	}
+	return decodeBlockNum(b), nil
+}
+
+func (mgr *blockfileMgr) saveTxTimeIndexFromBlock(blockNum uint64) error {
+	return mgr.db.Put(txTimeIndexFromBlockKey, encodeBlockNum(blockNum), true)
+}
+
+func (mgr *blockfileMgr) retrieveTxsByTime(creatorHash []byte, from, to time.Time, bookmark string, limit int) (*peer.TxQueryResponse, error) {
+	resp, err := mgr.index.getTxsByTime(creatorHash, from, to, bookmark, limit)
+	if err != nil {
+		return nil, err
+	}
+	if resp.IndexedFromBlock, err = mgr.loadTxTimeIndexFromBlock(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// backfillTxTimeIndex indexes by time the transactions of the blocks still present in the block store that were
+// committed before the index was enabled. It returns the number of blocks backfilled.
+func (mgr *blockfileMgr) backfillTxTimeIndex() (uint64, error) {
+	// keep the block files in place while they are read
+	mgr.relocationLock.Lock()
+	defer mgr.relocationLock.Unlock()
+	indexedFromBlock, err := mgr.loadTxTimeIndexFromBlock()
+	if err != nil {
+		return 0, err
+	}
+	arInfo := mgr.getArchiveInfo()
+	if indexedFromBlock <= arInfo.firstAvailableBlockNum {
+		return 0, nil
+	}
+	mgr.cpInfoCond.L.Lock()
+	endFileNum := mgr.cpInfo.latestFileChunkSuffixNum
+	mgr.cpInfoCond.L.Unlock()
+
+	logger.Infof("Backfilling the index of the transactions by time from block [%d] to block [%d]",
+		arInfo.firstAvailableBlockNum, indexedFromBlock-1)
+	stream, err := openBlockStream(mgr.openBlockfile, arInfo.firstAvailableFileNum, 0, endFileNum)
+	if err != nil {
+		return 0, err
+	}
+	defer stream.close()
+	numBackfilled := uint64(0)
+	for {
+		blockBytes, _, err := stream.nextBlockBytesAndPlacementInfo()
+		if err != nil {
+			return numBackfilled, err
+		}
+		if blockBytes == nil {
+			break
+		}
+		info, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return numBackfilled, err
+		}
+		blockNum := info.blockHeader.Number
+		if blockNum >= indexedFromBlock {
+			break
+		}
+		if blockNum < arInfo.firstAvailableBlockNum {
+			continue
+		}
+		if err = mgr.index.indexTxTimes(blockNum, info.txOffsets, info.metadata); err != nil {
+			return numBackfilled, err
+		}
+		numBackfilled++
+	}
+	if err = mgr.saveTxTimeIndexFromBlock(arInfo.firstAvailableBlockNum); err != nil {
+		return numBackfilled, err
+	}
+	logger.Infof("Backfilled the index of the transactions by time with [%d] blocks", numBackfilled)
+	return numBackfilled, nil
+}
@@ -0,0 +1,221 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"crypto/sha256"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var txTimeIndexAttrs = []blkstorage.IndexableAttr{
+	blkstorage.IndexableAttrBlockHash,
+	blkstorage.IndexableAttrBlockNum,
+	blkstorage.IndexableAttrTxID,
+	blkstorage.IndexableAttrBlockNumTranNum,
+	blkstorage.IndexableAttrTxTime,
+}
+
+func TestTxTimeIndex(t *testing.T) {
+	env := newTestEnvSelectiveIndexing(t, NewConf(testPath(), 0), txTimeIndexAttrs)
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	blkfileMgrWrapper.addBlocks(blocks)
+	mgr := blkfileMgrWrapper.blockfileMgr
+
+	expectedTxs := expectedIndexedTxs(t, blocks)
+	resp, err := mgr.retrieveTxsByTime(nil, time.Time{}, time.Time{}, "", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTxs, resp.Transactions)
+	assert.Empty(t, resp.Bookmark)
+	assert.Equal(t, uint64(0), resp.IndexedFromBlock)
+
+	// paginated query
+	assert.Equal(t, expectedTxs, retrieveAllTxsByTime(t, mgr, nil, time.Time{}, time.Time{}, 7))
+	_, err = mgr.retrieveTxsByTime(nil, time.Time{}, time.Time{}, "not-a-bookmark", 7)
+	assert.EqualError(t, err, "invalid bookmark [not-a-bookmark] for the requested time range")
+
+	// time range, the upper bound being excluded
+	from, to := txTime(expectedTxs[3]), txTime(expectedTxs[len(expectedTxs)-3])
+	var expectedInRange []*peer.IndexedTransaction
+	for _, tx := range expectedTxs {
+		if !txTime(tx).Before(from) && txTime(tx).Before(to) {
+			expectedInRange = append(expectedInRange, tx)
+		}
+	}
+	assert.Equal(t, expectedInRange, retrieveAllTxsByTime(t, mgr, nil, from, to, 3))
+	resp, err = mgr.retrieveTxsByTime(nil, from, from, "", 0)
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Transactions)
+
+	// query by creator
+	creatorHash := expectedTxs[len(expectedTxs)-1].CreatorHash
+	assert.Len(t, creatorHash, sha256.Size)
+	var expectedByCreator []*peer.IndexedTransaction
+	for _, tx := range expectedTxs {
+		if string(tx.CreatorHash) == string(creatorHash) {
+			expectedByCreator = append(expectedByCreator, tx)
+		}
+	}
+	assert.Equal(t, expectedByCreator, retrieveAllTxsByTime(t, mgr, creatorHash, time.Time{}, time.Time{}, 4))
+	unknownCreator := sha256.Sum256([]byte("unknown"))
+	resp, err = mgr.retrieveTxsByTime(unknownCreator[:], time.Time{}, time.Time{}, "", 0)
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Transactions)
+	_, err = mgr.retrieveTxsByTime([]byte("short"), time.Time{}, time.Time{}, "", 0)
+	assert.Error(t, err)
+}
+
+func TestTxTimeIndexNotEnabled(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgrWrapper.addBlocks(testutil.ConstructTestBlocks(t, 2))
+
+	_, err := blkfileMgrWrapper.blockfileMgr.retrieveTxsByTime(nil, time.Time{}, time.Time{}, "", 0)
+	assert.Equal(t, blkstorage.ErrAttrNotIndexed, err)
+	_, err = blkfileMgrWrapper.blockfileMgr.backfillTxTimeIndex()
+	assert.Equal(t, blkstorage.ErrAttrNotIndexed, err)
+}
+
+func TestTxTimeIndexBackfill(t *testing.T) {
+	conf := NewConf(testPath(), maxFileSizeForBlocks(t, testutil.ConstructTestBlocks(t, 2)))
+	env := newTestEnv(t, conf)
+	defer func() { env.Cleanup() }()
+	ledgerid := "testLedger"
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.addBlocks(blocks[:5])
+	blkfileMgrWrapper.close()
+
+	// enabling the index only indexes the blocks committed afterwards
+	env.provider.Close()
+	env = newTestEnvSelectiveIndexing(t, conf, txTimeIndexAttrs)
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.addBlocks(blocks[5:])
+	mgr := blkfileMgrWrapper.blockfileMgr
+	resp, err := mgr.retrieveTxsByTime(nil, time.Time{}, time.Time{}, "", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), resp.IndexedFromBlock)
+	assert.Equal(t, expectedIndexedTxs(t, blocks[5:]), resp.Transactions)
+
+	numBackfilled, err := mgr.backfillTxTimeIndex()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), numBackfilled)
+	resp, err = mgr.retrieveTxsByTime(nil, time.Time{}, time.Time{}, "", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), resp.IndexedFromBlock)
+	assert.Equal(t, expectedIndexedTxs(t, blocks), resp.Transactions)
+
+	numBackfilled, err = mgr.backfillTxTimeIndex()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), numBackfilled)
+	blkfileMgrWrapper.close()
+
+	// disabling the index forgets where it started from
+	env.provider.Close()
+	env = newTestEnv(t, conf)
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+	env = newTestEnvSelectiveIndexing(t, conf, txTimeIndexAttrs)
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	defer blkfileMgrWrapper.close()
+	fromBlock, err := blkfileMgrWrapper.blockfileMgr.loadTxTimeIndexFromBlock()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), fromBlock)
+}
+
+func TestExtractTxTimeAndCreator(t *testing.T) {
+	ts := &timestamp.Timestamp{Seconds: 1000, Nanos: 1}
+	chdr := utils.MarshalOrPanic(&common.ChannelHeader{TxId: "txid", Timestamp: ts})
+	txEnvBytes := func(signatureHeader []byte) []byte {
+		payload := utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{ChannelHeader: chdr, SignatureHeader: signatureHeader},
+		})
+		return utils.MarshalOrPanic(&common.Envelope{Payload: payload})
+	}
+
+	txTime, creator := extractTxTimeAndCreator(txEnvBytes(utils.MarshalOrPanic(&common.SignatureHeader{Creator: []byte("creator")})))
+	assert.True(t, proto.Equal(ts, txTime))
+	assert.Equal(t, []byte("creator"), creator)
+
+	// A signature header which can't be parsed is tolerated, and the transaction has no creator
+	txTime, creator = extractTxTimeAndCreator(txEnvBytes([]byte("garbage")))
+	assert.True(t, proto.Equal(ts, txTime))
+	assert.Nil(t, creator)
+	_, _, err := serializeBlock(&common.Block{
+		Header:   &common.BlockHeader{},
+		Data:     &common.BlockData{Data: [][]byte{txEnvBytes([]byte("garbage"))}},
+		Metadata: &common.BlockMetadata{},
+	})
+	assert.NoError(t, err)
+
+	// So is a payload which can't be parsed
+	txTime, creator = extractTxTimeAndCreator(utils.MarshalOrPanic(&common.Envelope{Payload: []byte("garbage")}))
+	assert.Nil(t, txTime)
+	assert.Nil(t, creator)
+}
+
+// expectedIndexedTxs returns the transactions of the blocks in the order of the index of the transactions by time
+func expectedIndexedTxs(t *testing.T, blocks []*common.Block) []*peer.IndexedTransaction {
+	var txs []*peer.IndexedTransaction
+	for _, block := range blocks {
+		for txNum, txEnvBytes := range block.Data.Data {
+			txID, err := extractTxID(txEnvBytes)
+			assert.NoError(t, err)
+			ts, creator := extractTxTimeAndCreator(txEnvBytes)
+			tx := &peer.IndexedTransaction{
+				TxId:      txID,
+				BlockNum:  block.Header.Number,
+				TxNum:     uint64(txNum),
+				Timestamp: ts,
+			}
+			if len(creator) > 0 {
+				creatorHash := sha256.Sum256(creator)
+				tx.CreatorHash = creatorHash[:]
+			}
+			txs = append(txs, tx)
+		}
+	}
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txTime(txs[i]).Before(txTime(txs[j]))
+	})
+	return txs
+}
+
+func retrieveAllTxsByTime(t *testing.T, mgr *blockfileMgr, creatorHash []byte, from, to time.Time, limit int) []*peer.IndexedTransaction {
+	var txs []*peer.IndexedTransaction
+	bookmark := ""
+	for {
+		resp, err := mgr.retrieveTxsByTime(creatorHash, from, to, bookmark, limit)
+		assert.NoError(t, err)
+		assert.True(t, len(resp.Transactions) <= limit)
+		txs = append(txs, resp.Transactions...)
+		if resp.Bookmark == "" {
+			return txs
+		}
+		bookmark = resp.Bookmark
+	}
+}
+
+func txTime(tx *peer.IndexedTransaction) time.Time {
+	return txTimestamp(tx.Timestamp)
+}
@@ -20,6 +20,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 )
 
 // blocksItr - an iterator for iterating over a sequence of blocks
@@ -54,7 +55,10 @@ func (itr *blocksItr) initStream() error {
 	if lp, err = itr.mgr.index.getBlockLocByBlockNum(itr.blockNumToRetrieve); err != nil {
 		return err
 	}
-	if itr.stream, err = newBlockStream(itr.mgr.rootDir, lp.fileSuffixNum, int64(lp.offset), -1); err != nil {
+	if itr.mgr.isArchived(lp.fileSuffixNum) {
+		return blkstorage.ErrBlockArchived
+	}
+	if itr.stream, err = openBlockStream(itr.mgr.openBlockfile, lp.fileSuffixNum, int64(lp.offset), -1); err != nil {
 		return err
 	}
 	return nil
@@ -0,0 +1,200 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/common"
+)
+
+// VerificationReport is the outcome of the verification of the block files of a ledger
+type VerificationReport struct {
+	LedgerID string
+	// NumBlocks is the number of blocks read from the block files
+	NumBlocks uint64
+	// FirstBlockNum and LastBlockNum are the numbers of the first and the last block read from the block files.
+	// The first block is not the genesis block when the oldest block files are archived
+	FirstBlockNum uint64
+	LastBlockNum  uint64
+	// NumBlocksWithoutChecksum is the number of blocks whose checksum is recorded neither in the block files
+	// nor in the index, typically because they were written by older versions of the block store
+	NumBlocksWithoutChecksum uint64
+	Problems                 []*VerificationProblem
+}
+
+// VerificationProblem describes a corruption detected in a block file
+type VerificationProblem struct {
+	FileNum int
+	// Offset is the offset in the block file of the affected block
+	Offset      int64
+	Description string
+}
+
+func (p *VerificationProblem) String() string {
+	return fmt.Sprintf("blockfile_%06d at offset %d: %s", p.FileNum, p.Offset, p.Description)
+}
+
+// Corrupted tells whether problems were detected in the block files
+func (r *VerificationReport) Corrupted() bool {
+	return len(r.Problems) > 0
+}
+
+// VerifyBlockStore reads the block files of the given ledger, and verifies the checksum of each block against the
+// checksum recorded along with the block and in the block index, the data hash in the header of each block against
+// the transactions of the block, and the previous hash in the header of each block against the header of the
+// preceding block. Neither the block files nor the index are modified, and the peer is expected not to be running.
+// Only the block files present in the ledger directory are verified, not those offloaded to an archive backend.
+// Corruptions are returned in the report, whereas an error is returned if the block files cannot be verified
+func VerifyBlockStore(conf *Conf, ledgerid string) (*VerificationReport, error) {
+	rootDir := conf.getLedgerBlockDir(ledgerid)
+	exists, _, err := util.FileExists(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("no block store found for ledger [%s] in [%s]", ledgerid, conf.getChainsDir())
+	}
+	fileNums, err := blockfileNumsBelow(rootDir, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+	sort.Ints(fileNums)
+
+	var idx index
+	if exists, _, err := util.FileExists(conf.getIndexDir()); err == nil && exists {
+		p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir(), ReadOnly: true})
+		defer p.Close()
+		idx = newBlockIndex(&blkstorage.IndexConfig{}, p.GetDBHandle(ledgerid))
+	} else {
+		logger.Warningf("Block index not found in [%s], the checksums of the blocks are not verified against the index", conf.getIndexDir())
+	}
+
+	v := &blockstoreVerifier{report: &VerificationReport{LedgerID: ledgerid}, index: idx}
+	for i, fileNum := range fileNums {
+		if err := v.verifyBlockfile(rootDir, fileNum, i == len(fileNums)-1); err != nil {
+			return nil, err
+		}
+	}
+	return v.report, nil
+}
+
+type blockstoreVerifier struct {
+	report *VerificationReport
+	index  index
+	// previousHeader is the header of the last block read, which is nil
+	// before the first block and after a block that could not be read
+	previousHeader *common.BlockHeader
+}
+
+func (v *blockstoreVerifier) problem(placement *blockPlacementInfo, format string, args ...interface{}) {
+	p := &VerificationProblem{FileNum: placement.fileNum, Offset: placement.blockStartOffset, Description: fmt.Sprintf(format, args...)}
+	logger.Warningf("Problem detected in the block files of ledger [%s]: %s", v.report.LedgerID, p)
+	v.report.Problems = append(v.report.Problems, p)
+}
+
+func (v *blockstoreVerifier) verifyBlockfile(rootDir string, fileNum int, lastFile bool) error {
+	logger.Infof("Verifying block file [%s]", deriveBlockfilePath(rootDir, fileNum))
+	stream, err := newBlockfileStream(rootDir, fileNum, 0)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+	for {
+		placement := &blockPlacementInfo{fileNum: fileNum, blockStartOffset: stream.currentOffset}
+		blockBytes, readPlacement, err := stream.nextBlockBytesAndPlacementInfo()
+		if readPlacement != nil {
+			placement = readPlacement
+		}
+		switch {
+		case err == ErrUnexpectedEndOfBlockfile:
+			if lastFile {
+				v.problem(placement, "partially written block at the end of the last block file, which may be left by a crash while appending a block")
+			} else {
+				v.problem(placement, "partially written block at the end of the block file")
+			}
+			return nil
+		case err != nil && readPlacement == nil:
+			// the stream cannot move past a record whose header cannot be read
+			v.problem(placement, "block cannot be read, the rest of the block file is not verified: %s", err)
+			v.previousHeader = nil
+			return nil
+		case err != nil:
+			if checksumErr, ok := err.(*ChecksumMismatchError); ok {
+				v.problem(placement, "stored block does not match its checksum [%x], computed checksum is [%x]", checksumErr.Expected, checksumErr.Actual)
+			} else {
+				v.problem(placement, "%s", err)
+			}
+			v.previousHeader = nil
+			continue
+		case blockBytes == nil:
+			return nil
+		}
+		v.verifyBlock(blockBytes, placement)
+	}
+}
+
+func (v *blockstoreVerifier) verifyBlock(blockBytes []byte, placement *blockPlacementInfo) {
+	block, err := deserializeBlock(blockBytes)
+	if err != nil {
+		v.problem(placement, "block cannot be deserialized: %s", err)
+		v.previousHeader = nil
+		return
+	}
+	header := block.Header
+	if v.report.NumBlocks == 0 {
+		v.report.FirstBlockNum = header.Number
+	}
+	v.report.NumBlocks++
+	v.report.LastBlockNum = header.Number
+
+	if !bytes.Equal(header.DataHash, block.Data.Hash()) {
+		v.problem(placement, "data hash of block [%d] does not match the hash of its transactions", header.Number)
+	}
+	if previous := v.previousHeader; previous != nil {
+		if header.Number != previous.Number+1 {
+			v.problem(placement, "block [%d] follows block [%d]", header.Number, previous.Number)
+		} else if !bytes.Equal(header.PreviousHash, previous.Hash()) {
+			v.problem(placement, "previous hash of block [%d] does not match the hash of the header of block [%d]", header.Number, previous.Number)
+		}
+	}
+	v.verifyChecksum(header.Number, blockBytes, placement)
+	v.previousHeader = header
+}
+
+// verifyChecksum verifies the checksum of a block against the one recorded in the index. A block stored as
+// a legacy record carries no checksum in the block file, so its checksum is computed from the stored bytes
+func (v *blockstoreVerifier) verifyChecksum(blockNum uint64, blockBytes []byte, placement *blockPlacementInfo) {
+	indexedChecksum, err := []byte(nil), blkstorage.ErrNotFoundInIndex
+	if v.index != nil {
+		indexedChecksum, err = v.index.getBlockChecksum(blockNum)
+	}
+	switch {
+	case err == blkstorage.ErrNotFoundInIndex:
+		logger.Debugf("Checksum of block [%d] not found in the index", blockNum)
+		if placement.checksum == nil {
+			v.report.NumBlocksWithoutChecksum++
+		}
+	case err != nil:
+		v.problem(placement, "checksum of block [%d] cannot be read from the index: %s", blockNum, err)
+	default:
+		checksum := placement.checksum
+		if checksum == nil {
+			checksum = recordChecksum(blockEncodingNone, blockBytes)
+		}
+		if !bytes.Equal(indexedChecksum, checksum) {
+			v.problem(placement, "checksum [%x] of block [%d] does not match the checksum [%x] recorded in the index",
+				checksum, blockNum, indexedChecksum)
+		}
+	}
+}
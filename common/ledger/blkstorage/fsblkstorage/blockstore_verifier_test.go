@@ -0,0 +1,153 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyBlockStore(t *testing.T) {
+	env := newTestEnv(t, NewConfWithCompression(testPath(), 0, "snappy"))
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	writeLegacyBlockfile(t, env.provider.conf.getLedgerBlockDir(ledgerid), blocks[:3])
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.addBlocks(blocks[3:])
+	blkfileMgrWrapper.close()
+	// the index is locked as long as the provider is open
+	env.provider.Close()
+
+	report, err := VerifyBlockStore(env.provider.conf, ledgerid)
+	assert.NoError(t, err)
+	assert.Equal(t, &VerificationReport{
+		LedgerID:                 ledgerid,
+		NumBlocks:                10,
+		FirstBlockNum:            0,
+		LastBlockNum:             9,
+		NumBlocksWithoutChecksum: 3,
+	}, report)
+	assert.False(t, report.Corrupted())
+
+	_, err = VerifyBlockStore(env.provider.conf, "missingLedger")
+	assert.EqualError(t, err, "no block store found for ledger [missingLedger] in ["+env.provider.conf.getChainsDir()+"]")
+}
+
+func TestVerifyBlockStoreCorruptions(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0).WithChecksums(true))
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	// the previous hash of block 5, which breaks the link from block 6 as well, and the transactions of block 7 are tampered with
+	blocks[5].Header.PreviousHash = []byte("wrong previous hash")
+	blocks[7].Data.Data[0], blocks[7].Data.Data[1] = blocks[7].Data.Data[1], blocks[7].Data.Data[0]
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.addBlocks(blocks)
+	block3Loc, err := blkfileMgrWrapper.blockfileMgr.index.getBlockLocByBlockNum(3)
+	assert.NoError(t, err)
+	block4Loc, err := blkfileMgrWrapper.blockfileMgr.index.getBlockLocByBlockNum(4)
+	assert.NoError(t, err)
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	// a byte of block 3 is flipped on disk
+	filePath := deriveBlockfilePath(env.provider.conf.getLedgerBlockDir(ledgerid), 0)
+	fileBytes, err := ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+	fileBytes[block4Loc.offset-1] ^= 0xff
+	assert.NoError(t, ioutil.WriteFile(filePath, fileBytes, 0644))
+	// and a partially written block is left at the end of the file
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_WRONLY, 0644)
+	assert.NoError(t, err)
+	_, err = file.Write([]byte{framedRecordMarker, 100})
+	assert.NoError(t, err)
+	file.Close()
+	fileBytes, err = ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+
+	report, err := VerifyBlockStore(env.provider.conf, ledgerid)
+	assert.NoError(t, err)
+	assert.True(t, report.Corrupted())
+	assert.Equal(t, uint64(9), report.NumBlocks)
+	assert.Len(t, report.Problems, 5)
+	assert.Equal(t, int64(block3Loc.offset), report.Problems[0].Offset)
+	assert.Contains(t, report.Problems[0].Description, "stored block does not match its checksum")
+	assert.Equal(t, "previous hash of block [5] does not match the hash of the header of block [4]", report.Problems[1].Description)
+	assert.Equal(t, "previous hash of block [6] does not match the hash of the header of block [5]", report.Problems[2].Description)
+	assert.Equal(t, "data hash of block [7] does not match the hash of its transactions", report.Problems[3].Description)
+	assert.Equal(t, int64(len(fileBytes)-2), report.Problems[4].Offset)
+	assert.Contains(t, report.Problems[4].Description, "partially written block")
+
+	// the block files are not modified by the verification
+	verifiedFileBytes, err := ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, fileBytes, verifiedFileBytes)
+}
+
+func TestVerifyBlockStoreIndexMismatch(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0).WithChecksums(true))
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	blocks := testutil.ConstructTestBlocks(t, 3)
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.addBlocks(blocks)
+	// the checksum of block 1 recorded in the index differs from the one of the block file
+	blkfileMgrWrapper.blockfileMgr.index.indexBlock(&blockIdxInfo{
+		blockNum: 1, blockHash: blocks[1].Header.Hash(),
+		flp: &fileLocPointer{}, metadata: &common.BlockMetadata{Metadata: [][]byte{{}, {}, {}, {}}},
+		checksum: []byte{1, 2, 3, 4}})
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	report, err := VerifyBlockStore(env.provider.conf, ledgerid)
+	assert.NoError(t, err)
+	assert.Len(t, report.Problems, 1)
+	assert.Contains(t, report.Problems[0].Description, "of block [1] does not match the checksum [01020304] recorded in the index")
+}
+
+func TestVerifyBlockStoreLegacyRecords(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	// the serialized block 3 ends with the bytes of its last metadata
+	metadata := blocks[3].Metadata.Metadata
+	metadata[len(metadata)-1] = []byte("metadata")
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.addBlocks(blocks)
+	block3Loc, err := blkfileMgrWrapper.blockfileMgr.index.getBlockLocByBlockNum(3)
+	assert.NoError(t, err)
+	block4Loc, err := blkfileMgrWrapper.blockfileMgr.index.getBlockLocByBlockNum(4)
+	assert.NoError(t, err)
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	// the blocks are stored as legacy records, whose checksum is recorded in the index only
+	report, err := VerifyBlockStore(env.provider.conf, ledgerid)
+	assert.NoError(t, err)
+	assert.False(t, report.Corrupted())
+	assert.Equal(t, uint64(0), report.NumBlocksWithoutChecksum)
+
+	// a byte of the metadata of block 3 is flipped on disk, which is only detected by the checksum
+	filePath := deriveBlockfilePath(env.provider.conf.getLedgerBlockDir(ledgerid), 0)
+	fileBytes, err := ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+	fileBytes[block4Loc.offset-1] ^= 0xff
+	assert.NoError(t, ioutil.WriteFile(filePath, fileBytes, 0644))
+
+	report, err = VerifyBlockStore(env.provider.conf, ledgerid)
+	assert.NoError(t, err)
+	assert.Len(t, report.Problems, 1)
+	assert.Equal(t, int64(block3Loc.offset), report.Problems[0].Offset)
+	assert.Contains(t, report.Problems[0].Description, "of block [3] does not match the checksum")
+}
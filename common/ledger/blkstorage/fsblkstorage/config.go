@@ -24,12 +24,17 @@ const (
 	// IndexDir is the name of the directory containing all block indexes across ledgers.
 	IndexDir                = "index"
 	defaultMaxBlockfileSize = 64 * 1024 * 1024 // bytes
+	// tierCacheDir is the name of the default directory caching the block files fetched from an archive backend
+	tierCacheDir = "tiercache"
 )
 
 // Conf encapsulates all the configurations for `FsBlockStore`
 type Conf struct {
 	blockStorageDir  string
 	maxBlockfileSize int
+	compression      string
+	checksums        bool
+	tiering          *TieringConf
 }
 
 // NewConf constructs new `Conf`.
@@ -38,7 +43,33 @@ func NewConf(blockStorageDir string, maxBlockfileSize int) *Conf {
 	if maxBlockfileSize <= 0 {
 		maxBlockfileSize = defaultMaxBlockfileSize
 	}
-	return &Conf{blockStorageDir, maxBlockfileSize}
+	return &Conf{blockStorageDir, maxBlockfileSize, "", false, nil}
+}
+
+// NewConfWithCompression constructs new `Conf` for a `FsBlockStore` that compresses the blocks it appends to the block files.
+// compression is the name of the compression algorithm, either "none" or "snappy". Blocks are read back irrespective of
+// the compression they were stored with, so the compression can be changed across restarts
+func NewConfWithCompression(blockStorageDir string, maxBlockfileSize int, compression string) *Conf {
+	conf := NewConf(blockStorageDir, maxBlockfileSize)
+	conf.compression = compression
+	return conf
+}
+
+// WithChecksums returns a copy of the configuration for a `FsBlockStore` that stores a checksum along with each block
+// it appends to the block files, even if the block is not compressed. Compressed blocks are always stored with a checksum.
+// Otherwise, the checksum of an uncompressed block is recorded in the block index only
+func (conf *Conf) WithChecksums(checksums bool) *Conf {
+	c := *conf
+	c.checksums = checksums
+	return &c
+}
+
+// WithTiering returns a copy of the configuration for a `FsBlockStore` that offloads its old block files
+// to an archive backend, as described by tiering, and fetches them back on demand
+func (conf *Conf) WithTiering(tiering *TieringConf) *Conf {
+	c := *conf
+	c.tiering = tiering
+	return &c
 }
 
 func (conf *Conf) getIndexDir() string {
@@ -52,3 +83,10 @@ func (conf *Conf) getChainsDir() string {
 func (conf *Conf) getLedgerBlockDir(ledgerid string) string {
 	return filepath.Join(conf.getChainsDir(), ledgerid)
 }
+
+func (conf *Conf) getTierCacheDir(ledgerid string) string {
+	if conf.tiering != nil && conf.tiering.CacheDir != "" {
+		return filepath.Join(conf.tiering.CacheDir, ledgerid)
+	}
+	return filepath.Join(conf.blockStorageDir, tierCacheDir, ledgerid)
+}
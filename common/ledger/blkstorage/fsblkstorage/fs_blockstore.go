@@ -17,6 +17,9 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"path/filepath"
+	"time"
+
 	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
@@ -86,6 +89,42 @@ func (store *fsBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
 }
 
+// RetrieveTxsByTime returns a page of the transactions whose timestamp is in [from, to), optionally restricted to
+// the transactions created by the identity of hash creatorHash. It returns blkstorage.ErrAttrNotIndexed if the
+// transactions are not indexed by time.
+func (store *fsBlockStore) RetrieveTxsByTime(creatorHash []byte, from, to time.Time, bookmark string, limit int) (*peer.TxQueryResponse, error) {
+	return store.fileMgr.retrieveTxsByTime(creatorHash, from, to, bookmark, limit)
+}
+
+// ArchiveBlocks moves the block files that only contain blocks with a number lower than blockNum,
+// and that were last modified before modifiedBefore, to the archive described by archiveConf.
+// A zero modifiedBefore disables the age check. Archived blocks can no longer be retrieved and
+// the retrieval functions return blkstorage.ErrBlockArchived for them.
+// It returns the number of the oldest block that is still available in the block store.
+func (store *fsBlockStore) ArchiveBlocks(blockNum uint64, modifiedBefore time.Time, archiveConf *ArchiveConf) (uint64, error) {
+	archiveDir := filepath.Join(archiveConf.ArchiveDir, store.id)
+	return store.fileMgr.archiveBlockfiles(blockNum, modifiedBefore, archiveDir, archiveConf.Compress)
+}
+
+// FirstAvailableBlockNum returns the number of the oldest block that has not been archived
+func (store *fsBlockStore) FirstAvailableBlockNum() uint64 {
+	return store.fileMgr.getArchiveInfo().firstAvailableBlockNum
+}
+
+// OffloadBlocks moves the block files that were last modified before modifiedBefore to the archive backend
+// configured through `Conf.WithTiering`. A zero modifiedBefore disables the age check. Unlike archived
+// blocks, offloaded blocks are still retrieved, the block files being fetched back from the backend.
+// It returns the number of block files offloaded.
+func (store *fsBlockStore) OffloadBlocks(modifiedBefore time.Time) (int, error) {
+	return store.fileMgr.offloadBlockfiles(modifiedBefore)
+}
+
+// BackfillTxTimeIndex indexes by time the transactions of the blocks committed before the index of the
+// transactions by time was enabled, except for the archived blocks. It returns the number of blocks backfilled.
+func (store *fsBlockStore) BackfillTxTimeIndex() (uint64, error) {
+	return store.fileMgr.backfillTxTimeIndex()
+}
+
 // Shutdown shuts down the block store
 func (store *fsBlockStore) Shutdown() {
 	logger.Debugf("closing fs blockStore:%s", store.id)
@@ -18,6 +18,7 @@ package fileledger
 
 import (
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
@@ -28,6 +29,8 @@ type fileLedgerFactory struct {
 	blkstorageProvider blkstorage.BlockStoreProvider
 	ledgers            map[string]blockledger.ReadWriter
 	mutex              sync.Mutex
+	retention          *RetentionPolicy
+	doneC              chan struct{}
 }
 
 // GetOrCreate gets an existing ledger (if it exists) or creates it if it does not
@@ -62,9 +65,43 @@ func (flf *fileLedgerFactory) ChainIDs() []string {
 
 // Close releases all resources acquired by the factory
 func (flf *fileLedgerFactory) Close() {
+	if flf.doneC != nil {
+		close(flf.doneC)
+	}
 	flf.blkstorageProvider.Close()
 }
 
+// applyRetention archives the old block files of all the ledgers opened so far
+func (flf *fileLedgerFactory) applyRetention() {
+	flf.mutex.Lock()
+	ledgers := make(map[string]*FileLedger, len(flf.ledgers))
+	for chainID, ledger := range flf.ledgers {
+		if fl, ok := ledger.(*FileLedger); ok {
+			ledgers[chainID] = fl
+		}
+	}
+	flf.mutex.Unlock()
+
+	for chainID, fl := range ledgers {
+		if err := fl.archive(flf.retention); err != nil {
+			logger.Errorf("Failed archiving old blocks of chain %s: %s", chainID, err)
+		}
+	}
+}
+
+func (flf *fileLedgerFactory) retentionLoop() {
+	ticker := time.NewTicker(flf.retention.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			flf.applyRetention()
+		case <-flf.doneC:
+			return
+		}
+	}
+}
+
 // New creates a new ledger factory
 func New(directory string) blockledger.Factory {
 	return &fileLedgerFactory{
@@ -76,3 +113,13 @@ func New(directory string) blockledger.Factory {
 		ledgers: make(map[string]blockledger.ReadWriter),
 	}
 }
+
+// NewWithRetention creates a new ledger factory which periodically moves the
+// block files that fall outside of the retention policy to an archive
+func NewWithRetention(directory string, retention *RetentionPolicy) blockledger.Factory {
+	flf := New(directory).(*fileLedgerFactory)
+	flf.retention = retention
+	flf.doneC = make(chan struct{})
+	go flf.retentionLoop()
+	return flf
+}
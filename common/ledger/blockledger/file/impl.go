@@ -19,6 +19,7 @@ package fileledger
 import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -67,6 +68,10 @@ type fileLedgerIterator struct {
 // It returns an error if the next block is no longer retrievable.
 func (i *fileLedgerIterator) Next() (*cb.Block, cb.Status) {
 	result, err := i.commonIterator.Next()
+	if err == blkstorage.ErrBlockArchived {
+		logger.Warning("Requested block has been archived and is no longer available from this ledger")
+		return nil, cb.Status_NOT_FOUND
+	}
 	if err != nil {
 		logger.Error(err)
 		return nil, cb.Status_SERVICE_UNAVAILABLE
@@ -98,7 +103,7 @@ func (fl *FileLedger) Iterator(startPosition *ab.SeekPosition) (blockledger.Iter
 	var startingBlockNumber uint64
 	switch start := startPosition.Type.(type) {
 	case *ab.SeekPosition_Oldest:
-		startingBlockNumber = 0
+		startingBlockNumber = fl.oldestBlockNumber()
 	case *ab.SeekPosition_Newest:
 		info, err := fl.blockStore.GetBlockchainInfo()
 		if err != nil {
@@ -117,6 +122,10 @@ func (fl *FileLedger) Iterator(startPosition *ab.SeekPosition) (blockledger.Iter
 	}
 
 	iterator, err := fl.blockStore.RetrieveBlocks(startingBlockNumber)
+	if err == blkstorage.ErrBlockArchived {
+		logger.Warningf("Block [%d] has been archived and is no longer available from this ledger", startingBlockNumber)
+		return &blockledger.NotFoundErrorIterator{}, 0
+	}
 	if err != nil {
 		return &blockledger.NotFoundErrorIterator{}, 0
 	}
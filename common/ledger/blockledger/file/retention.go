@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fileledger
+
+import (
+	"math"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// RetentionPolicy configures the archiving of the old block files of the file ledgers.
+// A block file is archived only once all of its blocks fall outside of both the
+// BlocksToKeep and the MaxAge windows. Setting a window to zero disables it.
+type RetentionPolicy struct {
+	// BlocksToKeep is the number of most recent blocks that are never archived
+	BlocksToKeep uint64
+	// MaxAge is the minimum time since a block file was last written to before it may be archived
+	MaxAge time.Duration
+	// ArchiveDir is the directory the archived block files are moved to
+	ArchiveDir string
+	// Compress stores the archived block files as gzip compressed tarballs
+	Compress bool
+	// CheckInterval is how often the ledgers are checked for block files to archive
+	CheckInterval time.Duration
+}
+
+// archivableBlockStore is implemented by the block stores which support moving
+// their old blocks to an archive
+type archivableBlockStore interface {
+	RetrieveBlockByNumber(blockNum uint64) (*cb.Block, error)
+	ArchiveBlocks(blockNum uint64, modifiedBefore time.Time, archiveConf *fsblkstorage.ArchiveConf) (uint64, error)
+	FirstAvailableBlockNum() uint64
+}
+
+// oldestBlockNumber returns the number of the oldest block which has not been archived
+func (fl *FileLedger) oldestBlockNumber() uint64 {
+	blockStore, ok := fl.blockStore.(archivableBlockStore)
+	if !ok {
+		return 0
+	}
+	return blockStore.FirstAvailableBlockNum()
+}
+
+// archive moves the block files which fall outside of the retention policy to the archive.
+// The last config block is always retained, as the orderer needs it to resume the chain.
+func (fl *FileLedger) archive(policy *RetentionPolicy) error {
+	blockStore, ok := fl.blockStore.(archivableBlockStore)
+	if !ok {
+		logger.Debugf("Block store does not support archiving, skipping")
+		return nil
+	}
+
+	height := fl.Height()
+	if height <= policy.BlocksToKeep {
+		return nil
+	}
+	retainFrom := height - policy.BlocksToKeep
+
+	lastBlock, err := blockStore.RetrieveBlockByNumber(math.MaxUint64)
+	if err != nil {
+		return err
+	}
+	lastConfigIndex, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return err
+	}
+	if lastConfigIndex < retainFrom {
+		retainFrom = lastConfigIndex
+	}
+
+	var modifiedBefore time.Time
+	if policy.MaxAge > 0 {
+		modifiedBefore = time.Now().Add(-policy.MaxAge)
+	}
+	firstAvailable, err := blockStore.ArchiveBlocks(retainFrom, modifiedBefore, &fsblkstorage.ArchiveConf{
+		ArchiveDir: policy.ArchiveDir,
+		Compress:   policy.Compress,
+	})
+	if err != nil {
+		return err
+	}
+	logger.Debugf("Oldest block available after applying retention policy is [%d]", firstAvailable)
+	return nil
+}
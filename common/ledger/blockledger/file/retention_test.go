@@ -0,0 +1,186 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fileledger
+
+import (
+	"testing"
+	"time"
+
+	cl "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockArchivableBlockStore struct {
+	mockBlockStore
+	archivedBelow  uint64
+	modifiedBefore time.Time
+	archiveConf    *fsblkstorage.ArchiveConf
+	archiveCalls   int
+	firstAvailable uint64
+	retrievedFrom  uint64
+}
+
+func (mbs *mockArchivableBlockStore) FirstAvailableBlockNum() uint64 {
+	return mbs.firstAvailable
+}
+
+func (mbs *mockArchivableBlockStore) RetrieveBlocks(startNum uint64) (cl.ResultsIterator, error) {
+	mbs.retrievedFrom = startNum
+	return mbs.mockBlockStore.RetrieveBlocks(startNum)
+}
+
+func (mbs *mockArchivableBlockStore) ArchiveBlocks(blockNum uint64, modifiedBefore time.Time, archiveConf *fsblkstorage.ArchiveConf) (uint64, error) {
+	mbs.archiveCalls++
+	mbs.archivedBelow = blockNum
+	mbs.modifiedBefore = modifiedBefore
+	mbs.archiveConf = archiveConf
+	return blockNum, nil
+}
+
+func blockWithLastConfig(number uint64, lastConfig uint64) *cb.Block {
+	block := cb.NewBlock(number, nil)
+	block.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&cb.Metadata{
+		Value: utils.MarshalOrPanic(&cb.LastConfig{Index: lastConfig}),
+	})
+	return block
+}
+
+func TestArchive(t *testing.T) {
+	policy := &RetentionPolicy{BlocksToKeep: 3, ArchiveDir: "/archive", Compress: true}
+
+	t.Run("BelowBlocksToKeep", func(t *testing.T) {
+		store := &mockArchivableBlockStore{mockBlockStore: mockBlockStore{
+			blockchainInfo: &cb.BlockchainInfo{Height: 3},
+			block:          blockWithLastConfig(2, 0),
+		}}
+		fl := NewFileLedger(store)
+		assert.NoError(t, fl.archive(policy))
+		assert.Equal(t, 0, store.archiveCalls)
+	})
+
+	t.Run("KeepsRecentBlocks", func(t *testing.T) {
+		store := &mockArchivableBlockStore{mockBlockStore: mockBlockStore{
+			blockchainInfo: &cb.BlockchainInfo{Height: 10},
+			block:          blockWithLastConfig(9, 8),
+		}}
+		fl := NewFileLedger(store)
+		assert.NoError(t, fl.archive(policy))
+		assert.Equal(t, 1, store.archiveCalls)
+		assert.Equal(t, uint64(7), store.archivedBelow)
+		assert.True(t, store.modifiedBefore.IsZero())
+		assert.Equal(t, &fsblkstorage.ArchiveConf{ArchiveDir: "/archive", Compress: true}, store.archiveConf)
+	})
+
+	t.Run("KeepsLastConfigBlock", func(t *testing.T) {
+		store := &mockArchivableBlockStore{mockBlockStore: mockBlockStore{
+			blockchainInfo: &cb.BlockchainInfo{Height: 10},
+			block:          blockWithLastConfig(9, 5),
+		}}
+		fl := NewFileLedger(store)
+		assert.NoError(t, fl.archive(policy))
+		assert.Equal(t, uint64(5), store.archivedBelow)
+	})
+
+	t.Run("MaxAge", func(t *testing.T) {
+		store := &mockArchivableBlockStore{mockBlockStore: mockBlockStore{
+			blockchainInfo: &cb.BlockchainInfo{Height: 10},
+			block:          blockWithLastConfig(9, 9),
+		}}
+		fl := NewFileLedger(store)
+		assert.NoError(t, fl.archive(&RetentionPolicy{MaxAge: time.Hour}))
+		assert.Equal(t, uint64(9), store.archivedBelow)
+		assert.WithinDuration(t, time.Now().Add(-time.Hour), store.modifiedBefore, time.Minute)
+	})
+
+	t.Run("NotArchivable", func(t *testing.T) {
+		fl := NewFileLedger(&mockBlockStore{blockchainInfo: &cb.BlockchainInfo{Height: 10}})
+		assert.NoError(t, fl.archive(policy))
+	})
+
+	t.Run("BadLastBlock", func(t *testing.T) {
+		block := cb.NewBlock(9, nil)
+		block.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = []byte("garbage")
+		store := &mockArchivableBlockStore{mockBlockStore: mockBlockStore{
+			blockchainInfo: &cb.BlockchainInfo{Height: 10},
+			block:          block,
+		}}
+		fl := NewFileLedger(store)
+		assert.Error(t, fl.archive(policy))
+		assert.Equal(t, 0, store.archiveCalls)
+	})
+}
+
+func TestArchivedRetrieval(t *testing.T) {
+	{
+		fl := NewFileLedger(&mockBlockStore{
+			blockchainInfo: &cb.BlockchainInfo{Height: 10},
+			defaultError:   blkstorage.ErrBlockArchived,
+		})
+		it, _ := fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+		defer it.Close()
+		_, status := it.Next()
+		assert.Equal(t, cb.Status_NOT_FOUND, status)
+	}
+
+	{
+		resultsIterator := &mockBlockStoreIterator{}
+		resultsIterator.On("Next").Return(nil, blkstorage.ErrBlockArchived)
+		resultsIterator.On("Close").Return()
+		fl := NewFileLedger(&mockBlockStore{
+			blockchainInfo:  &cb.BlockchainInfo{Height: 10},
+			resultsIterator: resultsIterator,
+		})
+		it, _ := fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 3}}})
+		defer it.Close()
+		_, status := it.Next()
+		assert.Equal(t, cb.Status_NOT_FOUND, status)
+	}
+}
+
+func TestOldestAfterArchiving(t *testing.T) {
+	resultsIterator := &mockBlockStoreIterator{}
+	resultsIterator.On("Next").Return(cb.NewBlock(5, nil), nil)
+	resultsIterator.On("Close").Return()
+	store := &mockArchivableBlockStore{
+		mockBlockStore: mockBlockStore{
+			blockchainInfo:  &cb.BlockchainInfo{Height: 10},
+			resultsIterator: resultsIterator,
+		},
+		firstAvailable: 5,
+	}
+	fl := NewFileLedger(store)
+	it, num := fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+	defer it.Close()
+	assert.Equal(t, uint64(5), num)
+	assert.Equal(t, uint64(5), store.retrievedFrom)
+	block, status := it.Next()
+	assert.Equal(t, cb.Status_SUCCESS, status)
+	assert.Equal(t, uint64(5), block.Header.Number)
+}
+
+func TestApplyRetention(t *testing.T) {
+	store := &mockArchivableBlockStore{mockBlockStore: mockBlockStore{
+		blockchainInfo: &cb.BlockchainInfo{Height: 10},
+		block:          blockWithLastConfig(9, 9),
+	}}
+	flf := &fileLedgerFactory{
+		blkstorageProvider: &mockBlockStoreProvider{blockstore: store},
+		ledgers:            make(map[string]blockledger.ReadWriter),
+		retention:          &RetentionPolicy{BlocksToKeep: 5},
+	}
+	_, err := flf.GetOrCreate("foo")
+	assert.NoError(t, err)
+	flf.applyRetention()
+	assert.Equal(t, 1, store.archiveCalls)
+	assert.Equal(t, uint64(5), store.archivedBelow)
+}
@@ -107,7 +107,7 @@ func (h *DBHandle) GetIterator(startKey []byte, endKey []byte) *Iterator {
 		eKey[len(eKey)-1] = lastKeyIndicator
 	}
 	logger.Debugf("Getting iterator for range [%#v] - [%#v]", sKey, eKey)
-	return &Iterator{h.db.GetIterator(sKey, eKey)}
+	return &Iterator{h.dbName, h.db.GetIterator(sKey, eKey)}
 }
 
 // UpdateBatch encloses the details of multiple `updates`
@@ -135,6 +135,7 @@ func (batch *UpdateBatch) Delete(key []byte) {
 
 // Iterator extends actual leveldb iterator
 type Iterator struct {
+	dbName string
 	iterator.Iterator
 }
 
@@ -143,6 +144,12 @@ func (itr *Iterator) Key() []byte {
 	return retrieveAppKey(itr.Iterator.Key())
 }
 
+// Seek moves the iterator to the first key that is greater than or equal to the given key,
+// it wraps actual leveldb iterator method so that the key is looked up in the db of the handle
+func (itr *Iterator) Seek(key []byte) bool {
+	return itr.Iterator.Seek(constructLevelKey(itr.dbName, key))
+}
+
 func constructLevelKey(dbName string, key []byte) []byte {
 	return append(append([]byte(dbName), dbNameKeySep...), key...)
 }
@@ -52,6 +52,15 @@ func TestIterator(t *testing.T) {
 	itr3 := db2.GetIterator(nil, nil)
 	defer itr3.Release()
 	checkItrResults(t, itr3, createTestKeys(0, 19), createTestValues("db2", 0, 19))
+
+	itr4 := db2.GetIterator([]byte(createTestKey(2)), []byte(createTestKey(10)))
+	defer itr4.Release()
+	testutil.AssertEquals(t, itr4.Seek([]byte(createTestKey(5))), true)
+	testutil.AssertEquals(t, string(itr4.Key()), createTestKey(5))
+	testutil.AssertEquals(t, string(itr4.Value()), createTestValue("db2", 5))
+	testutil.AssertEquals(t, itr4.Prev(), true)
+	testutil.AssertEquals(t, string(itr4.Key()), createTestKey(4))
+	testutil.AssertEquals(t, itr4.Seek([]byte(createTestKey(10))), false)
 }
 
 func TestBatchedUpdates(t *testing.T) {
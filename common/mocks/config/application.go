@@ -21,12 +21,14 @@ func (m *MockApplication) Capabilities() channelconfig.ApplicationCapabilities {
 }
 
 type MockApplicationCapabilities struct {
-	SupportedRv                  error
-	ForbidDuplicateTXIdInBlockRv bool
-	ResourcesTreeRv              bool
-	PrivateChannelDataRv         bool
-	V1_1ValidationRv             bool
-	MetadataLifecycleRv          bool
+	SupportedRv                     error
+	ForbidDuplicateTXIdInBlockRv    bool
+	ResourcesTreeRv                 bool
+	PrivateChannelDataRv            bool
+	V1_1ValidationRv                bool
+	V1_3ValidationRv                bool
+	CollectionEndorsementPoliciesRv bool
+	MetadataLifecycleRv             bool
 }
 
 func (mac *MockApplicationCapabilities) Supported() error {
@@ -49,6 +51,14 @@ func (mac *MockApplicationCapabilities) V1_1Validation() bool {
 	return mac.V1_1ValidationRv
 }
 
+func (mac *MockApplicationCapabilities) V1_3Validation() bool {
+	return mac.V1_3ValidationRv
+}
+
+func (mac *MockApplicationCapabilities) CollectionEndorsementPolicies() bool {
+	return mac.CollectionEndorsementPoliciesRv
+}
+
 func (mac *MockApplicationCapabilities) MetadataLifecycle() bool {
 	return mac.MetadataLifecycleRv
 }
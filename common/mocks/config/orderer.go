@@ -17,6 +17,12 @@ import (
 type Orderer struct {
 	// ConsensusTypeVal is returned as the result of ConsensusType()
 	ConsensusTypeVal string
+	// ConsensusMetadataVal is returned as the result of ConsensusMetadata()
+	ConsensusMetadataVal []byte
+	// ConsensusStateVal is returned as the result of ConsensusState()
+	ConsensusStateVal ab.ConsensusType_State
+	// BatchingStrategyVal is returned as the result of BatchingStrategy()
+	BatchingStrategyVal *ab.BatchingStrategy
 	// BatchSizeVal is returned as the result of BatchSize()
 	BatchSizeVal *ab.BatchSize
 	// BatchTimeoutVal is returned as the result of BatchTimeout()
@@ -36,6 +42,16 @@ func (scm *Orderer) ConsensusType() string {
 	return scm.ConsensusTypeVal
 }
 
+// ConsensusMetadata returns the ConsensusMetadataVal
+func (scm *Orderer) ConsensusMetadata() []byte {
+	return scm.ConsensusMetadataVal
+}
+
+// ConsensusState returns the ConsensusStateVal
+func (scm *Orderer) ConsensusState() ab.ConsensusType_State {
+	return scm.ConsensusStateVal
+}
+
 // BatchSize returns the BatchSizeVal
 func (scm *Orderer) BatchSize() *ab.BatchSize {
 	return scm.BatchSizeVal
@@ -46,6 +62,11 @@ func (scm *Orderer) BatchTimeout() time.Duration {
 	return scm.BatchTimeoutVal
 }
 
+// BatchingStrategy returns the BatchingStrategyVal
+func (scm *Orderer) BatchingStrategy() *ab.BatchingStrategy {
+	return scm.BatchingStrategyVal
+}
+
 // KafkaBrokers returns the KafkaBrokersVal
 func (scm *Orderer) KafkaBrokers() []string {
 	return scm.KafkaBrokersVal
@@ -79,6 +100,12 @@ type OrdererCapabilities struct {
 
 	// ExpirationVal is returned by ExpirationCheck()
 	ExpirationVal bool
+
+	// ConsensusTypeMigrationVal is returned by ConsensusTypeMigration()
+	ConsensusTypeMigrationVal bool
+
+	// BatchingStrategiesVal is returned by BatchingStrategies()
+	BatchingStrategiesVal bool
 }
 
 // Supported returns SupportedErr
@@ -101,3 +128,13 @@ func (oc *OrdererCapabilities) Resubmission() bool {
 func (oc *OrdererCapabilities) ExpirationCheck() bool {
 	return oc.ExpirationVal
 }
+
+// ConsensusTypeMigration returns ConsensusTypeMigrationVal
+func (oc *OrdererCapabilities) ConsensusTypeMigration() bool {
+	return oc.ConsensusTypeMigrationVal
+}
+
+// BatchingStrategies returns BatchingStrategiesVal
+func (oc *OrdererCapabilities) BatchingStrategies() bool {
+	return oc.BatchingStrategiesVal
+}
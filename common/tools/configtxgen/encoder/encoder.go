@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 
@@ -154,6 +155,19 @@ func NewOrdererGroup(conf *genesisconfig.Orderer) (*cb.ConfigGroup, error) {
 	addValue(ordererGroup, channelconfig.BatchTimeoutValue(conf.BatchTimeout.String()), channelconfig.AdminsPolicyKey)
 	addValue(ordererGroup, channelconfig.ChannelRestrictionsValue(conf.MaxChannels), channelconfig.AdminsPolicyKey)
 
+	// The batching strategy is only encoded when set, as orderers which predate it cannot parse the value
+	if conf.BatchingStrategy.Type != "" {
+		strategyType, ok := ab.BatchingStrategy_Type_value[conf.BatchingStrategy.Type]
+		if !ok {
+			return nil, errors.Errorf("unknown batching strategy: %s", conf.BatchingStrategy.Type)
+		}
+		var minBatchTimeout string
+		if conf.BatchingStrategy.MinBatchTimeout > 0 {
+			minBatchTimeout = conf.BatchingStrategy.MinBatchTimeout.String()
+		}
+		addValue(ordererGroup, channelconfig.BatchingStrategyValue(ab.BatchingStrategy_Type(strategyType), minBatchTimeout), channelconfig.AdminsPolicyKey)
+	}
+
 	if len(conf.Capabilities) > 0 {
 		addValue(ordererGroup, channelconfig.CapabilitiesValue(conf.Capabilities), channelconfig.AdminsPolicyKey)
 	}
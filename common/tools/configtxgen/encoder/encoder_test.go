@@ -8,6 +8,7 @@ package encoder
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/channelconfig"
@@ -17,6 +18,7 @@ import (
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 
@@ -263,6 +265,24 @@ func TestNewOrdererGroup(t *testing.T) {
 		assert.Error(t, err)
 		assert.Nil(t, group)
 	})
+
+	t.Run("Batching strategy", func(t *testing.T) {
+		config := genesisconfig.Load(genesisconfig.SampleDevModeSoloProfile)
+		group, err := NewOrdererGroup(config.Orderer)
+		assert.NoError(t, err)
+		assert.NotContains(t, group.Values, channelconfig.BatchingStrategyKey, "Unset batching strategy should not be encoded")
+
+		config.Orderer.BatchingStrategy = genesisconfig.BatchingStrategy{Type: "ADAPTIVE_TIMEOUT", MinBatchTimeout: 100 * time.Millisecond}
+		group, err = NewOrdererGroup(config.Orderer)
+		assert.NoError(t, err)
+		strategy := &ab.BatchingStrategy{}
+		assert.NoError(t, proto.Unmarshal(group.Values[channelconfig.BatchingStrategyKey].Value, strategy))
+		assert.Equal(t, &ab.BatchingStrategy{Type: ab.BatchingStrategy_ADAPTIVE_TIMEOUT, MinBatchTimeout: "100ms"}, strategy)
+
+		config.Orderer.BatchingStrategy.Type = "FOO"
+		_, err = NewOrdererGroup(config.Orderer)
+		assert.EqualError(t, err, "unknown batching strategy: FOO")
+	})
 }
 
 func TestBootstrapper(t *testing.T) {
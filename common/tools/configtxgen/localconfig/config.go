@@ -137,14 +137,15 @@ type AnchorPeer struct {
 // Orderer contains configuration which is used for the
 // bootstrapping of an orderer by the provisional bootstrapper.
 type Orderer struct {
-	OrdererType   string          `yaml:"OrdererType"`
-	Addresses     []string        `yaml:"Addresses"`
-	BatchTimeout  time.Duration   `yaml:"BatchTimeout"`
-	BatchSize     BatchSize       `yaml:"BatchSize"`
-	Kafka         Kafka           `yaml:"Kafka"`
-	Organizations []*Organization `yaml:"Organizations"`
-	MaxChannels   uint64          `yaml:"MaxChannels"`
-	Capabilities  map[string]bool `yaml:"Capabilities"`
+	OrdererType      string           `yaml:"OrdererType"`
+	Addresses        []string         `yaml:"Addresses"`
+	BatchTimeout     time.Duration    `yaml:"BatchTimeout"`
+	BatchSize        BatchSize        `yaml:"BatchSize"`
+	BatchingStrategy BatchingStrategy `yaml:"BatchingStrategy"`
+	Kafka            Kafka            `yaml:"Kafka"`
+	Organizations    []*Organization  `yaml:"Organizations"`
+	MaxChannels      uint64           `yaml:"MaxChannels"`
+	Capabilities     map[string]bool  `yaml:"Capabilities"`
 }
 
 // BatchSize contains configuration affecting the size of batches.
@@ -154,6 +155,12 @@ type BatchSize struct {
 	PreferredMaxBytes uint32 `yaml:"PreferredMaxBytes"`
 }
 
+// BatchingStrategy selects how the ordered messages are grouped into batches.
+type BatchingStrategy struct {
+	Type            string        `yaml:"Type"`
+	MinBatchTimeout time.Duration `yaml:"MinBatchTimeout"`
+}
+
 // Kafka contains configuration for the Kafka-based orderer.
 type Kafka struct {
 	Brokers []string `yaml:"Brokers"`
@@ -74,6 +74,12 @@ func (d *defaultACLProvider) initialize() {
 	d.cResourcePolicyMap[resources.QSCC_GetBlockByHash] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.QSCC_GetTransactionByID] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.QSCC_GetBlockByTxID] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.QSCC_GetPvtDataStatus] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.QSCC_GetStateAtHeight] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.QSCC_GetStateByRangeAtHeight] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.QSCC_GetIndexStatus] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.QSCC_GetTransactionsByTime] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.QSCC_GetTransactionsByCreator] = CHANNELREADERS
 
 	//--------------- CSCC resources -----------
 	//p resources (implemented by the chaincode currently)
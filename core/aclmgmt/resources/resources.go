@@ -24,11 +24,17 @@ const (
 	LSCC_GETINSTALLEDCHAINCODES = "LSCC.GETINSTALLEDCHAINCODES"
 
 	//QSCC resources
-	QSCC_GetChainInfo       = "QSCC.GetChainInfo"
-	QSCC_GetBlockByNumber   = "QSCC.GetBlockByNumber"
-	QSCC_GetBlockByHash     = "QSCC.GetBlockByHash"
-	QSCC_GetTransactionByID = "QSCC.GetTransactionByID"
-	QSCC_GetBlockByTxID     = "QSCC.GetBlockByTxID"
+	QSCC_GetChainInfo             = "QSCC.GetChainInfo"
+	QSCC_GetBlockByNumber         = "QSCC.GetBlockByNumber"
+	QSCC_GetBlockByHash           = "QSCC.GetBlockByHash"
+	QSCC_GetTransactionByID       = "QSCC.GetTransactionByID"
+	QSCC_GetBlockByTxID           = "QSCC.GetBlockByTxID"
+	QSCC_GetPvtDataStatus         = "QSCC.GetPvtDataStatus"
+	QSCC_GetStateAtHeight         = "QSCC.GetStateAtHeight"
+	QSCC_GetStateByRangeAtHeight  = "QSCC.GetStateByRangeAtHeight"
+	QSCC_GetIndexStatus           = "QSCC.GetIndexStatus"
+	QSCC_GetTransactionsByTime    = "QSCC.GetTransactionsByTime"
+	QSCC_GetTransactionsByCreator = "QSCC.GetTransactionsByCreator"
 
 	//CSCC resources
 	CSCC_JoinChain                = "CSCC.JoinChain"
@@ -7,8 +7,18 @@ SPDX-License-Identifier: Apache-2.0
 package admin
 
 import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/service"
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
@@ -29,19 +39,38 @@ type AccessControlEvaluator interface {
 	Evaluate(signatureSet []*common.SignedData) error
 }
 
+// GossipService provides the state of the gossip service of the peer
+type GossipService interface {
+	// OrdererHealth returns the health of the ordering service endpoints
+	// blocks of the given channel are delivered from
+	OrdererHealth(chainID string) ([]blocksprovider.EndpointHealth, error)
+
+	// Inspect returns a snapshot of the gossip membership and channel state of the peer
+	Inspect() *service.GossipState
+
+	// YieldLeadership relinquishes the leadership of the peer in the given channel
+	YieldLeadership(chainID string) error
+
+	// Reachability returns the outcome of the last verification that the
+	// external endpoint of the peer is reachable from other organizations
+	Reachability() gossip.ReachabilityStatus
+}
+
 // NewAdminServer creates and returns a Admin service instance.
-func NewAdminServer(ace AccessControlEvaluator) *ServerAdmin {
+func NewAdminServer(ace AccessControlEvaluator, gs GossipService) *ServerAdmin {
 	s := &ServerAdmin{
 		v: &validator{
 			ace: ace,
 		},
+		gs: gs,
 	}
 	return s
 }
 
 // ServerAdmin implementation of the Admin service for the Peer
 type ServerAdmin struct {
-	v requestValidator
+	v  requestValidator
+	gs GossipService
 }
 
 func (s *ServerAdmin) GetStatus(ctx context.Context, env *common.Envelope) (*pb.ServerStatus, error) {
@@ -49,6 +78,12 @@ func (s *ServerAdmin) GetStatus(ctx context.Context, env *common.Envelope) (*pb.
 		return nil, err
 	}
 	status := &pb.ServerStatus{Status: pb.ServerStatus_STARTED}
+	if s.gs != nil {
+		if reachability := s.gs.Reachability(); reachability.Reachability == gossip.Unreachable {
+			status.Status = pb.ServerStatus_ERROR
+			status.Reason = fmt.Sprintf("gossip external endpoint %s isn't reachable from other organizations", reachability.Endpoint)
+		}
+	}
 	logger.Debugf("returning status: %s", status)
 	return status, nil
 }
@@ -97,3 +132,152 @@ func (s *ServerAdmin) RevertLogLevels(ctx context.Context, env *common.Envelope)
 	err := flogging.RevertToPeerStartupLevels()
 	return &empty.Empty{}, err
 }
+
+func (s *ServerAdmin) GetOrdererHealth(ctx context.Context, env *common.Envelope) (*pb.OrdererHealthResponse, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	request := op.GetOrdererHealthReq()
+	if request == nil {
+		return nil, errors.New("request is nil")
+	}
+	health, err := s.gs.OrdererHealth(request.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+	response := &pb.OrdererHealthResponse{}
+	for _, eh := range health {
+		response.Endpoints = append(response.Endpoints, &pb.OrdererEndpointHealth{
+			Endpoint:         eh.Endpoint,
+			LatencyMs:        uint64(eh.Latency / time.Millisecond),
+			BlockLag:         eh.BlockLag,
+			ErrorRate:        eh.ErrorRate,
+			Score:            eh.Score,
+			Connected:        eh.Connected,
+			LastInvalidBlock: ordererInvalidBlock(eh.LastInvalidBlock),
+		})
+	}
+	return response, nil
+}
+
+func ordererInvalidBlock(invalidBlock *blocksprovider.InvalidBlock) *pb.OrdererInvalidBlock {
+	if invalidBlock == nil {
+		return nil
+	}
+	return &pb.OrdererInvalidBlock{
+		SeqNum: invalidBlock.SeqNum,
+		Reason: invalidBlock.Reason,
+		Time:   timestampOrNil(invalidBlock.Time),
+		Count:  uint64(invalidBlock.Count),
+	}
+}
+
+func (s *ServerAdmin) GetGossipState(ctx context.Context, env *common.Envelope) (*pb.GossipStateResponse, error) {
+	if _, err := s.v.validate(ctx, env); err != nil {
+		return nil, err
+	}
+	state := s.gs.Inspect()
+	response := &pb.GossipStateResponse{
+		Self: gossipMember(state.Self),
+		Reachability: &pb.GossipReachability{
+			Endpoint:     state.Reachability.Endpoint,
+			Status:       gossipReachabilityStatus(state.Reachability.Reachability),
+			LastVerified: timestampOrNil(state.Reachability.LastVerified),
+			Errors:       state.Reachability.Errors,
+		},
+	}
+	for _, member := range state.Alive {
+		response.Alive = append(response.Alive, gossipMember(member))
+	}
+	for _, member := range state.Dead {
+		response.Dead = append(response.Dead, gossipMember(member))
+	}
+	for _, chState := range state.Channels {
+		channel := &pb.GossipChannelState{
+			ChannelId:    chState.ChannelID,
+			LedgerHeight: chState.LedgerHeight,
+			LeaderPkiId:  hex.EncodeToString(chState.Leader),
+			IsLeader:     chState.IsLeader,
+		}
+		for _, event := range chState.LeadershipEvents {
+			channel.LeadershipEvents = append(channel.LeadershipEvents, &pb.LeadershipEvent{
+				IsLeader: event.IsLeader,
+				Time:     timestampOrNil(event.Time),
+			})
+		}
+		for _, peer := range chState.Peers {
+			channel.Peers = append(channel.Peers, &pb.GossipChannelPeer{
+				PkiId:        hex.EncodeToString(peer.PKIid),
+				Endpoint:     peer.Endpoint,
+				LedgerHeight: peer.Properties.GetLedgerHeight(),
+			})
+		}
+		response.Channels = append(response.Channels, channel)
+	}
+	for _, identity := range state.Identities {
+		response.Identities = append(response.Identities, &pb.GossipIdentity{
+			PkiId:        hex.EncodeToString(identity.PKIId),
+			Organization: string(identity.Organization),
+			Expiration:   timestampOrNil(identity.Expiration),
+		})
+	}
+	for _, conn := range state.Connections {
+		response.Connections = append(response.Connections, &pb.GossipConnection{
+			PkiId:        hex.EncodeToString(conn.PKIID),
+			Endpoint:     conn.Endpoint,
+			Outbound:     conn.Outbound,
+			Established:  timestampOrNil(conn.Established),
+			SentMsgs:     conn.SentMsgs,
+			ReceivedMsgs: conn.ReceivedMsgs,
+			DroppedMsgs:  conn.DroppedMsgs,
+		})
+	}
+	return response, nil
+}
+
+func (s *ServerAdmin) YieldLeadership(ctx context.Context, env *common.Envelope) (*empty.Empty, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	request := op.GetYieldLeadershipReq()
+	if request == nil {
+		return nil, errors.New("request is nil")
+	}
+	if err := s.gs.YieldLeadership(request.ChannelId); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func gossipMember(member discovery.NetworkMember) *pb.GossipMember {
+	return &pb.GossipMember{
+		PkiId:            hex.EncodeToString(member.PKIid),
+		Endpoint:         member.Endpoint,
+		InternalEndpoint: member.InternalEndpoint,
+		Unreachable:      member.Unreachable,
+	}
+}
+
+func gossipReachabilityStatus(reachability gossip.Reachability) pb.GossipReachability_Status {
+	switch reachability {
+	case gossip.Reachable:
+		return pb.GossipReachability_REACHABLE
+	case gossip.Unreachable:
+		return pb.GossipReachability_UNREACHABLE
+	default:
+		return pb.GossipReachability_UNKNOWN
+	}
+}
+
+func timestampOrNil(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return nil
+	}
+	return ts
+}
@@ -9,11 +9,22 @@ package admin
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	"github.com/hyperledger/fabric/core/testutil"
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	gcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/service"
 	"github.com/hyperledger/fabric/protos/common"
+	gproto "github.com/hyperledger/fabric/protos/gossip"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	context2 "golang.org/x/net/context"
@@ -35,8 +46,32 @@ func (v *mockValidator) validate(ctx context2.Context, env *common.Envelope) (*p
 	return args.Get(0).(*pb.AdminOperation), nil
 }
 
+type mockGossipService struct {
+	mock.Mock
+}
+
+func (gs *mockGossipService) OrdererHealth(chainID string) ([]blocksprovider.EndpointHealth, error) {
+	args := gs.Called(chainID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]blocksprovider.EndpointHealth), nil
+}
+
+func (gs *mockGossipService) Inspect() *service.GossipState {
+	return gs.Called().Get(0).(*service.GossipState)
+}
+
+func (gs *mockGossipService) YieldLeadership(chainID string) error {
+	return gs.Called(chainID).Error(0)
+}
+
+func (gs *mockGossipService) Reachability() gossip.ReachabilityStatus {
+	return gs.Called().Get(0).(gossip.ReachabilityStatus)
+}
+
 func TestGetStatus(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	mv.On("validate").Return(nil, nil).Once()
@@ -45,8 +80,28 @@ func TestGetStatus(t *testing.T) {
 	assert.Nil(t, err, "Error should have been nil")
 }
 
+func TestGetStatusUnreachable(t *testing.T) {
+	gs := &mockGossipService{}
+	adminServer := NewAdminServer(nil, gs)
+	mv := &mockValidator{}
+	adminServer.v = mv
+
+	gs.On("Reachability").Return(gossip.ReachabilityStatus{Endpoint: "p0:7051", Reachability: gossip.Reachable}).Once()
+	mv.On("validate").Return(&pb.AdminOperation{}, nil).Once()
+	response, err := adminServer.GetStatus(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.ServerStatus{Status: pb.ServerStatus_STARTED}, response)
+
+	gs.On("Reachability").Return(gossip.ReachabilityStatus{Endpoint: "p0:7051", Reachability: gossip.Unreachable}).Once()
+	mv.On("validate").Return(&pb.AdminOperation{}, nil).Once()
+	response, err = adminServer.GetStatus(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.ServerStatus_ERROR, response.Status)
+	assert.Equal(t, "gossip external endpoint p0:7051 isn't reachable from other organizations", response.Reason)
+}
+
 func TestStartServer(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	mv.On("validate").Return(nil, nil).Once()
@@ -56,10 +111,10 @@ func TestStartServer(t *testing.T) {
 }
 
 func TestForbidden(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
-	mv.On("validate").Return(nil, accessDenied).Times(5)
+	mv.On("validate").Return(nil, accessDenied).Times(8)
 
 	ctx := context.Background()
 	status, err := adminServer.GetStatus(ctx, nil)
@@ -79,10 +134,19 @@ func TestForbidden(t *testing.T) {
 
 	_, err = adminServer.StartServer(ctx, nil)
 	assert.Equal(t, accessDenied, err)
+
+	_, err = adminServer.GetOrdererHealth(ctx, nil)
+	assert.Equal(t, accessDenied, err)
+
+	_, err = adminServer.GetGossipState(ctx, nil)
+	assert.Equal(t, accessDenied, err)
+
+	_, err = adminServer.YieldLeadership(ctx, nil)
+	assert.Equal(t, accessDenied, err)
 }
 
 func TestLoggingCalls(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	flogging.MustGetLogger("test")
@@ -132,3 +196,156 @@ func TestLoggingCalls(t *testing.T) {
 	assert.Equal(t, flogging.DefaultLevel(), logResponse.LogLevel, "logger level should have been the default")
 	assert.Nil(t, err, "Error should have been nil")
 }
+
+func TestGetOrdererHealth(t *testing.T) {
+	gs := &mockGossipService{}
+	adminServer := NewAdminServer(nil, gs)
+	mv := &mockValidator{}
+	adminServer.v = mv
+
+	wrapOrdererHealthRequest := func(channelID string) *pb.AdminOperation {
+		return &pb.AdminOperation{
+			Content: &pb.AdminOperation_OrdererHealthReq{
+				OrdererHealthReq: &pb.OrdererHealthRequest{ChannelId: channelID},
+			},
+		}
+	}
+
+	mv.On("validate").Return(&pb.AdminOperation{}, nil).Once()
+	_, err := adminServer.GetOrdererHealth(context.Background(), nil)
+	assert.EqualError(t, err, "request is nil")
+
+	gs.On("OrdererHealth", "foo").Return(nil, errors.New("not delivering blocks"))
+	mv.On("validate").Return(wrapOrdererHealthRequest("foo"), nil).Once()
+	_, err = adminServer.GetOrdererHealth(context.Background(), nil)
+	assert.EqualError(t, err, "not delivering blocks")
+
+	received := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	gs.On("OrdererHealth", "bar").Return([]blocksprovider.EndpointHealth{
+		{Endpoint: "orderer0:7050", Latency: time.Second, BlockLag: 3, ErrorRate: 0.5, Score: 9, Connected: true},
+		{Endpoint: "orderer1:7050", LastInvalidBlock: &blocksprovider.InvalidBlock{SeqNum: 5, Reason: "bad signature", Time: received, Count: 2}},
+	}, nil)
+	mv.On("validate").Return(wrapOrdererHealthRequest("bar"), nil).Once()
+	response, err := adminServer.GetOrdererHealth(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.OrdererEndpointHealth{
+		{Endpoint: "orderer0:7050", LatencyMs: 1000, BlockLag: 3, ErrorRate: 0.5, Score: 9, Connected: true},
+		{Endpoint: "orderer1:7050", LastInvalidBlock: &pb.OrdererInvalidBlock{
+			SeqNum: 5,
+			Reason: "bad signature",
+			Time:   &timestamp.Timestamp{Seconds: received.Unix()},
+			Count:  2,
+		}},
+	}, response.Endpoints)
+}
+
+func TestGetGossipState(t *testing.T) {
+	gs := &mockGossipService{}
+	adminServer := NewAdminServer(nil, gs)
+	mv := &mockValidator{}
+	adminServer.v = mv
+
+	expiration := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	established := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	gs.On("Inspect").Return(&service.GossipState{
+		Self:  discovery.NetworkMember{PKIid: gcommon.PKIidType("p0"), Endpoint: "p0:7051", InternalEndpoint: "p0.internal:7051"},
+		Alive: []discovery.NetworkMember{{PKIid: gcommon.PKIidType("p1"), Endpoint: "p1:7051", Unreachable: true}},
+		Dead:  []discovery.NetworkMember{{PKIid: gcommon.PKIidType("p2"), Endpoint: "p2:7051"}},
+		Channels: []service.ChannelState{
+			{
+				ChannelID:    "foo",
+				LedgerHeight: 10,
+				Leader:       gcommon.PKIidType("p1"),
+				LeadershipEvents: []service.LeadershipEvent{
+					{IsLeader: true, Time: established},
+					{IsLeader: false, Time: expiration},
+				},
+				Peers: []discovery.NetworkMember{
+					{PKIid: gcommon.PKIidType("p1"), Endpoint: "p1:7051", Properties: &gproto.Properties{LedgerHeight: 12}},
+				},
+			},
+			{
+				ChannelID: "bar",
+			},
+		},
+		Identities: api.PeerIdentitySet{
+			{PKIId: gcommon.PKIidType("p0"), Organization: api.OrgIdentityType("Org1MSP"), Expiration: expiration},
+			{PKIId: gcommon.PKIidType("p1"), Organization: api.OrgIdentityType("Org2MSP")},
+		},
+		Connections: []comm.ConnectionStats{
+			{PKIID: gcommon.PKIidType("p1"), Endpoint: "p1:7051", Outbound: true, Established: established, SentMsgs: 5, ReceivedMsgs: 3, DroppedMsgs: 1},
+		},
+		Reachability: gossip.ReachabilityStatus{
+			Endpoint:     "p0:7051",
+			Reachability: gossip.Unreachable,
+			LastVerified: established,
+			Errors:       []string{"p1:7051: connection refused"},
+		},
+	})
+	mv.On("validate").Return(&pb.AdminOperation{}, nil).Once()
+	response, err := adminServer.GetGossipState(context.Background(), nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, &pb.GossipMember{PkiId: "7030", Endpoint: "p0:7051", InternalEndpoint: "p0.internal:7051"}, response.Self)
+	assert.Equal(t, []*pb.GossipMember{{PkiId: "7031", Endpoint: "p1:7051", Unreachable: true}}, response.Alive)
+	assert.Equal(t, []*pb.GossipMember{{PkiId: "7032", Endpoint: "p2:7051"}}, response.Dead)
+	assert.Equal(t, []*pb.GossipChannelState{
+		{
+			ChannelId:    "foo",
+			LedgerHeight: 10,
+			LeaderPkiId:  "7031",
+			Peers:        []*pb.GossipChannelPeer{{PkiId: "7031", Endpoint: "p1:7051", LedgerHeight: 12}},
+			LeadershipEvents: []*pb.LeadershipEvent{
+				{IsLeader: true, Time: &timestamp.Timestamp{Seconds: established.Unix()}},
+				{IsLeader: false, Time: &timestamp.Timestamp{Seconds: expiration.Unix()}},
+			},
+		},
+		{
+			ChannelId: "bar",
+		},
+	}, response.Channels)
+	assert.Equal(t, []*pb.GossipIdentity{
+		{PkiId: "7030", Organization: "Org1MSP", Expiration: &timestamp.Timestamp{Seconds: expiration.Unix()}},
+		{PkiId: "7031", Organization: "Org2MSP"},
+	}, response.Identities)
+	assert.Equal(t, []*pb.GossipConnection{
+		{PkiId: "7031", Endpoint: "p1:7051", Outbound: true, Established: &timestamp.Timestamp{Seconds: established.Unix()}, SentMsgs: 5, ReceivedMsgs: 3, DroppedMsgs: 1},
+	}, response.Connections)
+	assert.Equal(t, &pb.GossipReachability{
+		Endpoint:     "p0:7051",
+		Status:       pb.GossipReachability_UNREACHABLE,
+		LastVerified: &timestamp.Timestamp{Seconds: established.Unix()},
+		Errors:       []string{"p1:7051: connection refused"},
+	}, response.Reachability)
+}
+
+func TestYieldLeadership(t *testing.T) {
+	gs := &mockGossipService{}
+	adminServer := NewAdminServer(nil, gs)
+	mv := &mockValidator{}
+	adminServer.v = mv
+
+	yieldReq := func(channel string) *pb.AdminOperation {
+		return &pb.AdminOperation{
+			Content: &pb.AdminOperation_YieldLeadershipReq{
+				YieldLeadershipReq: &pb.YieldLeadershipRequest{ChannelId: channel},
+			},
+		}
+	}
+
+	mv.On("validate").Return(&pb.AdminOperation{}, nil).Once()
+	_, err := adminServer.YieldLeadership(context.Background(), nil)
+	assert.EqualError(t, err, "request is nil")
+
+	gs.On("YieldLeadership", "foo").Return(nil)
+	mv.On("validate").Return(yieldReq("foo"), nil).Once()
+	response, err := adminServer.YieldLeadership(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+
+	gs.On("YieldLeadership", "bar").Return(errors.New("peer isn't the leader in channel bar"))
+	mv.On("validate").Return(yieldReq("bar"), nil).Once()
+	_, err = adminServer.YieldLeadership(context.Background(), nil)
+	assert.EqualError(t, err, "peer isn't the leader in channel bar")
+	gs.AssertExpectations(t)
+}
@@ -79,6 +79,10 @@ func (meqe *mockExecQuerySimulator) GetHistoryForKey(namespace, query string) (c
 	return meqe.commonQuery(namespace, query)
 }
 
+func (meqe *mockExecQuerySimulator) GetHistoryForKeyWithOptions(namespace, query string, options *ledger.HistoryQueryOptions) (commonledger.ResultsIterator, error) {
+	return meqe.commonQuery(namespace, query)
+}
+
 func (meqe *mockExecQuerySimulator) ExecuteQuery(namespace, query string) (commonledger.ResultsIterator, error) {
 	return meqe.commonQuery(namespace, query)
 }
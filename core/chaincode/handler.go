@@ -20,11 +20,15 @@ import (
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/container/ccintf"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/peer"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 )
@@ -259,6 +263,43 @@ func (handler *Handler) checkACL(signedProp *pb.SignedProposal, proposal *pb.Pro
 	return aclmgmt.GetACLProvider().CheckACL(resources.CC2CC, ccIns.ChainID, signedProp)
 }
 
+// Check if the creator of the proposal is allowed to read the private data of the collection.
+// The private data of an implicit collection can only be read by members of its org
+func (handler *Handler) checkPrivateDataReadAccess(txContext *transactionContext, collection string) error {
+	isImplicit, mspID := privdata.MspIDIfImplicitCollection(collection)
+	if !isImplicit {
+		return nil
+	}
+
+	// A Nil signedProp will be rejected, as the reader can't be determined
+	if txContext.signedProp == nil || txContext.proposal == nil {
+		return errors.Errorf("private data of implicit collection %s can only be read by members of %s", collection, mspID)
+	}
+
+	header, err := utils.GetHeader(txContext.proposal.Header)
+	if err != nil {
+		return errors.WithMessage(err, "failed extracting header of proposal")
+	}
+	shdr, err := utils.GetSignatureHeader(header.SignatureHeader)
+	if err != nil {
+		return errors.WithMessage(err, "failed extracting signature header of proposal")
+	}
+
+	sc, err := privdata.NewImplicitCollection(mspID, mspmgmt.GetIdentityDeserializer(txContext.chainID))
+	if err != nil {
+		return err
+	}
+	sd := common.SignedData{
+		Data:      txContext.signedProp.ProposalBytes,
+		Identity:  shdr.Creator,
+		Signature: txContext.signedProp.Signature,
+	}
+	if !sc.AccessFilter()(sd) {
+		return errors.Errorf("private data of implicit collection %s can only be read by members of %s", collection, mspID)
+	}
+	return nil
+}
+
 func (handler *Handler) deregister() error {
 	if handler.registered {
 		handler.chaincodeSupport.deregisterHandler(handler)
@@ -385,6 +426,7 @@ func newChaincodeSupportHandler(chaincodeSupport *ChaincodeSupport, peerChatStre
 		pb.ChaincodeMessage_GET_STATE_BY_RANGE:  v.handleGetStateByRange,
 		pb.ChaincodeMessage_GET_QUERY_RESULT:    v.handleGetQueryResult,
 		pb.ChaincodeMessage_GET_HISTORY_FOR_KEY: v.handleGetHistoryForKey,
+		pb.ChaincodeMessage_GET_STATE_AT_HEIGHT: v.handleGetStateAtHeight,
 		pb.ChaincodeMessage_QUERY_STATE_NEXT:    v.handleQueryStateNext,
 		pb.ChaincodeMessage_QUERY_STATE_CLOSE:   v.handleQueryStateClose,
 		pb.ChaincodeMessage_PUT_STATE:           v.handleModState,
@@ -596,7 +638,9 @@ func (handler *Handler) handleGetState(msg *pb.ChaincodeMessage) {
 		var res []byte
 		var err error
 		if isCollectionSet(getState.Collection) {
-			res, err = txContext.txsimulator.GetPrivateData(chaincodeID, getState.Collection, getState.Key)
+			if err = handler.checkPrivateDataReadAccess(txContext, getState.Collection); err == nil {
+				res, err = txContext.txsimulator.GetPrivateData(chaincodeID, getState.Collection, getState.Key)
+			}
 		} else {
 			res, err = txContext.txsimulator.GetState(chaincodeID, getState.Key)
 		}
@@ -668,6 +712,10 @@ func (handler *Handler) handleGetStateByRange(msg *pb.ChaincodeMessage) {
 		var err error
 
 		if isCollectionSet(getStateByRange.Collection) {
+			if err = handler.checkPrivateDataReadAccess(txContext, getStateByRange.Collection); err != nil {
+				errHandler(err, nil, "Failed to get ledger scan iterator. Sending %s", pb.ChaincodeMessage_ERROR)
+				return
+			}
 			rangeIter, err = txContext.txsimulator.GetPrivateDataRangeScanIterator(chaincodeID, getStateByRange.Collection, getStateByRange.StartKey, getStateByRange.EndKey)
 		} else {
 			rangeIter, err = txContext.txsimulator.GetStateRangeScanIterator(chaincodeID, getStateByRange.StartKey, getStateByRange.EndKey)
@@ -910,6 +958,10 @@ func (handler *Handler) handleGetQueryResult(msg *pb.ChaincodeMessage) {
 		var err error
 		var executeIter commonledger.ResultsIterator
 		if isCollectionSet(getQueryResult.Collection) {
+			if err = handler.checkPrivateDataReadAccess(txContext, getQueryResult.Collection); err != nil {
+				errHandler([]byte(err.Error()), nil, "Failed to get ledger query iterator. Sending %s", pb.ChaincodeMessage_ERROR)
+				return
+			}
 			executeIter, err = txContext.txsimulator.ExecuteQueryOnPrivateData(chaincodeID, getQueryResult.Collection, getQueryResult.Query)
 		} else {
 			executeIter, err = txContext.txsimulator.ExecuteQuery(chaincodeID, getQueryResult.Query)
@@ -983,7 +1035,13 @@ func (handler *Handler) handleGetHistoryForKey(msg *pb.ChaincodeMessage) {
 		}
 		chaincodeID := handler.getCCRootName()
 
-		historyIter, err := txContext.historyQueryExecutor.GetHistoryForKey(chaincodeID, getHistoryForKey.Key)
+		historyIter, err := txContext.historyQueryExecutor.GetHistoryForKeyWithOptions(chaincodeID, getHistoryForKey.Key,
+			&ledger.HistoryQueryOptions{
+				StartBlock:  getHistoryForKey.StartBlock,
+				EndBlock:    getHistoryForKey.EndBlock,
+				NewestFirst: getHistoryForKey.NewestFirst,
+				Limit:       getHistoryForKey.Limit,
+			})
 		if err != nil {
 			errHandler([]byte(err.Error()), nil, "Failed to get ledger history iterator. Sending %s", pb.ChaincodeMessage_ERROR)
 			return
@@ -1012,6 +1070,65 @@ func (handler *Handler) handleGetHistoryForKey(msg *pb.ChaincodeMessage) {
 	}()
 }
 
+// Handles query to ledger to get state as of a past block
+func (handler *Handler) handleGetStateAtHeight(msg *pb.ChaincodeMessage) {
+	go func() {
+		chaincodeLogger.Debugf("[%s]handling %s from chaincode", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_STATE_AT_HEIGHT)
+		if !handler.registerTxid(msg) {
+			return
+		}
+
+		var serialSendMsg *pb.ChaincodeMessage
+		var txContext *transactionContext
+		txContext, serialSendMsg = handler.isValidTxSim(msg.ChannelId, msg.Txid,
+			"[%s]No ledger context for GetStateAtHeight. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR)
+
+		defer func() {
+			handler.deRegisterTxid(msg, serialSendMsg, false)
+		}()
+
+		if txContext == nil {
+			return
+		}
+
+		errHandler := func(err error, errFmt string, errArgs ...interface{}) {
+			chaincodeLogger.Errorf(errFmt, errArgs...)
+			serialSendMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte(err.Error()), Txid: msg.Txid, ChannelId: msg.ChannelId}
+		}
+
+		getStateAtHeight := &pb.GetStateAtHeight{}
+		if err := proto.Unmarshal(msg.Payload, getStateAtHeight); err != nil {
+			errHandler(err, "[%s]Failed to unmarshall state at height request. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR)
+			return
+		}
+		chaincodeID := handler.getCCRootName()
+		chaincodeLogger.Debugf("[%s] getting state for chaincode %s, key %s, channel %s as of block %d",
+			shorttxid(msg.Txid), chaincodeID, getStateAtHeight.Key, txContext.chainID, getStateAtHeight.BlockNum)
+
+		lgr := peer.GetLedger(txContext.chainID)
+		if lgr == nil {
+			errHandler(errors.Errorf("failed to find ledger for channel %s", txContext.chainID),
+				"[%s]Failed to find ledger for channel %s. Sending %s", shorttxid(msg.Txid), txContext.chainID, pb.ChaincodeMessage_ERROR)
+			return
+		}
+		historicalQueryExecutor, err := lgr.NewHistoricalQueryExecutor(getStateAtHeight.BlockNum)
+		if err != nil {
+			errHandler(err, "[%s]Failed to get historical query executor(%s). Sending %s", shorttxid(msg.Txid), err, pb.ChaincodeMessage_ERROR)
+			return
+		}
+		res, err := historicalQueryExecutor.GetState(chaincodeID, getStateAtHeight.Key)
+		if err != nil {
+			errHandler(err, "[%s]Failed to get chaincode state as of block %d(%s). Sending %s",
+				shorttxid(msg.Txid), getStateAtHeight.BlockNum, err, pb.ChaincodeMessage_ERROR)
+			return
+		}
+
+		// Send response msg back to chaincode, an empty payload means the key didn't exist at that height
+		chaincodeLogger.Debugf("[%s]Got state as of block %d. Sending %s", shorttxid(msg.Txid), getStateAtHeight.BlockNum, pb.ChaincodeMessage_RESPONSE)
+		serialSendMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}
+	}()
+}
+
 func isCollectionSet(collection string) bool {
 	if collection == "" {
 		return false
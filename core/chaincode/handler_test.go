@@ -12,7 +12,11 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/util"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -107,3 +111,31 @@ func (m *MockResultsIterator) Next() (ledger.QueryResult, error) {
 func (m *MockResultsIterator) Close() {
 	m.Called()
 }
+
+func TestCheckPrivateDataReadAccess(t *testing.T) {
+	signer, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	mspID, err := mspmgmt.GetLocalMSP().GetIdentifier()
+	assert.NoError(t, err)
+
+	chainID := util.GetTestChainID()
+	spec := &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "mycc"}}
+	signedProp, prop := utils.MockSignedEndorserProposal2OrPanic(chainID, spec, signer)
+	txContext := &transactionContext{chainID: chainID, signedProp: signedProp, proposal: prop}
+	handler := &Handler{}
+
+	// Collections which aren't implicit are readable as far as the handler is concerned
+	assert.NoError(t, handler.checkPrivateDataReadAccess(txContext, "mycollection"))
+
+	// The implicit collection of the org of the creator is readable
+	assert.NoError(t, handler.checkPrivateDataReadAccess(txContext, "_implicit_org_"+mspID))
+
+	// The implicit collection of another org isn't readable
+	err = handler.checkPrivateDataReadAccess(txContext, "_implicit_org_OtherOrg")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "can only be read by members of OtherOrg")
+
+	// Implicit collections aren't readable without a proposal
+	err = handler.checkPrivateDataReadAccess(&transactionContext{chainID: chainID}, "_implicit_org_"+mspID)
+	assert.Error(t, err)
+}
@@ -487,13 +487,42 @@ func (stub *ChaincodeStub) GetStateByRange(startKey, endKey string) (StateQueryI
 
 // GetHistoryForKey documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error) {
-	response, err := stub.handler.handleGetHistoryForKey(key, stub.ChannelId, stub.TxID)
+	return stub.GetHistoryForKeyWithOptions(key, nil)
+}
+
+// HistoryQueryOptions restricts the results of GetHistoryForKeyWithOptions
+type HistoryQueryOptions struct {
+	// StartBlock is the lowest block number of the returned modifications
+	StartBlock uint64
+	// EndBlock is the highest block number of the returned modifications, 0 means no upper bound
+	EndBlock uint64
+	// NewestFirst returns the modifications from the newest to the oldest one
+	NewestFirst bool
+	// Limit is the maximum number of returned modifications, 0 means no limit
+	Limit uint32
+}
+
+// GetHistoryForKeyWithOptions documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetHistoryForKeyWithOptions(key string, options *HistoryQueryOptions) (HistoryQueryIteratorInterface, error) {
+	getHistoryForKey := &pb.GetHistoryForKey{Key: key}
+	if options != nil {
+		getHistoryForKey.StartBlock = options.StartBlock
+		getHistoryForKey.EndBlock = options.EndBlock
+		getHistoryForKey.NewestFirst = options.NewestFirst
+		getHistoryForKey.Limit = options.Limit
+	}
+	response, err := stub.handler.handleGetHistoryForKey(getHistoryForKey, stub.ChannelId, stub.TxID)
 	if err != nil {
 		return nil, err
 	}
 	return &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}, nil
 }
 
+// GetStateAtHeight documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetStateAtHeight(key string, blockNum uint64) ([]byte, error) {
+	return stub.handler.handleGetStateAtHeight(key, blockNum, stub.ChannelId, stub.TxID)
+}
+
 //CreateCompositeKey documentation can be found in interfaces.go
 func (stub *ChaincodeStub) CreateCompositeKey(objectType string, attributes []string) (string, error) {
 	return createCompositeKey(objectType, attributes)
@@ -162,6 +162,25 @@ type ChaincodeStubInterface interface {
 	// update ledger, and should limit use to read-only chaincode operations.
 	GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error)
 
+	// GetHistoryForKeyWithOptions returns a history of key values across time,
+	// like GetHistoryForKey, restricted to the modifications committed in the
+	// blocks from `options.StartBlock` to `options.EndBlock`, from the newest
+	// to the oldest one if `options.NewestFirst` is set, and to at most
+	// `options.Limit` modifications. A nil `options` is the same as GetHistoryForKey.
+	// It is intended for keys with a long history, that can't be fully read
+	// within the execution timeout of the chaincode.
+	GetHistoryForKeyWithOptions(key string, options *HistoryQueryOptions) (HistoryQueryIteratorInterface, error)
+
+	// GetStateAtHeight returns the value of the specified `key` as of the block
+	// `blockNum`, i.e. after the transactions of that block were committed,
+	// or nil if the key didn't exist, or was deleted, at that height.
+	// GetStateAtHeight requires peer configuration
+	// core.ledger.history.enableHistoryDatabase to be true.
+	// The read is NOT recorded in the read set of the transaction, and not
+	// re-executed during validation phase. GetStateAtHeight is intended to be
+	// used for read-only queries, such as audits.
+	GetStateAtHeight(key string, blockNum uint64) ([]byte, error)
+
 	// GetPrivateData returns the value of the specified `key` from the specified
 	// `collection`. Note that GetPrivateData doesn't read data from the
 	// private writeset, which has not been committed to the `collection`. In
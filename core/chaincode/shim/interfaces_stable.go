@@ -162,6 +162,15 @@ type ChaincodeStubInterface interface {
 	// update ledger, and should limit use to read-only chaincode operations.
 	GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error)
 
+	// GetHistoryForKeyWithOptions returns a history of key values across time,
+	// like GetHistoryForKey, restricted to the modifications committed in the
+	// blocks from `options.StartBlock` to `options.EndBlock`, from the newest
+	// to the oldest one if `options.NewestFirst` is set, and to at most
+	// `options.Limit` modifications. A nil `options` is the same as GetHistoryForKey.
+	// It is intended for keys with a long history, that can't be fully read
+	// within the execution timeout of the chaincode.
+	GetHistoryForKeyWithOptions(key string, options *HistoryQueryOptions) (HistoryQueryIteratorInterface, error)
+
 	// GetCreator returns `SignatureHeader.Creator` (e.g. an identity)
 	// of the `SignedProposal`. This is the identity of the agent (or user)
 	// submitting the transaction.
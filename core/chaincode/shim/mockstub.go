@@ -278,6 +278,18 @@ func (stub *MockStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterfac
 	return nil, errors.New("not implemented")
 }
 
+// GetHistoryForKeyWithOptions function can be invoked by a chaincode to return a bounded
+// history of key values across time. GetHistoryForKeyWithOptions is intended to be used for read-only queries.
+func (stub *MockStub) GetHistoryForKeyWithOptions(key string, options *HistoryQueryOptions) (HistoryQueryIteratorInterface, error) {
+	return nil, errors.New("not implemented")
+}
+
+// GetStateAtHeight function can be invoked by a chaincode to read the value of
+// a key as of a past block. GetStateAtHeight is intended to be used for read-only queries.
+func (stub *MockStub) GetStateAtHeight(key string, blockNum uint64) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
 //GetStateByPartialCompositeKey function can be invoked by a chaincode to query the
 //state based on a given partial composite key. This function returns an
 //iterator which can be used to iterate over all composite keys whose prefix
@@ -265,6 +265,8 @@ func TestMockMock(t *testing.T) {
 	stub.GetArgsSlice()
 	stub.SetEvent("e", nil)
 	stub.GetHistoryForKey("k")
+	stub.GetHistoryForKeyWithOptions("k", &HistoryQueryOptions{NewestFirst: true})
+	stub.GetStateAtHeight("k", 1)
 	iter := &MockStateRangeQueryIterator{}
 	iter.HasNext()
 	iter.Close()
@@ -92,6 +92,8 @@ func (t *shimTestCC) Invoke(stub ChaincodeStubInterface) pb.Response {
 		return t.rangeq(stub, args)
 	} else if function == "historyq" {
 		return t.historyq(stub, args)
+	} else if function == "stateatheight" {
+		return t.stateatheight(stub, args)
 	} else if function == "richq" {
 		return t.richq(stub, args)
 	}
@@ -297,6 +299,25 @@ func (t *shimTestCC) richq(stub ChaincodeStubInterface, args []string) pb.Respon
 	return Success(buffer.Bytes())
 }
 
+// stateatheight reads the value of a key as of a block
+func (t *shimTestCC) stateatheight(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) < 2 {
+		return Error("Incorrect number of arguments. Expecting 2")
+	}
+
+	blockNum, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return Error(err.Error())
+	}
+	// GetStateAtHeight is only part of the experimental ChaincodeStubInterface
+	value, err := stub.(*ChaincodeStub).GetStateAtHeight(args[0], blockNum)
+	if err != nil {
+		return Error(err.Error())
+	}
+
+	return Success(value)
+}
+
 // rangeq calls range query
 func (t *shimTestCC) historyq(stub ChaincodeStubInterface, args []string) pb.Response {
 	if len(args) < 1 {
@@ -794,6 +815,36 @@ func TestInvoke(t *testing.T) {
 	//wait for done
 	processDone(t, done, false)
 
+	//state at height query
+
+	//create the response
+	respSet = &mockpeer.MockResponseSet{errorFunc, errorFunc, []*mockpeer.MockResponse{
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_AT_HEIGHT, Txid: "7b", ChannelId: channelId}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: []byte("100"), Txid: "7b", ChannelId: channelId}},
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7b", ChannelId: channelId}, nil}}}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("stateatheight"), []byte("A"), []byte("1")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7b", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//error state at height query
+
+	//create the response
+	respSet = &mockpeer.MockResponseSet{errorFunc, errorFunc, []*mockpeer.MockResponse{
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_AT_HEIGHT, Txid: "7c", ChannelId: channelId}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte("block not committed"), Txid: "7c", ChannelId: channelId}},
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7c", ChannelId: channelId}, nil}}}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("stateatheight"), []byte("A"), []byte("1")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7c", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
 	//query result
 
 	//create the response
@@ -23,6 +23,10 @@ var EndpointDisableInterval = time.Second * 10
 // ConnectionFactory creates a connection to a certain endpoint
 type ConnectionFactory func(endpoint string) (*grpc.ClientConn, error)
 
+// EndpointRanker orders the given endpoints by preference, most preferred first.
+// Endpoints of equal preference must keep their relative order.
+type EndpointRanker func(endpoints []string) []string
+
 // ConnectionProducer produces connections out of a set of predefined
 // endpoints
 type ConnectionProducer interface {
@@ -44,6 +48,7 @@ type connProducer struct {
 	endpoints         []string
 	disabledEndpoints map[string]time.Time
 	connect           ConnectionFactory
+	rank              EndpointRanker
 }
 
 // NewConnectionProducer creates a new ConnectionProducer with given endpoints and connection factory.
@@ -55,6 +60,16 @@ func NewConnectionProducer(factory ConnectionFactory, endpoints []string) Connec
 	return &connProducer{endpoints: endpoints, connect: factory, disabledEndpoints: make(map[string]time.Time)}
 }
 
+// NewRankedConnectionProducer creates a new ConnectionProducer with given endpoints and connection factory,
+// which attempts to connect to the endpoints in the order given by the ranker.
+// It returns nil, if the given endpoints slice is empty.
+func NewRankedConnectionProducer(factory ConnectionFactory, endpoints []string, ranker EndpointRanker) ConnectionProducer {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	return &connProducer{endpoints: endpoints, connect: factory, disabledEndpoints: make(map[string]time.Time), rank: ranker}
+}
+
 // NewConnection creates a new connection.
 // Returns the connection, the endpoint selected, nil on success.
 // Returns nil, "", error on failure
@@ -69,6 +84,10 @@ func (cp *connProducer) NewConnection() (*grpc.ClientConn, string, error) {
 	}
 
 	endpoints := shuffle(cp.endpoints)
+	if cp.rank != nil {
+		// Shuffling beforehand spreads the load among endpoints of equal rank
+		endpoints = cp.rank(endpoints)
+	}
 	checkedEndpoints := make([]string, 0)
 	for _, endpoint := range endpoints {
 		if _, ok := cp.disabledEndpoints[endpoint]; !ok {
@@ -133,3 +133,41 @@ func TestDisableEndpoint(t *testing.T) {
 	assert.Equal(t, "b", a)
 
 }
+
+func TestRankedConnectionProducer(t *testing.T) {
+	t.Parallel()
+	noopFactory := func(endpoint string) (*grpc.ClientConn, error) {
+		return nil, nil
+	}
+	assert.Nil(t, NewRankedConnectionProducer(noopFactory, []string{}, nil))
+
+	shouldConnFail := map[string]bool{}
+	connFactory := func(endpoint string) (*grpc.ClientConn, error) {
+		if shouldConnFail[endpoint] {
+			return nil, fmt.Errorf("Failed connecting to %s", endpoint)
+		}
+		return &grpc.ClientConn{}, nil
+	}
+	// Rank 'c' first, 'b' second and 'a' last
+	ranker := func(endpoints []string) []string {
+		return []string{"c", "b", "a"}
+	}
+	producer := NewRankedConnectionProducer(connFactory, []string{"a", "b", "c"}, ranker)
+	for i := 0; i < 100; i++ {
+		_, endpoint, err := producer.NewConnection()
+		assert.NoError(t, err)
+		assert.Equal(t, "c", endpoint)
+	}
+
+	// The next in rank is selected when the preferred endpoint fails
+	shouldConnFail["c"] = true
+	_, endpoint, err := producer.NewConnection()
+	assert.NoError(t, err)
+	assert.Equal(t, "b", endpoint)
+
+	// Disabled endpoints are skipped regardless of their rank
+	producer.DisableEndpoint("b")
+	_, endpoint, err = producer.NewConnection()
+	assert.NoError(t, err)
+	assert.Equal(t, "a", endpoint)
+}
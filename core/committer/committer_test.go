@@ -10,6 +10,7 @@ import (
 	"errors"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/ledger"
@@ -88,6 +89,21 @@ func (m *mockLedger) NewHistoryQueryExecutor() (ledger2.HistoryQueryExecutor, er
 	return args.Get(0).(ledger2.HistoryQueryExecutor), args.Error(1)
 }
 
+func (m *mockLedger) NewHistoricalQueryExecutor(blockNum uint64) (ledger2.HistoricalQueryExecutor, error) {
+	args := m.Called(blockNum)
+	return args.Get(0).(ledger2.HistoricalQueryExecutor), args.Error(1)
+}
+
+func (m *mockLedger) GetIndexStatus(namespace string) (*peer.IndexStatusResponse, error) {
+	args := m.Called(namespace)
+	return args.Get(0).(*peer.IndexStatusResponse), args.Error(1)
+}
+
+func (m *mockLedger) GetTransactionsByTime(creatorHash []byte, from, to time.Time, bookmark string, limit int) (*peer.TxQueryResponse, error) {
+	args := m.Called(creatorHash, from, to, bookmark, limit)
+	return args.Get(0).(*peer.TxQueryResponse), args.Error(1)
+}
+
 func (m *mockLedger) GetPvtDataAndBlockByNum(blockNum uint64, filter ledger2.PvtNsCollFilter) (*ledger2.BlockAndPvtData, error) {
 	args := m.Called(blockNum, filter)
 	return args.Get(0).(*ledger2.BlockAndPvtData), args.Error(1)
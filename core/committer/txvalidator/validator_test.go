@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	ctxt "github.com/hyperledger/fabric/common/configtx/test"
@@ -468,6 +469,24 @@ func (m *mockLedger) NewHistoryQueryExecutor() (ledger.HistoryQueryExecutor, err
 	return args.Get(0).(ledger.HistoryQueryExecutor), nil
 }
 
+// NewHistoricalQueryExecutor historical query executor
+func (m *mockLedger) NewHistoricalQueryExecutor(blockNum uint64) (ledger.HistoricalQueryExecutor, error) {
+	args := m.Called(blockNum)
+	return args.Get(0).(ledger.HistoricalQueryExecutor), nil
+}
+
+// GetIndexStatus returns the status of the indexes of a chaincode
+func (m *mockLedger) GetIndexStatus(namespace string) (*peer.IndexStatusResponse, error) {
+	args := m.Called(namespace)
+	return args.Get(0).(*peer.IndexStatusResponse), nil
+}
+
+// GetTransactionsByTime returns the transactions of a time range
+func (m *mockLedger) GetTransactionsByTime(creatorHash []byte, from, to time.Time, bookmark string, limit int) (*peer.TxQueryResponse, error) {
+	args := m.Called(creatorHash, from, to, bookmark, limit)
+	return args.Get(0).(*peer.TxQueryResponse), nil
+}
+
 // GetPvtDataAndBlockByNum retrieves pvt data and block
 func (m *mockLedger) GetPvtDataAndBlockByNum(blockNum uint64, filter ledger.PvtNsCollFilter) (*ledger.BlockAndPvtData, error) {
 	args := m.Called()
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
 )
 
 // Collection defines a common interface for collections
@@ -99,3 +100,34 @@ func BuildCollectionKVSKey(ccname string) string {
 func IsCollectionConfigKey(key string) bool {
 	return strings.Contains(key, collectionSeparator)
 }
+
+// ValidateCollectionConfigPackage verifies that the collections defined for a chaincode
+// are named, uniquely, that none of them is named like an implicit collection,
+// and that their endorsement policies are well formed
+func ValidateCollectionConfigPackage(collections *common.CollectionConfigPackage) error {
+	names := make(map[string]struct{})
+	for _, cconf := range collections.Config {
+		staticConf := cconf.GetStaticCollectionConfig()
+		if staticConf == nil {
+			return errors.New("unexpected collection type")
+		}
+		name := staticConf.Name
+		if name == "" {
+			return errors.New("collection name must not be empty")
+		}
+		if strings.HasPrefix(name, ImplicitCollectionNamePrefix) {
+			return errors.Errorf("collection %s must not be defined, the prefix %s is reserved for implicit collections",
+				name, ImplicitCollectionNamePrefix)
+		}
+		if ep := staticConf.EndorsementPolicy; ep != nil {
+			if err := validateCollectionEndorsementPolicy(ep); err != nil {
+				return errors.WithMessage(err, "invalid endorsement policy of collection "+name)
+			}
+		}
+		if _, exists := names[name]; exists {
+			return errors.Errorf("collection %s is defined more than once", name)
+		}
+		names[name] = struct{}{}
+	}
+	return nil
+}
@@ -9,6 +9,8 @@ package privdata
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/protos/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,3 +28,80 @@ func TestIsCollectionConfigKey(t *testing.T) {
 	isCollection = IsCollectionConfigKey("chaincodeKey~collection")
 	assert.True(t, isCollection, "key with tilda is a collection key and should have returned true")
 }
+
+func TestValidateCollectionConfigPackage(t *testing.T) {
+	collection := func(name string) *common.CollectionConfig {
+		return &common.CollectionConfig{
+			Payload: &common.CollectionConfig_StaticCollectionConfig{
+				StaticCollectionConfig: &common.StaticCollectionConfig{Name: name},
+			},
+		}
+	}
+
+	err := ValidateCollectionConfigPackage(&common.CollectionConfigPackage{
+		Config: []*common.CollectionConfig{collection("c1"), collection("c2")},
+	})
+	assert.NoError(t, err)
+
+	err = ValidateCollectionConfigPackage(&common.CollectionConfigPackage{
+		Config: []*common.CollectionConfig{collection("c1"), collection("_implicit_org_Org1MSP")},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "reserved for implicit collections")
+
+	err = ValidateCollectionConfigPackage(&common.CollectionConfigPackage{
+		Config: []*common.CollectionConfig{collection("c1"), collection("c1")},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "defined more than once")
+
+	err = ValidateCollectionConfigPackage(&common.CollectionConfigPackage{
+		Config: []*common.CollectionConfig{collection("")},
+	})
+	assert.Error(t, err)
+
+	err = ValidateCollectionConfigPackage(&common.CollectionConfigPackage{
+		Config: []*common.CollectionConfig{{}},
+	})
+	assert.Error(t, err)
+
+	withEndorsementPolicy := func(ep *common.CollectionEndorsementPolicy) *common.CollectionConfig {
+		cc := collection("c1")
+		cc.GetStaticCollectionConfig().EndorsementPolicy = ep
+		return cc
+	}
+
+	err = ValidateCollectionConfigPackage(&common.CollectionConfigPackage{
+		Config: []*common.CollectionConfig{withEndorsementPolicy(&common.CollectionEndorsementPolicy{
+			Payload: &common.CollectionEndorsementPolicy_SignaturePolicy{SignaturePolicy: cauthdsl.SignedByMspMember("Org1MSP")},
+		})},
+	})
+	assert.NoError(t, err)
+
+	err = ValidateCollectionConfigPackage(&common.CollectionConfigPackage{
+		Config: []*common.CollectionConfig{withEndorsementPolicy(&common.CollectionEndorsementPolicy{
+			Payload: &common.CollectionEndorsementPolicy_ChannelConfigPolicyReference{ChannelConfigPolicyReference: "/Channel/Application/Writers"},
+		})},
+	})
+	assert.NoError(t, err)
+
+	err = ValidateCollectionConfigPackage(&common.CollectionConfigPackage{
+		Config: []*common.CollectionConfig{withEndorsementPolicy(&common.CollectionEndorsementPolicy{
+			Payload: &common.CollectionEndorsementPolicy_SignaturePolicy{SignaturePolicy: &common.SignaturePolicyEnvelope{}},
+		})},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid endorsement policy of collection c1")
+
+	err = ValidateCollectionConfigPackage(&common.CollectionConfigPackage{
+		Config: []*common.CollectionConfig{withEndorsementPolicy(&common.CollectionEndorsementPolicy{
+			Payload: &common.CollectionEndorsementPolicy_ChannelConfigPolicyReference{},
+		})},
+	})
+	assert.Error(t, err)
+
+	err = ValidateCollectionConfigPackage(&common.CollectionConfigPackage{
+		Config: []*common.CollectionConfig{withEndorsementPolicy(&common.CollectionEndorsementPolicy{})},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// EndorsementPolicyOfCollection returns the endorsement policy of the collection
+// with the given name in the given collection configuration, or nil if the
+// collection has no endorsement policy or isn't defined in the configuration
+func EndorsementPolicyOfCollection(collections *common.CollectionConfigPackage, collectionName string) *common.CollectionEndorsementPolicy {
+	for _, cconf := range collections.Config {
+		staticConf := cconf.GetStaticCollectionConfig()
+		if staticConf != nil && staticConf.Name == collectionName {
+			return staticConf.EndorsementPolicy
+		}
+	}
+	return nil
+}
+
+// NewCollectionEndorsementPolicy creates the policy of the given collection endorsement policy;
+// signature policies are created with the given deserializer, and references to policies
+// in the config of the channel are resolved through the given policy manager
+func NewCollectionEndorsementPolicy(ep *common.CollectionEndorsementPolicy, deserializer msp.IdentityDeserializer, policyManager policies.Manager) (policies.Policy, error) {
+	switch ep := ep.GetPayload().(type) {
+	case *common.CollectionEndorsementPolicy_SignaturePolicy:
+		polBytes, err := proto.Marshal(ep.SignaturePolicy)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed marshaling signature policy")
+		}
+		policy, _, err := cauthdsl.NewPolicyProvider(deserializer).NewPolicy(polBytes)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid signature policy")
+		}
+		return policy, nil
+	case *common.CollectionEndorsementPolicy_ChannelConfigPolicyReference:
+		if policyManager == nil {
+			return nil, errors.Errorf("no policy manager to resolve policy %s", ep.ChannelConfigPolicyReference)
+		}
+		policy, exists := policyManager.GetPolicy(ep.ChannelConfigPolicyReference)
+		if !exists {
+			return nil, errors.Errorf("policy %s doesn't exist in the config of the channel", ep.ChannelConfigPolicyReference)
+		}
+		return policy, nil
+	default:
+		return nil, errors.New("endorsement policy is empty")
+	}
+}
+
+// validateCollectionEndorsementPolicy verifies that the given collection endorsement policy is well formed
+func validateCollectionEndorsementPolicy(ep *common.CollectionEndorsementPolicy) error {
+	switch ep := ep.Payload.(type) {
+	case *common.CollectionEndorsementPolicy_SignaturePolicy:
+		if ep.SignaturePolicy == nil || ep.SignaturePolicy.Rule == nil {
+			return errors.New("signature policy is empty")
+		}
+	case *common.CollectionEndorsementPolicy_ChannelConfigPolicyReference:
+		if ep.ChannelConfigPolicyReference == "" {
+			return errors.New("channel config policy reference is empty")
+		}
+	default:
+		return errors.New("endorsement policy is empty")
+	}
+	return nil
+}
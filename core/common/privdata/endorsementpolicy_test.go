@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndorsementPolicyOfCollection(t *testing.T) {
+	ep := &common.CollectionEndorsementPolicy{
+		Payload: &common.CollectionEndorsementPolicy_ChannelConfigPolicyReference{ChannelConfigPolicyReference: "/Channel/Application/Writers"},
+	}
+	ccp := &common.CollectionConfigPackage{
+		Config: []*common.CollectionConfig{
+			{Payload: &common.CollectionConfig_StaticCollectionConfig{StaticCollectionConfig: &common.StaticCollectionConfig{Name: "c1"}}},
+			{Payload: &common.CollectionConfig_StaticCollectionConfig{StaticCollectionConfig: &common.StaticCollectionConfig{Name: "c2", EndorsementPolicy: ep}}},
+		},
+	}
+	assert.Nil(t, EndorsementPolicyOfCollection(ccp, "c1"))
+	assert.Equal(t, ep, EndorsementPolicyOfCollection(ccp, "c2"))
+	assert.Nil(t, EndorsementPolicyOfCollection(ccp, "c3"))
+}
+
+func TestNewCollectionEndorsementPolicy(t *testing.T) {
+	signers := [][]byte{[]byte("signer0"), []byte("signer1")}
+	signaturePolicy := &common.CollectionEndorsementPolicy{
+		Payload: &common.CollectionEndorsementPolicy_SignaturePolicy{
+			SignaturePolicy: cauthdsl.Envelope(cauthdsl.SignedBy(0), signers),
+		},
+	}
+	policyReference := &common.CollectionEndorsementPolicy{
+		Payload: &common.CollectionEndorsementPolicy_ChannelConfigPolicyReference{ChannelConfigPolicyReference: "/Channel/Application/Writers"},
+	}
+	policyManager := &mockpolicies.Manager{
+		PolicyMap: map[string]policies.Policy{
+			"/Channel/Application/Writers": &mockpolicies.Policy{Err: errors.New("not satisfied")},
+		},
+	}
+
+	// A signature policy is evaluated against the signed data
+	policy, err := NewCollectionEndorsementPolicy(signaturePolicy, &mockDeserializer{}, policyManager)
+	assert.NoError(t, err)
+	assert.NoError(t, policy.Evaluate([]*common.SignedData{{Identity: signers[0]}}))
+	assert.Error(t, policy.Evaluate([]*common.SignedData{{Identity: signers[1]}}))
+
+	// A policy reference is resolved through the policy manager
+	policy, err = NewCollectionEndorsementPolicy(policyReference, &mockDeserializer{}, policyManager)
+	assert.NoError(t, err)
+	assert.EqualError(t, policy.Evaluate(nil), "not satisfied")
+
+	// A reference to a policy which doesn't exist is an error
+	_, err = NewCollectionEndorsementPolicy(policyReference, &mockDeserializer{}, &mockpolicies.Manager{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "doesn't exist in the config of the channel")
+
+	_, err = NewCollectionEndorsementPolicy(policyReference, &mockDeserializer{}, nil)
+	assert.Error(t, err)
+
+	_, err = NewCollectionEndorsementPolicy(&common.CollectionEndorsementPolicy{}, &mockDeserializer{}, policyManager)
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"strings"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	// ImplicitCollectionNamePrefix is the prefix of the names of the implicit
+	// collections; every org has an implicit collection in every chaincode,
+	// which is available without being defined in the collection configuration
+	// of the chaincode, and whose private data only peers of the org receive
+	// and only clients of the org can read
+	ImplicitCollectionNamePrefix = "_implicit_org_"
+
+	implicitCollectionRequiredPeerCountConfigKey = "peer.gossip.pvtData.implicitCollectionDisseminationPolicy.requiredPeerCount"
+	implicitCollectionMaxPeerCountConfigKey      = "peer.gossip.pvtData.implicitCollectionDisseminationPolicy.maxPeerCount"
+	defImplicitCollectionMaxPeerCount            = 1
+)
+
+// ImplicitCollectionNameForOrg returns the name of the implicit collection of the given org
+func ImplicitCollectionNameForOrg(mspID string) string {
+	return ImplicitCollectionNamePrefix + mspID
+}
+
+// MspIDIfImplicitCollection returns whether the given collection is an
+// implicit collection, and if so, the MSP ID of the org it belongs to
+func MspIDIfImplicitCollection(collectionName string) (isImplicit bool, mspID string) {
+	if !strings.HasPrefix(collectionName, ImplicitCollectionNamePrefix) {
+		return false, ""
+	}
+	mspID = strings.TrimPrefix(collectionName, ImplicitCollectionNamePrefix)
+	if mspID == "" {
+		return false, ""
+	}
+	return true, mspID
+}
+
+// GenerateImplicitCollectionForOrg returns the configuration of the implicit
+// collection of the given org; its private data is disseminated according to
+// peer.gossip.pvtData.implicitCollectionDisseminationPolicy
+func GenerateImplicitCollectionForOrg(mspID string) *common.StaticCollectionConfig {
+	requiredPeerCount := viper.GetInt(implicitCollectionRequiredPeerCountConfigKey)
+	maxPeerCount := defImplicitCollectionMaxPeerCount
+	if viper.IsSet(implicitCollectionMaxPeerCountConfigKey) {
+		maxPeerCount = viper.GetInt(implicitCollectionMaxPeerCountConfigKey)
+	}
+	if maxPeerCount < requiredPeerCount {
+		maxPeerCount = requiredPeerCount
+	}
+	return &common.StaticCollectionConfig{
+		Name: ImplicitCollectionNameForOrg(mspID),
+		MemberOrgsPolicy: &common.CollectionPolicyConfig{
+			Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+				SignaturePolicy: cauthdsl.SignedByMspMember(mspID),
+			},
+		},
+		RequiredPeerCount: int32(requiredPeerCount),
+		MaximumPeerCount:  int32(maxPeerCount),
+	}
+}
+
+// NewImplicitCollection returns the implicit collection of the given org
+func NewImplicitCollection(mspID string, deserializer msp.IdentityDeserializer) (*SimpleCollection, error) {
+	sc := &SimpleCollection{}
+	if err := sc.Setup(GenerateImplicitCollectionForOrg(mspID), deserializer); err != nil {
+		return nil, errors.WithMessage(err, "error setting up implicit collection of "+mspID)
+	}
+	return sc, nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	lm "github.com/hyperledger/fabric/common/mocks/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric/protos/msp"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImplicitCollectionName(t *testing.T) {
+	name := ImplicitCollectionNameForOrg("Org1MSP")
+	assert.Equal(t, "_implicit_org_Org1MSP", name)
+
+	isImplicit, mspID := MspIDIfImplicitCollection(name)
+	assert.True(t, isImplicit)
+	assert.Equal(t, "Org1MSP", mspID)
+
+	isImplicit, _ = MspIDIfImplicitCollection("mycollection")
+	assert.False(t, isImplicit)
+
+	isImplicit, _ = MspIDIfImplicitCollection(ImplicitCollectionNamePrefix)
+	assert.False(t, isImplicit)
+}
+
+func TestGenerateImplicitCollectionForOrg(t *testing.T) {
+	defer viper.Reset()
+
+	// By default, the private data of implicit collections is sent to one peer at most,
+	// and doesn't need to be disseminated for the endorsement to succeed
+	conf := GenerateImplicitCollectionForOrg("Org1MSP")
+	assert.Equal(t, "_implicit_org_Org1MSP", conf.Name)
+	assert.Equal(t, int32(0), conf.RequiredPeerCount)
+	assert.Equal(t, int32(1), conf.MaximumPeerCount)
+
+	viper.Set(implicitCollectionRequiredPeerCountConfigKey, 2)
+	viper.Set(implicitCollectionMaxPeerCountConfigKey, 3)
+	conf = GenerateImplicitCollectionForOrg("Org1MSP")
+	assert.Equal(t, int32(2), conf.RequiredPeerCount)
+	assert.Equal(t, int32(3), conf.MaximumPeerCount)
+
+	// The maximum peer count is never smaller than the required peer count
+	viper.Set(implicitCollectionMaxPeerCountConfigKey, 1)
+	conf = GenerateImplicitCollectionForOrg("Org1MSP")
+	assert.Equal(t, int32(2), conf.MaximumPeerCount)
+}
+
+func TestImplicitCollection(t *testing.T) {
+	sc, err := NewImplicitCollection("Org1MSP", &mockDeserializer{})
+	assert.NoError(t, err)
+	assert.Equal(t, "_implicit_org_Org1MSP", sc.CollectionID())
+	assert.Equal(t, []string{"Org1MSP"}, sc.MemberOrgs())
+
+	// Only members of the org satisfy the access policy of the implicit collection
+	member, err := proto.Marshal(&mb.MSPRole{MspIdentifier: "Org1MSP", Role: mb.MSPRole_MEMBER})
+	assert.NoError(t, err)
+	accessFilter := sc.AccessFilter()
+	assert.True(t, accessFilter(common.SignedData{Identity: member}))
+	assert.False(t, accessFilter(common.SignedData{Identity: []byte("Org2MSP")}))
+}
+
+func TestCollectionStoreImplicitCollection(t *testing.T) {
+	// Implicit collections are retrieved even if the chaincode has no collections defined
+	support := &mockStoreSupport{Qe: &lm.MockQueryExecutor{State: make(map[string]map[string][]byte)}}
+	cs := NewSimpleCollectionStore(support)
+	ccr := common.CollectionCriteria{Channel: "ch", Namespace: "cc", Collection: "_implicit_org_Org1MSP"}
+
+	c, err := cs.RetrieveCollection(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, "_implicit_org_Org1MSP", c.CollectionID())
+	assert.Equal(t, []string{"Org1MSP"}, c.MemberOrgs())
+
+	ap, err := cs.RetrieveCollectionAccessPolicy(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ap.MaximumPeerCount())
+
+	_, err = cs.RetrieveCollection(common.CollectionCriteria{Channel: "ch", Namespace: "cc", Collection: "mycollection"})
+	assert.Error(t, err)
+}
@@ -73,6 +73,15 @@ func (c *simpleCollectionStore) retrieveCollectionConfigPackage(cc common.Collec
 }
 
 func (c *simpleCollectionStore) retrieveSimpleCollection(cc common.CollectionCriteria) (*SimpleCollection, error) {
+	// implicit collections are available to every chaincode without being defined
+	if isImplicit, mspID := MspIDIfImplicitCollection(cc.Collection); isImplicit {
+		sc, err := NewImplicitCollection(mspID, c.s.GetIdentityDeserializer(cc.Channel))
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("error setting up collection for collection criteria %#v", cc))
+		}
+		return sc, nil
+	}
+
 	collections, err := c.retrieveCollectionConfigPackage(cc)
 	if err != nil {
 		return nil, err
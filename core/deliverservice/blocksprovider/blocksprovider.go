@@ -8,6 +8,7 @@ package blocksprovider
 
 import (
 	"math"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -52,6 +53,9 @@ type BlocksProvider interface {
 	// UpdateClientEndpoints update endpoints
 	UpdateOrderingEndpoints(endpoints []string)
 
+	// OrdererHealth returns the health of the ordering service endpoints
+	OrdererHealth() []EndpointHealth
+
 	// Stop shutdowns blocks provider and stops delivering new blocks
 	Stop()
 }
@@ -78,6 +82,9 @@ type streamClient interface {
 	// GetEndpoints
 	GetEndpoints() []string
 
+	// GetEndpoint returns the endpoint the client is currently connected to
+	GetEndpoint() string
+
 	// Close closes the stream and its underlying connection
 	Close()
 
@@ -98,10 +105,37 @@ type blocksProviderImpl struct {
 	done int32
 
 	wrongStatusThreshold int
+
+	lock          sync.Mutex
+	invalidBlocks map[string]InvalidBlock
+
+	health            *HealthTracker
+	blockLagThreshold uint64
+	prevBlockLag      uint64
+	lagStrikes        int
+}
+
+// InvalidBlock records a block received from an ordering service node
+// which failed verification against the channel's BlockValidation policy
+type InvalidBlock struct {
+	// SeqNum is the sequence number of the block
+	SeqNum uint64
+	// Reason is the verification failure
+	Reason string
+	// Time is when the block was received
+	Time time.Time
+	// Count is the number of invalid blocks received from the endpoint
+	Count int
 }
 
 const wrongStatusThreshold = 10
 
+// lagStrikesThreshold is the number of consecutive blocks, received while the orderer lags behind
+// the peers of the channel and without closing the gap, after which a healthier orderer is sought
+const lagStrikesThreshold = 3
+
+const defaultBlockLagThreshold = 10
+
 var maxRetryDelay = time.Second * 10
 
 var logger *logging.Logger // package-level logger
@@ -112,12 +146,21 @@ func init() {
 
 // NewBlocksProvider constructor function to create blocks deliverer instance
 func NewBlocksProvider(chainID string, client streamClient, gossip GossipServiceAdapter, mcs api.MessageCryptoService) BlocksProvider {
+	return NewBlocksProviderWithHealthTracker(chainID, client, gossip, mcs, NewHealthTracker())
+}
+
+// NewBlocksProviderWithHealthTracker creates a blocks deliverer instance which records
+// the health of the ordering service endpoints in the given HealthTracker
+func NewBlocksProviderWithHealthTracker(chainID string, client streamClient, gossip GossipServiceAdapter, mcs api.MessageCryptoService, health *HealthTracker) BlocksProvider {
 	return &blocksProviderImpl{
 		chainID:              chainID,
 		client:               client,
 		gossip:               gossip,
 		mcs:                  mcs,
 		wrongStatusThreshold: wrongStatusThreshold,
+		invalidBlocks:        make(map[string]InvalidBlock),
+		health:               health,
+		blockLagThreshold:    uint64(util.GetIntOrDefault("peer.deliveryclient.blockLagThreshold", defaultBlockLagThreshold)),
 	}
 }
 
@@ -128,6 +171,7 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 	statusCounter := 0
 	defer b.client.Close()
 	for !b.isDone() {
+		start := time.Now()
 		msg, err := b.client.Recv()
 		if err != nil {
 			logger.Warningf("[%s] Receive error: %s", b.chainID, err.Error())
@@ -150,12 +194,8 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 				errorStatusCounter = 0
 				logger.Warningf("[%s] Got error %v", b.chainID, t)
 			}
-			maxDelay := float64(maxRetryDelay)
-			currDelay := float64(time.Duration(math.Pow(2, float64(statusCounter))) * 100 * time.Millisecond)
-			time.Sleep(time.Duration(math.Min(maxDelay, currDelay)))
-			if currDelay < maxDelay {
-				statusCounter++
-			}
+			b.errorReceived(b.client.GetEndpoint())
+			statusCounter = backoff(statusCounter)
 			if t.Status == common.Status_BAD_REQUEST {
 				b.client.Disconnect(false)
 			} else {
@@ -163,8 +203,6 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 			}
 			continue
 		case *orderer.DeliverResponse_Block:
-			errorStatusCounter = 0
-			statusCounter = 0
 			seqNum := t.Block.Header.Number
 
 			marshaledBlock, err := proto.Marshal(t.Block)
@@ -172,12 +210,29 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 				logger.Errorf("[%s] Error serializing block with sequence number %d, due to %s", b.chainID, seqNum, err)
 				continue
 			}
+			// The message crypto service evaluates the channel's BlockValidation policy
+			// against the block signatures. A block which fails it means the orderer we
+			// are connected to cannot be trusted, so we record it and fail over to another
+			// ordering service node, which is disabled for a period of time.
 			if err := b.mcs.VerifyBlock(gossipcommon.ChainID(b.chainID), seqNum, marshaledBlock); err != nil {
-				logger.Errorf("[%s] Error verifying block with sequnce number %d, due to %s", b.chainID, seqNum, err)
+				endpoint := b.client.GetEndpoint()
+				logger.Errorf("[%s] Error verifying block with sequence number %d received from %s, due to %s", b.chainID, seqNum, endpoint, err)
+				b.recordInvalidBlock(endpoint, seqNum, err)
+				b.errorReceived(endpoint)
+				statusCounter = backoff(statusCounter)
+				b.client.Disconnect(true)
 				continue
 			}
+			errorStatusCounter = 0
+			statusCounter = 0
 
-			numberOfPeers := len(b.gossip.PeersOfChannel(gossipcommon.ChainID(b.chainID)))
+			peers := b.gossip.PeersOfChannel(gossipcommon.ChainID(b.chainID))
+			numberOfPeers := len(peers)
+			endpoint := b.client.GetEndpoint()
+			blockLag := blockLag(seqNum, peers)
+			if endpoint != "" {
+				b.health.blockReceived(endpoint, time.Since(start), blockLag)
+			}
 			// Create payload with a block received
 			payload := createPayload(seqNum, marshaledBlock)
 			// Use payload to create gossip message
@@ -192,6 +247,12 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 			// Gossip messages with other nodes
 			logger.Debugf("[%s] Gossiping block [%d], peers number [%d]", b.chainID, seqNum, numberOfPeers)
 			b.gossip.Gossip(gossipMsg)
+
+			if b.shouldSwitchOrderer(endpoint, blockLag) {
+				logger.Warningf("[%s] Orderer %s lags %d blocks behind the peers of the channel, switching to a healthier orderer, orderers health: %+v",
+					b.chainID, endpoint, blockLag, b.OrdererHealth())
+				b.client.Disconnect(true)
+			}
 		default:
 			logger.Warningf("[%s] Received unknown: ", b.chainID, t)
 			return
@@ -199,6 +260,92 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 	}
 }
 
+// blockLag returns the number of blocks the block with the given sequence
+// number is behind the highest ledger height among the given peers
+func blockLag(seqNum uint64, peers []discovery.NetworkMember) uint64 {
+	var maxHeight uint64
+	for _, peer := range peers {
+		if peer.Properties != nil && peer.Properties.LedgerHeight > maxHeight {
+			maxHeight = peer.Properties.LedgerHeight
+		}
+	}
+	if maxHeight <= seqNum+1 {
+		return 0
+	}
+	return maxHeight - seqNum - 1
+}
+
+// shouldSwitchOrderer returns whether the orderer we are connected to consistently lags
+// behind the peers of the channel without closing the gap, while a healthier orderer exists
+func (b *blocksProviderImpl) shouldSwitchOrderer(endpoint string, blockLag uint64) bool {
+	if b.blockLagThreshold == 0 || endpoint == "" {
+		return false
+	}
+	if blockLag < b.blockLagThreshold || blockLag < b.prevBlockLag {
+		b.lagStrikes = 0
+	} else {
+		b.lagStrikes++
+	}
+	b.prevBlockLag = blockLag
+	if b.lagStrikes < lagStrikesThreshold {
+		return false
+	}
+	for _, other := range b.client.GetEndpoints() {
+		if other != endpoint && b.health.Score(other) < b.health.Score(endpoint) {
+			b.lagStrikes = 0
+			b.prevBlockLag = 0
+			return true
+		}
+	}
+	return false
+}
+
+func (b *blocksProviderImpl) errorReceived(endpoint string) {
+	if endpoint == "" {
+		return
+	}
+	b.health.errorReceived(endpoint)
+}
+
+// OrdererHealth returns the health of the ordering service endpoints
+func (b *blocksProviderImpl) OrdererHealth() []EndpointHealth {
+	connected := b.client.GetEndpoint()
+	health := b.health.Health(b.client.GetEndpoints())
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for i := range health {
+		health[i].Connected = health[i].Endpoint == connected
+		if invalidBlock, exists := b.invalidBlocks[health[i].Endpoint]; exists {
+			health[i].LastInvalidBlock = &invalidBlock
+		}
+	}
+	return health
+}
+
+// backoff sleeps exponentially to the given attempt counter, up to maxRetryDelay,
+// and returns the counter for the next attempt
+func backoff(counter int) int {
+	maxDelay := float64(maxRetryDelay)
+	currDelay := float64(time.Duration(math.Pow(2, float64(counter))) * 100 * time.Millisecond)
+	time.Sleep(time.Duration(math.Min(maxDelay, currDelay)))
+	if currDelay < maxDelay {
+		counter++
+	}
+	return counter
+}
+
+func (b *blocksProviderImpl) recordInvalidBlock(endpoint string, seqNum uint64, err error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.invalidBlocks[endpoint] = InvalidBlock{
+		SeqNum: seqNum,
+		Reason: err.Error(),
+		Time:   time.Now(),
+		Count:  b.invalidBlocks[endpoint].Count + 1,
+	}
+}
+
 // Stop stops blocks delivery provider
 func (b *blocksProviderImpl) Stop() {
 	atomic.StoreInt32(&b.done, 1)
@@ -16,7 +16,9 @@ import (
 	"github.com/hyperledger/fabric/core/deliverservice/mocks"
 	"github.com/hyperledger/fabric/gossip/api"
 	common2 "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -443,3 +445,131 @@ func TestBlockVerificationFailure(t *testing.T) {
 	mcs.On("VerifyBlock", mock.Anything).Return(errors.New("Invalid signature"))
 	makeTestCase(uint64(0), mcs, false, rcvr)(t)
 }
+
+func TestBlockVerificationFailureDisablesOrderer(t *testing.T) {
+	makeBlock := func(seqNum uint64) *orderer.DeliverResponse {
+		return &orderer.DeliverResponse{
+			Type: &orderer.DeliverResponse_Block{
+				Block: &common.Block{
+					Header: &common.BlockHeader{Number: seqNum},
+					Data:   &common.BlockData{Data: [][]byte{}},
+				}},
+		}
+	}
+
+	bd := mocks.MockBlocksDeliverer{
+		DisconnectCalled:           make(chan struct{}, 10),
+		DisconnectAndDisableCalled: make(chan struct{}, 10),
+		CloseCalled:                make(chan struct{}, 1),
+		Endpoints:                  []string{"orderer1:7050", "orderer2:7050"},
+		Endpoint:                   "orderer1:7050",
+	}
+	mcs := &mockMCS{}
+	mcs.On("VerifyBlock", mock.Anything).Return(errors.New("implicit policy evaluation failed")).Twice()
+	mcs.On("VerifyBlock", mock.Anything).Return(nil)
+	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64, 1)}
+	provider := NewBlocksProvider("***TEST_CHAINID***", &bd, gossipServiceAdapter, mcs)
+	defer provider.Stop()
+
+	// The endpoint a block is received from is switched by the receiving goroutine,
+	// which is the one reading it
+	type incomingMsg struct {
+		endpoint string
+		msg      *orderer.DeliverResponse
+	}
+	incomingMsgs := make(chan incomingMsg, 3)
+	bd.MockRecv = func(mock *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
+		incoming := <-incomingMsgs
+		mock.Endpoint = incoming.endpoint
+		return incoming.msg, nil
+	}
+	go provider.DeliverBlocks()
+
+	// The first orderer sends blocks which fail the BlockValidation policy
+	incomingMsgs <- incomingMsg{endpoint: "orderer1:7050", msg: makeBlock(5)}
+	incomingMsgs <- incomingMsg{endpoint: "orderer1:7050", msg: makeBlock(5)}
+	waitUntilOrFail(t, func() bool {
+		return len(bd.DisconnectAndDisableCalled) == 2
+	})
+	assert.Len(t, bd.DisconnectCalled, 0)
+	<-bd.DisconnectAndDisableCalled
+	<-bd.DisconnectAndDisableCalled
+
+	health := provider.OrdererHealth()
+	assert.Len(t, health, 2)
+	assert.Equal(t, "orderer1:7050", health[0].Endpoint)
+	assert.NotNil(t, health[0].LastInvalidBlock)
+	assert.Equal(t, uint64(5), health[0].LastInvalidBlock.SeqNum)
+	assert.Equal(t, 2, health[0].LastInvalidBlock.Count)
+	assert.Equal(t, "implicit policy evaluation failed", health[0].LastInvalidBlock.Reason)
+	assert.Nil(t, health[1].LastInvalidBlock)
+
+	// The client fails over to another orderer, which sends a valid block
+	incomingMsgs <- incomingMsg{endpoint: "orderer2:7050", msg: makeBlock(5)}
+	select {
+	case seqNum := <-gossipServiceAdapter.GossipBlockDisseminations:
+		assert.Equal(t, uint64(5), seqNum)
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "Didn't gossip a block within a timely manner")
+	}
+	health = provider.OrdererHealth()
+	assert.NotNil(t, health[0].LastInvalidBlock)
+	assert.Nil(t, health[1].LastInvalidBlock)
+}
+
+func TestBlocksProviderSwitchesLaggingOrderer(t *testing.T) {
+	peersOfHeight := func(height uint64) []discovery.NetworkMember {
+		return []discovery.NetworkMember{{Properties: &gossip.Properties{LedgerHeight: height}}}
+	}
+	newProvider := func(rcv rcvFunc) (*mocks.MockBlocksDeliverer, *mocks.MockGossipServiceAdapter, BlocksProvider) {
+		bd := &mocks.MockBlocksDeliverer{
+			DisconnectCalled:           make(chan struct{}, 100),
+			DisconnectAndDisableCalled: make(chan struct{}, 100),
+			CloseCalled:                make(chan struct{}, 1),
+			Endpoint:                   "orderer1:7050",
+			Endpoints:                  []string{"orderer1:7050", "orderer2:7050"},
+			MockRecv:                   rcv,
+		}
+		mcs := &mockMCS{}
+		mcs.On("VerifyBlock", mock.Anything).Return(nil)
+		gossipServiceAdapter := &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64, 100)}
+		return bd, gossipServiceAdapter, NewBlocksProvider("***TEST_CHAINID***", bd, gossipServiceAdapter, mcs)
+	}
+
+	t.Run("catching up", func(t *testing.T) {
+		var gossipServiceAdapter *mocks.MockGossipServiceAdapter
+		bd, gossipServiceAdapter, provider := newProvider(func(bd *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
+			// The peers of the channel are far ahead, but the gap is being closed
+			gossipServiceAdapter.Peers = peersOfHeight(100)
+			return mocks.MockRecv(bd)
+		})
+		defer provider.Stop()
+		go provider.DeliverBlocks()
+
+		waitUntilOrFail(t, func() bool {
+			return len(gossipServiceAdapter.GossipBlockDisseminations) >= 10
+		})
+		assert.Len(t, bd.DisconnectAndDisableCalled, 0)
+	})
+
+	t.Run("lagging", func(t *testing.T) {
+		var gossipServiceAdapter *mocks.MockGossipServiceAdapter
+		bd, gossipServiceAdapter, provider := newProvider(func(bd *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
+			// The peers of the channel keep being 20 blocks ahead
+			gossipServiceAdapter.Peers = peersOfHeight(bd.Pos + 21)
+			return mocks.MockRecv(bd)
+		})
+		defer provider.Stop()
+		go provider.DeliverBlocks()
+
+		waitUntilOrFail(t, func() bool {
+			return len(bd.DisconnectAndDisableCalled) > 0
+		})
+		health := provider.OrdererHealth()
+		assert.Len(t, health, 2)
+		assert.Equal(t, "orderer1:7050", health[0].Endpoint)
+		assert.True(t, health[0].Connected)
+		assert.Equal(t, uint64(20), health[0].BlockLag)
+		assert.Equal(t, EndpointHealth{Endpoint: "orderer2:7050"}, health[1])
+	})
+}
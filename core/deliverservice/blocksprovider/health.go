@@ -0,0 +1,135 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blocksprovider
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// healthSmoothing is the weight given to the latest observation
+	// in the exponentially weighted moving averages of the endpoint health
+	healthSmoothing = 0.2
+
+	// errorRateWeight is the weight of the error rate in the health score,
+	// such that an endpoint failing every response is 10 blocks worth of lag
+	errorRateWeight = 10
+)
+
+// EndpointHealth describes the health of an ordering service endpoint,
+// as observed by the blocks provider
+type EndpointHealth struct {
+	// Endpoint is the ordering service endpoint
+	Endpoint string
+	// Latency is the smoothed time it took to receive a block from the endpoint,
+	// measured only while the peer is behind the rest of the channel
+	Latency time.Duration
+	// BlockLag is the number of blocks the endpoint was behind the highest ledger
+	// height advertised by the peers of the channel, when its last block was received
+	BlockLag uint64
+	// ErrorRate is the smoothed fraction of the responses of the endpoint which were
+	// either error statuses or blocks which failed verification
+	ErrorRate float64
+	// Score is the overall health of the endpoint, lower is healthier
+	Score float64
+	// Connected indicates whether the blocks provider is currently connected to the endpoint
+	Connected bool
+	// LastInvalidBlock is the last block received from the endpoint which failed verification,
+	// or nil if there is none
+	LastInvalidBlock *InvalidBlock
+}
+
+func (eh *EndpointHealth) updateScore() {
+	eh.Score = eh.Latency.Seconds() + float64(eh.BlockLag) + eh.ErrorRate*errorRateWeight
+}
+
+// HealthTracker tracks the health of the ordering service endpoints
+// a blocks provider receives blocks from, and ranks them accordingly
+type HealthTracker struct {
+	lock      sync.RWMutex
+	endpoints map[string]*EndpointHealth
+}
+
+// NewHealthTracker creates a new HealthTracker
+func NewHealthTracker() *HealthTracker {
+	return &HealthTracker{
+		endpoints: make(map[string]*EndpointHealth),
+	}
+}
+
+func (ht *HealthTracker) endpoint(endpoint string) *EndpointHealth {
+	eh, exists := ht.endpoints[endpoint]
+	if !exists {
+		eh = &EndpointHealth{Endpoint: endpoint}
+		ht.endpoints[endpoint] = eh
+	}
+	return eh
+}
+
+// blockReceived records a valid block received from the endpoint
+func (ht *HealthTracker) blockReceived(endpoint string, latency time.Duration, blockLag uint64) {
+	ht.lock.Lock()
+	defer ht.lock.Unlock()
+	eh := ht.endpoint(endpoint)
+	if blockLag > 0 {
+		eh.Latency = time.Duration((1-healthSmoothing)*float64(eh.Latency) + healthSmoothing*float64(latency))
+	}
+	eh.BlockLag = blockLag
+	eh.ErrorRate = (1 - healthSmoothing) * eh.ErrorRate
+	eh.updateScore()
+}
+
+// errorReceived records an error status or an invalid block received from the endpoint
+func (ht *HealthTracker) errorReceived(endpoint string) {
+	ht.lock.Lock()
+	defer ht.lock.Unlock()
+	eh := ht.endpoint(endpoint)
+	eh.ErrorRate = (1-healthSmoothing)*eh.ErrorRate + healthSmoothing
+	eh.updateScore()
+}
+
+// Score returns the health score of the given endpoint, lower is healthier.
+// Endpoints which have not been observed yet have a perfect score.
+func (ht *HealthTracker) Score(endpoint string) float64 {
+	ht.lock.RLock()
+	defer ht.lock.RUnlock()
+	if eh, exists := ht.endpoints[endpoint]; exists {
+		return eh.Score
+	}
+	return 0
+}
+
+// Rank orders the given endpoints by their health, healthiest first.
+// It satisfies comm.EndpointRanker.
+func (ht *HealthTracker) Rank(endpoints []string) []string {
+	ranked := make([]string, len(endpoints))
+	copy(ranked, endpoints)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ht.Score(ranked[i]) < ht.Score(ranked[j])
+	})
+	return ranked
+}
+
+// Health returns the health of the given endpoints, sorted by endpoint
+func (ht *HealthTracker) Health(endpoints []string) []EndpointHealth {
+	ht.lock.RLock()
+	defer ht.lock.RUnlock()
+	res := make([]EndpointHealth, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		eh := EndpointHealth{Endpoint: endpoint}
+		if tracked, exists := ht.endpoints[endpoint]; exists {
+			eh = *tracked
+		}
+		res = append(res, eh)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Endpoint < res[j].Endpoint
+	})
+	return res
+}
@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blocksprovider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthTracker(t *testing.T) {
+	ht := NewHealthTracker()
+	assert.Equal(t, float64(0), ht.Score("a"))
+
+	// Latency is only accounted for while the peer is behind
+	ht.blockReceived("a", time.Second, 0)
+	assert.Equal(t, float64(0), ht.Score("a"))
+	ht.blockReceived("a", time.Second, 5)
+	assert.InDelta(t, 5.2, ht.Score("a"), 0.0001)
+
+	ht.errorReceived("b")
+	assert.InDelta(t, 2, ht.Score("b"), 0.0001)
+	ht.errorReceived("b")
+	assert.InDelta(t, 3.6, ht.Score("b"), 0.0001)
+	// Valid blocks decay the error rate
+	ht.blockReceived("b", 0, 0)
+	assert.InDelta(t, 2.88, ht.Score("b"), 0.0001)
+
+	assert.Equal(t, []string{"c", "b", "a"}, ht.Rank([]string{"a", "b", "c"}))
+	// Endpoints of equal health keep their order
+	assert.Equal(t, []string{"d", "c", "b", "a"}, ht.Rank([]string{"d", "c", "b", "a"}))
+	assert.Equal(t, []string{"c", "d", "b", "a"}, ht.Rank([]string{"a", "b", "c", "d"}))
+
+	health := ht.Health([]string{"c", "a"})
+	assert.Len(t, health, 2)
+	assert.Equal(t, "a", health[0].Endpoint)
+	assert.Equal(t, uint64(5), health[0].BlockLag)
+	assert.Equal(t, time.Millisecond*200, health[0].Latency)
+	assert.Equal(t, EndpointHealth{Endpoint: "c"}, health[1])
+}
+
+func TestBlockLag(t *testing.T) {
+	peerOfHeight := func(height uint64) discovery.NetworkMember {
+		return discovery.NetworkMember{Properties: &gossip.Properties{LedgerHeight: height}}
+	}
+	assert.Equal(t, uint64(0), blockLag(10, nil))
+	assert.Equal(t, uint64(0), blockLag(10, []discovery.NetworkMember{{}, peerOfHeight(11)}))
+	assert.Equal(t, uint64(0), blockLag(10, []discovery.NetworkMember{peerOfHeight(5)}))
+	assert.Equal(t, uint64(4), blockLag(10, []discovery.NetworkMember{peerOfHeight(13), peerOfHeight(15), {}}))
+}
@@ -234,6 +234,13 @@ func (bc *broadcastClient) UpdateEndpoints(endpoints []string) {
 	bc.prod.UpdateEndpoints(endpoints)
 }
 
+// GetEndpoint returns the endpoint of the ordering service node the client is currently connected to
+func (bc *broadcastClient) GetEndpoint() string {
+	bc.Lock()
+	defer bc.Unlock()
+	return bc.endpoint
+}
+
 // GetEndpoints returns ordering service endpoints
 func (bc *broadcastClient) GetEndpoints() []string {
 	return bc.prod.GetEndpoints()
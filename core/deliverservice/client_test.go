@@ -723,6 +723,13 @@ func TestDisconnectAndDisableEndpoint(t *testing.T) {
 	}), "Didn't get connection to orderer")
 
 	connectedToOS1 := os1.ConnCount() == 1
+	expectedEndpoint := "localhost:5614"
+	if connectedToOS1 {
+		expectedEndpoint = "localhost:5613"
+	}
+	assert.True(t, waitForWithTimeout(time.Millisecond*100, func() bool {
+		return cl.GetEndpoint() == expectedEndpoint
+	}), "Client doesn't report the endpoint it is connected to")
 
 	// Disconnect and disable endpoint
 	cl.Disconnect(true)
@@ -59,6 +59,10 @@ type DeliverService interface {
 	// UpdateEndpoints
 	UpdateEndpoints(chainID string, endpoints []string) error
 
+	// OrdererHealth returns the health of the ordering service endpoints
+	// blocks of the given channel are delivered from
+	OrdererHealth(chainID string) ([]blocksprovider.EndpointHealth, error)
+
 	// Stop terminates delivery service and closes the connection
 	Stop()
 }
@@ -118,6 +122,18 @@ func (d *deliverServiceImpl) UpdateEndpoints(chainID string, endpoints []string)
 	return errors.New(fmt.Sprintf("Channel with %s id was not found", chainID))
 }
 
+// OrdererHealth returns the health of the ordering service endpoints
+// blocks of the given channel are delivered from
+func (d *deliverServiceImpl) OrdererHealth(chainID string) ([]blocksprovider.EndpointHealth, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	bp, ok := d.blockProviders[chainID]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("Not delivering blocks from the ordering service for channel %s", chainID))
+	}
+	return bp.OrdererHealth(), nil
+}
+
 func (d *deliverServiceImpl) validateConfiguration() error {
 	conf := d.conf
 	if len(conf.Endpoints) == 0 {
@@ -155,9 +171,10 @@ func (d *deliverServiceImpl) StartDeliverForChannel(chainID string, ledgerInfo b
 		logger.Errorf(errMsg)
 		return errors.New(errMsg)
 	} else {
-		client := d.newClient(chainID, ledgerInfo)
+		health := blocksprovider.NewHealthTracker()
+		client := d.newClient(chainID, ledgerInfo, health.Rank)
 		logger.Debug("This peer will pass blocks from orderer service to other peers for channel", chainID)
-		d.blockProviders[chainID] = blocksprovider.NewBlocksProvider(chainID, client, d.conf.Gossip, d.conf.CryptoSvc)
+		d.blockProviders[chainID] = blocksprovider.NewBlocksProviderWithHealthTracker(chainID, client, d.conf.Gossip, d.conf.CryptoSvc, health)
 		go func() {
 			d.blockProviders[chainID].DeliverBlocks()
 			finalizer()
@@ -199,7 +216,7 @@ func (d *deliverServiceImpl) Stop() {
 	}
 }
 
-func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocksprovider.LedgerInfo) *broadcastClient {
+func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocksprovider.LedgerInfo, ranker comm.EndpointRanker) *broadcastClient {
 	requester := &blocksRequester{
 		tls:     comm.TLSEnabled(),
 		chainID: chainID,
@@ -215,7 +232,7 @@ func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocks
 		attempt := float64(attemptNum)
 		return time.Duration(math.Min(math.Pow(2, attempt)*sleepIncrement, reConnectBackoffThreshold)), true
 	}
-	connProd := comm.NewConnectionProducer(d.conf.ConnFactory(chainID), d.conf.Endpoints)
+	connProd := comm.NewRankedConnectionProducer(d.conf.ConnFactory(chainID), d.conf.Endpoints, ranker)
 	bClient := NewBroadcastClient(connProd, d.conf.ABCFactory, broadcastSetup, backoffPolicy)
 	requester.client = bClient
 	return bClient
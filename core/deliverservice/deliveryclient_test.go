@@ -589,7 +589,7 @@ func TestRetryPolicyOverflow(t *testing.T) {
 			return nil, errors.New("")
 		}
 	}
-	client := (&deliverServiceImpl{conf: &Config{ConnFactory: connFactory}}).newClient("TEST", &mocks.MockLedgerInfo{Height: uint64(100)})
+	client := (&deliverServiceImpl{conf: &Config{ConnFactory: connFactory}}).newClient("TEST", &mocks.MockLedgerInfo{Height: uint64(100)}, nil)
 	assert.NotNil(t, client.shouldRetry)
 	for i := 0; i < 100; i++ {
 		retryTime, _ := client.shouldRetry(i, time.Second)
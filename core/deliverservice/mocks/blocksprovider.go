@@ -27,6 +27,8 @@ type MockGossipServiceAdapter struct {
 	AddPayloadsCnt int32
 
 	GossipBlockDisseminations chan uint64
+
+	Peers []discovery.NetworkMember
 }
 
 type MockAtomicBroadcastClient struct {
@@ -41,8 +43,8 @@ func (mabc *MockAtomicBroadcastClient) Deliver(ctx context.Context, opts ...grpc
 }
 
 // PeersOfChannel returns the slice with peers participating in given channel
-func (*MockGossipServiceAdapter) PeersOfChannel(gossip_common.ChainID) []discovery.NetworkMember {
-	return []discovery.NetworkMember{}
+func (mock *MockGossipServiceAdapter) PeersOfChannel(gossip_common.ChainID) []discovery.NetworkMember {
+	return mock.Peers
 }
 
 // AddPayload adds gossip payload to the local state transfer buffer
@@ -64,8 +66,10 @@ type MockBlocksDeliverer struct {
 	CloseCalled                chan struct{}
 	Pos                        uint64
 	grpc.ClientStream
-	RecvCnt  int32
-	MockRecv func(mock *MockBlocksDeliverer) (*orderer.DeliverResponse, error)
+	RecvCnt   int32
+	MockRecv  func(mock *MockBlocksDeliverer) (*orderer.DeliverResponse, error)
+	Endpoint  string
+	Endpoints []string
 }
 
 // Recv gets responses from the ordering service, currently mocked to return
@@ -116,9 +120,13 @@ func (mock *MockBlocksDeliverer) Send(env *common.Envelope) error {
 
 func (mock *MockBlocksDeliverer) Disconnect(disableEndpoint bool) {
 	if disableEndpoint {
-		mock.DisconnectAndDisableCalled <- struct{}{}
+		if mock.DisconnectAndDisableCalled != nil {
+			mock.DisconnectAndDisableCalled <- struct{}{}
+		}
 	} else {
-		mock.DisconnectCalled <- struct{}{}
+		if mock.DisconnectCalled != nil {
+			mock.DisconnectCalled <- struct{}{}
+		}
 	}
 }
 
@@ -134,7 +142,11 @@ func (mock *MockBlocksDeliverer) UpdateEndpoints(endpoints []string) {
 }
 
 func (mock *MockBlocksDeliverer) GetEndpoints() []string {
-	return []string{} // empty slice
+	return mock.Endpoints
+}
+
+func (mock *MockBlocksDeliverer) GetEndpoint() string {
+	return mock.Endpoint
 }
 
 // MockLedgerInfo mocking implementation of LedgerInfo interface, needed
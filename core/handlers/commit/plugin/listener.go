@@ -0,0 +1,27 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"github.com/hyperledger/fabric/core/ledger"
+)
+
+// NewCommitListener creates a new commit listener
+func NewCommitListener() ledger.CommitListener {
+	return &listener{}
+}
+
+type listener struct {
+}
+
+// HandleCommit is notified of the state updates of a committed block
+func (l *listener) HandleCommit(event *ledger.CommitEvent) error {
+	return nil
+}
+
+func main() {
+}
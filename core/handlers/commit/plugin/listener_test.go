@@ -0,0 +1,19 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitListener(t *testing.T) {
+	l := NewCommitListener()
+	assert.NoError(t, l.HandleCommit(&ledger.CommitEvent{LedgerID: "testchannel", BlockNum: 1}))
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/hyperledger/fabric/core/handlers/auth"
 	"github.com/hyperledger/fabric/core/handlers/decoration"
+	"github.com/hyperledger/fabric/core/ledger"
 )
 
 // Registry defines an object that looks up
@@ -35,14 +36,19 @@ const (
 	// Decoration handler - append or mutate the chaincode input
 	// passed to the chaincode
 	Decoration
+	// CommitListener handler - get notified of the state
+	// updates of every block committed to the ledger
+	CommitListener
 
-	authPluginFactory      = "NewFilter"
-	decoratorPluginFactory = "NewDecorator"
+	authPluginFactory           = "NewFilter"
+	decoratorPluginFactory      = "NewDecorator"
+	commitListenerPluginFactory = "NewCommitListener"
 )
 
 type registry struct {
-	filters    []auth.Filter
-	decorators []decoration.Decorator
+	filters         []auth.Filter
+	decorators      []decoration.Decorator
+	commitListeners []ledger.CommitListener
 }
 
 var once sync.Once
@@ -51,8 +57,9 @@ var reg registry
 // Config configures the factory methods
 // and plugins for the registry
 type Config struct {
-	AuthFilters []*HandlerConfig `mapstructure:"authFilters" yaml:"authFilters"`
-	Decorators  []*HandlerConfig `mapstructure:"decorators" yaml:"decorators"`
+	AuthFilters     []*HandlerConfig `mapstructure:"authFilters" yaml:"authFilters"`
+	Decorators      []*HandlerConfig `mapstructure:"decorators" yaml:"decorators"`
+	CommitListeners []*HandlerConfig `mapstructure:"commitListeners" yaml:"commitListeners"`
 }
 
 // HandlerConfig defines configuration for a plugin or compiled handler
@@ -79,6 +86,9 @@ func (r *registry) loadHandlers(c Config) {
 	for _, config := range c.Decorators {
 		r.evaluateModeAndLoad(config, Decoration)
 	}
+	for _, config := range c.CommitListeners {
+		r.evaluateModeAndLoad(config, CommitListener)
+	}
 }
 
 // evaluateModeAndLoad if a library path is provided, load the shared object
@@ -105,6 +115,8 @@ func (r *registry) loadCompiled(handlerFactory string, handlerType HandlerType)
 		r.filters = append(r.filters, inst.(auth.Filter))
 	} else if handlerType == Decoration {
 		r.decorators = append(r.decorators, inst.(decoration.Decorator))
+	} else if handlerType == CommitListener {
+		r.commitListeners = append(r.commitListeners, inst.(ledger.CommitListener))
 	}
 }
 
@@ -123,6 +135,8 @@ func (r *registry) loadPlugin(pluginPath string, handlerType HandlerType) {
 		r.initAuthPlugin(p)
 	} else if handlerType == Decoration {
 		r.initDecoratorPlugin(p)
+	} else if handlerType == CommitListener {
+		r.initCommitListenerPlugin(p)
 	}
 }
 
@@ -159,6 +173,22 @@ func (r *registry) initDecoratorPlugin(p *plugin.Plugin) {
 	}
 }
 
+// initCommitListenerPlugin constructs a commit listener from the given plugin
+func (r *registry) initCommitListenerPlugin(p *plugin.Plugin) {
+	constructorSymbol, err := p.Lookup(commitListenerPluginFactory)
+	if err != nil {
+		panicWithLookupError(commitListenerPluginFactory, err)
+	}
+	constructor, ok := constructorSymbol.(func() ledger.CommitListener)
+	if !ok {
+		panicWithDefinitionError(commitListenerPluginFactory)
+	}
+	listener := constructor()
+	if listener != nil {
+		r.commitListeners = append(r.commitListeners, listener)
+	}
+}
+
 // panicWithLookupError panics when a handler constructor lookup fails
 func panicWithLookupError(factory string, err error) {
 	panic(fmt.Errorf("Filter must contain constructor with name %s. Error from lookup: %s",
@@ -179,6 +209,8 @@ func (r *registry) Lookup(handlerType HandlerType) interface{} {
 		return r.filters
 	} else if handlerType == Decoration {
 		return r.decorators
+	} else if handlerType == CommitListener {
+		return r.commitListeners
 	}
 
 	return nil
@@ -19,13 +19,15 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/stretchr/testify/assert"
 )
 
 const (
-	authPluginPackage      = "github.com/hyperledger/fabric/core/handlers/auth/plugin"
-	decoratorPluginPackage = "github.com/hyperledger/fabric/core/handlers/decoration/plugin"
+	authPluginPackage           = "github.com/hyperledger/fabric/core/handlers/auth/plugin"
+	decoratorPluginPackage      = "github.com/hyperledger/fabric/core/handlers/decoration/plugin"
+	commitListenerPluginPackage = "github.com/hyperledger/fabric/core/handlers/commit/plugin"
 )
 
 func TestLoadAuthPlugin(t *testing.T) {
@@ -72,6 +74,24 @@ func TestLoadDecoratorPlugin(t *testing.T) {
 	assert.True(t, proto.Equal(decoratedInput, testInput), "Expected chaincode input to remain unchanged")
 }
 
+func TestLoadCommitListenerPlugin(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err, "Could not create temp directory for plugins")
+	defer os.Remove(testDir)
+	pluginPath := strings.Join([]string{testDir, "/", "commitlistenerplugin.so"}, "")
+
+	cmd := exec.Command("go", "build", "-o", pluginPath, "-buildmode=plugin",
+		commitListenerPluginPackage)
+	output, err := cmd.CombinedOutput()
+	assert.NoError(t, err, "Could not build plugin: "+string(output))
+
+	testReg := registry{}
+	testReg.loadPlugin(pluginPath, CommitListener)
+	assert.Len(t, testReg.commitListeners, 1, "Expected commit listener to be registered")
+
+	assert.NoError(t, testReg.commitListeners[0].HandleCommit(&ledger.CommitEvent{LedgerID: "testchannel", BlockNum: 1}))
+}
+
 func TestLoadPluginInvalidPath(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
@@ -11,6 +11,7 @@ import (
 
 	"github.com/hyperledger/fabric/core/handlers/auth"
 	"github.com/hyperledger/fabric/core/handlers/decoration"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -31,6 +32,11 @@ func TestInitRegistry(t *testing.T) {
 	decorators, isDecorators := decorationHandlers.([]decoration.Decorator)
 	assert.True(t, isDecorators)
 	assert.Len(t, decorators, 1)
+
+	commitListenerHandlers := r.Lookup(CommitListener)
+	commitListeners, isCommitListeners := commitListenerHandlers.([]ledger.CommitListener)
+	assert.True(t, isCommitListeners)
+	assert.Empty(t, commitListeners)
 }
 
 func TestLoadCompiledInvalid(t *testing.T) {
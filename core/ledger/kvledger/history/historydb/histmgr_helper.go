@@ -1,60 +0,0 @@
-/*
-Copyright IBM Corp. 2016 All Rights Reserved.
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-		 http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*/
-
-package historydb
-
-import (
-	"bytes"
-
-	"github.com/hyperledger/fabric/common/ledger/util"
-)
-
-var compositeKeySep = []byte{0x00}
-
-//ConstructCompositeHistoryKey builds the History Key of namespace~key~blocknum~trannum
-// using an order preserving encoding so that history query results are ordered by height
-func ConstructCompositeHistoryKey(ns string, key string, blocknum uint64, trannum uint64) []byte {
-
-	var compositeKey []byte
-	compositeKey = append(compositeKey, []byte(ns)...)
-	compositeKey = append(compositeKey, compositeKeySep...)
-	compositeKey = append(compositeKey, []byte(key)...)
-	compositeKey = append(compositeKey, compositeKeySep...)
-	compositeKey = append(compositeKey, util.EncodeOrderPreservingVarUint64(blocknum)...)
-	compositeKey = append(compositeKey, util.EncodeOrderPreservingVarUint64(trannum)...)
-
-	return compositeKey
-}
-
-//ConstructPartialCompositeHistoryKey builds a partial History Key namespace~key~
-// for use in history key range queries
-func ConstructPartialCompositeHistoryKey(ns string, key string, endkey bool) []byte {
-	var compositeKey []byte
-	compositeKey = append(compositeKey, []byte(ns)...)
-	compositeKey = append(compositeKey, compositeKeySep...)
-	compositeKey = append(compositeKey, []byte(key)...)
-	compositeKey = append(compositeKey, compositeKeySep...)
-	if endkey {
-		compositeKey = append(compositeKey, []byte{0xff}...)
-	}
-	return compositeKey
-}
-
-//SplitCompositeHistoryKey splits the key bytes using a separator
-func SplitCompositeHistoryKey(bytesToSplit []byte, separator []byte) ([]byte, []byte) {
-	split := bytes.SplitN(bytesToSplit, separator, 2)
-	return split[0], split[1]
-}
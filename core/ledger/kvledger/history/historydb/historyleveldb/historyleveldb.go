@@ -28,13 +28,25 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 )
 
 var logger = flogging.MustGetLogger("historyleveldb")
 
 var savePointKey = []byte{0x00}
+
+// versionIndexMarkerKey is present once the history DB maintains the version index,
+// see constructVersionIndexKey
+var versionIndexMarkerKey = []byte{0x02}
 var emptyValue = []byte{}
 
+// legacyRecordsStartKey precedes the history records of the form namespace~key~blocknum~trannum,
+// written before the version index replaced them, which are keyed by the namespace
+var legacyRecordsStartKey = []byte{0x03}
+
+// maxLegacyRecordsPurgeBatchSize is the maximum number of legacy history records deleted in a single batch
+const maxLegacyRecordsPurgeBatchSize = 10000
+
 // HistoryDBProvider implements interface HistoryDBProvider
 type HistoryDBProvider struct {
 	dbProvider *leveldbhelper.Provider
@@ -150,11 +162,11 @@ func (historyDB *historyDB) Commit(block *common.Block) error {
 				for _, kvWrite := range nsRWSet.KvRwSet.Writes {
 					writeKey := kvWrite.Key
 
-					//composite key for history records is in the form ns~key~blockNo~tranNo
-					compositeHistoryKey := historydb.ConstructCompositeHistoryKey(ns, writeKey, blockNo, tranNo)
+					//version index key for history records is in the form versionIndexPrefix~ns~key~blockNo~tranNo
+					versionIndexKey := constructVersionIndexKey(ns, writeKey, blockNo, tranNo)
 
 					// No value is required, write an empty byte array (emptyValue) since Put() of nil is not allowed
-					dbBatch.Put(compositeHistoryKey, emptyValue)
+					dbBatch.Put(versionIndexKey, emptyValue)
 				}
 			}
 
@@ -167,6 +179,7 @@ func (historyDB *historyDB) Commit(block *common.Block) error {
 	// add savepoint for recovery purpose
 	height := version.NewHeight(blockNo, tranNo)
 	dbBatch.Put(savePointKey, height.ToBytes())
+	dbBatch.Put(versionIndexMarkerKey, emptyValue)
 
 	// write the block's history records and savepoint to LevelDB
 	// Setting snyc to true as a precaution, false may be an ok optimization after further testing.
@@ -183,6 +196,21 @@ func (historyDB *historyDB) NewHistoryQueryExecutor(blockStore blkstorage.BlockS
 	return &LevelHistoryDBQueryExecutor{historyDB, blockStore}, nil
 }
 
+// NewHistoricalQueryExecutor implements method in HistoryDB interface
+func (historyDB *historyDB) NewHistoricalQueryExecutor(blockStore blkstorage.BlockStore, blockNum uint64) (ledger.HistoricalQueryExecutor, error) {
+	if !ledgerconfig.IsHistoryDBEnabled() {
+		return nil, errors.New("History tracking not enabled - historyDatabase is false")
+	}
+	savepoint, err := historyDB.GetLastSavepoint()
+	if err != nil {
+		return nil, err
+	}
+	if savepoint == nil || blockNum > savepoint.BlockNum {
+		return nil, errors.Errorf("block [%d] is not yet committed to the history database", blockNum)
+	}
+	return &levelHistoricalQueryExecutor{historyDB, blockStore, blockNum}, nil
+}
+
 // GetBlockNumFromSavepoint implements method in HistoryDB interface
 func (historyDB *historyDB) GetLastSavepoint() (*version.Height, error) {
 	versionBytes, err := historyDB.db.Get(savePointKey)
@@ -205,14 +233,63 @@ func (historyDB *historyDB) ShouldRecover(lastAvailableBlock uint64) (bool, uint
 	if savepoint == nil {
 		return true, 0, nil
 	}
+	// the history DB was created before the version index replaced the former history records,
+	// the blocks are re-committed so that the index is built
+	hasVersionIndex, err := historyDB.db.Get(versionIndexMarkerKey)
+	if err != nil {
+		return false, 0, err
+	}
+	if hasVersionIndex == nil {
+		return true, 0, nil
+	}
 	return savepoint.BlockNum != lastAvailableBlock, savepoint.BlockNum + 1, nil
 }
 
 // CommitLostBlock implements method in interface kvledger.Recoverer
 func (historyDB *historyDB) CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtData) error {
+	// the history records written before the version index replaced them are purged
+	// before the blocks are re-committed for building the index
+	hasVersionIndex, err := historyDB.db.Get(versionIndexMarkerKey)
+	if err != nil {
+		return err
+	}
+	if hasVersionIndex == nil {
+		if err := historyDB.purgeLegacyRecords(); err != nil {
+			return err
+		}
+	}
 	block := blockAndPvtdata.Block
 	if err := historyDB.Commit(block); err != nil {
 		return err
 	}
 	return nil
 }
+
+// purgeLegacyRecords deletes the history records of the form namespace~key~blocknum~trannum
+func (historyDB *historyDB) purgeLegacyRecords() error {
+	itr := historyDB.db.GetIterator(legacyRecordsStartKey, nil)
+	defer itr.Release()
+	numPurged := 0
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	for itr.Next() {
+		dbBatch.Delete(itr.Key())
+		if len(dbBatch.KVs) == maxLegacyRecordsPurgeBatchSize {
+			if err := historyDB.db.WriteBatch(dbBatch, true); err != nil {
+				return err
+			}
+			numPurged += len(dbBatch.KVs)
+			dbBatch = leveldbhelper.NewUpdateBatch()
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrap(err, "error while iterating over the legacy history records")
+	}
+	if err := historyDB.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	numPurged += len(dbBatch.KVs)
+	if numPurged > 0 {
+		logger.Infof("Channel [%s]: Purged %d history records superseded by the version index", historyDB.dbName, numPurged)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package historyleveldb
+
+import (
+	"bytes"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+)
+
+// The version index holds a history record for each write of a key, in the form
+// versionIndexPrefix~namespace~key~blocknum~trannum, which points to the transaction
+// that wrote the key in the block store.
+// The namespace and the key are escaped so that the records of a key never interleave
+// with the ones of another key, which allows seeking the records of a key between two blocks
+// in both directions, and scanning the keys of a namespace in order
+var versionIndexPrefix = []byte{0x01}
+
+var (
+	escapeByte  = byte(0x00)
+	escapedByte = []byte{0x00, 0xff}
+	terminator  = []byte{0x00, 0x01}
+)
+
+// escape replaces the 0x00 bytes of the given string by 0x00 0xff, and terminates it by 0x00 0x01,
+// so that the escaped strings compare in the same order as the strings
+func escape(s string) []byte {
+	var escaped []byte
+	for _, b := range []byte(s) {
+		if b == escapeByte {
+			escaped = append(escaped, escapedByte...)
+			continue
+		}
+		escaped = append(escaped, b)
+	}
+	return append(escaped, terminator...)
+}
+
+// unescape returns the string escaped at the start of the given bytes, and the number of bytes consumed
+func unescape(b []byte) (string, int) {
+	var s []byte
+	for i := 0; i < len(b); i++ {
+		if b[i] != escapeByte {
+			s = append(s, b[i])
+			continue
+		}
+		if i+1 < len(b) && b[i+1] == terminator[1] {
+			return string(s), i + 2
+		}
+		s = append(s, escapeByte)
+		i++
+	}
+	return string(s), len(b)
+}
+
+func constructVersionIndexNsPrefix(ns string) []byte {
+	return append(append([]byte{}, versionIndexPrefix...), escape(ns)...)
+}
+
+// constructVersionIndexNsEndKey returns a key which follows the records of all the keys of the namespace
+func constructVersionIndexNsEndKey(ns string) []byte {
+	nsPrefix := constructVersionIndexNsPrefix(ns)
+	nsPrefix[len(nsPrefix)-1] = terminator[1] + 1
+	return nsPrefix
+}
+
+func constructVersionIndexKeyPrefix(ns string, key string) []byte {
+	return append(constructVersionIndexNsPrefix(ns), escape(key)...)
+}
+
+func constructVersionIndexKey(ns string, key string, blockNum uint64, tranNum uint64) []byte {
+	k := constructVersionIndexKeyPrefix(ns, key)
+	k = append(k, util.EncodeOrderPreservingVarUint64(blockNum)...)
+	return append(k, util.EncodeOrderPreservingVarUint64(tranNum)...)
+}
+
+// splitVersionIndexKey returns the key prefix, the key, the block number and the transaction number
+// of the given version index record of the namespace whose prefix is given
+func splitVersionIndexKey(indexKey []byte, nsPrefix []byte) ([]byte, string, uint64, uint64) {
+	key, n := unescape(indexKey[len(nsPrefix):])
+	keyPrefix := indexKey[:len(nsPrefix)+n]
+	blockNum, n := util.DecodeOrderPreservingVarUint64(indexKey[len(keyPrefix):])
+	tranNum, _ := util.DecodeOrderPreservingVarUint64(indexKey[len(keyPrefix)+n:])
+	return keyPrefix, key, blockNum, tranNum
+}
+
+// levelHistoricalQueryExecutor implements interface `ledger.HistoricalQueryExecutor`.
+// The values are read from the transactions in the block store that last wrote them
+// as of the block of the executor, which are located by the version index
+type levelHistoricalQueryExecutor struct {
+	historyDB  *historyDB
+	blockStore blkstorage.BlockStore
+	blockNum   uint64
+}
+
+// GetState implements method in interface `ledger.HistoricalQueryExecutor`
+func (q *levelHistoricalQueryExecutor) GetState(namespace string, key string) ([]byte, error) {
+	keyPrefix := constructVersionIndexKeyPrefix(namespace, key)
+	// the versions of the key written up to the block of the executor
+	endKey := append(append([]byte{}, keyPrefix...), util.EncodeOrderPreservingVarUint64(q.blockNum+1)...)
+	dbItr := q.historyDB.db.GetIterator(keyPrefix, endKey)
+	defer dbItr.Release()
+	if !dbItr.Last() {
+		return nil, nil
+	}
+	_, _, blockNum, tranNum := splitVersionIndexKey(dbItr.Key(), constructVersionIndexNsPrefix(namespace))
+	kmod, err := q.getKeyModification(namespace, key, blockNum, tranNum)
+	if err != nil || kmod.IsDelete {
+		return nil, err
+	}
+	return kmod.Value, nil
+}
+
+// GetStateRangeScanIterator implements method in interface `ledger.HistoricalQueryExecutor`
+func (q *levelHistoricalQueryExecutor) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	nsPrefix := constructVersionIndexNsPrefix(namespace)
+	compositeStartKey := append(append([]byte{}, nsPrefix...), escape(startKey)...)
+	compositeEndKey := append(append([]byte{}, nsPrefix...), escape(endKey)...)
+	if endKey == "" {
+		compositeEndKey = constructVersionIndexNsEndKey(namespace)
+	}
+	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
+	return &historicalStateScanner{q, namespace, nsPrefix, dbItr, dbItr.First()}, nil
+}
+
+func (q *levelHistoricalQueryExecutor) getKeyModification(namespace string, key string, blockNum uint64, tranNum uint64) (*queryresult.KeyModification, error) {
+	tranEnvelope, err := q.blockStore.RetrieveTxByBlockNumTranNum(blockNum, tranNum)
+	if err != nil {
+		return nil, err
+	}
+	queryResult, err := getKeyModificationFromTran(tranEnvelope, namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	return queryResult.(*queryresult.KeyModification), nil
+}
+
+// historicalStateScanner implements ResultsIterator for iterating through the keys of a namespace
+// as of the block of the executor. For each key, it seeks the last version written up to that block,
+// then skips the rest of the versions of the key
+type historicalStateScanner struct {
+	q         *levelHistoricalQueryExecutor
+	namespace string
+	nsPrefix  []byte
+	dbItr     *leveldbhelper.Iterator
+	valid     bool
+}
+
+func (scanner *historicalStateScanner) Next() (commonledger.QueryResult, error) {
+	for scanner.valid {
+		keyPrefix, key, _, _ := splitVersionIndexKey(scanner.dbItr.Key(), scanner.nsPrefix)
+		keyPrefix = append([]byte{}, keyPrefix...)
+
+		// position the iterator on the last version of the key up to the block of the executor, if any
+		var found bool
+		if scanner.dbItr.Seek(append(append([]byte{}, keyPrefix...), util.EncodeOrderPreservingVarUint64(scanner.q.blockNum+1)...)) {
+			found = scanner.dbItr.Prev()
+		} else {
+			found = scanner.dbItr.Last()
+		}
+		var blockNum, tranNum uint64
+		if found && bytes.HasPrefix(scanner.dbItr.Key(), keyPrefix) {
+			_, _, blockNum, tranNum = splitVersionIndexKey(scanner.dbItr.Key(), scanner.nsPrefix)
+		} else {
+			found = false
+		}
+
+		// move on to the first version of the next key
+		scanner.valid = scanner.dbItr.Seek(append(keyPrefix, 0xff))
+
+		if !found {
+			continue
+		}
+		kmod, err := scanner.q.getKeyModification(scanner.namespace, key, blockNum, tranNum)
+		if err != nil {
+			return nil, err
+		}
+		if kmod.IsDelete {
+			continue
+		}
+		return &queryresult.KV{Namespace: scanner.namespace, Key: key, Value: kmod.Value}, nil
+	}
+	return nil, nil
+}
+
+func (scanner *historicalStateScanner) Close() {
+	scanner.dbItr.Release()
+}
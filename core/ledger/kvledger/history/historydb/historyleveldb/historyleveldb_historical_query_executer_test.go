@@ -0,0 +1,200 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package historyleveldb
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	util2 "github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionIndexKeyEncoding(t *testing.T) {
+	keys := []string{"", "a", "a\x00", "a\x00b", "a\x01", "ab", "b", "\x00"}
+	var encoded [][]byte
+	for _, k := range keys {
+		indexKey := constructVersionIndexKey("ns1", k, 10, 2)
+		keyPrefix, key, blockNum, tranNum := splitVersionIndexKey(indexKey, constructVersionIndexNsPrefix("ns1"))
+		assert.Equal(t, constructVersionIndexKeyPrefix("ns1", k), keyPrefix)
+		assert.Equal(t, k, key)
+		assert.Equal(t, uint64(10), blockNum)
+		assert.Equal(t, uint64(2), tranNum)
+		encoded = append(encoded, indexKey)
+	}
+	// the records are ordered by key, then by height
+	sort.Strings(keys)
+	sort.Slice(encoded, func(i, j int) bool { return bytes.Compare(encoded[i], encoded[j]) < 0 })
+	for i, k := range keys {
+		assert.Equal(t, constructVersionIndexKey("ns1", k, 10, 2), encoded[i])
+	}
+	assert.True(t, bytes.Compare(constructVersionIndexKey("ns1", "a", 300, 0), constructVersionIndexKey("ns1", "a\x00", 0, 0)) < 0)
+	assert.True(t, bytes.Compare(constructVersionIndexKey("ns1", "zzz", 300, 0), constructVersionIndexNsEndKey("ns1")) < 0)
+}
+
+func TestHistoricalQueryExecutor(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	store, err := env.testBlockStorageEnv.provider.OpenBlockStore("ledger1")
+	assert.NoError(t, err)
+	defer store.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, "ledger1", false)
+	assert.NoError(t, store.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+
+	commitBlock := func(sim func(s ledger.TxSimulator)) {
+		simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+		sim(simulator)
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+		block := bg.NextBlock([][]byte{pubSimResBytes})
+		assert.NoError(t, store.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block))
+	}
+	//block1
+	commitBlock(func(s ledger.TxSimulator) {
+		s.SetState("ns1", "key1", []byte("value1_1"))
+		s.SetState("ns1", "key2", []byte("value2_1"))
+		s.SetState("ns1", "key2\x00a", []byte("value2a_1"))
+		s.SetState("ns2", "key1", []byte("ns2_value1_1"))
+	})
+	//block2
+	commitBlock(func(s ledger.TxSimulator) {
+		s.SetState("ns1", "key1", []byte("value1_2"))
+		s.DeleteState("ns1", "key2")
+	})
+	//block3
+	commitBlock(func(s ledger.TxSimulator) {
+		s.SetState("ns1", "key3", []byte("value3_3"))
+		s.SetState("ns1", "key2", []byte("value2_3"))
+	})
+
+	testGetState := func(blockNum uint64, key string, expectedValue string) {
+		q, err := env.testHistoryDB.NewHistoricalQueryExecutor(store, blockNum)
+		assert.NoError(t, err)
+		value, err := q.GetState("ns1", key)
+		assert.NoError(t, err)
+		if expectedValue == "" {
+			assert.Nil(t, value, "key [%s] at block [%d]", key, blockNum)
+			return
+		}
+		assert.Equal(t, expectedValue, string(value), "key [%s] at block [%d]", key, blockNum)
+	}
+	testGetState(0, "key1", "")
+	testGetState(1, "key1", "value1_1")
+	testGetState(2, "key1", "value1_2")
+	testGetState(3, "key1", "value1_2")
+	testGetState(1, "key2", "value2_1")
+	testGetState(2, "key2", "")
+	testGetState(3, "key2", "value2_3")
+	testGetState(2, "key2\x00a", "value2a_1")
+	testGetState(2, "key3", "")
+	testGetState(3, "key3", "value3_3")
+
+	testRangeScan := func(blockNum uint64, startKey, endKey string, expected map[string]string) {
+		q, err := env.testHistoryDB.NewHistoricalQueryExecutor(store, blockNum)
+		assert.NoError(t, err)
+		itr, err := q.GetStateRangeScanIterator("ns1", startKey, endKey)
+		assert.NoError(t, err)
+		defer itr.Close()
+		var keys []string
+		results := map[string]string{}
+		for {
+			res, err := itr.Next()
+			assert.NoError(t, err)
+			if res == nil {
+				break
+			}
+			kv := res.(*queryresult.KV)
+			assert.Equal(t, "ns1", kv.Namespace)
+			keys = append(keys, kv.Key)
+			results[kv.Key] = string(kv.Value)
+		}
+		assert.True(t, sort.StringsAreSorted(keys))
+		assert.Equal(t, expected, results, "range [%s, %s) at block [%d]", startKey, endKey, blockNum)
+	}
+	testRangeScan(0, "", "", map[string]string{})
+	testRangeScan(1, "", "", map[string]string{"key1": "value1_1", "key2": "value2_1", "key2\x00a": "value2a_1"})
+	testRangeScan(2, "", "", map[string]string{"key1": "value1_2", "key2\x00a": "value2a_1"})
+	testRangeScan(3, "", "", map[string]string{"key1": "value1_2", "key2": "value2_3", "key2\x00a": "value2a_1", "key3": "value3_3"})
+	testRangeScan(3, "key2", "key3", map[string]string{"key2": "value2_3", "key2\x00a": "value2a_1"})
+	testRangeScan(1, "key2\x00", "", map[string]string{"key2\x00a": "value2a_1"})
+
+	// blocks that are not yet committed can't be queried
+	_, err = env.testHistoryDB.NewHistoricalQueryExecutor(store, 4)
+	assert.Error(t, err)
+
+	viper.Set("ledger.history.enableHistoryDatabase", "false")
+	defer viper.Set("ledger.history.enableHistoryDatabase", "true")
+	_, err = env.testHistoryDB.NewHistoricalQueryExecutor(store, 1)
+	assert.Error(t, err)
+}
+
+func TestShouldRecoverWithoutVersionIndex(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+
+	_, gb := testutil.NewBlockGenerator(t, "ledger1", false)
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+	recover, _, err := env.testHistoryDB.ShouldRecover(0)
+	assert.NoError(t, err)
+	assert.False(t, recover)
+
+	// a history DB that was created before the version index was added is rebuilt from the first block
+	historyDB := env.testHistoryDB.(*historyDB)
+	assert.NoError(t, historyDB.db.Delete(versionIndexMarkerKey, true))
+	recover, blockNum, err := env.testHistoryDB.ShouldRecover(0)
+	assert.NoError(t, err)
+	assert.True(t, recover)
+	assert.Equal(t, uint64(0), blockNum)
+}
+
+func TestCommitLostBlockPurgesLegacyRecords(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	historyDB := env.testHistoryDB.(*historyDB)
+
+	bg, gb := testutil.NewBlockGenerator(t, "ledger1", false)
+	simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+	simulator.SetState("ns1", "key1", []byte("value1"))
+	simulator.Done()
+	simRes, _ := simulator.GetTxSimulationResults()
+	pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+	block1 := bg.NextBlock([][]byte{pubSimResBytes})
+	assert.NoError(t, historyDB.Commit(gb))
+	assert.NoError(t, historyDB.Commit(block1))
+
+	// the history DB holds the records of the form ns~key~blocknum~trannum of an older version
+	legacyRecordKey := func(ns, key string, blockNum, tranNum uint64) []byte {
+		k := append([]byte(ns), 0x00)
+		k = append(append(k, key...), 0x00)
+		k = append(k, util.EncodeOrderPreservingVarUint64(blockNum)...)
+		return append(k, util.EncodeOrderPreservingVarUint64(tranNum)...)
+	}
+	assert.NoError(t, historyDB.db.Put(legacyRecordKey("ns1", "key1", 1, 0), emptyValue, true))
+	assert.NoError(t, historyDB.db.Put(legacyRecordKey("ns2", "key2", 1, 0), emptyValue, true))
+	assert.NoError(t, historyDB.db.Delete(versionIndexMarkerKey, true))
+
+	// the legacy records are purged when the blocks are re-committed
+	assert.NoError(t, historyDB.CommitLostBlock(&ledger.BlockAndPvtData{Block: gb}))
+	assert.NoError(t, historyDB.CommitLostBlock(&ledger.BlockAndPvtData{Block: block1}))
+	var keys [][]byte
+	itr := historyDB.db.GetIterator(nil, nil)
+	defer itr.Release()
+	for itr.Next() {
+		keys = append(keys, append([]byte{}, itr.Key()...))
+	}
+	assert.Equal(t, [][]byte{savePointKey, constructVersionIndexKey("ns1", "key1", 1, 0), versionIndexMarkerKey}, keys)
+}
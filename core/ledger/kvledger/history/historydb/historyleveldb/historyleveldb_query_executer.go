@@ -17,17 +17,18 @@ limitations under the License.
 package historyleveldb
 
 import (
-	"errors"
+	"math"
 
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
-	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 )
 
@@ -39,45 +40,62 @@ type LevelHistoryDBQueryExecutor struct {
 
 // GetHistoryForKey implements method in interface `ledger.HistoryQueryExecutor`
 func (q *LevelHistoryDBQueryExecutor) GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error) {
+	return q.GetHistoryForKeyWithOptions(namespace, key, nil)
+}
+
+// GetHistoryForKeyWithOptions implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetHistoryForKeyWithOptions(namespace string, key string, options *ledger.HistoryQueryOptions) (commonledger.ResultsIterator, error) {
 
 	if ledgerconfig.IsHistoryDBEnabled() == false {
 		return nil, errors.New("History tracking not enabled - historyDatabase is false")
 	}
+	if options == nil {
+		options = &ledger.HistoryQueryOptions{}
+	}
+	if options.EndBlock != 0 && options.EndBlock < options.StartBlock {
+		return nil, errors.Errorf("end block [%d] must not be lower than start block [%d]", options.EndBlock, options.StartBlock)
+	}
 
-	var compositeStartKey []byte
-	var compositeEndKey []byte
-	compositeStartKey = historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
-	compositeEndKey = historydb.ConstructPartialCompositeHistoryKey(namespace, key, true)
+	// range scan the version index records of namespace~key between the start and end blocks
+	keyPrefix := constructVersionIndexKeyPrefix(namespace, key)
+	compositeStartKey := append(append([]byte{}, keyPrefix...), util.EncodeOrderPreservingVarUint64(options.StartBlock)...)
+	compositeEndKey := append(append([]byte{}, keyPrefix...), 0xff)
+	if options.EndBlock != 0 && options.EndBlock != math.MaxUint64 {
+		compositeEndKey = append(append([]byte{}, keyPrefix...), util.EncodeOrderPreservingVarUint64(options.EndBlock+1)...)
+	}
 
-	// range scan to find any history records starting with namespace~key
 	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
-	return newHistoryScanner(compositeStartKey, namespace, key, dbItr, q.blockStore), nil
+	return newHistoryScanner(constructVersionIndexNsPrefix(namespace), namespace, key, dbItr, q.blockStore, options), nil
 }
 
 //historyScanner implements ResultsIterator for iterating through history results
 type historyScanner struct {
-	compositePartialKey []byte //compositePartialKey includes namespace~key
-	namespace           string
-	key                 string
-	dbItr               iterator.Iterator
-	blockStore          blkstorage.BlockStore
+	nsPrefix    []byte //nsPrefix is the version index prefix of the namespace
+	namespace   string
+	key         string
+	dbItr       iterator.Iterator
+	blockStore  blkstorage.BlockStore
+	newestFirst bool
+	limit       uint32
+	count       uint32
 }
 
-func newHistoryScanner(compositePartialKey []byte, namespace string, key string,
-	dbItr iterator.Iterator, blockStore blkstorage.BlockStore) *historyScanner {
-	return &historyScanner{compositePartialKey, namespace, key, dbItr, blockStore}
+func newHistoryScanner(nsPrefix []byte, namespace string, key string,
+	dbItr iterator.Iterator, blockStore blkstorage.BlockStore, options *ledger.HistoryQueryOptions) *historyScanner {
+	return &historyScanner{nsPrefix, namespace, key, dbItr, blockStore, options.NewestFirst, options.Limit, 0}
 }
 
 func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
-	if !scanner.dbItr.Next() {
+	if scanner.limit != 0 && scanner.count >= scanner.limit {
 		return nil, nil
 	}
-	historyKey := scanner.dbItr.Key() // history key is in the form namespace~key~blocknum~trannum
+	if !scanner.move() {
+		return nil, nil
+	}
+	scanner.count++
+	historyKey := scanner.dbItr.Key() // history key is in the form versionIndexPrefix~namespace~key~blocknum~trannum
 
-	// SplitCompositeKey(namespace~key~blocknum~trannum, namespace~key~) will return the blocknum~trannum in second position
-	_, blockNumTranNumBytes := historydb.SplitCompositeHistoryKey(historyKey, scanner.compositePartialKey)
-	blockNum, bytesConsumed := util.DecodeOrderPreservingVarUint64(blockNumTranNumBytes[0:])
-	tranNum, _ := util.DecodeOrderPreservingVarUint64(blockNumTranNumBytes[bytesConsumed:])
+	_, _, blockNum, tranNum := splitVersionIndexKey(historyKey, scanner.nsPrefix)
 	logger.Debugf("Found history record for namespace:%s key:%s at blockNumTranNum %v:%v\n",
 		scanner.namespace, scanner.key, blockNum, tranNum)
 
@@ -97,6 +115,18 @@ func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
 	return queryResult, nil
 }
 
+// move moves the iterator to the next history record in the order of the scanner
+func (scanner *historyScanner) move() bool {
+	if !scanner.newestFirst {
+		return scanner.dbItr.Next()
+	}
+	// the iterator starts before the first record, the newest record is the last one
+	if scanner.count == 0 {
+		return scanner.dbItr.Last()
+	}
+	return scanner.dbItr.Prev()
+}
+
 func (scanner *historyScanner) Close() {
 	scanner.dbItr.Release()
 }
@@ -200,6 +200,65 @@ func TestHistory(t *testing.T) {
 	testutil.AssertEquals(t, count, 4)
 }
 
+func TestHistoryWithOptions(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	store1, err := provider.OpenBlockStore("ledger1")
+	testutil.AssertNoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, "ledger1", false)
+	testutil.AssertNoError(t, store1.AddBlock(gb), "")
+	testutil.AssertNoError(t, env.testHistoryDB.Commit(gb), "")
+
+	// blocks 1 to 5 write value1 to value5 of key7,
+	// along with a key whose history records shared the prefix of key7 in the former layout
+	for i := 1; i <= 5; i++ {
+		simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+		simulator.SetState("ns1", "key7", []byte("value"+strconv.Itoa(i)))
+		simulator.SetState("ns1", "key7\x00\x01", []byte("other"))
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+		block := bg.NextBlock([][]byte{pubSimResBytes})
+		testutil.AssertNoError(t, store1.AddBlock(block), "")
+		testutil.AssertNoError(t, env.testHistoryDB.Commit(block), "")
+	}
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	testutil.AssertNoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	getValues := func(options *ledger.HistoryQueryOptions) []string {
+		itr, err := qhistory.GetHistoryForKeyWithOptions("ns1", "key7", options)
+		testutil.AssertNoError(t, err, "Error upon GetHistoryForKeyWithOptions()")
+		defer itr.Close()
+		var values []string
+		for {
+			kmod, err := itr.Next()
+			testutil.AssertNoError(t, err, "")
+			if kmod == nil {
+				break
+			}
+			values = append(values, string(kmod.(*queryresult.KeyModification).Value))
+		}
+		return values
+	}
+	testutil.AssertEquals(t, getValues(nil), []string{"value1", "value2", "value3", "value4", "value5"})
+	testutil.AssertEquals(t, getValues(&ledger.HistoryQueryOptions{StartBlock: 2, EndBlock: 4}),
+		[]string{"value2", "value3", "value4"})
+	testutil.AssertEquals(t, getValues(&ledger.HistoryQueryOptions{StartBlock: 4}), []string{"value4", "value5"})
+	testutil.AssertEquals(t, getValues(&ledger.HistoryQueryOptions{NewestFirst: true}),
+		[]string{"value5", "value4", "value3", "value2", "value1"})
+	testutil.AssertEquals(t, getValues(&ledger.HistoryQueryOptions{EndBlock: 3, NewestFirst: true, Limit: 2}),
+		[]string{"value3", "value2"})
+	testutil.AssertEquals(t, getValues(&ledger.HistoryQueryOptions{Limit: 1}), []string{"value1"})
+	testutil.AssertEquals(t, getValues(&ledger.HistoryQueryOptions{StartBlock: 6}), []string(nil))
+
+	_, err = qhistory.GetHistoryForKeyWithOptions("ns1", "key7", &ledger.HistoryQueryOptions{StartBlock: 3, EndBlock: 2})
+	testutil.AssertError(t, err, "Error should have been returned when the end block is lower than the start block")
+}
+
 func TestHistoryForInvalidTran(t *testing.T) {
 	env := newTestHistoryEnv(t)
 	defer env.cleanup()
@@ -10,14 +10,17 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr/lockbasedtxmgr"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
@@ -36,12 +39,17 @@ type kvLedger struct {
 	txtmgmt         txmgr.TxMgr
 	historyDB       historydb.HistoryDB
 	blockAPIsRWLock *sync.RWMutex
+	// indexStatusProvider is nil if the state database does not report the status of the indexes of the chaincodes
+	indexStatusProvider statedb.IndexStatusProvider
+	// createsIndexes tells whether the state database creates the indexes of the chaincodes
+	createsIndexes  bool
+	commitListeners []ledger.CommitListener
 }
 
 // NewKVLedger constructs new `KVLedger`
 func newKVLedger(ledgerID string, blockStore *ledgerstorage.Store,
 	versionedDB privacyenabledstate.DB, historyDB historydb.HistoryDB,
-	stateListeners ledger.StateListeners) (*kvLedger, error) {
+	stateListeners ledger.StateListeners, commitListeners []ledger.CommitListener) (*kvLedger, error) {
 
 	logger.Debugf("Creating KVLedger ledgerID=%s: ", ledgerID)
 
@@ -49,14 +57,16 @@ func newKVLedger(ledgerID string, blockStore *ledgerstorage.Store,
 	var txmgmt txmgr.TxMgr
 	txmgmt = lockbasedtxmgr.NewLockBasedTxMgr(ledgerID, versionedDB, stateListeners)
 
-	// Create a kvLedger for this chain/ledger, which encasulates the underlying
-	// id store, blockstore, txmgr (state database), history database
-	l := &kvLedger{ledgerID, blockStore, txmgmt, historyDB, &sync.RWMutex{}}
-
 	// TODO Move the function `GetChaincodeEventListener` to ledger interface and
 	// this functionality of regiserting for events to ledgermgmt package so that this
 	// is reused across other future ledger implementations
 	ccEventListener := versionedDB.GetChaincodeEventListener()
+
+	// Create a kvLedger for this chain/ledger, which encasulates the underlying
+	// id store, blockstore, txmgr (state database), history database
+	l := &kvLedger{ledgerID, blockStore, txmgmt, historyDB, &sync.RWMutex{},
+		versionedDB.GetIndexStatusProvider(), ccEventListener != nil, commitListeners}
+
 	logger.Debugf("Register state db for chaincode lifecycle events: %t", ccEventListener != nil)
 	if ccEventListener != nil {
 		cceventmgmt.GetMgr().Register(ledgerID, ccEventListener)
@@ -124,15 +134,33 @@ func (l *kvLedger) recommitLostBlocks(firstBlockNum uint64, lastBlockNum uint64,
 		if blockAndPvtdata, err = l.GetPvtDataAndBlockByNum(blockNumber, nil); err != nil {
 			return err
 		}
+		var event *ledger.CommitEvent
 		for _, r := range recoverables {
+			if r == recoverable(l.txtmgmt) && len(l.commitListeners) > 0 {
+				if event, err = l.recommitLostState(blockAndPvtdata); err != nil {
+					return err
+				}
+				continue
+			}
 			if err := r.CommitLostBlock(blockAndPvtdata); err != nil {
 				return err
 			}
 		}
+		l.invokeCommitListeners(event)
 	}
 	return nil
 }
 
+// recommitLostState recommits a block to the state database, as CommitLostBlock does,
+// and returns the commit event of the block for the commit listeners
+func (l *kvLedger) recommitLostState(blockAndPvtdata *ledger.BlockAndPvtData) (*ledger.CommitEvent, error) {
+	if err := l.txtmgmt.ValidateAndPrepare(blockAndPvtdata, false); err != nil {
+		return nil, err
+	}
+	event := l.txtmgmt.NewCommitEvent()
+	return event, l.txtmgmt.Commit()
+}
+
 // GetTransactionByID retrieves a transaction by id
 func (l *kvLedger) GetTransactionByID(txID string) (*peer.ProcessedTransaction, error) {
 	tranEnv, err := l.blockStore.RetrieveTxByID(txID)
@@ -225,10 +253,35 @@ func (l *kvLedger) NewHistoryQueryExecutor() (ledger.HistoryQueryExecutor, error
 	return l.historyDB.NewHistoryQueryExecutor(l.blockStore)
 }
 
+// NewHistoricalQueryExecutor gives handle to a query executor that reads the state as of the given block.
+// The values are looked up from the chain, using the history database to locate the transactions that wrote them
+func (l *kvLedger) NewHistoricalQueryExecutor(blockNum uint64) (ledger.HistoricalQueryExecutor, error) {
+	return l.historyDB.NewHistoricalQueryExecutor(l.blockStore, blockNum)
+}
+
+// GetIndexStatus returns the status of the state database indexes of the given chaincode
+func (l *kvLedger) GetIndexStatus(namespace string) (*peer.IndexStatusResponse, error) {
+	if l.indexStatusProvider == nil {
+		if l.createsIndexes {
+			return nil, fmt.Errorf("the state database [%s] creates chaincode indexes but does not report their status", ledgerconfig.GetStateDatabase())
+		}
+		return nil, fmt.Errorf("the state database [%s] does not create chaincode indexes", ledgerconfig.GetStateDatabase())
+	}
+	return l.indexStatusProvider.GetIndexStatus(namespace)
+}
+
+// GetTransactionsByTime returns a page of the transactions whose timestamp is in [from, to), optionally restricted to a creator
+func (l *kvLedger) GetTransactionsByTime(creatorHash []byte, from, to time.Time, bookmark string, limit int) (*peer.TxQueryResponse, error) {
+	resp, err := l.blockStore.RetrieveTxsByTime(creatorHash, from, to, bookmark, limit)
+	if err == blkstorage.ErrAttrNotIndexed {
+		return nil, fmt.Errorf("the transactions are not indexed by time, the index is enabled through ledger.blockchain.enableTxTimeIndex")
+	}
+	return resp, err
+}
+
 // CommitWithPvtData commits the block and the corresponding pvt data in an atomic operation
 func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) error {
 	var err error
-	block := pvtdataAndBlock.Block
 	blockNo := pvtdataAndBlock.Block.Header.Number
 
 	logger.Debugf("Channel [%s]: Validating state for block [%d]", l.ledgerID, blockNo)
@@ -237,17 +290,35 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 		return err
 	}
 
+	// The commit event is constructed before the updates are committed, as the commit releases them
+	var event *ledger.CommitEvent
+	if len(l.commitListeners) > 0 {
+		event = l.txtmgmt.NewCommitEvent()
+	}
+	if err = l.commit(pvtdataAndBlock); err != nil {
+		return err
+	}
+	// The commit listeners are notified once the block is committed to all the stores and the lock
+	// is released, so that they may query the ledger
+	l.invokeCommitListeners(event)
+	return nil
+}
+
+// commit commits the validated block to the block storage, the state database and the history database
+func (l *kvLedger) commit(pvtdataAndBlock *ledger.BlockAndPvtData) error {
+	block := pvtdataAndBlock.Block
+	blockNo := block.Header.Number
 	logger.Debugf("Channel [%s]: Committing block [%d] to storage", l.ledgerID, blockNo)
 
 	l.blockAPIsRWLock.Lock()
 	defer l.blockAPIsRWLock.Unlock()
-	if err = l.blockStore.CommitWithPvtData(pvtdataAndBlock); err != nil {
+	if err := l.blockStore.CommitWithPvtData(pvtdataAndBlock); err != nil {
 		return err
 	}
 	logger.Infof("Channel [%s]: Committed block [%d] with %d transaction(s)", l.ledgerID, block.Header.Number, len(block.Data.Data))
 
 	logger.Debugf("Channel [%s]: Committing block [%d] transactions to state database", l.ledgerID, blockNo)
-	if err = l.txtmgmt.Commit(); err != nil {
+	if err := l.txtmgmt.Commit(); err != nil {
 		panic(fmt.Errorf(`Error during commit to txmgr:%s`, err))
 	}
 
@@ -261,6 +332,20 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 	return nil
 }
 
+// invokeCommitListeners notifies the commit listeners of the given event, an error of a
+// listener is logged as the block is already committed
+func (l *kvLedger) invokeCommitListeners(event *ledger.CommitEvent) {
+	if event == nil {
+		return
+	}
+	for _, listener := range l.commitListeners {
+		logger.Debugf("Channel [%s]: Invoking commit listener for block [%d]", l.ledgerID, event.BlockNum)
+		if err := listener.HandleCommit(event); err != nil {
+			logger.Warningf("Channel [%s]: Commit listener failed handling block [%d]: %s", l.ledgerID, event.BlockNum, err)
+		}
+	}
+}
+
 // GetPvtDataAndBlockByNum returns the block and the corresponding pvt data.
 // The pvt data is filtered by the list of 'collections' supplied
 func (l *kvLedger) GetPvtDataAndBlockByNum(blockNum uint64, filter ledger.PvtNsCollFilter) (*ledger.BlockAndPvtData, error) {
@@ -43,6 +43,7 @@ type Provider struct {
 	vdbProvider         privacyenabledstate.DBProvider
 	historydbProvider   historydb.HistoryDBProvider
 	stateListeners      ledger.StateListeners
+	commitListeners     []ledger.CommitListener
 }
 
 // NewProvider instantiates a new Provider.
@@ -67,14 +68,15 @@ func NewProvider() (ledger.PeerLedgerProvider, error) {
 	historydbProvider = historyleveldb.NewHistoryDBProvider()
 
 	logger.Info("ledger provider Initialized")
-	provider := &Provider{idStore, ledgerStoreProvider, vdbProvider, historydbProvider, nil}
+	provider := &Provider{idStore, ledgerStoreProvider, vdbProvider, historydbProvider, nil, nil}
 	provider.recoverUnderConstructionLedger()
 	return provider, nil
 }
 
 // Initialize implements the corresponding method from interface ledger.PeerLedgerProvider
-func (provider *Provider) Initialize(stateListeners ledger.StateListeners) {
+func (provider *Provider) Initialize(stateListeners ledger.StateListeners, commitListeners []ledger.CommitListener) {
 	provider.stateListeners = stateListeners
+	provider.commitListeners = commitListeners
 }
 
 // Create implements the corresponding method from interface ledger.PeerLedgerProvider
@@ -149,7 +151,7 @@ func (provider *Provider) openInternal(ledgerID string) (ledger.PeerLedger, erro
 
 	// Create a kvLedger for this chain/ledger, which encasulates the underlying data stores
 	// (id store, blockstore, state database, history database)
-	l, err := newKVLedger(ledgerID, blockStore, vDB, historyDB, provider.stateListeners)
+	l, err := newKVLedger(ledgerID, blockStore, vDB, historyDB, provider.stateListeners, provider.commitListeners)
 	if err != nil {
 		return nil, err
 	}
@@ -20,11 +20,13 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
 	"github.com/hyperledger/fabric/protos/common"
@@ -189,6 +191,59 @@ func TestKVLedgerBlockStorageWithPvtdata(t *testing.T) {
 	testutil.AssertNil(t, pvtdataAndBlock.BlockPvtData)
 }
 
+func TestKVLedgerTransactionsByTime(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, _ := provider.Create(gb)
+	_, err := ledger.GetTransactionsByTime(nil, time.Time{}, time.Time{}, "", 0)
+	testutil.AssertEquals(t, err.Error(),
+		"the transactions are not indexed by time, the index is enabled through ledger.blockchain.enableTxTimeIndex")
+	ledger.Close()
+	provider.Close()
+
+	viper.Set("ledger.blockchain.enableTxTimeIndex", true)
+	defer viper.Set("ledger.blockchain.enableTxTimeIndex", false)
+	provider, _ = NewProvider()
+	defer provider.Close()
+	ledger, _ = provider.Open("testLedger")
+	defer ledger.Close()
+	block1 := bg.NextBlock([][]byte{[]byte("tx1"), []byte("tx2")})
+	testutil.AssertNoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block1}), "")
+
+	// the genesis block was committed before the index was enabled
+	res, err := ledger.GetTransactionsByTime(nil, time.Time{}, time.Time{}, "", 0)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, res.IndexedFromBlock, uint64(1))
+	testutil.AssertEquals(t, len(res.Transactions), 2)
+}
+
+func TestKVLedgerIndexStatus(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, _ := provider.Create(gb)
+	_, err := ledger.GetIndexStatus("ns")
+	testutil.AssertEquals(t, err.Error(), "the state database [goleveldb] does not create chaincode indexes")
+	ledger.Close()
+	provider.Close()
+
+	// the indexed state database registers for the chaincode lifecycle events
+	cceventmgmt.Initialize()
+	viper.Set("ledger.state.stateDatabase", "IndexedBoltDB")
+	defer viper.Set("ledger.state.stateDatabase", "")
+	provider, err = NewProvider()
+	testutil.AssertNoError(t, err, "")
+	defer provider.Close()
+	ledger, err = provider.Open("testLedger")
+	testutil.AssertNoError(t, err, "")
+	defer ledger.Close()
+	_, err = ledger.GetIndexStatus("ns")
+	testutil.AssertEquals(t, err.Error(), "the state database [IndexedBoltDB] creates chaincode indexes but does not report their status")
+}
+
 func TestKVLedgerDBRecovery(t *testing.T) {
 	env := newTestEnv(t)
 	defer env.cleanup()
@@ -7,12 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
+	"os"
 	"testing"
 
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,7 +30,7 @@ func TestStateListener(t *testing.T) {
 	channelid := "testLedger"
 	namespace := "testchaincode"
 	mockListener := &mockStateListener{}
-	provider.Initialize(ledger.StateListeners{namespace: mockListener})
+	provider.Initialize(ledger.StateListeners{namespace: mockListener}, nil)
 
 	bg, gb := testutil.NewBlockGenerator(t, channelid, false)
 	lgr, err := provider.Create(gb)
@@ -87,6 +91,156 @@ func TestStateListener(t *testing.T) {
 	}, mockListener.kvWrites)
 }
 
+func TestCommitListener(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+	defer provider.Close()
+
+	channelid := "testLedger"
+	namespace := "testchaincode"
+	mockListener := &mockCommitListener{}
+	provider.Initialize(nil, []ledger.CommitListener{mockListener})
+
+	bg, gb := testutil.NewBlockGenerator(t, channelid, false)
+	lgr, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer lgr.Close()
+	assert.Len(t, mockListener.events, 1)
+	assert.Equal(t, uint64(0), mockListener.events[0].BlockNum)
+	mockListener.lgr = lgr
+	mockListener.namespace = namespace
+
+	// tx1 writes public and private data across two namespaces
+	sim1, err := lgr.NewTxSimulator("test_tx_1")
+	assert.NoError(t, err)
+	sim1.GetState(namespace, "key1")
+	sim1.SetState(namespace, "key2", []byte("value2"))
+	sim1.SetState(namespace, "key1", []byte("value1"))
+	sim1.SetState("othercc", "key3", []byte("value3"))
+	sim1.SetPrivateData(namespace, "coll1", "pvtkey1", []byte("pvtvalue1"))
+	sim1.Done()
+
+	// tx2 conflicts with tx1 because it reads "key1"
+	sim2, err := lgr.NewTxSimulator("test_tx_2")
+	assert.NoError(t, err)
+	sim2.GetState(namespace, "key1")
+	sim2.SetState(namespace, "key4", []byte("value4"))
+	sim2.Done()
+
+	sim1Res, _ := sim1.GetTxSimulationResults()
+	sim1ResBytes, _ := sim1Res.GetPubSimulationBytes()
+	sim2Res, _ := sim2.GetTxSimulationResults()
+	sim2ResBytes, _ := sim2Res.GetPubSimulationBytes()
+	blk1 := bg.NextBlock([][]byte{sim1ResBytes, sim2ResBytes})
+	assert.NoError(t, lgr.CommitWithPvtData(&ledger.BlockAndPvtData{Block: blk1}))
+
+	// the listener receives the updates of the valid tx1 across all the namespaces, after the commit
+	assert.Len(t, mockListener.events, 2)
+	event := mockListener.events[1]
+	assert.Equal(t, channelid, event.LedgerID)
+	assert.Equal(t, uint64(1), event.BlockNum)
+	assert.Equal(t, map[string][]*kvrwset.KVWrite{
+		namespace: {
+			{Key: "key1", Value: []byte("value1")},
+			{Key: "key2", Value: []byte("value2")},
+		},
+		"othercc": {{Key: "key3", Value: []byte("value3")}},
+	}, event.PubUpdates)
+	assert.Equal(t, map[string]map[string][]*kvrwset.KVWriteHash{
+		namespace: {
+			"coll1": {{KeyHash: util.ComputeStringHash("pvtkey1"), ValueHash: util.ComputeStringHash("pvtvalue1")}},
+		},
+	}, event.PvtDataHashes)
+	assert.Equal(t, []peer.TxValidationCode{peer.TxValidationCode_VALID, peer.TxValidationCode_MVCC_READ_CONFLICT},
+		event.TxValidationFlags)
+	// the block and the state are committed, and readable, when the listener is invoked
+	assert.Equal(t, uint64(2), mockListener.committedHeight)
+	assert.Equal(t, []byte("value1"), mockListener.committedValue)
+}
+
+func TestCommitListenerRecovery(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+	mockListener := &mockCommitListener{}
+	provider.Initialize(nil, []ledger.CommitListener{mockListener})
+
+	channelid := "testLedger"
+	bg, gb := testutil.NewBlockGenerator(t, channelid, false)
+	lgr, err := provider.Create(gb)
+	assert.NoError(t, err)
+	blockAndPvtdata1 := prepareNextBlockForTest(t, lgr, bg, "SimulateForBlk1",
+		map[string]string{"key1": "value1.1"}, map[string]string{"key1": "pvtValue1.1"})
+	assert.NoError(t, lgr.CommitWithPvtData(blockAndPvtdata1))
+	// the peer fails after committing the second block to the block storage, before committing it to the state DB
+	blockAndPvtdata2 := prepareNextBlockForTest(t, lgr, bg, "SimulateForBlk2",
+		map[string]string{"key1": "value1.2"}, map[string]string{"key1": "pvtValue1.2"})
+	assert.NoError(t, lgr.(*kvLedger).txtmgmt.ValidateAndPrepare(blockAndPvtdata2, true))
+	assert.NoError(t, lgr.(*kvLedger).blockStore.CommitWithPvtData(blockAndPvtdata2))
+	lgr.Close()
+	provider.Close()
+	assert.Equal(t, []uint64{0, 1}, committedBlockNums(mockListener.events))
+
+	// the listener is notified of the second block once it is recommitted to the state DB
+	mockListener.events = nil
+	provider, _ = NewProvider()
+	provider.Initialize(nil, []ledger.CommitListener{mockListener})
+	lgr, err = provider.Open(channelid)
+	assert.NoError(t, err)
+	lgr.Close()
+	provider.Close()
+	assert.Equal(t, []uint64{2}, committedBlockNums(mockListener.events))
+	assert.Equal(t, map[string][]*kvrwset.KVWrite{"ns": {{Key: "key1", Value: []byte("value1.2")}}},
+		mockListener.events[0].PubUpdates)
+
+	// when the state DB is rebuilt, the listener is notified again of all the blocks
+	mockListener.events = nil
+	assert.NoError(t, os.RemoveAll(ledgerconfig.GetStateLevelDBPath()))
+	provider, _ = NewProvider()
+	defer provider.Close()
+	provider.Initialize(nil, []ledger.CommitListener{mockListener})
+	lgr, err = provider.Open(channelid)
+	assert.NoError(t, err)
+	defer lgr.Close()
+	assert.Equal(t, []uint64{0, 1, 2}, committedBlockNums(mockListener.events))
+}
+
+func committedBlockNums(events []*ledger.CommitEvent) []uint64 {
+	var blockNums []uint64
+	for _, event := range events {
+		blockNums = append(blockNums, event.BlockNum)
+	}
+	return blockNums
+}
+
+type mockCommitListener struct {
+	lgr             ledger.PeerLedger
+	namespace       string
+	events          []*ledger.CommitEvent
+	committedValue  []byte
+	committedHeight uint64
+}
+
+func (l *mockCommitListener) HandleCommit(event *ledger.CommitEvent) error {
+	l.events = append(l.events, event)
+	if l.lgr == nil {
+		return nil
+	}
+	bcInfo, err := l.lgr.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	l.committedHeight = bcInfo.Height
+	qe, err := l.lgr.NewQueryExecutor()
+	if err != nil {
+		return err
+	}
+	defer qe.Done()
+	l.committedValue, err = qe.GetState(l.namespace, "key1")
+	return err
+}
+
 type mockStateListener struct {
 	channelName string
 	kvWrites    []*kvrwset.KVWrite
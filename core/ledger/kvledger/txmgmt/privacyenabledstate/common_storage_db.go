@@ -13,8 +13,10 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
-	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
-	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	// the built-in state databases register themselves with package statedb
+	_ "github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
+	_ "github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateindexeddb"
+	_ "github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 )
@@ -31,15 +33,11 @@ type CommonStorageDBProvider struct {
 }
 
 // NewCommonStorageDBProvider constructs an instance of DBProvider
+// on top of the state database configured in core.yaml
 func NewCommonStorageDBProvider() (DBProvider, error) {
-	var vdbProvider statedb.VersionedDBProvider
-	var err error
-	if ledgerconfig.IsCouchDBEnabled() {
-		if vdbProvider, err = statecouchdb.NewVersionedDBProvider(); err != nil {
-			return nil, err
-		}
-	} else {
-		vdbProvider = stateleveldb.NewVersionedDBProvider()
+	vdbProvider, err := statedb.NewVersionedDBProvider(ledgerconfig.GetStateDatabase())
+	if err != nil {
+		return nil, err
 	}
 	return &CommonStorageDBProvider{vdbProvider}, nil
 }
@@ -126,6 +124,15 @@ func (s *CommonStorageDB) GetChaincodeEventListener() cceventmgmt.ChaincodeLifec
 	return nil
 }
 
+// GetIndexStatusProvider implements corresponding function in interface DB
+func (s *CommonStorageDB) GetIndexStatusProvider() statedb.IndexStatusProvider {
+	indexStatusProvider, ok := s.VersionedDB.(statedb.IndexStatusProvider)
+	if ok {
+		return indexStatusProvider
+	}
+	return nil
+}
+
 // GetPrivateData implements corresponding function in interface DB
 func (s *CommonStorageDB) GetPrivateData(namespace, collection, key string) (*statedb.VersionedValue, error) {
 	return s.GetState(derivePvtDataNs(namespace, collection), key)
@@ -233,8 +233,7 @@ func testGetStateRangeScanIterator(t *testing.T, env TestEnv) {
 
 func TestQueryOnCouchDB(t *testing.T) {
 	for _, env := range testEnvs {
-		_, ok := env.(*CouchDBCommonStorageTestEnv)
-		if !ok {
+		if _, ok := env.(*LevelDBCommonStorageTestEnv); ok {
 			continue
 		}
 		t.Run(env.GetName(), func(t *testing.T) {
@@ -28,7 +28,7 @@ type TestEnv interface {
 // Tests will be run against each environment in this array
 // For example, to skip CouchDB tests, remove &couchDBLockBasedEnv{}
 //var testEnvs = []testEnv{&levelDBCommonStorageTestEnv{}, &couchDBCommonStorageTestEnv{}}
-var testEnvs = []TestEnv{&LevelDBCommonStorageTestEnv{}, &CouchDBCommonStorageTestEnv{}}
+var testEnvs = []TestEnv{&LevelDBCommonStorageTestEnv{}, &IndexedBoltDBCommonStorageTestEnv{}, &CouchDBCommonStorageTestEnv{}}
 
 ///////////// LevelDB Environment //////////////
 
@@ -66,6 +66,43 @@ func (env *LevelDBCommonStorageTestEnv) Cleanup() {
 	removeDBPath(env.t)
 }
 
+///////////// IndexedBoltDB Environment //////////////
+
+// IndexedBoltDBCommonStorageTestEnv implements TestEnv interface for indexed bolt db based storage
+type IndexedBoltDBCommonStorageTestEnv struct {
+	t        testing.TB
+	provider DBProvider
+}
+
+// Init implements corresponding function from interface TestEnv
+func (env *IndexedBoltDBCommonStorageTestEnv) Init(t testing.TB) {
+	viper.Set("ledger.state.stateDatabase", "IndexedBoltDB")
+	removeIndexedDBPath(t)
+	dbProvider, err := NewCommonStorageDBProvider()
+	assert.NoError(t, err)
+	env.t = t
+	env.provider = dbProvider
+}
+
+// GetDBHandle implements corresponding function from interface TestEnv
+func (env *IndexedBoltDBCommonStorageTestEnv) GetDBHandle(id string) DB {
+	db, err := env.provider.GetDBHandle(id)
+	assert.NoError(env.t, err)
+	return db
+}
+
+// GetName implements corresponding function from interface TestEnv
+func (env *IndexedBoltDBCommonStorageTestEnv) GetName() string {
+	return "indexedBoltDBCommonStorageTestEnv"
+}
+
+// Cleanup implements corresponding function from interface TestEnv
+func (env *IndexedBoltDBCommonStorageTestEnv) Cleanup() {
+	env.provider.Close()
+	removeIndexedDBPath(env.t)
+	viper.Set("ledger.state.stateDatabase", "")
+}
+
 ///////////// CouchDB Environment //////////////
 
 // CouchDBCommonStorageTestEnv implements TestEnv interface for couchdb based storage
@@ -122,3 +159,11 @@ func removeDBPath(t testing.TB) {
 		t.FailNow()
 	}
 }
+
+func removeIndexedDBPath(t testing.TB) {
+	dbPath := ledgerconfig.GetStateIndexedBoltDBPath()
+	if err := os.RemoveAll(dbPath); err != nil {
+		t.Fatalf("Err: %s", err)
+		t.FailNow()
+	}
+}
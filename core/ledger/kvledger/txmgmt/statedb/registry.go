@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// VersionedDBProviderFactory constructs the VersionedDBProvider of a state database backend
+type VersionedDBProviderFactory func() (VersionedDBProvider, error)
+
+var (
+	factoriesLock sync.RWMutex
+	factories     = make(map[string]VersionedDBProviderFactory)
+)
+
+// RegisterVersionedDBProvider makes a state database backend available under the given name,
+// which is then selected through the property `ledger.state.stateDatabase` in core.yaml.
+// Backends are expected to register themselves from the init function of their package.
+// This function panics if the factory is nil or if a backend is already registered under the name
+func RegisterVersionedDBProvider(name string, factory VersionedDBProviderFactory) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+	if factory == nil {
+		panic("nil factory registered for state database " + name)
+	}
+	if _, ok := factories[name]; ok {
+		panic("state database " + name + " registered twice")
+	}
+	factories[name] = factory
+}
+
+// NewVersionedDBProvider constructs the VersionedDBProvider of the backend registered under the given name
+func NewVersionedDBProvider(name string) (VersionedDBProvider, error) {
+	factoriesLock.RLock()
+	factory, ok := factories[name]
+	factoriesLock.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("unknown state database [%s], the registered state databases are %v", name, RegisteredVersionedDBProviders())
+	}
+	return factory()
+}
+
+// RegisteredVersionedDBProviders returns the sorted names of the registered state database backends
+func RegisteredVersionedDBProviders() []string {
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+	var names []string
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,35 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockVersionedDBProvider struct {
+	VersionedDBProvider
+}
+
+func TestRegisterVersionedDBProvider(t *testing.T) {
+	provider := &mockVersionedDBProvider{}
+	RegisterVersionedDBProvider("testdb", func() (VersionedDBProvider, error) { return provider, nil })
+	assert.Contains(t, RegisteredVersionedDBProviders(), "testdb")
+
+	p, err := NewVersionedDBProvider("testdb")
+	assert.NoError(t, err)
+	assert.Equal(t, provider, p)
+
+	_, err = NewVersionedDBProvider("unknowndb")
+	assert.EqualError(t, err, "unknown state database [unknowndb], the registered state databases are [testdb]")
+
+	assert.Panics(t, func() {
+		RegisterVersionedDBProvider("testdb", func() (VersionedDBProvider, error) { return provider, nil })
+	})
+	assert.Panics(t, func() { RegisterVersionedDBProvider("nildb", nil) })
+}
@@ -0,0 +1,269 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecouchdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/util/couchdb"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// indexStatusDocIDPrefix prefixes the ids of the documents of the metadataDB that record, per namespace,
+// the outcome of the last reconciliation of the indexes packaged with the chaincode
+const indexStatusDocIDPrefix = "statedb_indexes_"
+
+const designDocPrefix = "_design/"
+
+// indexManager lists, creates and deletes the indexes of the database of a namespace
+type indexManager interface {
+	ListIndex() (*[]couchdb.IndexResult, error)
+	CreateIndex(indexdefinition string) (*couchdb.CreateIndexResponse, error)
+	DeleteIndex(designdoc, indexname string) error
+}
+
+// indexRecord is the status of an index of a namespace, as recorded in the metadataDB
+type indexRecord struct {
+	DesignDoc  string `json:"ddoc"`
+	Name       string `json:"name"`
+	File       string `json:"file,omitempty"`
+	Definition string `json:"definition,omitempty"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	// Managed is set for the indexes that exist because they are packaged with the chaincode,
+	// and which are deleted once they are removed from the package of an upgraded chaincode
+	Managed bool `json:"managed"`
+}
+
+type couchIndexStatusData struct {
+	Indexes []*indexRecord `json:"indexes"`
+}
+
+func (r *indexRecord) fail(err error) {
+	r.Status = pb.IndexStatus_FAILED.String()
+	r.Error = err.Error()
+	r.Managed = false
+}
+
+// couchIndexDefinition is the part of an index definition which identifies the index and its fields,
+// e.g. {"index":{"fields":["docType",{"owner":"desc"}]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}
+type couchIndexDefinition struct {
+	Index struct {
+		Fields []interface{} `json:"fields"`
+	} `json:"index"`
+	DesignDoc string `json:"ddoc"`
+	Name      string `json:"name"`
+}
+
+// parseIndexDefinition returns the design document and the name of an index, which are empty
+// when they are left to CouchDB, and its fields in the form returned by normalizeIndexFields
+func parseIndexDefinition(definition []byte) (string, string, string, error) {
+	def := &couchIndexDefinition{}
+	if err := json.Unmarshal(definition, def); err != nil {
+		return "", "", "", fmt.Errorf("invalid index definition: %s", err)
+	}
+	fields, err := normalizeIndexFields(def.Index.Fields)
+	if err != nil {
+		return "", "", "", err
+	}
+	return strings.TrimPrefix(def.DesignDoc, designDocPrefix), def.Name, fields, nil
+}
+
+// parseListedIndexFields returns the fields of an index returned by ListIndex, whose definition
+// is of the form {"fields":[{"owner":"asc"}]}, in the form returned by normalizeIndexFields
+func parseListedIndexFields(definition string) (string, error) {
+	def := &struct {
+		Fields []interface{} `json:"fields"`
+	}{}
+	if err := json.Unmarshal([]byte(definition), def); err != nil {
+		return "", fmt.Errorf("invalid index definition: %s", err)
+	}
+	return normalizeIndexFields(def.Fields)
+}
+
+// normalizeIndexFields returns the fields of an index as a comma separated list of field:direction
+func normalizeIndexFields(fields []interface{}) (string, error) {
+	if len(fields) == 0 {
+		return "", fmt.Errorf("no fields defined for the index")
+	}
+	var normalized []string
+	for _, field := range fields {
+		switch f := field.(type) {
+		case string:
+			normalized = append(normalized, f+":asc")
+		case map[string]interface{}:
+			if len(f) != 1 {
+				return "", fmt.Errorf("invalid field %v in the index", f)
+			}
+			for name, direction := range f {
+				normalized = append(normalized, fmt.Sprintf("%s:%v", name, direction))
+			}
+		default:
+			return "", fmt.Errorf("invalid field %v in the index", f)
+		}
+	}
+	return strings.Join(normalized, ","), nil
+}
+
+func constructIndexID(designDoc, name string) string {
+	return designDoc + "/" + name
+}
+
+// reconcileIndexes brings the indexes of a namespace in line with the index definitions packaged with the chaincode.
+// An index is created if it does not exist, and is recreated if its fields have changed. The indexes which were created
+// by a previous reconciliation and which are no longer packaged are deleted. Errors are recorded in the returned status
+// of the affected index and do not stop the reconciliation of the other indexes
+func reconcileIndexes(mgr indexManager, previous []*indexRecord, fileEntries []*ccprovider.TarFileEntry) []*indexRecord {
+	existingIndexes := make(map[string]string)
+	listedIndexes, err := mgr.ListIndex()
+	listed := err == nil
+	if err != nil {
+		logger.Errorf("Error while listing the existing indexes, the indexes will be created without being compared. Error=%s", err)
+	} else {
+		for _, idx := range *listedIndexes {
+			fields, err := parseListedIndexFields(idx.Definition)
+			if err != nil {
+				logger.Warningf("Error while parsing the definition of index [%s] of design document [%s]. Error=%s", idx.Name, idx.DesignDocument, err)
+			}
+			existingIndexes[constructIndexID(idx.DesignDocument, idx.Name)] = fields
+		}
+	}
+
+	var records []*indexRecord
+	desiredIndexes := make(map[string]bool)
+	for _, fileEntry := range fileEntries {
+		record := &indexRecord{File: fileEntry.FileHeader.Name, Definition: string(fileEntry.FileContent)}
+		records = append(records, record)
+
+		designDoc, name, fields, err := parseIndexDefinition(fileEntry.FileContent)
+		if err != nil {
+			record.fail(err)
+			continue
+		}
+		record.DesignDoc, record.Name = designDoc, name
+		if designDoc != "" && name != "" {
+			indexID := constructIndexID(designDoc, name)
+			desiredIndexes[indexID] = true
+			if existingFields, ok := existingIndexes[indexID]; ok {
+				if existingFields == fields {
+					record.Status = pb.IndexStatus_UNCHANGED.String()
+					record.Managed = true
+					continue
+				}
+				// CouchDB does not update an existing index whose definition has changed
+				if err := mgr.DeleteIndex(designDoc, name); err != nil {
+					record.fail(err)
+					continue
+				}
+				record.Status = pb.IndexStatus_UPDATED.String()
+			}
+		}
+
+		resp, err := mgr.CreateIndex(string(fileEntry.FileContent))
+		if err != nil {
+			record.fail(err)
+			continue
+		}
+		record.DesignDoc, record.Name = strings.TrimPrefix(resp.ID, designDocPrefix), resp.Name
+		record.Managed = true
+		desiredIndexes[constructIndexID(record.DesignDoc, record.Name)] = true
+		if record.Status == "" {
+			if resp.Result == "exists" {
+				record.Status = pb.IndexStatus_UNCHANGED.String()
+			} else {
+				record.Status = pb.IndexStatus_CREATED.String()
+			}
+		}
+	}
+
+	for _, prev := range previous {
+		indexID := constructIndexID(prev.DesignDoc, prev.Name)
+		if !prev.Managed || desiredIndexes[indexID] {
+			continue
+		}
+		record := &indexRecord{DesignDoc: prev.DesignDoc, Name: prev.Name, File: prev.File, Definition: prev.Definition}
+		records = append(records, record)
+		if _, ok := existingIndexes[indexID]; listed && !ok {
+			record.Status = pb.IndexStatus_DELETED.String()
+			continue
+		}
+		if err := mgr.DeleteIndex(prev.DesignDoc, prev.Name); err != nil {
+			record.fail(err)
+			// the deletion is retried on the next reconciliation
+			record.Managed = true
+			continue
+		}
+		record.Status = pb.IndexStatus_DELETED.String()
+	}
+	return records
+}
+
+// reconcileIndexes reconciles the indexes of the given namespace and records their status in the metadataDB
+func (vdb *VersionedDB) reconcileIndexes(namespace string, db indexManager, fileEntries []*ccprovider.TarFileEntry) error {
+	previous, err := vdb.readIndexStatus(namespace)
+	if err != nil {
+		return err
+	}
+	records := reconcileIndexes(db, previous, fileEntries)
+	for _, record := range records {
+		if record.Status == pb.IndexStatus_FAILED.String() {
+			logger.Errorf("Error during reconciliation of index [%s] of design document [%s] from file=[%s] for chaincode=[%s] on chain=[%s]. Error=%s",
+				record.Name, record.DesignDoc, record.File, namespace, vdb.chainName, record.Error)
+			continue
+		}
+		logger.Infof("Index [%s] of design document [%s] for chaincode=[%s] on chain=[%s] is %s",
+			record.Name, record.DesignDoc, namespace, vdb.chainName, record.Status)
+	}
+	return vdb.recordIndexStatus(namespace, records)
+}
+
+func (vdb *VersionedDB) readIndexStatus(namespace string) ([]*indexRecord, error) {
+	couchDoc, _, err := vdb.metadataDB.ReadDoc(indexStatusDocIDPrefix + namespace)
+	if err != nil {
+		return nil, err
+	}
+	// ReadDoc() not found (404) will result in nil response
+	if couchDoc == nil || couchDoc.JSONValue == nil {
+		return nil, nil
+	}
+	statusDoc := &couchIndexStatusData{}
+	if err := json.Unmarshal(couchDoc.JSONValue, statusDoc); err != nil {
+		return nil, err
+	}
+	return statusDoc.Indexes, nil
+}
+
+func (vdb *VersionedDB) recordIndexStatus(namespace string, records []*indexRecord) error {
+	statusDocJSON, err := json.Marshal(&couchIndexStatusData{Indexes: records})
+	if err != nil {
+		return err
+	}
+	_, err = vdb.metadataDB.SaveDoc(indexStatusDocIDPrefix+namespace, "", &couchdb.CouchDoc{JSONValue: statusDocJSON, Attachments: nil})
+	return err
+}
+
+// GetIndexStatus implements method in statedb.IndexStatusProvider interface
+func (vdb *VersionedDB) GetIndexStatus(namespace string) (*pb.IndexStatusResponse, error) {
+	records, err := vdb.readIndexStatus(namespace)
+	if err != nil {
+		return nil, err
+	}
+	response := &pb.IndexStatusResponse{}
+	for _, record := range records {
+		response.Indexes = append(response.Indexes, &pb.IndexStatus{
+			DesignDoc:  record.DesignDoc,
+			Name:       record.Name,
+			File:       record.File,
+			Definition: record.Definition,
+			Status:     pb.IndexStatus_Status(pb.IndexStatus_Status_value[record.Status]),
+			Error:      record.Error,
+		})
+	}
+	return response, nil
+}
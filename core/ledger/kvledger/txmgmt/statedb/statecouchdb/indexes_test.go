@@ -0,0 +1,150 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecouchdb
+
+import (
+	"archive/tar"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/util/couchdb"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockIndexManager struct {
+	indexes   map[string]string
+	listErr   error
+	createErr error
+	deleteErr error
+	deleted   []string
+}
+
+func (m *mockIndexManager) ListIndex() (*[]couchdb.IndexResult, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	var results []couchdb.IndexResult
+	for id, fields := range m.indexes {
+		s := strings.SplitN(id, "/", 2)
+		results = append(results, couchdb.IndexResult{DesignDocument: s[0], Name: s[1], Definition: `{"fields":` + fields + `}`})
+	}
+	return &results, nil
+}
+
+func (m *mockIndexManager) CreateIndex(indexdefinition string) (*couchdb.CreateIndexResponse, error) {
+	if m.createErr != nil {
+		return nil, m.createErr
+	}
+	designDoc, name, _, err := parseIndexDefinition([]byte(indexdefinition))
+	if err != nil {
+		return nil, err
+	}
+	result := "created"
+	if _, ok := m.indexes[constructIndexID(designDoc, name)]; ok {
+		result = "exists"
+	}
+	m.indexes[constructIndexID(designDoc, name)] = `[]`
+	return &couchdb.CreateIndexResponse{Result: result, ID: designDocPrefix + designDoc, Name: name}, nil
+}
+
+func (m *mockIndexManager) DeleteIndex(designdoc, indexname string) error {
+	if m.deleteErr != nil {
+		return m.deleteErr
+	}
+	delete(m.indexes, constructIndexID(designdoc, indexname))
+	m.deleted = append(m.deleted, constructIndexID(designdoc, indexname))
+	return nil
+}
+
+func indexFile(name, content string) *ccprovider.TarFileEntry {
+	return &ccprovider.TarFileEntry{FileHeader: &tar.Header{Name: name}, FileContent: []byte(content)}
+}
+
+func statusOf(records []*indexRecord) map[string]string {
+	status := make(map[string]string)
+	for _, record := range records {
+		status[constructIndexID(record.DesignDoc, record.Name)] = record.Status
+	}
+	return status
+}
+
+func TestReconcileIndexes(t *testing.T) {
+	ownerIndex := indexFile("indexOwner.json", `{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`)
+	sizeIndex := indexFile("indexSize.json", `{"index":{"fields":[{"size":"desc"}]},"ddoc":"indexSizeDoc","name":"indexSize","type":"json"}`)
+	mgr := &mockIndexManager{indexes: map[string]string{
+		"indexOwnerDoc/indexOwner": `[{"owner":"asc"}]`,
+		"otherDoc/otherIndex":      `[{"color":"asc"}]`,
+	}}
+
+	// the owner index is unchanged and the size index is created
+	records := reconcileIndexes(mgr, nil, []*ccprovider.TarFileEntry{ownerIndex, sizeIndex})
+	assert.Equal(t, map[string]string{
+		"indexOwnerDoc/indexOwner": pb.IndexStatus_UNCHANGED.String(),
+		"indexSizeDoc/indexSize":   pb.IndexStatus_CREATED.String(),
+	}, statusOf(records))
+	assert.Empty(t, mgr.deleted)
+
+	// the size index is updated, the owner index is removed from the package and deleted,
+	// and the index which was not created from the package is kept
+	mgr.indexes["indexSizeDoc/indexSize"] = `[{"size":"asc"}]`
+	records = reconcileIndexes(mgr, records, []*ccprovider.TarFileEntry{sizeIndex})
+	assert.Equal(t, map[string]string{
+		"indexOwnerDoc/indexOwner": pb.IndexStatus_DELETED.String(),
+		"indexSizeDoc/indexSize":   pb.IndexStatus_UPDATED.String(),
+	}, statusOf(records))
+	assert.Equal(t, []string{"indexSizeDoc/indexSize", "indexOwnerDoc/indexOwner"}, mgr.deleted)
+	assert.Contains(t, mgr.indexes, "otherDoc/otherIndex")
+
+	// the deleted index is no longer reported
+	records = reconcileIndexes(mgr, records, []*ccprovider.TarFileEntry{sizeIndex})
+	assert.Equal(t, map[string]string{"indexSizeDoc/indexSize": pb.IndexStatus_UPDATED.String()}, statusOf(records))
+}
+
+func TestReconcileIndexesErrors(t *testing.T) {
+	badIndex := indexFile("bad.json", `{"index":{"fields":[]},"name":"bad"}`)
+	ownerIndex := indexFile("indexOwner.json", `{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`)
+	previous := []*indexRecord{{DesignDoc: "sizeDoc", Name: "size", Status: pb.IndexStatus_CREATED.String(), Managed: true}}
+	mgr := &mockIndexManager{
+		indexes:   map[string]string{},
+		listErr:   errors.New("list error"),
+		createErr: errors.New("create error"),
+		deleteErr: errors.New("delete error"),
+	}
+
+	records := reconcileIndexes(mgr, previous, []*ccprovider.TarFileEntry{badIndex, ownerIndex})
+	assert.Len(t, records, 3)
+	assert.Equal(t, pb.IndexStatus_FAILED.String(), records[0].Status)
+	assert.Equal(t, "no fields defined for the index", records[0].Error)
+	assert.Equal(t, "bad.json", records[0].File)
+	assert.Equal(t, pb.IndexStatus_FAILED.String(), records[1].Status)
+	assert.Equal(t, "create error", records[1].Error)
+	assert.False(t, records[1].Managed)
+	// the failed deletion is retried on the next reconciliation
+	assert.Equal(t, pb.IndexStatus_FAILED.String(), records[2].Status)
+	assert.Equal(t, "delete error", records[2].Error)
+	assert.True(t, records[2].Managed)
+
+	mgr.listErr, mgr.createErr, mgr.deleteErr = nil, nil, nil
+	records = reconcileIndexes(mgr, records, []*ccprovider.TarFileEntry{ownerIndex})
+	assert.Equal(t, map[string]string{
+		"indexOwnerDoc/indexOwner": pb.IndexStatus_CREATED.String(),
+		"sizeDoc/size":             pb.IndexStatus_DELETED.String(),
+	}, statusOf(records))
+}
+
+func TestNormalizeIndexFields(t *testing.T) {
+	fields, err := normalizeIndexFields([]interface{}{"docType", map[string]interface{}{"owner": "desc"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "docType:asc,owner:desc", fields)
+
+	_, err = normalizeIndexFields([]interface{}{map[string]interface{}{"owner": "desc", "size": "asc"}})
+	assert.Error(t, err)
+	_, err = normalizeIndexFields([]interface{}{1})
+	assert.Error(t, err)
+}
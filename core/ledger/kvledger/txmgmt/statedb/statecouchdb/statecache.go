@@ -0,0 +1,156 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecouchdb
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+)
+
+// stateCache caches the committed values and versions of keys in front of CouchDB,
+// so that reads during endorsement don't require a round-trip to CouchDB.
+// It keeps up to maxKeysPerNs keys of each namespace, evicting the least recently used ones.
+// Keys which don't exist in CouchDB are cached as well, with a nil value.
+//
+// The cache is only updated with the updates of a batch once they're committed to CouchDB,
+// and it's cleared if committing a batch fails, since CouchDB may then contain part of the batch.
+// Since the cache is kept in memory only, it starts out empty when the peer recovers
+// and re-commits the blocks after the last savepoint.
+type stateCache struct {
+	mux          sync.Mutex
+	channel      string
+	maxKeysPerNs int
+	namespaces   map[string]*lru.Cache
+	// seq is incremented on every update of the cache, to detect values read from
+	// CouchDB before an update of the cache, which may no longer be up to date
+	seq    uint64
+	hits   uint64
+	misses uint64
+}
+
+// cacheEntry is a cached value, nil if the key doesn't exist
+type cacheEntry struct {
+	vv *statedb.VersionedValue
+}
+
+func newStateCache(channel string, maxKeysPerNs int) *stateCache {
+	return &stateCache{
+		channel:      channel,
+		maxKeysPerNs: maxKeysPerNs,
+		namespaces:   make(map[string]*lru.Cache),
+	}
+}
+
+func (c *stateCache) enabled() bool {
+	return c.maxKeysPerNs > 0
+}
+
+// get returns the cached value of the given key, and whether it was found in the cache.
+// A nil value that was found means the key doesn't exist
+func (c *stateCache) get(namespace, key string) (*statedb.VersionedValue, bool) {
+	if !c.enabled() {
+		return nil, false
+	}
+	c.mux.Lock()
+	var entry interface{}
+	found := false
+	if nsCache, exists := c.namespaces[namespace]; exists {
+		entry, found = nsCache.Get(key)
+	}
+	c.mux.Unlock()
+
+	if !found {
+		atomic.AddUint64(&c.misses, 1)
+		c.reportMetric("misses")
+		return nil, false
+	}
+	atomic.AddUint64(&c.hits, 1)
+	c.reportMetric("hits")
+	vv := entry.(cacheEntry).vv
+	if vv == nil {
+		return nil, true
+	}
+	// Return a copy, so that callers can't modify the cached value
+	return &statedb.VersionedValue{Value: vv.Value, Version: vv.Version}, true
+}
+
+// currentSeq returns the sequence of the last update of the cache,
+// to be passed to putIfUnchanged along with a value read from CouchDB
+func (c *stateCache) currentSeq() uint64 {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.seq
+}
+
+// putIfUnchanged caches the given value of the given key, read from CouchDB,
+// unless the cache was updated since the given sequence, in which case the value
+// may have been read before the key was updated
+func (c *stateCache) putIfUnchanged(namespace, key string, vv *statedb.VersionedValue, seq uint64) {
+	if !c.enabled() {
+		return
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.seq != seq {
+		return
+	}
+	c.nsCache(namespace).Add(key, cacheEntry{vv: vv})
+}
+
+// applyUpdates updates the cached keys with the given batch, which was committed to CouchDB.
+// Keys of the batch which aren't cached aren't added to the cache
+func (c *stateCache) applyUpdates(batch *statedb.UpdateBatch) {
+	if !c.enabled() {
+		return
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.seq++
+	for _, ns := range batch.GetUpdatedNamespaces() {
+		nsCache, exists := c.namespaces[ns]
+		if !exists {
+			continue
+		}
+		for key, vv := range batch.GetUpdates(ns) {
+			if _, cached := nsCache.Get(key); !cached {
+				continue
+			}
+			if vv.Value == nil {
+				nsCache.Add(key, cacheEntry{})
+				continue
+			}
+			nsCache.Add(key, cacheEntry{vv: &statedb.VersionedValue{Value: vv.Value, Version: vv.Version}})
+		}
+	}
+}
+
+// clear removes all keys from the cache
+func (c *stateCache) clear() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.seq++
+	c.namespaces = make(map[string]*lru.Cache)
+}
+
+func (c *stateCache) nsCache(namespace string) *lru.Cache {
+	nsCache, exists := c.namespaces[namespace]
+	if !exists {
+		nsCache = lru.New(c.maxKeysPerNs)
+		c.namespaces[namespace] = nsCache
+	}
+	return nsCache
+}
+
+func (c *stateCache) reportMetric(name string) {
+	if metrics.RootScope == nil {
+		return
+	}
+	metrics.RootScope.SubScope("couchdb_state_cache").Tagged(map[string]string{"channel": c.channel}).Counter(name).Inc(1)
+}
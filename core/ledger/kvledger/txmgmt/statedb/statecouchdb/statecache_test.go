@@ -0,0 +1,164 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecouchdb
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateCacheGetAndPut(t *testing.T) {
+	cache := newStateCache("testchannel", 2)
+
+	_, found := cache.get("ns1", "key1")
+	assert.False(t, found)
+
+	vv := &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}
+	cache.putIfUnchanged("ns1", "key1", vv, cache.currentSeq())
+	cached, found := cache.get("ns1", "key1")
+	assert.True(t, found)
+	assert.Equal(t, vv, cached)
+	// the cached value is returned as a copy
+	cached.Version = version.NewHeight(2, 1)
+	cached, _ = cache.get("ns1", "key1")
+	assert.Equal(t, version.NewHeight(1, 1), cached.Version)
+
+	// keys that don't exist are cached as well
+	cache.putIfUnchanged("ns1", "key2", nil, cache.currentSeq())
+	cached, found = cache.get("ns1", "key2")
+	assert.True(t, found)
+	assert.Nil(t, cached)
+
+	// the keys of the other namespaces are kept separately
+	_, found = cache.get("ns2", "key1")
+	assert.False(t, found)
+
+	// the least recently used key of the namespace is evicted
+	cache.putIfUnchanged("ns1", "key3", vv, cache.currentSeq())
+	_, found = cache.get("ns1", "key1")
+	assert.False(t, found)
+	_, found = cache.get("ns1", "key2")
+	assert.True(t, found)
+
+	assert.Equal(t, uint64(4), cache.hits)
+	assert.Equal(t, uint64(3), cache.misses)
+}
+
+func TestStateCacheApplyUpdates(t *testing.T) {
+	cache := newStateCache("testchannel", 10)
+	cache.putIfUnchanged("ns1", "key1", &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, cache.currentSeq())
+	cache.putIfUnchanged("ns1", "key2", &statedb.VersionedValue{Value: []byte("value2"), Version: version.NewHeight(1, 2)}, cache.currentSeq())
+	cache.putIfUnchanged("ns1", "key3", nil, cache.currentSeq())
+
+	// a value read from CouchDB before the batch is applied isn't cached afterwards
+	seq := cache.currentSeq()
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1_new"), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key2", version.NewHeight(2, 2))
+	batch.Put("ns1", "key3", []byte("value3"), version.NewHeight(2, 3))
+	batch.Put("ns1", "key4", []byte("value4"), version.NewHeight(2, 4))
+	batch.Put("ns2", "key1", []byte("value1"), version.NewHeight(2, 5))
+	cache.applyUpdates(batch)
+
+	cached, found := cache.get("ns1", "key1")
+	assert.True(t, found)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value1_new"), Version: version.NewHeight(2, 1)}, cached)
+	cached, found = cache.get("ns1", "key2")
+	assert.True(t, found)
+	assert.Nil(t, cached)
+	cached, found = cache.get("ns1", "key3")
+	assert.True(t, found)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value3"), Version: version.NewHeight(2, 3)}, cached)
+
+	// keys that weren't cached aren't added
+	_, found = cache.get("ns1", "key4")
+	assert.False(t, found)
+	_, found = cache.get("ns2", "key1")
+	assert.False(t, found)
+
+	cache.putIfUnchanged("ns1", "key4", nil, seq)
+	_, found = cache.get("ns1", "key4")
+	assert.False(t, found)
+}
+
+func TestStateCacheClear(t *testing.T) {
+	cache := newStateCache("testchannel", 10)
+	seq := cache.currentSeq()
+	cache.putIfUnchanged("ns1", "key1", &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, seq)
+
+	cache.clear()
+	_, found := cache.get("ns1", "key1")
+	assert.False(t, found)
+
+	// values read from CouchDB before the cache was cleared aren't cached
+	cache.putIfUnchanged("ns1", "key1", &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, seq)
+	_, found = cache.get("ns1", "key1")
+	assert.False(t, found)
+}
+
+func TestStateCacheDisabled(t *testing.T) {
+	cache := newStateCache("testchannel", 0)
+	cache.putIfUnchanged("ns1", "key1", &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, cache.currentSeq())
+	_, found := cache.get("ns1", "key1")
+	assert.False(t, found)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(2, 1))
+	cache.applyUpdates(batch)
+	_, found = cache.get("ns1", "key1")
+	assert.False(t, found)
+	assert.Equal(t, uint64(0), cache.misses)
+}
+
+func TestStateCacheMetrics(t *testing.T) {
+	server, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")})
+	assert.NoError(t, err)
+	defer server.Close()
+
+	assert.NoError(t, metrics.Init(metrics.Opts{
+		Enabled:  true,
+		Reporter: "statsd",
+		Interval: 100 * time.Millisecond,
+		StatsdReporterOpts: metrics.StatsdReporterOpts{
+			Address:       server.LocalAddr().String(),
+			FlushInterval: 100 * time.Millisecond,
+			FlushBytes:    512,
+		},
+	}))
+	assert.NoError(t, metrics.Start())
+	defer metrics.Shutdown()
+
+	cache := newStateCache("testchannel", 2)
+	cache.get("ns1", "key1")
+	cache.putIfUnchanged("ns1", "key1", nil, cache.currentSeq())
+	cache.get("ns1", "key1")
+	cache.get("ns1", "key1")
+
+	expected := []string{
+		"hyperledger_fabric.couchdb_state_cache.misses.channel-testchannel:1|c",
+		"hyperledger_fabric.couchdb_state_cache.hits.channel-testchannel:2|c",
+	}
+	var reported string
+	buffer := make([]byte, 4096)
+	server.SetReadDeadline(time.Now().Add(10 * time.Second))
+	for _, metric := range expected {
+		for !strings.Contains(reported, metric) {
+			n, err := server.Read(buffer)
+			if !assert.NoError(t, err, "%s wasn't reported, got: %s", metric, reported) {
+				return
+			}
+			reported += string(buffer[:n])
+		}
+	}
+}
@@ -39,6 +39,12 @@ var reservedFields = []string{idField, revField, versionField, deletedField}
 
 var dbArtifactsDirFilter = map[string]bool{"META-INF/statedb/couchdb/indexes": true}
 
+func init() {
+	statedb.RegisterVersionedDBProvider("CouchDB", func() (statedb.VersionedDBProvider, error) {
+		return NewVersionedDBProvider()
+	})
+}
+
 // querySkip is implemented for future use by query paging
 // currently defaulted to 0 and is not used
 const querySkip = 0
@@ -82,7 +88,9 @@ func NewVersionedDBProvider() (*VersionedDBProvider, error) {
 	return &VersionedDBProvider{couchInstance, make(map[string]*VersionedDB), sync.Mutex{}, 0}, nil
 }
 
-//HandleChaincodeDeploy initializes database artifacts for the database associated with the namespace
+//HandleChaincodeDeploy initializes database artifacts for the database associated with the namespace.
+// On a chaincode upgrade, the indexes whose definition has changed are recreated and the indexes that are no
+// longer packaged with the chaincode are deleted. The status of the indexes can be retrieved with GetIndexStatus.
 // This function delibrately suppresses the errors that occur during the creation of the indexes on couchdb.
 // This is because, in the present code, we do not differentiate between the errors because of couchdb interaction
 // and the errors because of bad index files - the later being unfixable by the admin. Note that the error suppression
@@ -105,14 +113,9 @@ func (vdb *VersionedDB) HandleChaincodeDeploy(chaincodeDefinition *cceventmgmt.C
 		return nil
 	}
 
-	for _, fileEntry := range fileEntries {
-		indexData := fileEntry.FileContent
-		filename := fileEntry.FileHeader.Name
-		_, err = db.CreateIndex(string(indexData))
-		if err != nil {
-			logger.Errorf("Error during creation of index from file=[%s] for chaincode=[%s] on chain=[%s]. Error=%s",
-				filename, chaincodeDefinition, vdb.chainName, err)
-		}
+	if err := vdb.reconcileIndexes(chaincodeDefinition.Name, db, fileEntries); err != nil {
+		logger.Errorf("Error during reconciliation of indexes for chaincode=[%s] on chain=[%s]. Error=%s",
+			chaincodeDefinition, vdb.chainName, err)
 	}
 
 	return nil
@@ -151,6 +154,7 @@ type VersionedDB struct {
 	//TODO: Decide whether to split committedDataCache into multiple cahces, i.e., one per namespace.
 	committedDataCache *CommittedVersions // Used as a local cache during bulk processing of a block.
 	mux                sync.RWMutex
+	stateCache         *stateCache // Caches committed values and versions of keys, for reads during endorsement.
 }
 
 // newVersionedDB constructs an instance of VersionedDB
@@ -170,7 +174,9 @@ func newVersionedDB(couchInstance *couchdb.CouchInstance, dbName string) (*Versi
 
 	committedDataCache := &CommittedVersions{committedVersions: versionMap, revisionNumbers: revMap}
 
-	return &VersionedDB{couchInstance, metadataDB, chainName, namespaceDBMap, committedDataCache, sync.RWMutex{}}, nil
+	stateCache := newStateCache(chainName, ledgerconfig.GetStateCacheSize())
+
+	return &VersionedDB{couchInstance, metadataDB, chainName, namespaceDBMap, committedDataCache, sync.RWMutex{}, stateCache}, nil
 }
 
 // getNamespaceDBHandle gets the handle to a named chaincode database
@@ -235,6 +241,22 @@ func (vdb *VersionedDB) BytesKeySuppoted() bool {
 func (vdb *VersionedDB) GetState(namespace string, key string) (*statedb.VersionedValue, error) {
 	logger.Debugf("GetState(). ns=%s, key=%s", namespace, key)
 
+	if vv, found := vdb.stateCache.get(namespace, key); found {
+		return vv, nil
+	}
+	// Values read from CouchDB are only cached if no batch was committed in the meantime
+	cacheSeq := vdb.stateCache.currentSeq()
+
+	vv, err := vdb.readState(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	vdb.stateCache.putIfUnchanged(namespace, key, vv, cacheSeq)
+	return vv, nil
+}
+
+// readState reads the value and version of the given key from CouchDB
+func (vdb *VersionedDB) readState(namespace string, key string) (*statedb.VersionedValue, error) {
 	db, err := vdb.getNamespaceDBHandle(namespace)
 	if err != nil {
 		return nil, err
@@ -281,7 +303,17 @@ func (vdb *VersionedDB) GetVersion(namespace string, key string) (*version.Heigh
 
 	returnVersion, keyFound := vdb.GetCachedVersion(namespace, key)
 
-	// If the version was not found in the committed data cache, retrieve it from statedb.
+	// If the version was not found in the committed data cache, look it up in the state cache
+	if !keyFound {
+		if vv, found := vdb.stateCache.get(namespace, key); found {
+			if vv == nil {
+				return nil, nil
+			}
+			return vv.Version, nil
+		}
+	}
+
+	// If the version was not found in either cache, retrieve it from statedb.
 	if !keyFound {
 
 		db, err := vdb.getNamespaceDBHandle(namespace)
@@ -525,9 +557,14 @@ func (vdb *VersionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version
 	// TODO: Currently, we are returing only one error. We need to create a new error type
 	// that can encapsulate all the errors and return that type
 	if len(errResponses) > 0 {
+		// Some of the namespaces may have been committed, the cached values can no longer be trusted
+		vdb.stateCache.clear()
 		return <-errResponses
 	}
 
+	// All namespaces were committed, reflect the batch in the state cache
+	vdb.stateCache.applyUpdates(batch)
+
 	// Record a savepoint at a given height
 	err := vdb.recordSavepoint(height, namespaces)
 	if err != nil {
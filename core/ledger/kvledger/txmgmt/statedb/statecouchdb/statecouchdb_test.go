@@ -30,6 +30,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/spf13/viper"
 )
 
@@ -368,6 +369,29 @@ func TestHandleChaincodeDeploy(t *testing.T) {
 	_, err = db.ExecuteQuery("ns2", queryString)
 	testutil.AssertError(t, err, "Error should have been thrown for a missing index")
 
+	indexStatus, err := db.(*VersionedDB).GetIndexStatus("ns1")
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, len(indexStatus.Indexes), 2)
+	testutil.AssertEquals(t, indexStatus.Indexes[0].Status, pb.IndexStatus_CREATED)
+	testutil.AssertEquals(t, indexStatus.Indexes[1].Status, pb.IndexStatus_CREATED)
+
+	//Upgrade the chaincode with a changed size index and without the color index
+	dbArtifactsTarBytes = createTarBytesForTest(t,
+		[]*testFile{
+			{"META-INF/statedb/couchdb/indexes/indexSizeSortName.json", `{"index":{"fields":[{"size":"desc"},{"owner":"desc"}]},"ddoc":"indexSizeSortName","name":"indexSizeSortName","type":"json"}`},
+		},
+	)
+	err = handleDefinition.HandleChaincodeDeploy(chaincodeDef, dbArtifactsTarBytes)
+	testutil.AssertNoError(t, err, "")
+
+	indexStatus, err = db.(*VersionedDB).GetIndexStatus("ns1")
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, len(indexStatus.Indexes), 2)
+	testutil.AssertEquals(t, indexStatus.Indexes[0].Name, "indexSizeSortName")
+	testutil.AssertEquals(t, indexStatus.Indexes[0].Status, pb.IndexStatus_UPDATED)
+	testutil.AssertEquals(t, indexStatus.Indexes[1].Name, "indexColorSortName")
+	testutil.AssertEquals(t, indexStatus.Indexes[1].Status, pb.IndexStatus_DELETED)
+
 	//Test HandleChaincodeDefinition with a nil tar file
 	err = handleDefinition.HandleChaincodeDeploy(chaincodeDef, nil)
 	testutil.AssertNoError(t, err, "")
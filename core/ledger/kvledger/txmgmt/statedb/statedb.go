@@ -10,6 +10,7 @@ import (
 
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/peer"
 )
 
 // VersionedDBProvider provides an instance of an versioned DB
@@ -62,6 +63,14 @@ type BulkOptimizable interface {
 	ClearCachedVersions()
 }
 
+// IndexStatusProvider is implemented by the databases that create
+// the indexes defined in the chaincode packages
+type IndexStatusProvider interface {
+	// GetIndexStatus returns the status of the indexes of the given namespace,
+	// as of their last reconciliation with the chaincode package
+	GetIndexStatus(namespace string) (*peer.IndexStatusResponse, error)
+}
+
 // CompositeKey encloses Namespace and Key components
 type CompositeKey struct {
 	Namespace string
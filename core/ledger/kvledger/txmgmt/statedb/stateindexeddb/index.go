@@ -0,0 +1,212 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateindexeddb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/pkg/errors"
+)
+
+// index is a secondary index on one or more fields of the JSON values of a namespace.
+// A value has an entry in the index only if all the fields of the index hold a scalar
+// (a string, a number, a boolean or null), in which case the entry is keyed by the
+// encoded values of the fields, followed by the key of the value
+type index struct {
+	Name   string   `json:"name"`
+	Fields []string `json:"fields"`
+}
+
+// indexDefinition is the CouchDB style definition of an index, as packaged in the db artifacts of a chaincode, e.g.
+// {"index":{"fields":["docType",{"owner":"desc"}]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}.
+// The sort direction of the fields is not relevant to this state database
+type indexDefinition struct {
+	Index struct {
+		Fields []interface{} `json:"fields"`
+	} `json:"index"`
+	Name string `json:"name"`
+}
+
+func parseIndexDefinition(definition []byte) (*index, error) {
+	def := &indexDefinition{}
+	if err := json.Unmarshal(definition, def); err != nil {
+		return nil, errors.Wrap(err, "invalid index definition")
+	}
+	if def.Name == "" || strings.ContainsRune(def.Name, rune(compositeKeySep[0])) {
+		return nil, errors.Errorf("invalid index name [%s]", def.Name)
+	}
+	if len(def.Index.Fields) == 0 {
+		return nil, errors.Errorf("no fields defined for index [%s]", def.Name)
+	}
+	idx := &index{Name: def.Name}
+	for _, field := range def.Index.Fields {
+		switch f := field.(type) {
+		case string:
+			idx.Fields = append(idx.Fields, f)
+		case map[string]interface{}:
+			if len(f) != 1 {
+				return nil, errors.Errorf("invalid field %v in index [%s]", f, def.Name)
+			}
+			for name := range f {
+				idx.Fields = append(idx.Fields, name)
+			}
+		default:
+			return nil, errors.Errorf("invalid field %v in index [%s]", f, def.Name)
+		}
+	}
+	return idx, nil
+}
+
+func unmarshalIndex(b []byte) (*index, error) {
+	idx := &index{}
+	if err := json.Unmarshal(b, idx); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling index")
+	}
+	return idx, nil
+}
+
+func (idx *index) marshal() ([]byte, error) {
+	b, err := json.Marshal(idx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling index")
+	}
+	return b, nil
+}
+
+func (idx *index) equal(other *index) bool {
+	if idx.Name != other.Name || len(idx.Fields) != len(other.Fields) {
+		return false
+	}
+	for i, field := range idx.Fields {
+		if field != other.Fields[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (idx *index) constructEntryPrefix(ns string) []byte {
+	return append(constructCompositeKey(indexEntryPrefix, ns, idx.Name), compositeKeySep...)
+}
+
+// constructIndexEntryKeys returns the keys of the entries of the given value in the given indexes
+func constructIndexEntryKeys(ns string, indexes []*index, key string, value []byte) [][]byte {
+	if value == nil {
+		return nil
+	}
+	doc, err := unmarshalDocument(value)
+	if err != nil {
+		// only JSON values are indexed
+		return nil
+	}
+	var entryKeys [][]byte
+	for _, idx := range indexes {
+		entryKey := idx.constructEntryPrefix(ns)
+		indexed := true
+		for _, field := range idx.Fields {
+			fieldValue, ok := lookupField(doc, splitFieldPath(field))
+			if !ok {
+				indexed = false
+				break
+			}
+			encoded, ok := encodeIndexValue(fieldValue)
+			if !ok {
+				indexed = false
+				break
+			}
+			entryKey = append(entryKey, encoded...)
+		}
+		if !indexed {
+			continue
+		}
+		entryKey = append(entryKey, key...)
+		if len(entryKey) > bolt.MaxKeySize {
+			logger.Warningf("Key [%s] of namespace [%s] is not indexed in index [%s], as its entry exceeds the maximum key size",
+				key, ns, idx.Name)
+			continue
+		}
+		entryKeys = append(entryKeys, entryKey)
+	}
+	return entryKeys
+}
+
+// selectIndex returns the index whose leading fields are matched for equality by the
+// most fields of the given equalities, and the prefix of the entries of these values
+func selectIndex(ns string, indexes []*index, equalities map[string]interface{}) (*index, []byte) {
+	var selected *index
+	var selectedPrefix []byte
+	selectedFields := 0
+	for _, idx := range indexes {
+		prefix := idx.constructEntryPrefix(ns)
+		fields := 0
+		for _, field := range idx.Fields {
+			fieldValue, ok := equalities[field]
+			if !ok {
+				break
+			}
+			encoded, ok := encodeIndexValue(fieldValue)
+			if !ok {
+				break
+			}
+			prefix = append(prefix, encoded...)
+			fields++
+		}
+		if fields > selectedFields {
+			selected, selectedPrefix, selectedFields = idx, prefix, fields
+		}
+	}
+	return selected, selectedPrefix
+}
+
+var (
+	escapeByte  = byte(0x00)
+	escapedByte = []byte{0x00, 0xff}
+	terminator  = []byte{0x00, 0x01}
+)
+
+// maxIndexedStringLength is the length above which a string is indexed by its hash,
+// which keeps the entry keys short. The index is only looked up for equality, and the
+// selector is evaluated on the values found, so the order of the hashes doesn't matter
+const maxIndexedStringLength = 512
+
+// encodeIndexValue encodes a scalar JSON value as its type followed by its canonical form,
+// escaped and terminated so that the encoded values of several fields can be concatenated
+// without ambiguity. Numbers are encoded as floating point numbers, as they are compared
+func encodeIndexValue(value interface{}) ([]byte, bool) {
+	var encoded []byte
+	switch v := value.(type) {
+	case nil:
+		encoded = []byte{'z'}
+	case bool:
+		encoded = []byte{'b', '0'}
+		if v {
+			encoded[1] = '1'
+		}
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return nil, false
+		}
+		encoded = append([]byte{'n'}, strconv.FormatFloat(f, 'g', -1, 64)...)
+	case string:
+		if len(v) > maxIndexedStringLength {
+			hash := sha256.Sum256([]byte(v))
+			encoded = append([]byte{'h'}, hash[:]...)
+		} else {
+			encoded = append([]byte{'s'}, v...)
+		}
+	default:
+		return nil, false
+	}
+	escaped := bytes.Replace(encoded, []byte{escapeByte}, escapedByte, -1)
+	return append(escaped, terminator...), true
+}
@@ -0,0 +1,611 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateindexeddb
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+)
+
+// query is a parsed CouchDB style JSON query
+type query struct {
+	selector matcher
+	// equalities holds the fields that the selector matches for equality with a scalar,
+	// which are candidates for the lookup of the values through an index
+	equalities map[string]interface{}
+	fields     []string
+	sort       []sortField
+	skip       int
+	limit      int
+}
+
+type sortField struct {
+	path       []string
+	descending bool
+}
+
+func parseQuery(queryString string, queryLimit int) (*query, error) {
+	jsonQuery, err := unmarshalDocument([]byte(queryString))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid query")
+	}
+	selector, ok := jsonQuery["selector"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid query, the selector must be a JSON object")
+	}
+	q := &query{equalities: make(map[string]interface{}), limit: queryLimit}
+	if q.selector, err = compileSelector(selector); err != nil {
+		return nil, err
+	}
+	collectEqualities(selector, q.equalities)
+
+	for name, value := range jsonQuery {
+		switch name {
+		case "selector":
+		case "fields":
+			fields, ok := value.([]interface{})
+			if !ok {
+				return nil, errors.New("invalid query, the fields must be a JSON array")
+			}
+			for _, field := range fields {
+				f, ok := field.(string)
+				if !ok {
+					return nil, errors.Errorf("invalid query, invalid field %v", field)
+				}
+				q.fields = append(q.fields, f)
+			}
+		case "sort":
+			if q.sort, err = parseSort(value); err != nil {
+				return nil, err
+			}
+		case "skip", "limit":
+			n, ok := value.(json.Number)
+			if !ok {
+				return nil, errors.Errorf("invalid query, the %s must be a number", name)
+			}
+			i, err := n.Int64()
+			if err != nil || i < 0 {
+				return nil, errors.Errorf("invalid query, invalid %s %s", name, n)
+			}
+			if name == "skip" {
+				q.skip = int(i)
+			} else if int(i) < q.limit {
+				q.limit = int(i)
+			}
+		default:
+			return nil, errors.Errorf("invalid query, unsupported query option [%s]", name)
+		}
+	}
+	return q, nil
+}
+
+func parseSort(value interface{}) ([]sortField, error) {
+	fields, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.New("invalid query, the sort must be a JSON array")
+	}
+	var sortFields []sortField
+	for _, field := range fields {
+		switch f := field.(type) {
+		case string:
+			sortFields = append(sortFields, sortField{path: splitFieldPath(f)})
+		case map[string]interface{}:
+			if len(f) != 1 {
+				return nil, errors.Errorf("invalid query, invalid sort field %v", f)
+			}
+			for name, direction := range f {
+				if direction != "asc" && direction != "desc" {
+					return nil, errors.Errorf("invalid query, invalid sort direction %v", direction)
+				}
+				sortFields = append(sortFields, sortField{path: splitFieldPath(name), descending: direction == "desc"})
+			}
+		default:
+			return nil, errors.Errorf("invalid query, invalid sort field %v", f)
+		}
+	}
+	return sortFields, nil
+}
+
+// collectEqualities collects the fields of the selector which are implicitly or explicitly
+// matched for equality, including in the conditions of a top level $and
+func collectEqualities(selector map[string]interface{}, equalities map[string]interface{}) {
+	for name, value := range selector {
+		if name == "$and" {
+			conditions, _ := value.([]interface{})
+			for _, condition := range conditions {
+				if c, ok := condition.(map[string]interface{}); ok {
+					collectEqualities(c, equalities)
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(name, "$") {
+			continue
+		}
+		if operators, ok := value.(map[string]interface{}); ok {
+			if len(operators) != 1 {
+				continue
+			}
+			if value, ok = operators["$eq"]; !ok {
+				continue
+			}
+		}
+		if _, ok := encodeIndexValue(value); ok {
+			equalities[name] = value
+		}
+	}
+}
+
+// matcher evaluates a selector, or a part of it, against a JSON document
+type matcher interface {
+	match(doc interface{}) bool
+}
+
+type andMatcher []matcher
+
+func (m andMatcher) match(doc interface{}) bool {
+	for _, c := range m {
+		if !c.match(doc) {
+			return false
+		}
+	}
+	return true
+}
+
+type orMatcher []matcher
+
+func (m orMatcher) match(doc interface{}) bool {
+	for _, c := range m {
+		if c.match(doc) {
+			return true
+		}
+	}
+	return false
+}
+
+type notMatcher struct {
+	matcher
+}
+
+func (m notMatcher) match(doc interface{}) bool {
+	return !m.matcher.match(doc)
+}
+
+type fieldMatcher struct {
+	path     []string
+	operator string
+	arg      interface{}
+	regexp   *regexp.Regexp
+}
+
+func (m *fieldMatcher) match(doc interface{}) bool {
+	value, ok := lookupField(doc, m.path)
+	if m.operator == "$exists" {
+		return ok == m.arg.(bool)
+	}
+	if !ok {
+		return false
+	}
+	switch m.operator {
+	case "$eq":
+		return equalValues(value, m.arg)
+	case "$ne":
+		return !equalValues(value, m.arg)
+	case "$gt", "$gte", "$lt", "$lte":
+		c, ok := compareScalars(value, m.arg)
+		if !ok {
+			return false
+		}
+		switch m.operator {
+		case "$gt":
+			return c > 0
+		case "$gte":
+			return c >= 0
+		case "$lt":
+			return c < 0
+		default:
+			return c <= 0
+		}
+	case "$in", "$nin":
+		in := false
+		for _, arg := range m.arg.([]interface{}) {
+			if equalValues(value, arg) {
+				in = true
+				break
+			}
+		}
+		return in == (m.operator == "$in")
+	case "$regex":
+		s, ok := value.(string)
+		return ok && m.regexp.MatchString(s)
+	}
+	return false
+}
+
+func compileSelector(selector map[string]interface{}) (matcher, error) {
+	var m andMatcher
+	for name, value := range selector {
+		switch name {
+		case "$and", "$or", "$nor":
+			conditions, ok := value.([]interface{})
+			if !ok {
+				return nil, errors.Errorf("invalid query, the argument of %s must be a JSON array", name)
+			}
+			var combined []matcher
+			for _, condition := range conditions {
+				c, ok := condition.(map[string]interface{})
+				if !ok {
+					return nil, errors.Errorf("invalid query, the conditions of %s must be JSON objects", name)
+				}
+				cm, err := compileSelector(c)
+				if err != nil {
+					return nil, err
+				}
+				combined = append(combined, cm)
+			}
+			switch name {
+			case "$and":
+				m = append(m, andMatcher(combined))
+			case "$or":
+				m = append(m, orMatcher(combined))
+			default:
+				m = append(m, notMatcher{orMatcher(combined)})
+			}
+		case "$not":
+			condition, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("invalid query, the argument of $not must be a JSON object")
+			}
+			cm, err := compileSelector(condition)
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, notMatcher{cm})
+		default:
+			if strings.HasPrefix(name, "$") {
+				return nil, errors.Errorf("invalid query, unsupported operator [%s]", name)
+			}
+			fm, err := compileFieldCondition(splitFieldPath(name), value)
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, fm)
+		}
+	}
+	return m, nil
+}
+
+// compileFieldCondition compiles the condition on a field, which is either a value to be matched
+// for equality, or an object of operators and of conditions on the subfields of the field
+func compileFieldCondition(path []string, condition interface{}) (matcher, error) {
+	operators, ok := condition.(map[string]interface{})
+	if !ok {
+		return &fieldMatcher{path: path, operator: "$eq", arg: condition}, nil
+	}
+	var m andMatcher
+	for name, arg := range operators {
+		if !strings.HasPrefix(name, "$") {
+			subfield, err := compileFieldCondition(append(append([]string{}, path...), name), arg)
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, subfield)
+			continue
+		}
+		fm := &fieldMatcher{path: path, operator: name, arg: arg}
+		switch name {
+		case "$eq", "$ne", "$gt", "$gte", "$lt", "$lte":
+		case "$exists":
+			if _, ok := arg.(bool); !ok {
+				return nil, errors.New("invalid query, the argument of $exists must be a boolean")
+			}
+		case "$in", "$nin":
+			if _, ok := arg.([]interface{}); !ok {
+				return nil, errors.Errorf("invalid query, the argument of %s must be a JSON array", name)
+			}
+		case "$regex":
+			pattern, ok := arg.(string)
+			if !ok {
+				return nil, errors.New("invalid query, the argument of $regex must be a string")
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid query, invalid regular expression")
+			}
+			fm.regexp = re
+		case "$not":
+			c, err := compileFieldCondition(path, arg)
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, notMatcher{c})
+			continue
+		default:
+			return nil, errors.Errorf("invalid query, unsupported operator [%s]", name)
+		}
+		m = append(m, fm)
+	}
+	return m, nil
+}
+
+func unmarshalDocument(value []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	var doc map[string]interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after the JSON object")
+	}
+	return doc, nil
+}
+
+func splitFieldPath(field string) []string {
+	return strings.Split(field, ".")
+}
+
+func lookupField(doc interface{}, path []string) (interface{}, bool) {
+	value := doc
+	for _, name := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func equalValues(a, b interface{}) bool {
+	if c, ok := compareScalars(a, b); ok {
+		return c == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compareScalars compares two numbers or two strings
+func compareScalars(a, b interface{}) (int, bool) {
+	switch av := a.(type) {
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return 0, false
+		}
+		af, err1 := av.Float64()
+		bf, err2 := bv.Float64()
+		if err1 != nil || err2 != nil {
+			return 0, false
+		}
+		switch {
+		case af < bf:
+			return -1, true
+		case af > bf:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		bv, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(av, bv), true
+	}
+	return 0, false
+}
+
+// collationRank orders the values of different types for sorting, following CouchDB
+func collationRank(value interface{}, present bool) int {
+	if !present {
+		return 0
+	}
+	switch value.(type) {
+	case nil:
+		return 1
+	case bool:
+		return 2
+	case json.Number:
+		return 3
+	case string:
+		return 4
+	case []interface{}:
+		return 5
+	}
+	return 6
+}
+
+func compareForSort(a interface{}, aPresent bool, b interface{}, bPresent bool) int {
+	ra, rb := collationRank(a, aPresent), collationRank(b, bPresent)
+	if ra != rb {
+		return ra - rb
+	}
+	if c, ok := compareScalars(a, b); ok {
+		return c
+	}
+	if ab, ok := a.(bool); ok && ab != b.(bool) {
+		if ab {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
+
+// project returns the given fields of the document
+func project(doc map[string]interface{}, fields []string) map[string]interface{} {
+	projected := make(map[string]interface{})
+	for _, field := range fields {
+		path := splitFieldPath(field)
+		value, ok := lookupField(doc, path)
+		if !ok {
+			continue
+		}
+		object := projected
+		for _, name := range path[:len(path)-1] {
+			child, ok := object[name].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				object[name] = child
+			}
+			object = child
+		}
+		object[path[len(path)-1]] = value
+	}
+	return projected
+}
+
+// queryScanner implements ResultsIterator for the results of a query. It evaluates the selector on
+// the values of the namespace, or on the values referenced by the entries of an index
+type queryScanner struct {
+	vdb       *versionedDB
+	namespace string
+	query     *query
+	dbItr     *dbIterator
+	viaIndex  bool
+	skipped   int
+	returned  int
+}
+
+func (scanner *queryScanner) Next() (statedb.QueryResult, error) {
+	if scanner.returned >= scanner.query.limit {
+		return nil, nil
+	}
+	for {
+		kv, doc, err := scanner.nextMatch()
+		if err != nil || kv == nil {
+			return nil, err
+		}
+		if scanner.skipped < scanner.query.skip {
+			scanner.skipped++
+			continue
+		}
+		if err := scanner.applyFields(kv, doc); err != nil {
+			return nil, err
+		}
+		scanner.returned++
+		return kv, nil
+	}
+}
+
+// nextMatch returns the next value that matches the selector, along with the document it holds
+func (scanner *queryScanner) nextMatch() (*statedb.VersionedKV, map[string]interface{}, error) {
+	for {
+		ok, err := scanner.dbItr.Next()
+		if !ok {
+			return nil, nil, err
+		}
+		var key string
+		var dbVal []byte
+		if scanner.viaIndex {
+			key = string(scanner.dbItr.Value())
+			if dbVal, err = scanner.vdb.get(constructDataKey(scanner.namespace, key)); err != nil {
+				return nil, nil, err
+			}
+			if dbVal == nil {
+				continue
+			}
+		} else {
+			_, key = splitDataKey(scanner.dbItr.Key())
+			dbVal = scanner.dbItr.Value()
+		}
+		value, version := statedb.DecodeValue(dbVal)
+		doc, err := unmarshalDocument(value)
+		if err != nil || !scanner.query.selector.match(doc) {
+			continue
+		}
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: scanner.namespace, Key: key},
+			VersionedValue: statedb.VersionedValue{Value: value, Version: version}}, doc, nil
+	}
+}
+
+func (scanner *queryScanner) applyFields(kv *statedb.VersionedKV, doc map[string]interface{}) error {
+	if len(scanner.query.fields) == 0 {
+		return nil
+	}
+	value, err := json.Marshal(project(doc, scanner.query.fields))
+	if err != nil {
+		return errors.Wrap(err, "error marshalling the fields of the query result")
+	}
+	kv.Value = value
+	return nil
+}
+
+func (scanner *queryScanner) Close() {
+	scanner.dbItr.Release()
+}
+
+// sortedScanner implements ResultsIterator for the results of a query with a sort,
+// which are all collected and sorted before being returned
+type sortedScanner struct {
+	results []*statedb.VersionedKV
+}
+
+type sortableResult struct {
+	kv  *statedb.VersionedKV
+	doc map[string]interface{}
+}
+
+func newSortedScanner(scanner *queryScanner, q *query) (*sortedScanner, error) {
+	var results []*sortableResult
+	for {
+		kv, doc, err := scanner.nextMatch()
+		if err != nil {
+			return nil, err
+		}
+		if kv == nil {
+			break
+		}
+		results = append(results, &sortableResult{kv, doc})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		for _, field := range q.sort {
+			a, aPresent := lookupField(results[i].doc, field.path)
+			b, bPresent := lookupField(results[j].doc, field.path)
+			c := compareForSort(a, aPresent, b, bPresent)
+			if c == 0 {
+				continue
+			}
+			if field.descending {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	sorted := &sortedScanner{}
+	for i := q.skip; i < len(results) && len(sorted.results) < q.limit; i++ {
+		if err := scanner.applyFields(results[i].kv, results[i].doc); err != nil {
+			return nil, err
+		}
+		sorted.results = append(sorted.results, results[i].kv)
+	}
+	return sorted, nil
+}
+
+func (scanner *sortedScanner) Next() (statedb.QueryResult, error) {
+	if len(scanner.results) == 0 {
+		return nil, nil
+	}
+	kv := scanner.results[0]
+	scanner.results = scanner.results[1:]
+	return kv, nil
+}
+
+func (scanner *sortedScanner) Close() {
+	scanner.results = nil
+}
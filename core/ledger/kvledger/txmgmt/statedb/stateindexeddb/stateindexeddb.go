@@ -0,0 +1,589 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateindexeddb
+
+import (
+	"bytes"
+	"path/filepath"
+	"sync"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("stateindexeddb")
+
+// openTimeout bounds the wait for the file lock of a channel db, which is held by the process that has the db open
+const openTimeout = 10 * time.Second
+
+// maxKeyLength bounds the length of the keys, leaving room within bolt.MaxKeySize
+// for the namespace and the indexed values that precede a key in the keys of the records
+const maxKeyLength = 16 * 1024
+
+// iteratorBatchSize is the number of entries that an iterator reads at once
+const iteratorBatchSize = 1000
+
+// The db of a channel is a bbolt file with a single bucket, which holds the following records
+// savePointKey                                               -> height of the save point
+// dataKeyPrefix~namespace~key                                -> encoded value and version
+// indexDefPrefix~namespace~indexname                         -> index definition
+// indexEntryPrefix~namespace~indexname~indexedvalues...key   -> key
+var (
+	stateBucket      = []byte("state")
+	savePointKey     = []byte{0x00}
+	dataKeyPrefix    = []byte{0x01}
+	indexDefPrefix   = []byte{0x02}
+	indexEntryPrefix = []byte{0x03}
+	compositeKeySep  = []byte{0x00}
+	lastKeyIndicator = byte(0x01)
+)
+
+// dbArtifactsDirFilter selects the index definitions packaged with a chaincode. The CouchDB index
+// definitions are honored as well, so that a chaincode gets the same indexes with both state databases
+var dbArtifactsDirFilter = map[string]bool{
+	"META-INF/statedb/indexedboltdb/indexes": true,
+	"META-INF/statedb/couchdb/indexes":       true,
+}
+
+func init() {
+	statedb.RegisterVersionedDBProvider("IndexedBoltDB", func() (statedb.VersionedDBProvider, error) {
+		return NewVersionedDBProvider(), nil
+	})
+}
+
+// VersionedDBProvider implements interface VersionedDBProvider
+type VersionedDBProvider struct {
+	dbPath    string
+	databases map[string]*versionedDB
+	mux       sync.Mutex
+}
+
+// NewVersionedDBProvider instantiates VersionedDBProvider
+func NewVersionedDBProvider() *VersionedDBProvider {
+	dbPath := ledgerconfig.GetStateIndexedBoltDBPath()
+	logger.Debugf("constructing VersionedDBProvider dbPath=%s", dbPath)
+	return &VersionedDBProvider{dbPath: dbPath, databases: make(map[string]*versionedDB)}
+}
+
+// GetDBHandle gets the handle to a named database
+func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.VersionedDB, error) {
+	provider.mux.Lock()
+	defer provider.mux.Unlock()
+	vdb := provider.databases[dbName]
+	if vdb == nil {
+		db, err := provider.openDB(dbName)
+		if err != nil {
+			return nil, err
+		}
+		vdb = newVersionedDB(db, dbName)
+		provider.databases[dbName] = vdb
+	}
+	return vdb, nil
+}
+
+// Close closes the dbs of all the channels
+func (provider *VersionedDBProvider) Close() {
+	provider.mux.Lock()
+	defer provider.mux.Unlock()
+	for dbName, vdb := range provider.databases {
+		if err := vdb.db.Close(); err != nil {
+			logger.Warningf("Error closing the indexed state database of channel [%s]: %s", dbName, err)
+		}
+	}
+	provider.databases = make(map[string]*versionedDB)
+}
+
+// openDB opens the bbolt file of the channel, creating it along with its bucket if needed
+func (provider *VersionedDBProvider) openDB(dbName string) (*bolt.DB, error) {
+	if _, err := util.CreateDirIfMissing(provider.dbPath); err != nil {
+		return nil, errors.Wrapf(err, "error creating the indexed state database directory [%s]", provider.dbPath)
+	}
+	db, err := bolt.Open(filepath.Join(provider.dbPath, dbName), 0600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening the indexed state database of channel [%s]", dbName)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(stateBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, errors.Wrapf(err, "error initializing the indexed state database of channel [%s]", dbName)
+	}
+	return db, nil
+}
+
+// versionedDB implements VersionedDB interface on top of a bbolt db that
+// maintains secondary indexes on the fields of the JSON values
+type versionedDB struct {
+	db     *bolt.DB
+	dbName string
+	// mux guards indexes and serializes the maintenance of the index entries
+	mux     sync.Mutex
+	indexes map[string][]*index
+}
+
+// newVersionedDB constructs an instance of VersionedDB
+func newVersionedDB(db *bolt.DB, dbName string) *versionedDB {
+	return &versionedDB{db: db, dbName: dbName, indexes: make(map[string][]*index)}
+}
+
+// Open implements method in VersionedDB interface
+func (vdb *versionedDB) Open() error {
+	// do nothing because the db is opened by the provider
+	return nil
+}
+
+// Close implements method in VersionedDB interface
+func (vdb *versionedDB) Close() {
+	// do nothing because the db is closed by the provider
+}
+
+// ValidateKeyValue implements method in VersionedDB interface.
+// The length of the keys is bounded, so that the records keyed by them stay within the key size limit of bbolt
+func (vdb *versionedDB) ValidateKeyValue(key string, value []byte) error {
+	if len(key) > maxKeyLength {
+		return errors.Errorf("key of length %d exceeds the maximum length %d", len(key), maxKeyLength)
+	}
+	return nil
+}
+
+// BytesKeySuppoted implements method in VersionedDB interface
+func (vdb *versionedDB) BytesKeySuppoted() bool {
+	return true
+}
+
+// GetState implements method in VersionedDB interface
+func (vdb *versionedDB) GetState(namespace string, key string) (*statedb.VersionedValue, error) {
+	logger.Debugf("GetState(). ns=%s, key=%s", namespace, key)
+	dbVal, err := vdb.get(constructDataKey(namespace, key))
+	if err != nil {
+		return nil, err
+	}
+	if dbVal == nil {
+		return nil, nil
+	}
+	val, ver := statedb.DecodeValue(dbVal)
+	return &statedb.VersionedValue{Value: val, Version: ver}, nil
+}
+
+// GetVersion implements method in VersionedDB interface
+func (vdb *versionedDB) GetVersion(namespace string, key string) (*version.Height, error) {
+	versionedValue, err := vdb.GetState(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	if versionedValue == nil {
+		return nil, nil
+	}
+	return versionedValue.Version, nil
+}
+
+// GetStateMultipleKeys implements method in VersionedDB interface
+func (vdb *versionedDB) GetStateMultipleKeys(namespace string, keys []string) ([]*statedb.VersionedValue, error) {
+	vals := make([]*statedb.VersionedValue, len(keys))
+	for i, key := range keys {
+		val, err := vdb.GetState(namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = val
+	}
+	return vals, nil
+}
+
+// GetStateRangeScanIterator implements method in VersionedDB interface
+// startKey is inclusive
+// endKey is exclusive
+func (vdb *versionedDB) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
+	dbItr := vdb.getIterator(namespaceRange(namespace, startKey, endKey))
+	return &kvScanner{namespace, dbItr}, nil
+}
+
+// ExecuteQuery implements method in VersionedDB interface.
+// The query is a CouchDB style JSON query with a selector and the optional fields, sort, skip and limit.
+// When the selector matches fields for equality that are the leading fields of an index, only the values
+// referenced by the matching index entries are evaluated instead of all the values of the namespace
+func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
+	q, err := parseQuery(query, ledgerconfig.GetQueryLimit())
+	if err != nil {
+		return nil, err
+	}
+	vdb.mux.Lock()
+	indexes, err := vdb.getIndexes(namespace)
+	vdb.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := &queryScanner{vdb: vdb, namespace: namespace, query: q}
+	if idx, prefix := selectIndex(namespace, indexes, q.equalities); idx != nil {
+		logger.Debugf("Channel [%s]: Executing query on namespace [%s] using index [%s]", vdb.dbName, namespace, idx.Name)
+		scanner.dbItr = vdb.getIterator(prefix, prefixEnd(prefix))
+		scanner.viaIndex = true
+	} else {
+		scanner.dbItr = vdb.getIterator(namespaceRange(namespace, "", ""))
+	}
+	if len(q.sort) == 0 {
+		return scanner, nil
+	}
+	defer scanner.Close()
+	return newSortedScanner(scanner, q)
+}
+
+// ApplyUpdates implements method in VersionedDB interface
+func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	vdb.mux.Lock()
+	defer vdb.mux.Unlock()
+	namespaces := batch.GetUpdatedNamespaces()
+	nsIndexes := make(map[string][]*index)
+	for _, ns := range namespaces {
+		indexes, err := vdb.getIndexes(ns)
+		if err != nil {
+			return err
+		}
+		nsIndexes[ns] = indexes
+	}
+	// bbolt syncs the file when the transaction commits
+	return vdb.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(stateBucket)
+		for _, ns := range namespaces {
+			indexes := nsIndexes[ns]
+			updates := batch.GetUpdates(ns)
+			for k, vv := range updates {
+				dataKey := constructDataKey(ns, k)
+				logger.Debugf("Channel [%s]: Applying key(string)=[%s] key(bytes)=[%#v]", vdb.dbName, string(dataKey), dataKey)
+
+				if len(indexes) > 0 {
+					// the entries of the previous value are removed before the ones of the new value are added,
+					// as both values may have the same entries
+					if dbVal := bucket.Get(dataKey); dbVal != nil {
+						val, _ := statedb.DecodeValue(append([]byte{}, dbVal...))
+						for _, entryKey := range constructIndexEntryKeys(ns, indexes, k, val) {
+							if err := bucket.Delete(entryKey); err != nil {
+								return err
+							}
+						}
+					}
+					for _, entryKey := range constructIndexEntryKeys(ns, indexes, k, vv.Value) {
+						if err := bucket.Put(entryKey, []byte(k)); err != nil {
+							return err
+						}
+					}
+				}
+
+				var err error
+				if vv.Value == nil {
+					err = bucket.Delete(dataKey)
+				} else {
+					err = bucket.Put(dataKey, statedb.EncodeValue(vv.Value, vv.Version))
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return bucket.Put(savePointKey, height.ToBytes())
+	})
+}
+
+// GetLatestSavePoint implements method in VersionedDB interface
+func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
+	versionBytes, err := vdb.get(savePointKey)
+	if err != nil {
+		return nil, err
+	}
+	if versionBytes == nil {
+		return nil, nil
+	}
+	version, _ := version.NewHeightFromBytes(versionBytes)
+	return version, nil
+}
+
+// HandleChaincodeDeploy creates the indexes defined in the db artifacts of the chaincode, and indexes
+// the values that are already present in the namespace of the chaincode.
+// Like for CouchDB, the errors caused by bad index definitions are only logged, since the peer
+// can continue in the committing role without the indexes
+func (vdb *versionedDB) HandleChaincodeDeploy(chaincodeDefinition *cceventmgmt.ChaincodeDefinition, dbArtifactsTar []byte) error {
+	logger.Debugf("Entering HandleChaincodeDeploy")
+	if chaincodeDefinition == nil {
+		return errors.Errorf("chaincodeDefinition found nil while creating index on chain=%s", vdb.dbName)
+	}
+	fileEntries, err := ccprovider.ExtractFileEntries(dbArtifactsTar, dbArtifactsDirFilter)
+	if err != nil {
+		logger.Errorf("Error during extracting db artifacts from tar for chaincode=[%s] on chain=[%s]. Error=%s",
+			chaincodeDefinition, vdb.dbName, err)
+		return nil
+	}
+
+	for _, fileEntry := range fileEntries {
+		filename := fileEntry.FileHeader.Name
+		idx, err := parseIndexDefinition(fileEntry.FileContent)
+		if err != nil {
+			logger.Errorf("Error during creation of index from file=[%s] for chaincode=[%s] on chain=[%s]. Error=%s",
+				filename, chaincodeDefinition, vdb.dbName, err)
+			continue
+		}
+		if err := vdb.createIndex(chaincodeDefinition.Name, idx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createIndex stores the definition of the index and indexes the values of the namespace.
+// An index with the same name but different fields is replaced
+func (vdb *versionedDB) createIndex(namespace string, idx *index) error {
+	vdb.mux.Lock()
+	defer vdb.mux.Unlock()
+	indexes, err := vdb.getIndexes(namespace)
+	if err != nil {
+		return err
+	}
+
+	var others []*index
+	var replaced *index
+	for _, existing := range indexes {
+		if existing.Name != idx.Name {
+			others = append(others, existing)
+			continue
+		}
+		if existing.equal(idx) {
+			logger.Debugf("Channel [%s]: Index [%s] already exists on namespace [%s]", vdb.dbName, idx.Name, namespace)
+			return nil
+		}
+		replaced = existing
+	}
+
+	defBytes, err := idx.marshal()
+	if err != nil {
+		return err
+	}
+	err = vdb.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(stateBucket)
+		// the keys are collected before the bucket is modified, since
+		// bbolt does not support modifying a bucket while a cursor iterates over it
+		if replaced != nil {
+			var staleKeys [][]byte
+			entryPrefix := replaced.constructEntryPrefix(namespace)
+			scan(bucket, entryPrefix, prefixEnd(entryPrefix), func(k, v []byte) {
+				staleKeys = append(staleKeys, append([]byte{}, k...))
+			})
+			for _, k := range staleKeys {
+				if err := bucket.Delete(k); err != nil {
+					return err
+				}
+			}
+		}
+		var entryKeys [][]byte
+		var keys []string
+		startKey, endKey := namespaceRange(namespace, "", "")
+		scan(bucket, startKey, endKey, func(k, v []byte) {
+			_, key := splitDataKey(k)
+			val, _ := statedb.DecodeValue(append([]byte{}, v...))
+			for _, entryKey := range constructIndexEntryKeys(namespace, []*index{idx}, key, val) {
+				entryKeys = append(entryKeys, entryKey)
+				keys = append(keys, key)
+			}
+		})
+		for i, entryKey := range entryKeys {
+			if err := bucket.Put(entryKey, []byte(keys[i])); err != nil {
+				return err
+			}
+		}
+		return bucket.Put(constructIndexDefKey(namespace, idx.Name), defBytes)
+	})
+	if err != nil {
+		return err
+	}
+	vdb.indexes[namespace] = append(others, idx)
+	logger.Infof("Channel [%s]: Created index [%s] on fields %v of namespace [%s]", vdb.dbName, idx.Name, idx.Fields, namespace)
+	return nil
+}
+
+// getIndexes returns the indexes of the namespace, loading their definitions from the db on first use.
+// The caller is expected to hold the lock
+func (vdb *versionedDB) getIndexes(namespace string) ([]*index, error) {
+	if indexes, ok := vdb.indexes[namespace]; ok {
+		return indexes, nil
+	}
+	var indexes []*index
+	err := vdb.db.View(func(tx *bolt.Tx) error {
+		defPrefix := constructIndexDefKey(namespace, "")
+		var err error
+		scan(tx.Bucket(stateBucket), defPrefix, prefixEnd(defPrefix), func(k, v []byte) {
+			if err != nil {
+				return
+			}
+			var idx *index
+			if idx, err = unmarshalIndex(v); err == nil {
+				indexes = append(indexes, idx)
+			}
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	vdb.indexes[namespace] = indexes
+	return indexes, nil
+}
+
+// get returns a copy of the value of the given key, or nil if the key is not present
+func (vdb *versionedDB) get(key []byte) ([]byte, error) {
+	var val []byte
+	err := vdb.db.View(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(stateBucket).Get(key); v != nil {
+			val = append([]byte{}, v...)
+		}
+		return nil
+	})
+	return val, err
+}
+
+// getIterator returns an iterator over the keys of the range [startKey, endKey)
+func (vdb *versionedDB) getIterator(startKey []byte, endKey []byte) *dbIterator {
+	return &dbIterator{db: vdb.db, nextKey: startKey, endKey: endKey}
+}
+
+// namespaceRange returns the range of the data keys of the namespace between startKey and endKey.
+// An empty endKey selects the keys up to the end of the namespace
+func namespaceRange(namespace string, startKey string, endKey string) ([]byte, []byte) {
+	compositeStartKey := constructDataKey(namespace, startKey)
+	compositeEndKey := constructDataKey(namespace, endKey)
+	if endKey == "" {
+		compositeEndKey[len(compositeEndKey)-1] = lastKeyIndicator
+	}
+	return compositeStartKey, compositeEndKey
+}
+
+// scan calls fn for the keys of the range [startKey, endKey) of the bucket. The keys and
+// values passed to fn are only valid for the life of the transaction
+func scan(bucket *bolt.Bucket, startKey []byte, endKey []byte, fn func(k, v []byte)) {
+	cursor := bucket.Cursor()
+	for k, v := cursor.Seek(startKey); k != nil && bytes.Compare(k, endKey) < 0; k, v = cursor.Next() {
+		fn(k, v)
+	}
+}
+
+func constructDataKey(ns string, key string) []byte {
+	return constructCompositeKey(dataKeyPrefix, ns, key)
+}
+
+func splitDataKey(dataKey []byte) (string, string) {
+	split := bytes.SplitN(dataKey[len(dataKeyPrefix):], compositeKeySep, 2)
+	return string(split[0]), string(split[1])
+}
+
+func constructIndexDefKey(ns string, indexName string) []byte {
+	return constructCompositeKey(indexDefPrefix, ns, indexName)
+}
+
+func constructCompositeKey(prefix []byte, ns string, key string) []byte {
+	k := append(append([]byte{}, prefix...), ns...)
+	k = append(k, compositeKeySep...)
+	return append(k, key...)
+}
+
+// prefixEnd returns the key that follows all the keys starting with the given prefix,
+// which ends either with compositeKeySep or with the terminator of an escaped value
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	end[len(end)-1]++
+	return end
+}
+
+// dbIterator iterates over the keys of a range. It reads the range in batches, each in its own
+// read-only transaction, because bbolt cannot grow the db file or close the db while a read-only
+// transaction is open, and the results iterators of the state database may be left open
+type dbIterator struct {
+	db      *bolt.DB
+	nextKey []byte
+	endKey  []byte
+	batch   []*dbEntry
+	current *dbEntry
+}
+
+type dbEntry struct {
+	key, value []byte
+}
+
+// Next moves to the next key of the range and returns false when the range is exhausted
+func (itr *dbIterator) Next() (bool, error) {
+	if len(itr.batch) == 0 && itr.nextKey != nil {
+		if err := itr.readBatch(); err != nil {
+			return false, err
+		}
+	}
+	if len(itr.batch) == 0 {
+		itr.current = nil
+		return false, nil
+	}
+	itr.current, itr.batch = itr.batch[0], itr.batch[1:]
+	return true, nil
+}
+
+// readBatch reads up to iteratorBatchSize entries from nextKey, and sets nextKey to
+// the key that follows the last entry read, or to nil when the range is exhausted
+func (itr *dbIterator) readBatch() error {
+	return itr.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(stateBucket).Cursor()
+		k, v := cursor.Seek(itr.nextKey)
+		itr.nextKey = nil
+		for ; k != nil && bytes.Compare(k, itr.endKey) < 0; k, v = cursor.Next() {
+			if len(itr.batch) == iteratorBatchSize {
+				itr.nextKey = append([]byte{}, k...)
+				break
+			}
+			itr.batch = append(itr.batch, &dbEntry{key: append([]byte{}, k...), value: append([]byte{}, v...)})
+		}
+		return nil
+	})
+}
+
+// Key returns the current key
+func (itr *dbIterator) Key() []byte {
+	return itr.current.key
+}
+
+// Value returns the current value
+func (itr *dbIterator) Value() []byte {
+	return itr.current.value
+}
+
+// Release discards the entries read ahead
+func (itr *dbIterator) Release() {
+	itr.nextKey, itr.batch, itr.current = nil, nil, nil
+}
+
+type kvScanner struct {
+	namespace string
+	dbItr     *dbIterator
+}
+
+func (scanner *kvScanner) Next() (statedb.QueryResult, error) {
+	if ok, err := scanner.dbItr.Next(); !ok {
+		return nil, err
+	}
+	_, key := splitDataKey(scanner.dbItr.Key())
+	value, version := statedb.DecodeValue(scanner.dbItr.Value())
+	return &statedb.VersionedKV{
+		CompositeKey:   statedb.CompositeKey{Namespace: scanner.namespace, Key: key},
+		VersionedValue: statedb.VersionedValue{Value: value, Version: version}}, nil
+}
+
+func (scanner *kvScanner) Close() {
+	scanner.dbItr.Release()
+}
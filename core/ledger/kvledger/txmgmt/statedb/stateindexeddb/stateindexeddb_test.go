@@ -0,0 +1,304 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateindexeddb
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/ledgertests/kvledger/txmgmt/statedb/stateindexeddb")
+	os.Exit(m.Run())
+}
+
+func TestRegistered(t *testing.T) {
+	assert.Contains(t, statedb.RegisteredVersionedDBProviders(), "IndexedBoltDB")
+}
+
+func TestBasicRW(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestBasicRW(t, env.DBProvider)
+}
+
+func TestMultiDBBasicRW(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestMultiDBBasicRW(t, env.DBProvider)
+}
+
+func TestDeletes(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestDeletes(t, env.DBProvider)
+}
+
+func TestIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestIterator(t, env.DBProvider)
+}
+
+func TestQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestQuery(t, env.DBProvider)
+}
+
+func TestGetStateMultipleKeys(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestGetStateMultipleKeys(t, env.DBProvider)
+}
+
+func TestGetVersion(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestGetVersion(t, env.DBProvider)
+}
+
+func TestHandleChaincodeDeploy(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testinit")
+	assert.NoError(t, err)
+	vdb := db.(*versionedDB)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"color":"blue","size":1,"owner":"tom"}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte(`{"color":"blue","size":2,"owner":"fred"}`), version.NewHeight(1, 2))
+	batch.Put("ns1", "key3", []byte(`{"color":"green","size":3,"owner":"fred"}`), version.NewHeight(1, 3))
+	batch.Put("ns1", "key4", []byte(`not a json value`), version.NewHeight(1, 4))
+	batch.Put("ns2", "key1", []byte(`{"color":"blue","size":1,"owner":"fred"}`), version.NewHeight(1, 5))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 5)))
+
+	dbArtifactsTarBytes := createTarBytesForTest(t, []*testFile{
+		{"META-INF/statedb/couchdb/indexes/indexOwner.json", `{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`},
+		{"META-INF/statedb/indexedboltdb/indexes/indexColorSize.json", `{"index":{"fields":[{"color":"desc"},{"size":"desc"}]},"name":"indexColorSize"}`},
+		{"META-INF/statedb/indexedboltdb/indexes/badIndex.json", `{"index":{"fields":[]},"name":"badIndex"}`},
+		{"META-INF/statedb/couchdb/collections/coll1/indexes/indexColl.json", `{"index":{"fields":["owner"]},"name":"indexColl"}`},
+	})
+	handleDefinition := db.(cceventmgmt.ChaincodeLifecycleEventListener)
+	chaincodeDef := &cceventmgmt.ChaincodeDefinition{Name: "ns1", Hash: nil, Version: ""}
+	assert.NoError(t, handleDefinition.HandleChaincodeDeploy(chaincodeDef, dbArtifactsTarBytes))
+	// deploying the same indexes again is a noop
+	assert.NoError(t, handleDefinition.HandleChaincodeDeploy(chaincodeDef, dbArtifactsTarBytes))
+	assert.NoError(t, handleDefinition.HandleChaincodeDeploy(chaincodeDef, nil))
+	assert.NoError(t, handleDefinition.HandleChaincodeDeploy(chaincodeDef, []byte(`This is a really bad tar file`)))
+	assert.Error(t, handleDefinition.HandleChaincodeDeploy(nil, dbArtifactsTarBytes))
+
+	indexes, err := vdb.getIndexes("ns1")
+	assert.NoError(t, err)
+	assert.Equal(t, []*index{
+		{Name: "indexOwner", Fields: []string{"owner"}},
+		{Name: "indexColorSize", Fields: []string{"color", "size"}},
+	}, indexes)
+	assert.Equal(t, []string{"key2", "key3", "key1"}, indexEntries(t, vdb, "ns1", "indexOwner"))
+	assert.Equal(t, []string{"key1", "key2", "key3"}, indexEntries(t, vdb, "ns1", "indexColorSize"))
+
+	// the index entries follow the updates of the values
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"color":"blue","size":1,"owner":"fred"}`), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key2", version.NewHeight(2, 2))
+	batch.Put("ns1", "key3", []byte(`{"color":"green","owner":"jerry"}`), version.NewHeight(2, 3))
+	batch.Put("ns1", "key5", []byte(`{"color":"green","size":5,"owner":"fred"}`), version.NewHeight(2, 4))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 4)))
+	assert.Equal(t, []string{"key1", "key5", "key3"}, indexEntries(t, vdb, "ns1", "indexOwner"))
+	assert.Equal(t, []string{"key1", "key5"}, indexEntries(t, vdb, "ns1", "indexColorSize"))
+
+	testQuery(t, db, "ns1", `{"selector":{"owner":"fred"}}`, []string{"key1", "key5"})
+	testQuery(t, db, "ns1", `{"selector":{"owner":"jerry"}}`, []string{"key3"})
+	testQuery(t, db, "ns1", `{"selector":{"color":"green","size":{"$gt":2}}}`, []string{"key5"})
+	testQuery(t, db, "ns1", `{"selector":{"$and":[{"color":{"$eq":"blue"}},{"size":1}]}}`, []string{"key1"})
+	testQuery(t, db, "ns1", `{"selector":{"owner":"fred"},"sort":[{"size":"desc"}]}`, []string{"key5", "key1"})
+	testQuery(t, db, "ns1", `{"selector":{"owner":{"$in":["fred","jerry"]}},"sort":["owner"],"skip":1,"limit":1}`, []string{"key5"})
+	testQuery(t, db, "ns1", `{"selector":{"size":{"$exists":false}}}`, []string{"key3"})
+	testQuery(t, db, "ns1", `{"selector":{"owner":{"$regex":"^je"}}}`, []string{"key3"})
+	testQuery(t, db, "ns2", `{"selector":{"owner":"fred"}}`, []string{"key1"})
+
+	itr, err := db.ExecuteQuery("ns1", `{"selector":{"owner":"jerry"},"fields":["owner"]}`)
+	assert.NoError(t, err)
+	result, err := itr.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"owner":"jerry"}`, string(result.(*statedb.VersionedKV).Value))
+	assert.Equal(t, version.NewHeight(2, 3), result.(*statedb.VersionedKV).Version)
+	itr.Close()
+
+	for _, query := range []string{
+		`{"fields":["owner"]}`,
+		`{"selector":{"owner":{"$unknown":1}}}`,
+		`{"selector":{"$unknown":[]}}`,
+		`{"selector":{"owner":"fred"},"sort":[{"size":"up"}]}`,
+		`{"selector":{"owner":"fred"},"limit":-1}`,
+		`{"selector":{"owner":"fred"},"use_index":"indexOwner"}`,
+	} {
+		_, err := db.ExecuteQuery("ns1", query)
+		assert.Error(t, err, "query %s", query)
+	}
+}
+
+func TestIndexesReloaded(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testreload")
+	assert.NoError(t, err)
+	dbArtifactsTarBytes := createTarBytesForTest(t, []*testFile{
+		{"META-INF/statedb/indexedboltdb/indexes/indexOwner.json", `{"index":{"fields":["owner"]},"name":"indexOwner"}`},
+	})
+	assert.NoError(t, db.(cceventmgmt.ChaincodeLifecycleEventListener).HandleChaincodeDeploy(
+		&cceventmgmt.ChaincodeDefinition{Name: "ns1"}, dbArtifactsTarBytes))
+
+	// the handle of a reopened db loads the definitions of the indexes from the db
+	env.DBProvider.Close()
+	env.DBProvider = NewVersionedDBProvider()
+	db, err = env.DBProvider.GetDBHandle("testreload")
+	assert.NoError(t, err)
+	vdb := db.(*versionedDB)
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"owner":"fred"}`), version.NewHeight(1, 1))
+	assert.NoError(t, vdb.ApplyUpdates(batch, version.NewHeight(1, 1)))
+	assert.Equal(t, []string{"key1"}, indexEntries(t, vdb, "ns1", "indexOwner"))
+	savePoint, err := vdb.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(1, 1), savePoint)
+}
+
+func TestIteratorBatches(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testiteratorbatches")
+	assert.NoError(t, err)
+	numKeys := 2*iteratorBatchSize + 1
+	batch := statedb.NewUpdateBatch()
+	for i := 0; i < numKeys; i++ {
+		batch.Put("ns1", fmt.Sprintf("key%05d", i), []byte(`{"owner":"fred"}`), version.NewHeight(1, uint64(i)))
+	}
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, uint64(numKeys))))
+
+	itr, err := db.GetStateRangeScanIterator("ns1", "", "")
+	assert.NoError(t, err)
+	// the db can be updated, and closed, while an iterator is open
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns2", "key1", bytes.Repeat([]byte("x"), 10*1024*1024), version.NewHeight(2, 1))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 1)))
+	for i := 0; i < numKeys; i++ {
+		result, err := itr.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("key%05d", i), result.(*statedb.VersionedKV).Key)
+	}
+	result, err := itr.Next()
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+
+	_, err = db.ExecuteQuery("ns1", `{"selector":{"owner":"fred"}}`)
+	assert.NoError(t, err)
+	env.DBProvider.Close()
+	env.DBProvider = NewVersionedDBProvider()
+}
+
+func TestValidateKeyValue(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testvalidatekeyvalue")
+	assert.NoError(t, err)
+	assert.NoError(t, db.ValidateKeyValue(string(make([]byte, maxKeyLength)), nil))
+	assert.EqualError(t, db.ValidateKeyValue(string(make([]byte, maxKeyLength+1)), nil),
+		"key of length 16385 exceeds the maximum length 16384")
+}
+
+func TestIndexValueEncoding(t *testing.T) {
+	doc, err := unmarshalDocument([]byte(`{"a":1,"b":1.0,"c":"1","d":true,"e":null,"f":[1],"g":{"h":"x\u0000"}}`))
+	assert.NoError(t, err)
+	encoded := func(field string) []byte {
+		v, ok := lookupField(doc, splitFieldPath(field))
+		assert.True(t, ok)
+		e, ok := encodeIndexValue(v)
+		if !ok {
+			return nil
+		}
+		return e
+	}
+	assert.Equal(t, encoded("a"), encoded("b"))
+	assert.NotEqual(t, encoded("a"), encoded("c"))
+	assert.Equal(t, []byte{'b', '1', 0x00, 0x01}, encoded("d"))
+	assert.Equal(t, []byte{'z', 0x00, 0x01}, encoded("e"))
+	assert.Nil(t, encoded("f"))
+	assert.Equal(t, []byte{'s', 'x', 0x00, 0xff, 0x00, 0x01}, encoded("g.h"))
+
+	// the long strings are encoded by their hash
+	long := strings.Repeat("x", maxIndexedStringLength+1)
+	doc, err = unmarshalDocument([]byte(`{"a":"` + long + `","b":"` + long + `","c":"` + long[1:] + `"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, byte('h'), encoded("a")[0])
+	assert.Equal(t, encoded("a"), encoded("b"))
+	assert.Equal(t, append(append([]byte{'s'}, long[1:]...), 0x00, 0x01), encoded("c"))
+}
+
+func testQuery(t *testing.T, db statedb.VersionedDB, namespace, query string, expectedKeys []string) {
+	itr, err := db.ExecuteQuery(namespace, query)
+	assert.NoError(t, err, "query %s", query)
+	defer itr.Close()
+	var keys []string
+	for {
+		result, err := itr.Next()
+		assert.NoError(t, err)
+		if result == nil {
+			break
+		}
+		keys = append(keys, result.(*statedb.VersionedKV).Key)
+	}
+	assert.Equal(t, expectedKeys, keys, "query %s", query)
+}
+
+func indexEntries(t *testing.T, vdb *versionedDB, namespace, indexName string) []string {
+	prefix := (&index{Name: indexName}).constructEntryPrefix(namespace)
+	itr := vdb.getIterator(prefix, prefixEnd(prefix))
+	defer itr.Release()
+	var keys []string
+	for {
+		ok, err := itr.Next()
+		assert.NoError(t, err)
+		if !ok {
+			break
+		}
+		keys = append(keys, string(itr.Value()))
+	}
+	return keys
+}
+
+type testFile struct {
+	name, body string
+}
+
+func createTarBytesForTest(t *testing.T, testFiles []*testFile) []byte {
+	buffer := new(bytes.Buffer)
+	tarWriter := tar.NewWriter(buffer)
+	for _, file := range testFiles {
+		tarHeader := &tar.Header{
+			Name: file.name,
+			Mode: 0600,
+			Size: int64(len(file.body)),
+		}
+		assert.NoError(t, tarWriter.WriteHeader(tarHeader))
+		_, err := tarWriter.Write([]byte(file.body))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tarWriter.Close())
+	return buffer.Bytes()
+}
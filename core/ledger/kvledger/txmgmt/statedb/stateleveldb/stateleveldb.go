@@ -23,6 +23,12 @@ var compositeKeySep = []byte{0x00}
 var lastKeyIndicator = byte(0x01)
 var savePointKey = []byte{0x00}
 
+func init() {
+	statedb.RegisterVersionedDBProvider("goleveldb", func() (statedb.VersionedDBProvider, error) {
+		return NewVersionedDBProvider(), nil
+	})
+}
+
 // VersionedDBProvider implements interface VersionedDBProvider
 type VersionedDBProvider struct {
 	dbProvider *leveldbhelper.Provider
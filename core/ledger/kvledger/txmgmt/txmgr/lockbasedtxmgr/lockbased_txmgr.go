@@ -6,6 +6,8 @@ SPDX-License-Identifier: Apache-2.0
 package lockbasedtxmgr
 
 import (
+	"bytes"
+	"sort"
 	"sync"
 
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
@@ -16,7 +18,9 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/valimpl"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
 )
 
 var logger = flogging.MustGetLogger("lockbasedtxmgr")
@@ -128,6 +132,49 @@ func (txmgr *LockBasedTxMgr) Commit() error {
 	return nil
 }
 
+// NewCommitEvent implements method in interface `txmgmt.TxMgr`
+func (txmgr *LockBasedTxMgr) NewCommitEvent() *ledger.CommitEvent {
+	if txmgr.batch == nil {
+		panic("validateAndPrepare() method should have been called before calling NewCommitEvent()")
+	}
+	block := txmgr.currentBlock
+	event := &ledger.CommitEvent{
+		LedgerID:      txmgr.ledgerid,
+		BlockNum:      block.Header.Number,
+		PubUpdates:    make(map[string][]*kvrwset.KVWrite),
+		PvtDataHashes: make(map[string]map[string][]*kvrwset.KVWriteHash),
+	}
+	for _, namespace := range txmgr.batch.PubUpdates.GetUpdatedNamespaces() {
+		var kvwrites []*kvrwset.KVWrite
+		for key, versionedValue := range txmgr.batch.PubUpdates.GetUpdates(namespace) {
+			kvwrites = append(kvwrites, &kvrwset.KVWrite{Key: key, IsDelete: versionedValue.Value == nil, Value: versionedValue.Value})
+		}
+		sort.Slice(kvwrites, func(i, j int) bool { return kvwrites[i].Key < kvwrites[j].Key })
+		event.PubUpdates[namespace] = kvwrites
+	}
+	for namespace, nsBatch := range txmgr.batch.HashUpdates.UpdateMap {
+		collHashes := make(map[string][]*kvrwset.KVWriteHash)
+		for _, coll := range nsBatch.GetCollectionNames() {
+			var kvwriteHashes []*kvrwset.KVWriteHash
+			for keyHash, versionedValue := range nsBatch.GetUpdates(coll) {
+				kvwriteHashes = append(kvwriteHashes,
+					&kvrwset.KVWriteHash{KeyHash: []byte(keyHash), IsDelete: versionedValue.Value == nil, ValueHash: versionedValue.Value})
+			}
+			sort.Slice(kvwriteHashes, func(i, j int) bool {
+				return bytes.Compare(kvwriteHashes[i].KeyHash, kvwriteHashes[j].KeyHash) < 0
+			})
+			collHashes[coll] = kvwriteHashes
+		}
+		event.PvtDataHashes[namespace] = collHashes
+	}
+	txsFilter := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	event.TxValidationFlags = make([]peer.TxValidationCode, len(txsFilter))
+	for i := range txsFilter {
+		event.TxValidationFlags[i] = txsFilter.Flag(i)
+	}
+	return event
+}
+
 // Rollback implements method in interface `txmgmt.TxMgr`
 func (txmgr *LockBasedTxMgr) Rollback() {
 	txmgr.batch = nil
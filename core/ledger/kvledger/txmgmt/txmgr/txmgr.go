@@ -29,6 +29,9 @@ type TxMgr interface {
 	GetLastSavepoint() (*version.Height, error)
 	ShouldRecover(lastAvailableBlock uint64) (bool, uint64, error)
 	CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtData) error
+	// NewCommitEvent constructs the commit event of the block prepared by ValidateAndPrepare,
+	// it is to be called before Commit, which releases the prepared updates
+	NewCommitEvent() *ledger.CommitEvent
 	Commit() error
 	Rollback()
 	Shutdown()
@@ -0,0 +1,190 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebasedval
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/valinternal"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+// blockWrites collects the keys written by the transactions of a block that have been analyzed so far
+type blockWrites struct {
+	pubKeys    map[statedb.CompositeKey]struct{}
+	pubNsKeys  map[string][]string
+	hashedKeys map[privacyenabledstate.HashedCompositeKey]struct{}
+}
+
+func newBlockWrites() *blockWrites {
+	return &blockWrites{
+		pubKeys:    make(map[statedb.CompositeKey]struct{}),
+		pubNsKeys:  make(map[string][]string),
+		hashedKeys: make(map[privacyenabledstate.HashedCompositeKey]struct{}),
+	}
+}
+
+func (w *blockWrites) add(txRWSet *rwsetutil.TxRwSet) {
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		ns := nsRWSet.NameSpace
+		for _, kvWrite := range nsRWSet.KvRwSet.Writes {
+			compositeKey := statedb.CompositeKey{Namespace: ns, Key: kvWrite.Key}
+			if _, ok := w.pubKeys[compositeKey]; !ok {
+				w.pubKeys[compositeKey] = struct{}{}
+				w.pubNsKeys[ns] = append(w.pubNsKeys[ns], kvWrite.Key)
+			}
+		}
+		for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+			for _, hashedWrite := range collHashedRWSet.HashedRwSet.HashedWrites {
+				w.hashedKeys[privacyenabledstate.HashedCompositeKey{
+					Namespace:      ns,
+					CollectionName: collHashedRWSet.CollectionName,
+					KeyHash:        string(hashedWrite.KeyHash),
+				}] = struct{}{}
+			}
+		}
+	}
+}
+
+// affects returns true if any of the collected writes is read by the given transaction, either as a key
+// of its public or hashed read-sets or as a key within the bounds of one of its range queries
+func (w *blockWrites) affects(txRWSet *rwsetutil.TxRwSet) bool {
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		ns := nsRWSet.NameSpace
+		for _, kvRead := range nsRWSet.KvRwSet.Reads {
+			if _, ok := w.pubKeys[statedb.CompositeKey{Namespace: ns, Key: kvRead.Key}]; ok {
+				return true
+			}
+		}
+		for _, rqi := range nsRWSet.KvRwSet.RangeQueriesInfo {
+			for _, key := range w.pubNsKeys[ns] {
+				// the end key is considered part of the range, whether or not the iterator was exhausted
+				if key >= rqi.StartKey && (rqi.EndKey == "" || key <= rqi.EndKey) {
+					return true
+				}
+			}
+		}
+		for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+			for _, kvReadHash := range collHashedRWSet.HashedRwSet.HashedReads {
+				if _, ok := w.hashedKeys[privacyenabledstate.HashedCompositeKey{
+					Namespace:      ns,
+					CollectionName: collHashedRWSet.CollectionName,
+					KeyHash:        string(kvReadHash.KeyHash),
+				}]; ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// findIndependentTxs returns, for each transaction of the block, whether none of the preceding transactions writes a key
+// that the transaction reads. Whether the preceding transactions are valid or not, the validation of such a transaction
+// against the committed state alone gives the same result as against the committed state and the updates of the
+// preceding valid transactions
+func findIndependentTxs(block *valinternal.Block) []bool {
+	independent := make([]bool, len(block.Txs))
+	writes := newBlockWrites()
+	for i, tx := range block.Txs {
+		independent[i] = !writes.affects(tx.RWSet)
+		writes.add(tx.RWSet)
+	}
+	return independent
+}
+
+// txValidationResult is the outcome of the validation of an independent transaction, and the
+// updates of the transaction if it is valid
+type txValidationResult struct {
+	validationCode peer.TxValidationCode
+	updates        *valinternal.PubAndHashUpdates
+	err            error
+}
+
+// validateAndPrepareBatchInParallel validates the independent transactions of the block in parallel, and prepares their updates,
+// before validating the other transactions sequentially against the updates of the preceding valid transactions.
+// The validation codes and the updates are the same as the ones of a sequential validation
+func (v *Validator) validateAndPrepareBatchInParallel(block *valinternal.Block) (*valinternal.PubAndHashUpdates, error) {
+	independent := findIndependentTxs(block)
+	results := make([]*txValidationResult, len(block.Txs))
+	// the updates are never modified during the parallel validation, which makes them safe for concurrent use
+	noUpdates := valinternal.NewPubAndHashUpdates()
+
+	workers := v.workers
+	if workers < 1 {
+		workers = 1
+	}
+	txIndexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range txIndexes {
+				results[i] = v.validateIndependentTx(block.Num, block.Txs[i], noUpdates)
+			}
+		}()
+	}
+	numIndependentTxs := 0
+	for i := range block.Txs {
+		if independent[i] {
+			txIndexes <- i
+			numIndependentTxs++
+		}
+	}
+	close(txIndexes)
+	wg.Wait()
+
+	logger.Debugf("Block [%d]: validated in parallel %d transactions out of %d", block.Num, numIndependentTxs, len(block.Txs))
+
+	updates := valinternal.NewPubAndHashUpdates()
+	for i, tx := range block.Txs {
+		var validationCode peer.TxValidationCode
+		if independent[i] {
+			if results[i].err != nil {
+				return nil, results[i].err
+			}
+			validationCode = results[i].validationCode
+			if validationCode == peer.TxValidationCode_VALID {
+				updates.Merge(results[i].updates)
+			}
+		} else {
+			var err error
+			if validationCode, err = v.validateTx(tx.RWSet, updates); err != nil {
+				return nil, err
+			}
+			if validationCode == peer.TxValidationCode_VALID {
+				updates.ApplyWriteSet(tx.RWSet, version.NewHeight(block.Num, uint64(tx.IndexInBlock)))
+			}
+		}
+
+		tx.ValidationCode = validationCode
+		if validationCode == peer.TxValidationCode_VALID {
+			logger.Debugf("Block [%d] Transaction index [%d] TxId [%s] marked as valid by state validator", block.Num, tx.IndexInBlock, tx.ID)
+		} else {
+			logger.Warningf("Block [%d] Transaction index [%d] TxId [%s] marked as invalid by state validator. Reason code [%s]",
+				block.Num, tx.IndexInBlock, tx.ID, validationCode.String())
+		}
+	}
+	return updates, nil
+}
+
+func (v *Validator) validateIndependentTx(blockNum uint64, tx *valinternal.Transaction, noUpdates *valinternal.PubAndHashUpdates) *txValidationResult {
+	validationCode, err := v.validateTx(tx.RWSet, noUpdates)
+	if err != nil {
+		return &txValidationResult{err: err}
+	}
+	result := &txValidationResult{validationCode: validationCode}
+	if validationCode == peer.TxValidationCode_VALID {
+		result.updates = valinternal.NewPubAndHashUpdates()
+		result.updates.ApplyWriteSet(tx.RWSet, version.NewHeight(blockNum, uint64(tx.IndexInBlock)))
+	}
+	return result
+}
@@ -0,0 +1,227 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebasedval
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/valinternal"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindIndependentTxs(t *testing.T) {
+	writeKey1 := rwsetutil.NewRWSetBuilder()
+	writeKey1.AddToWriteSet("ns1", "key1", []byte("value1"))
+
+	readKey1 := rwsetutil.NewRWSetBuilder()
+	readKey1.AddToReadSet("ns1", "key1", version.NewHeight(1, 0))
+
+	readKey1OtherNs := rwsetutil.NewRWSetBuilder()
+	readKey1OtherNs.AddToReadSet("ns2", "key1", version.NewHeight(1, 0))
+
+	rangeWithKey1 := rwsetutil.NewRWSetBuilder()
+	rangeWithKey1.AddToRangeQuerySet("ns1", &kvrwset.RangeQueryInfo{StartKey: "key0", EndKey: "key2", ItrExhausted: true})
+
+	rangeWithoutKey1 := rwsetutil.NewRWSetBuilder()
+	rangeWithoutKey1.AddToRangeQuerySet("ns1", &kvrwset.RangeQueryInfo{StartKey: "key2", EndKey: "key5", ItrExhausted: true})
+
+	rangeEndingWithKey1 := rwsetutil.NewRWSetBuilder()
+	rangeEndingWithKey1.AddToRangeQuerySet("ns1", &kvrwset.RangeQueryInfo{StartKey: "key0", EndKey: "key1", ItrExhausted: true})
+
+	writeHashedKey := rwsetutil.NewRWSetBuilder()
+	require.NoError(t, writeHashedKey.AddToPvtAndHashedWriteSet("ns1", "coll1", "pvtKey", []byte("value")))
+
+	readHashedKey := rwsetutil.NewRWSetBuilder()
+	require.NoError(t, readHashedKey.AddToHashedReadSet("ns1", "coll1", "pvtKey", version.NewHeight(1, 0)))
+
+	readHashedKeyOtherColl := rwsetutil.NewRWSetBuilder()
+	require.NoError(t, readHashedKeyOtherColl.AddToHashedReadSet("ns1", "coll2", "pvtKey", version.NewHeight(1, 0)))
+
+	testCases := []struct {
+		name        string
+		builders    []*rwsetutil.RWSetBuilder
+		independent []bool
+	}{
+		{"read after write", []*rwsetutil.RWSetBuilder{writeKey1, readKey1}, []bool{true, false}},
+		{"write after read", []*rwsetutil.RWSetBuilder{readKey1, writeKey1}, []bool{true, true}},
+		{"read of another namespace", []*rwsetutil.RWSetBuilder{writeKey1, readKey1OtherNs}, []bool{true, true}},
+		{"range with written key", []*rwsetutil.RWSetBuilder{writeKey1, rangeWithKey1}, []bool{true, false}},
+		{"range without written key", []*rwsetutil.RWSetBuilder{writeKey1, rangeWithoutKey1}, []bool{true, true}},
+		{"range ending with written key", []*rwsetutil.RWSetBuilder{writeKey1, rangeEndingWithKey1}, []bool{true, false}},
+		{"hashed read after write", []*rwsetutil.RWSetBuilder{writeHashedKey, readHashedKey}, []bool{true, false}},
+		{"hashed read of another collection", []*rwsetutil.RWSetBuilder{writeHashedKey, readHashedKeyOtherColl}, []bool{true, true}},
+		{"transitive", []*rwsetutil.RWSetBuilder{writeKey1, readKey1OtherNs, readKey1}, []bool{true, true, false}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			block := &valinternal.Block{Num: 1}
+			for i, builder := range testCase.builders {
+				block.Txs = append(block.Txs, &valinternal.Transaction{IndexInBlock: i, RWSet: builder.GetTxReadWriteSet()})
+			}
+			assert.Equal(t, testCase.independent, findIndependentTxs(block))
+		})
+	}
+}
+
+func TestParallelValidationMatchesSequentialValidation(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+	populateSyntheticState(db, 50)
+
+	sequentialValidator := &Validator{db: db}
+	parallelValidator := &Validator{db: db, parallelValidation: true, workers: 4}
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		sequentialBlock := generateSyntheticBlock(rnd, 50, 100, rnd.Intn(100))
+		parallelBlock := &valinternal.Block{Num: sequentialBlock.Num}
+		for _, tx := range sequentialBlock.Txs {
+			txCopy := *tx
+			parallelBlock.Txs = append(parallelBlock.Txs, &txCopy)
+		}
+
+		sequentialUpdates, err := sequentialValidator.ValidateAndPrepareBatch(sequentialBlock, true)
+		require.NoError(t, err)
+		parallelUpdates, err := parallelValidator.ValidateAndPrepareBatch(parallelBlock, true)
+		require.NoError(t, err)
+
+		for j, tx := range sequentialBlock.Txs {
+			assert.Equal(t, tx.ValidationCode, parallelBlock.Txs[j].ValidationCode, "validation code of transaction %d of block %d", j, i)
+		}
+		assert.Equal(t, sequentialUpdates, parallelUpdates)
+	}
+}
+
+func TestParallelValidation(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	batch.PubUpdates.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 1))
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 1))
+
+	validator := &Validator{db: db, parallelValidation: true, workers: 2}
+
+	// tx0 is valid and makes tx2 invalid, tx1 is invalid and does not affect tx3
+	rwsetBuilder0 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder0.AddToReadSet("ns1", "key1", version.NewHeight(1, 0))
+	rwsetBuilder0.AddToWriteSet("ns1", "key1", []byte("value1_new"))
+	rwsetBuilder1 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder1.AddToReadSet("ns1", "key2", version.NewHeight(1, 0))
+	rwsetBuilder1.AddToWriteSet("ns1", "key2", []byte("value2_new"))
+	rwsetBuilder2 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder2.AddToReadSet("ns1", "key1", version.NewHeight(1, 0))
+	rwsetBuilder3 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder3.AddToReadSet("ns1", "key2", version.NewHeight(1, 1))
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilder0, rwsetBuilder1, rwsetBuilder2, rwsetBuilder3), []int{1, 2})
+}
+
+// populateSyntheticState commits the keys key0 to key<numKeys-1> of namespace ns1,
+// and their hashes in collection coll1, at the version (1, index of the key)
+func populateSyntheticState(db privacyenabledstate.DB, numKeys int) {
+	batch := privacyenabledstate.NewUpdateBatch()
+	for i := 0; i < numKeys; i++ {
+		key := fmt.Sprintf("key%d", i)
+		batch.PubUpdates.Put("ns1", key, []byte("value"), version.NewHeight(1, uint64(i)))
+		batch.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash(key), util.ComputeStringHash("value"), version.NewHeight(1, uint64(i)))
+	}
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, uint64(numKeys)))
+}
+
+// generateSyntheticBlock generates a block of transactions which read and write keys of the state populated by
+// populateSyntheticState. The reads of a transaction hit, with the given percentage, the keys written by the
+// preceding transactions of the block, and otherwise random keys
+func generateSyntheticBlock(rnd *rand.Rand, numKeys, numTxs, conflictPercent int) *valinternal.Block {
+	block := &valinternal.Block{Num: 2}
+	var writtenKeys []int
+	for i := 0; i < numTxs; i++ {
+		builder := rwsetutil.NewRWSetBuilder()
+		for r := 0; r < 2; r++ {
+			k := rnd.Intn(numKeys)
+			if len(writtenKeys) > 0 && rnd.Intn(100) < conflictPercent {
+				k = writtenKeys[rnd.Intn(len(writtenKeys))]
+			}
+			key := fmt.Sprintf("key%d", k)
+			readVersion := version.NewHeight(1, uint64(k))
+			if rnd.Intn(20) == 0 {
+				// a stale read
+				readVersion = version.NewHeight(0, uint64(k))
+			}
+			switch rnd.Intn(4) {
+			case 0:
+				builder.AddToHashedReadSet("ns1", "coll1", key, readVersion)
+			case 1:
+				rqi := &kvrwset.RangeQueryInfo{StartKey: key, EndKey: key + "\x00", ItrExhausted: true}
+				rqi.SetRawReads([]*kvrwset.KVRead{rwsetutil.NewKVRead(key, readVersion)})
+				builder.AddToRangeQuerySet("ns1", rqi)
+			default:
+				builder.AddToReadSet("ns1", key, readVersion)
+			}
+		}
+		for w := 0; w < 2; w++ {
+			k := rnd.Intn(numKeys)
+			key := fmt.Sprintf("key%d", k)
+			if rnd.Intn(4) == 0 {
+				builder.AddToPvtAndHashedWriteSet("ns1", "coll1", key, []byte(fmt.Sprintf("value_%d", i)))
+			} else {
+				builder.AddToWriteSet("ns1", key, []byte(fmt.Sprintf("value_%d", i)))
+			}
+			writtenKeys = append(writtenKeys, k)
+		}
+		block.Txs = append(block.Txs, &valinternal.Transaction{
+			IndexInBlock:   i,
+			ID:             fmt.Sprintf("txid-%d", i),
+			RWSet:          builder.GetTxReadWriteSet(),
+			ValidationCode: peer.TxValidationCode_VALID,
+		})
+	}
+	return block
+}
+
+func BenchmarkValidateAndPrepareBatch(b *testing.B) {
+	// logging the validation of each transaction would dominate the measurements
+	flogging.SetModuleLevel("statebasedval", "error")
+	defer flogging.SetModuleLevel("statebasedval", "debug")
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(b)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("BenchmarkDB")
+	numKeys := 10000
+	populateSyntheticState(db, numKeys)
+
+	for _, conflictPercent := range []int{0, 10, 50} {
+		for _, workers := range []int{0, 1, 4, 16} {
+			validator := &Validator{db: db, parallelValidation: workers > 0, workers: workers}
+			b.Run(fmt.Sprintf("conflicts=%d%%/workers=%d", conflictPercent, workers), func(b *testing.B) {
+				rnd := rand.New(rand.NewSource(1))
+				blocks := make([]*valinternal.Block, b.N)
+				for i := range blocks {
+					blocks[i] = generateSyntheticBlock(rnd, numKeys, 500, conflictPercent)
+				}
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					if _, err := validator.ValidateAndPrepareBatch(blocks[i], true); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
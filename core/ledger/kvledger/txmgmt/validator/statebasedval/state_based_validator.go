@@ -12,6 +12,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/valinternal"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
 )
@@ -21,12 +22,18 @@ var logger = flogging.MustGetLogger("statebasedval")
 // Validator validates a tx against the latest committed state
 // and preceding valid transactions with in the same block
 type Validator struct {
-	db privacyenabledstate.DB
+	db                 privacyenabledstate.DB
+	parallelValidation bool
+	workers            int
 }
 
 // NewValidator constructs StateValidator
 func NewValidator(db privacyenabledstate.DB) *Validator {
-	return &Validator{db}
+	return &Validator{
+		db:                 db,
+		parallelValidation: ledgerconfig.IsParallelValidationEnabled(),
+		workers:            ledgerconfig.GetParallelValidationWorkers(),
+	}
 }
 
 // preLoadCommittedVersionOfRSet loads committed version of all keys in each
@@ -97,6 +104,10 @@ func (v *Validator) ValidateAndPrepareBatch(block *valinternal.Block, doMVCCVali
 		}
 	}
 
+	if v.parallelValidation && doMVCCValidation {
+		return v.validateAndPrepareBatchInParallel(block)
+	}
+
 	updates := valinternal.NewPubAndHashUpdates()
 	for _, tx := range block.Txs {
 		var validationCode peer.TxValidationCode
@@ -102,3 +102,23 @@ func (u *PubAndHashUpdates) ApplyWriteSet(txRWSet *rwsetutil.TxRwSet, txHeight *
 		}
 	}
 }
+
+// Merge adds the updates present in the other PubAndHashUpdates, which override the existing updates of the same keys
+func (u *PubAndHashUpdates) Merge(other *PubAndHashUpdates) {
+	for _, ns := range other.PubUpdates.GetUpdatedNamespaces() {
+		for key, vv := range other.PubUpdates.GetUpdates(ns) {
+			u.PubUpdates.Update(ns, key, vv)
+		}
+	}
+	for ns, nsBatch := range other.HashUpdates.UpdateMap {
+		for _, coll := range nsBatch.GetCollectionNames() {
+			for keyHash, vv := range nsBatch.GetUpdates(coll) {
+				if vv.Value == nil {
+					u.HashUpdates.UpdateMap.Delete(ns, coll, keyHash, vv.Version)
+				} else {
+					u.HashUpdates.UpdateMap.Put(ns, coll, keyHash, vv.Value, vv.Version)
+				}
+			}
+		}
+	}
+}
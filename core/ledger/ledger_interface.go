@@ -17,16 +17,19 @@ limitations under the License.
 package ledger
 
 import (
+	"time"
+
 	"github.com/golang/protobuf/proto"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
 )
 
 // PeerLedgerProvider provides handle to ledger instances
 type PeerLedgerProvider interface {
-	Initialize(statelisteners StateListeners)
+	Initialize(statelisteners StateListeners, commitListeners []CommitListener)
 	// Create creates a new ledger with the given genesis block.
 	// This function guarantees that the creation of ledger and committing the genesis block would an atomic action
 	// The chain id retrieved from the genesis block is treated as a ledger id
@@ -65,6 +68,17 @@ type PeerLedger interface {
 	// A client can obtain more than one 'HistoryQueryExecutor's for parallel execution.
 	// Any synchronization should be performed at the implementation level if required
 	NewHistoryQueryExecutor() (HistoryQueryExecutor, error)
+	// NewHistoricalQueryExecutor gives handle to a query executor that reads the state as of the given block,
+	// i.e. after the transactions of the block were committed. It requires the history database to be enabled
+	NewHistoricalQueryExecutor(blockNum uint64) (HistoricalQueryExecutor, error)
+	// GetIndexStatus returns the status of the state database indexes of the given chaincode,
+	// as reconciled with the index definitions of the chaincode package
+	GetIndexStatus(namespace string) (*peer.IndexStatusResponse, error)
+	// GetTransactionsByTime returns a page of the transactions whose timestamp is in [from, to), optionally
+	// restricted to the transactions created by the identity whose serialized form hashes to creatorHash (SHA-256).
+	// A zero from or to leaves the range unbounded on that side. The next page is retrieved by passing the bookmark
+	// of the response, which is empty on the last page. It requires the index of the transactions by time to be enabled
+	GetTransactionsByTime(creatorHash []byte, from, to time.Time, bookmark string, limit int) (*peer.TxQueryResponse, error)
 	// GetPvtDataAndBlockByNum returns the block and the corresponding pvt data.
 	// The pvt data is filtered by the list of 'ns/collections' supplied
 	// A nil filter does not filter any results and causes retrieving all the pvt data for the given blockNum
@@ -136,6 +150,34 @@ type HistoryQueryExecutor interface {
 	// GetHistoryForKey retrieves the history of values for a key.
 	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
 	GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error)
+	// GetHistoryForKeyWithOptions retrieves the history of values for a key, restricted to the given block range,
+	// in the given order and up to the given number of results. A nil options is the same as GetHistoryForKey.
+	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
+	GetHistoryForKeyWithOptions(namespace string, key string, options *HistoryQueryOptions) (commonledger.ResultsIterator, error)
+}
+
+// HistoryQueryOptions restricts the results of a history query
+type HistoryQueryOptions struct {
+	// StartBlock is the lowest block number of the returned modifications
+	StartBlock uint64
+	// EndBlock is the highest block number of the returned modifications, 0 means no upper bound
+	EndBlock uint64
+	// NewestFirst returns the modifications from the newest to the oldest one
+	NewestFirst bool
+	// Limit is the maximum number of returned modifications, 0 means no limit
+	Limit uint32
+}
+
+// HistoricalQueryExecutor executes queries against the state as of a past block height
+type HistoricalQueryExecutor interface {
+	// GetState gets the value for given namespace and key as of the block height of the executor.
+	// A nil value is returned if the key didn't exist, or was deleted, at that height
+	GetState(namespace string, key string) ([]byte, error)
+	// GetStateRangeScanIterator returns an iterator that contains all the key-values between given key ranges
+	// as of the block height of the executor. startKey is included in the results and endKey is excluded.
+	// An empty startKey refers to the first available key and an empty endKey refers to the last available key.
+	// The returned ResultsIterator contains results of type *KV which is defined in protos/ledger/queryresult.
+	GetStateRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error)
 }
 
 // TxSimulator simulates a transaction on a consistent snapshot of the 'as recent state as possible'
@@ -287,3 +329,32 @@ type StateUpdates interface{}
 
 // StateListeners maintains the association between a namespace to its corresponding listener
 type StateListeners map[string]StateListener
+
+// CommitListener allows a custom code to be notified of the state changes caused by every block,
+// across all the namespaces, for instance for mirroring the world state into an external database.
+// Unlike a `StateListener`, a ledger implementation is expected to invoke Function `HandleCommit`
+// after the block and its state and history changes are committed, without holding any lock of the
+// ledger, so that the listener may query the ledger. The blocks recommitted to the state database
+// when the ledger is opened, for instance after a crash or when the state database is rebuilt, are
+// notified again, so a listener may be notified more than once of a block and is expected to tolerate
+// it, for instance by keeping track of the last block number it handled. An error returned by the
+// function does not affect the commit, it is only logged by the ledger implementation.
+// The function is invoked synchronously by the committer and is thus expected to return promptly
+type CommitListener interface {
+	HandleCommit(event *CommitEvent) error
+}
+
+// CommitEvent captures the state changes caused by a committed block
+type CommitEvent struct {
+	// LedgerID is the id of the ledger the block is committed to
+	LedgerID string
+	// BlockNum is the number of the committed block
+	BlockNum uint64
+	// PubUpdates contains the writes to the public state, by namespace, in the order of the keys
+	PubUpdates map[string][]*kvrwset.KVWrite
+	// PvtDataHashes contains the writes to the hashes of the private data, by namespace and
+	// collection, in the order of the key hashes
+	PvtDataHashes map[string]map[string][]*kvrwset.KVWriteHash
+	// TxValidationFlags contains the validation code of every transaction of the block
+	TxValidationFlags []peer.TxValidationCode
+}
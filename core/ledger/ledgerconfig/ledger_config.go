@@ -18,6 +18,8 @@ package ledgerconfig
 
 import (
 	"path/filepath"
+	"runtime"
+	"time"
 
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/spf13/viper"
@@ -32,10 +34,21 @@ func IsCouchDBEnabled() bool {
 	return false
 }
 
+// GetStateDatabase returns the name of the state database backend to be used,
+// which defaults to "goleveldb"
+func GetStateDatabase() string {
+	stateDatabase := viper.GetString("ledger.state.stateDatabase")
+	if stateDatabase == "" {
+		return "goleveldb"
+	}
+	return stateDatabase
+}
+
 const confPeerFileSystemPath = "peer.fileSystemPath"
 const confLedgersData = "ledgersData"
 const confLedgerProvider = "ledgerProvider"
 const confStateleveldb = "stateLeveldb"
+const confStateIndexedBoltdb = "stateIndexedBoltdb"
 const confHistoryLeveldb = "historyLeveldb"
 const confPvtWritesetStore = "pvtWritesetStore"
 const confChains = "chains"
@@ -45,6 +58,17 @@ const confEnableHistoryDatabase = "ledger.history.enableHistoryDatabase"
 const confMaxBatchSize = "ledger.state.couchDBConfig.maxBatchUpdateSize"
 const confAutoWarmIndexes = "ledger.state.couchDBConfig.autoWarmIndexes"
 const confWarmIndexesAfterNBlocks = "ledger.state.couchDBConfig.warmIndexesAfterNBlocks"
+const confStateCacheSize = "ledger.state.couchDBConfig.cacheSize"
+const confParallelValidation = "ledger.state.parallelValidation.enabled"
+const confParallelValidationWorkers = "ledger.state.parallelValidation.workers"
+const confBlockStoreCompression = "ledger.blockchain.compression"
+const confBlockStoreChecksums = "ledger.blockchain.checksums"
+const confBlockStoreTieringBackend = "ledger.blockchain.tiering.backend"
+const confBlockStoreTieringLocation = "ledger.blockchain.tiering.location"
+const confBlockStoreTieringMaxAge = "ledger.blockchain.tiering.maxAge"
+const confBlockStoreTieringCacheSize = "ledger.blockchain.tiering.cacheSize"
+const confTierCache = "tierCache"
+const confEnableTxTimeIndex = "ledger.blockchain.enableTxTimeIndex"
 
 // GetRootPath returns the filesystem path.
 // All ledger related contents are expected to be stored under this path
@@ -63,6 +87,11 @@ func GetStateLevelDBPath() string {
 	return filepath.Join(GetRootPath(), confStateleveldb)
 }
 
+// GetStateIndexedBoltDBPath returns the filesystem path that is used to maintain the indexed state bolt dbs
+func GetStateIndexedBoltDBPath() string {
+	return filepath.Join(GetRootPath(), confStateIndexedBoltdb)
+}
+
 // GetHistoryLevelDBPath returns the filesystem path that is used to maintain the history level db
 func GetHistoryLevelDBPath() string {
 	return filepath.Join(GetRootPath(), confHistoryLeveldb)
@@ -78,6 +107,12 @@ func GetBlockStorePath() string {
 	return filepath.Join(GetRootPath(), confChains)
 }
 
+// GetBlockStoreTierCachePath returns the filesystem path that is used for caching the block files
+// fetched back from the archive backend
+func GetBlockStoreTierCachePath() string {
+	return filepath.Join(GetRootPath(), confTierCache)
+}
+
 // GetPvtdataStorePath returns the filesystem path that is used for permanent storage of private write-sets
 func GetPvtdataStorePath() string {
 	return filepath.Join(GetRootPath(), confPvtdataStore)
@@ -88,6 +123,58 @@ func GetMaxBlockfileSize() int {
 	return 64 * 1024 * 1024
 }
 
+//GetBlockStoreCompression exposes the blockchain.compression variable, the compression
+//algorithm of the blocks appended to the block files, either "none" or "snappy"
+func GetBlockStoreCompression() string {
+	compression := viper.GetString(confBlockStoreCompression)
+	// if compression was unset, default to none
+	if compression == "" {
+		compression = "none"
+	}
+	return compression
+}
+
+//GetBlockStoreChecksums exposes the blockchain.checksums variable, which tells whether a checksum
+//is stored along with each uncompressed block appended to the block files
+func GetBlockStoreChecksums() bool {
+	return viper.GetBool(confBlockStoreChecksums)
+}
+
+//GetBlockStoreTieringBackend exposes the blockchain.tiering.backend variable, the name of the
+//archive backend the old block files are offloaded to. An empty name disables the offloading
+func GetBlockStoreTieringBackend() string {
+	return viper.GetString(confBlockStoreTieringBackend)
+}
+
+//GetBlockStoreTieringLocation exposes the blockchain.tiering.location variable, the location
+//where the archive backend stores the offloaded block files
+func GetBlockStoreTieringLocation() string {
+	return viper.GetString(confBlockStoreTieringLocation)
+}
+
+//GetBlockStoreTieringMaxAge exposes the blockchain.tiering.maxAge variable, the time since a block
+//file was last written to after which it is offloaded. Zero disables the automatic offloading
+func GetBlockStoreTieringMaxAge() time.Duration {
+	return viper.GetDuration(confBlockStoreTieringMaxAge)
+}
+
+//GetBlockStoreTieringCacheSize exposes the blockchain.tiering.cacheSize variable, the maximum
+//number of offloaded block files of a channel cached on the local disk
+func GetBlockStoreTieringCacheSize() int {
+	cacheSize := viper.GetInt(confBlockStoreTieringCacheSize)
+	// if cacheSize was unset or not positive, default to 10
+	if cacheSize <= 0 {
+		cacheSize = 10
+	}
+	return cacheSize
+}
+
+//IsTxTimeIndexEnabled exposes the blockchain.enableTxTimeIndex variable, which enables
+//the index of the transactions by time and by creator in the block store
+func IsTxTimeIndexEnabled() bool {
+	return viper.GetBool(confEnableTxTimeIndex)
+}
+
 //GetQueryLimit exposes the queryLimit variable
 func GetQueryLimit() int {
 	queryLimit := viper.GetInt(confQueryLimit)
@@ -145,3 +232,31 @@ func GetWarmIndexesAfterNBlocks() int {
 	}
 	return warmAfterNBlocks
 }
+
+//GetStateCacheSize exposes the cacheSize variable, the maximum number of
+//keys per namespace whose values are cached in front of CouchDB, or 0 to disable the cache
+func GetStateCacheSize() int {
+	cacheSize := viper.GetInt(confStateCacheSize)
+	// if cacheSize was unset, default to 10000
+	if !viper.IsSet(confStateCacheSize) {
+		cacheSize = 10000
+	}
+	return cacheSize
+}
+
+//IsParallelValidationEnabled exposes the parallelValidation.enabled variable, which enables the
+//MVCC validation in parallel of the transactions of a block that do not depend on each other
+func IsParallelValidationEnabled() bool {
+	return viper.GetBool(confParallelValidation)
+}
+
+//GetParallelValidationWorkers exposes the parallelValidation.workers variable, the number of
+//transactions of a block validated in parallel
+func GetParallelValidationWorkers() int {
+	workers := viper.GetInt(confParallelValidationWorkers)
+	// if workers was unset or not positive, default to the number of CPUs
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return workers
+}
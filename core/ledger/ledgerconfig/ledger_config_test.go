@@ -17,7 +17,9 @@ limitations under the License.
 package ledgerconfig
 
 import (
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
@@ -46,6 +48,15 @@ func TestIsCouchDBEnabled(t *testing.T) {
 	testutil.AssertEquals(t, updatedValue, true) //test config returns true
 }
 
+func TestGetStateDatabase(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.state.stateDatabase", "")
+	testutil.AssertEquals(t, GetStateDatabase(), "goleveldb")
+	viper.Set("ledger.state.stateDatabase", "IndexedBoltDB")
+	testutil.AssertEquals(t, GetStateDatabase(), "IndexedBoltDB")
+}
+
 func TestLedgerConfigPathDefault(t *testing.T) {
 	setUpCoreYAMLConfig()
 	testutil.AssertEquals(t,
@@ -57,6 +68,9 @@ func TestLedgerConfigPathDefault(t *testing.T) {
 	testutil.AssertEquals(t,
 		GetStateLevelDBPath(),
 		"/var/hyperledger/production/ledgersData/stateLeveldb")
+	testutil.AssertEquals(t,
+		GetStateIndexedBoltDBPath(),
+		"/var/hyperledger/production/ledgersData/stateIndexedBoltdb")
 	testutil.AssertEquals(t,
 		GetHistoryLevelDBPath(),
 		"/var/hyperledger/production/ledgersData/historyLeveldb")
@@ -164,6 +178,82 @@ func TestGetWarmIndexesAfterNBlocks(t *testing.T) {
 	testutil.AssertEquals(t, updatedValue, 10)
 }
 
+func TestGetStateCacheSizeDefault(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defaultValue := GetStateCacheSize()
+	testutil.AssertEquals(t, defaultValue, 10000)
+}
+
+func TestGetStateCacheSizeUnset(t *testing.T) {
+	viper.Reset()
+	defaultValue := GetStateCacheSize()
+	testutil.AssertEquals(t, defaultValue, 10000)
+}
+
+func TestGetStateCacheSize(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.state.couchDBConfig.cacheSize", 0)
+	updatedValue := GetStateCacheSize()
+	testutil.AssertEquals(t, updatedValue, 0)
+}
+
+func TestIsParallelValidationEnabled(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	testutil.AssertEquals(t, IsParallelValidationEnabled(), false)
+	viper.Set("ledger.state.parallelValidation.enabled", true)
+	testutil.AssertEquals(t, IsParallelValidationEnabled(), true)
+}
+
+func TestGetParallelValidationWorkers(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	testutil.AssertEquals(t, GetParallelValidationWorkers(), runtime.NumCPU())
+	viper.Set("ledger.state.parallelValidation.workers", 4)
+	testutil.AssertEquals(t, GetParallelValidationWorkers(), 4)
+}
+
+func TestGetBlockStoreCompression(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	testutil.AssertEquals(t, GetBlockStoreCompression(), "none")
+	viper.Set("ledger.blockchain.compression", "snappy")
+	testutil.AssertEquals(t, GetBlockStoreCompression(), "snappy")
+}
+
+func TestGetBlockStoreChecksums(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	testutil.AssertEquals(t, GetBlockStoreChecksums(), false)
+	viper.Set("ledger.blockchain.checksums", true)
+	testutil.AssertEquals(t, GetBlockStoreChecksums(), true)
+}
+
+func TestGetBlockStoreTiering(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	testutil.AssertEquals(t, GetBlockStoreTieringBackend(), "")
+	testutil.AssertEquals(t, GetBlockStoreTieringMaxAge(), 720*time.Hour)
+	testutil.AssertEquals(t, GetBlockStoreTieringCacheSize(), 10)
+	viper.Set("ledger.blockchain.tiering.backend", "local")
+	viper.Set("ledger.blockchain.tiering.location", "/archive")
+	viper.Set("ledger.blockchain.tiering.maxAge", "1h")
+	viper.Set("ledger.blockchain.tiering.cacheSize", 0)
+	testutil.AssertEquals(t, GetBlockStoreTieringBackend(), "local")
+	testutil.AssertEquals(t, GetBlockStoreTieringLocation(), "/archive")
+	testutil.AssertEquals(t, GetBlockStoreTieringMaxAge(), time.Hour)
+	testutil.AssertEquals(t, GetBlockStoreTieringCacheSize(), 10)
+}
+
+func TestIsTxTimeIndexEnabled(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	testutil.AssertEquals(t, IsTxTimeIndexEnabled(), false) //test default config is false
+	viper.Set("ledger.blockchain.enableTxTimeIndex", true)
+	testutil.AssertEquals(t, IsTxTimeIndexEnabled(), true)
+}
+
 func setUpCoreYAMLConfig() {
 	//call a helper method to load the core.yaml
 	ledgertestutil.SetupCoreYAMLConfig()
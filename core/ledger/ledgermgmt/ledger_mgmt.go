@@ -46,14 +46,14 @@ var lock sync.Mutex
 var initialized bool
 var once sync.Once
 
-// Initialize initializes ledgermgmt
-func Initialize(customTxProcessors customtx.Processors) {
+// Initialize initializes ledgermgmt, the commit listeners being notified of every block committed to the ledgers
+func Initialize(customTxProcessors customtx.Processors, commitListeners ...ledger.CommitListener) {
 	once.Do(func() {
-		initialize(customTxProcessors)
+		initialize(customTxProcessors, commitListeners)
 	})
 }
 
-func initialize(customTxProcessors customtx.Processors) {
+func initialize(customTxProcessors customtx.Processors, commitListeners []ledger.CommitListener) {
 	logger.Info("Initializing ledger mgmt")
 	lock.Lock()
 	defer lock.Unlock()
@@ -65,7 +65,7 @@ func initialize(customTxProcessors customtx.Processors) {
 	if err != nil {
 		panic(fmt.Errorf("Error in instantiating ledger provider: %s", err))
 	}
-	provider.Initialize(kvLedgerStateListeners)
+	provider.Initialize(kvLedgerStateListeners, commitListeners)
 	ledgerProvider = provider
 	logger.Info("ledger mgmt initialized")
 }
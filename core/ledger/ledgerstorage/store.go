@@ -52,10 +52,25 @@ func NewProvider() *Provider {
 		blkstorage.IndexableAttrBlockTxID,
 		blkstorage.IndexableAttrTxValidationCode,
 	}
+	if ledgerconfig.IsTxTimeIndexEnabled() {
+		attrsToIndex = append(attrsToIndex, blkstorage.IndexableAttrTxTime)
+	}
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
-	blockStoreProvider := fsblkstorage.NewProvider(
-		fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize()),
-		indexConfig)
+	blockStoreConf := fsblkstorage.NewConfWithCompression(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize(),
+		ledgerconfig.GetBlockStoreCompression()).WithChecksums(ledgerconfig.GetBlockStoreChecksums())
+	if backendName := ledgerconfig.GetBlockStoreTieringBackend(); backendName != "" {
+		backend, err := fsblkstorage.NewArchiveBackend(backendName, ledgerconfig.GetBlockStoreTieringLocation())
+		if err != nil {
+			panic(fmt.Sprintf("Could not create the archive backend of the block store: %s", err))
+		}
+		blockStoreConf = blockStoreConf.WithTiering(&fsblkstorage.TieringConf{
+			Backend:   backend,
+			MaxAge:    ledgerconfig.GetBlockStoreTieringMaxAge(),
+			CacheDir:  ledgerconfig.GetBlockStoreTierCachePath(),
+			CacheSize: ledgerconfig.GetBlockStoreTieringCacheSize(),
+		})
+	}
+	blockStoreProvider := fsblkstorage.NewProvider(blockStoreConf, indexConfig)
 
 	pvtStoreProvider := pvtdatastorage.NewProvider()
 	return &Provider{blockStoreProvider, pvtStoreProvider}
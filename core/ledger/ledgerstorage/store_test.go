@@ -151,6 +151,14 @@ func TestStoreWithExistingBlockchain(t *testing.T) {
 	assert.Equal(t, uint64(10), pvtdataBlockHt)
 }
 
+func TestProviderWithUnknownArchiveBackend(t *testing.T) {
+	testEnv := newTestEnv(t)
+	defer testEnv.cleanup()
+	viper.Set("ledger.blockchain.tiering.backend", "unknown")
+	defer viper.Set("ledger.blockchain.tiering.backend", "")
+	assert.Panics(t, func() { NewProvider() })
+}
+
 func sampleData(t *testing.T) []*ledger.BlockAndPvtData {
 	var blockAndpvtdata []*ledger.BlockAndPvtData
 	blocks := testutil.ConstructTestBlocks(t, 10)
@@ -94,6 +94,15 @@ func ResetConfigToDefaultValues() {
 	viper.Set("ledger.history.enableHistoryDatabase", false)
 	viper.Set("ledger.state.couchDBConfig.autoWarmIndexes", true)
 	viper.Set("ledger.state.couchDBConfig.warmIndexesAfterNBlocks", 1)
+	viper.Set("ledger.state.couchDBConfig.cacheSize", 10000)
+	viper.Set("ledger.state.parallelValidation.enabled", false)
+	viper.Set("ledger.state.parallelValidation.workers", 0)
+	viper.Set("ledger.blockchain.compression", "none")
+	viper.Set("ledger.blockchain.tiering.backend", "")
+	viper.Set("ledger.blockchain.tiering.location", "")
+	viper.Set("ledger.blockchain.tiering.maxAge", "720h")
+	viper.Set("ledger.blockchain.tiering.cacheSize", 10)
+	viper.Set("ledger.blockchain.enableTxTimeIndex", false)
 	viper.Set("peer.fileSystemPath", "/var/hyperledger/production")
 }
 
@@ -66,6 +66,10 @@ func (ds *mockDeliveryClient) UpdateEndpoints(chainID string, endpoints []string
 	return nil
 }
 
+func (ds *mockDeliveryClient) OrdererHealth(chainID string) ([]blocksprovider.EndpointHealth, error) {
+	return nil, nil
+}
+
 // StartDeliverForChannel dynamically starts delivery of new blocks from ordering service
 // to channel peers.
 func (ds *mockDeliveryClient) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo, f func()) error {
@@ -172,6 +172,12 @@ func (lscc *lifeCycleSysCC) putChaincodeCollectionData(stub shim.ChaincodeStubIn
 	}
 
 	// TODO: FAB-6526 - to add validation of the collections object
+	if err := privdata.ValidateCollectionConfigPackage(collections); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("invalid collection configuration supplied for chaincode %s:%s", cd.Name, cd.Version))
+	}
+	if err := lscc.validateCollectionEndorsementPolicies(stub.GetChannelID(), collections); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("invalid collection configuration supplied for chaincode %s:%s", cd.Name, cd.Version))
+	}
 
 	key := privdata.BuildCollectionKVSKey(cd.Name)
 
@@ -192,6 +198,23 @@ func (lscc *lifeCycleSysCC) putChaincodeCollectionData(stub shim.ChaincodeStubIn
 	return nil
 }
 
+// validateCollectionEndorsementPolicies verifies that the endorsement policies of the
+// collections can be created, and that the policies they reference exist in the channel
+func (lscc *lifeCycleSysCC) validateCollectionEndorsementPolicies(channel string, collections *common.CollectionConfigPackage) error {
+	for _, cconf := range collections.Config {
+		staticConf := cconf.GetStaticCollectionConfig()
+		if staticConf == nil || staticConf.EndorsementPolicy == nil {
+			continue
+		}
+		policyManager, _ := lscc.sccprovider.PolicyManager(channel)
+		_, err := privdata.NewCollectionEndorsementPolicy(staticConf.EndorsementPolicy, mgmt.GetManagerForChain(channel), policyManager)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("invalid endorsement policy of collection %s", staticConf.Name))
+		}
+	}
+	return nil
+}
+
 //checks for existence of chaincode on the given channel
 func (lscc *lifeCycleSysCC) getCCInstance(stub shim.ChaincodeStubInterface, ccname string) ([]byte, error) {
 	cdbytes, err := stub.GetState(ccname)
@@ -16,6 +16,7 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/mocks/config"
 	mscc "github.com/hyperledger/fabric/common/mocks/scc"
 	"github.com/hyperledger/fabric/common/policies"
@@ -594,6 +595,46 @@ func TestPutChaincodeCollectionData(t *testing.T) {
 	assert.Error(t, err)
 	stub.MockTransactionEnd("foo")
 
+	implicitCC := &common.CollectionConfig{Payload: &common.CollectionConfig_StaticCollectionConfig{&common.StaticCollectionConfig{Name: "_implicit_org_SampleOrg"}}}
+	implicitCCPBytes, err := proto.Marshal(&common.CollectionConfigPackage{[]*common.CollectionConfig{cc, implicitCC}})
+	assert.NoError(t, err)
+
+	stub.MockTransactionStart("foo")
+	err = scc.putChaincodeCollectionData(stub, cd, implicitCCPBytes)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "reserved for implicit collections")
+	stub.MockTransactionEnd("foo")
+
+	// Collections can only reference policies which exist in the channel
+	missingPolicyCC := &common.CollectionConfig{Payload: &common.CollectionConfig_StaticCollectionConfig{&common.StaticCollectionConfig{
+		Name: "mycollection",
+		EndorsementPolicy: &common.CollectionEndorsementPolicy{
+			Payload: &common.CollectionEndorsementPolicy_ChannelConfigPolicyReference{ChannelConfigPolicyReference: "/Channel/Application/Missing"},
+		},
+	}}}
+	missingPolicyCCPBytes, err := proto.Marshal(&common.CollectionConfigPackage{[]*common.CollectionConfig{missingPolicyCC}})
+	assert.NoError(t, err)
+
+	stub.MockTransactionStart("foo")
+	err = scc.putChaincodeCollectionData(stub, cd, missingPolicyCCPBytes)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid endorsement policy of collection mycollection")
+	stub.MockTransactionEnd("foo")
+
+	signaturePolicyCC := &common.CollectionConfig{Payload: &common.CollectionConfig_StaticCollectionConfig{&common.StaticCollectionConfig{
+		Name: "mycollection",
+		EndorsementPolicy: &common.CollectionEndorsementPolicy{
+			Payload: &common.CollectionEndorsementPolicy_SignaturePolicy{SignaturePolicy: cauthdsl.SignedByMspMember("SampleOrg")},
+		},
+	}}}
+	signaturePolicyCCPBytes, err := proto.Marshal(&common.CollectionConfigPackage{[]*common.CollectionConfig{signaturePolicyCC}})
+	assert.NoError(t, err)
+
+	stub.MockTransactionStart("bar")
+	err = scc.putChaincodeCollectionData(stub, &ccprovider.ChaincodeData{Name: "bar"}, signaturePolicyCCPBytes)
+	assert.NoError(t, err)
+	stub.MockTransactionEnd("bar")
+
 	stub.MockTransactionStart("foo")
 	err = scc.putChaincodeCollectionData(stub, cd, ccpBytes)
 	assert.NoError(t, err)
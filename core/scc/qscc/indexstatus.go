@@ -0,0 +1,32 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package qscc
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/ledger"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+func getIndexStatus(vledger ledger.PeerLedger, ccname []byte) pb.Response {
+	if len(ccname) == 0 {
+		return shim.Error("Chaincode name must not be empty.")
+	}
+	status, err := vledger.GetIndexStatus(string(ccname))
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get the index status of chaincode %s, error %s", string(ccname), err))
+	}
+	bytes, err := proto.Marshal(status)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
@@ -0,0 +1,257 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package qscc
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/msp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// maxPvtDataStatusBlocks is the maximum number of blocks
+// a single GetPvtDataStatus query may span
+const maxPvtDataStatusBlocks = 1000
+
+// collectionSupport implements privdata.Support on top of the ledger of a channel
+type collectionSupport struct {
+	ledger.PeerLedger
+}
+
+func (cs *collectionSupport) GetQueryExecutorForLedger(cid string) (ledger.QueryExecutor, error) {
+	return cs.NewQueryExecutor()
+}
+
+func (*collectionSupport) GetCollectionKVSKey(cc common.CollectionCriteria) string {
+	return privdata.BuildCollectionKVSKey(cc.Namespace)
+}
+
+func (*collectionSupport) GetIdentityDeserializer(chainID string) msp.IdentityDeserializer {
+	return mspmgmt.GetIdentityDeserializer(chainID)
+}
+
+// localSignedData returns the signed data of the local peer, used to
+// evaluate whether the peer is eligible for the private data of a collection
+var localSignedData = func() (common.SignedData, error) {
+	signer, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+	if err != nil {
+		return common.SignedData{}, errors.WithMessage(err, "failed obtaining the local signing identity")
+	}
+	identity, err := signer.Serialize()
+	if err != nil {
+		return common.SignedData{}, errors.WithMessage(err, "failed serializing the local signing identity")
+	}
+	msg := []byte("pvtdata-status")
+	signature, err := signer.Sign(msg)
+	if err != nil {
+		return common.SignedData{}, errors.WithMessage(err, "failed signing with the local signing identity")
+	}
+	return common.SignedData{Data: msg, Identity: identity, Signature: signature}, nil
+}
+
+func getPvtDataStatus(vledger ledger.PeerLedger, cid string, args [][]byte) pb.Response {
+	if len(args) < 2 || args[0] == nil || args[1] == nil {
+		return shim.Error("Start and end block numbers must not be nil.")
+	}
+	startBlock, err := strconv.ParseUint(string(args[0]), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse start block number with error %s", err))
+	}
+	endBlock, err := strconv.ParseUint(string(args[1]), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse end block number with error %s", err))
+	}
+	if endBlock < startBlock {
+		return shim.Error(fmt.Sprintf("End block number %d is smaller than start block number %d", endBlock, startBlock))
+	}
+	if endBlock-startBlock >= maxPvtDataStatusBlocks {
+		return shim.Error(fmt.Sprintf("Block range [%d, %d] spans more than %d blocks", startBlock, endBlock, maxPvtDataStatusBlocks))
+	}
+
+	sd, err := localSignedData()
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	sc := &pvtDataStatusCollector{
+		ledger:          vledger,
+		channel:         cid,
+		collectionStore: privdata.NewSimpleCollectionStore(&collectionSupport{PeerLedger: vledger}),
+		signedData:      sd,
+		eligibility:     make(map[string]bool),
+	}
+	// The private data store doesn't implement purging yet,
+	// in that case none of the blocks are considered purged
+	if minBlockNum, err := vledger.PrivateDataMinBlockNum(); err == nil {
+		sc.minBlockNum = minBlockNum
+	} else {
+		qscclogger.Debugf("Failed obtaining the lowest retained private data block of channel %s: %s", cid, err)
+	}
+
+	res := &pb.PvtDataStatusResponse{}
+	for blockNum := startBlock; blockNum <= endBlock; blockNum++ {
+		status, err := sc.blockStatus(blockNum)
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to get private data status of block %d, error %s", blockNum, err))
+		}
+		res.Blocks = append(res.Blocks, status)
+	}
+
+	bytes, err := utils.Marshal(res)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(bytes)
+}
+
+// pvtDataStatusCollector computes the private data status of the blocks of a channel
+type pvtDataStatusCollector struct {
+	ledger          ledger.PeerLedger
+	channel         string
+	collectionStore privdata.CollectionStore
+	signedData      common.SignedData
+	minBlockNum     uint64
+	// eligibility caches whether the peer is eligible, per namespace and collection
+	eligibility map[string]bool
+}
+
+// collectionRef identifies a collection of a valid transaction of a block
+// whose read-write set contains hashed writes
+type collectionRef struct {
+	txNum      uint64
+	txID       string
+	namespace  string
+	collection string
+}
+
+func (sc *pvtDataStatusCollector) blockStatus(blockNum uint64) (*pb.BlockPvtDataStatus, error) {
+	block, err := sc.ledger.GetBlockByNumber(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	status := &pb.BlockPvtDataStatus{
+		BlockNum: blockNum,
+		Purged:   blockNum < sc.minBlockNum,
+	}
+
+	refs, err := collectionsOfBlock(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(refs) == 0 {
+		return status, nil
+	}
+
+	filter := ledger.NewPvtNsCollFilter()
+	for _, ref := range refs {
+		filter.Add(ref.namespace, ref.collection)
+	}
+	pvtData, err := sc.ledger.GetPvtDataByNum(blockNum, filter)
+	if err != nil {
+		return nil, err
+	}
+	pvtDataByTxNum := make(map[uint64]*ledger.TxPvtData, len(pvtData))
+	for _, txPvtData := range pvtData {
+		pvtDataByTxNum[txPvtData.SeqInBlock] = txPvtData
+	}
+
+	for _, ref := range refs {
+		if txPvtData, exists := pvtDataByTxNum[ref.txNum]; exists && txPvtData.Has(ref.namespace, ref.collection) {
+			continue
+		}
+		status.Missing = append(status.Missing, &pb.MissingPvtData{
+			TxNum:      ref.txNum,
+			TxId:       ref.txID,
+			Namespace:  ref.namespace,
+			Collection: ref.collection,
+			Eligible:   sc.isEligible(ref.namespace, ref.collection),
+		})
+	}
+	return status, nil
+}
+
+// isEligible returns whether the peer is a member of the given collection
+func (sc *pvtDataStatusCollector) isEligible(namespace, collection string) bool {
+	key := namespace + "/" + collection
+	if eligible, cached := sc.eligibility[key]; cached {
+		return eligible
+	}
+	eligible := false
+	ap, err := sc.collectionStore.RetrieveCollectionAccessPolicy(common.CollectionCriteria{
+		Channel:    sc.channel,
+		Namespace:  namespace,
+		Collection: collection,
+	})
+	if err != nil {
+		qscclogger.Debugf("Failed retrieving access policy of collection %s of chaincode %s: %s", collection, namespace, err)
+	} else {
+		eligible = ap.AccessFilter()(sc.signedData)
+	}
+	sc.eligibility[key] = eligible
+	return eligible
+}
+
+// collectionsOfBlock returns the collections with hashed writes of the valid transactions of the given block
+func collectionsOfBlock(block *common.Block) ([]collectionRef, error) {
+	var txsFilter util.TxValidationFlags
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		txsFilter = util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	var refs []collectionRef
+	for txNum, envBytes := range block.Data.Data {
+		if len(txsFilter) > txNum && txsFilter.IsInvalid(txNum) {
+			continue
+		}
+		env, err := utils.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := utils.GetPayload(env)
+		if err != nil {
+			return nil, err
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return nil, err
+		}
+		if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+		action, err := utils.GetActionFromEnvelope(envBytes)
+		if err != nil {
+			return nil, err
+		}
+		txRWSet := &rwsetutil.TxRwSet{}
+		if err := txRWSet.FromProtoBytes(action.Results); err != nil {
+			return nil, err
+		}
+		for _, nsRWSet := range txRWSet.NsRwSets {
+			for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+				if len(collHashedRWSet.PvtRwSetHash) == 0 {
+					continue
+				}
+				refs = append(refs, collectionRef{
+					txNum:      uint64(txNum),
+					txID:       chdr.TxId,
+					namespace:  nsRWSet.NameSpace,
+					collection: collHashedRWSet.CollectionName,
+				})
+			}
+		}
+	}
+	return refs, nil
+}
@@ -35,6 +35,12 @@ import (
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetPvtDataStatus returns the private data status of a range of blocks
+// - GetStateAtHeight returns the value of a key as of a block
+// - GetStateByRangeAtHeight returns the key-values of a range of keys as of a block
+// - GetIndexStatus returns the status of the state database indexes of a chaincode
+// - GetTransactionsByTime returns the transactions of a time range
+// - GetTransactionsByCreator returns the transactions of a creator in a time range
 type LedgerQuerier struct {
 }
 
@@ -42,11 +48,17 @@ var qscclogger = flogging.MustGetLogger("qscc")
 
 // These are function names from Invoke first parameter
 const (
-	GetChainInfo       string = "GetChainInfo"
-	GetBlockByNumber   string = "GetBlockByNumber"
-	GetBlockByHash     string = "GetBlockByHash"
-	GetTransactionByID string = "GetTransactionByID"
-	GetBlockByTxID     string = "GetBlockByTxID"
+	GetChainInfo             string = "GetChainInfo"
+	GetBlockByNumber         string = "GetBlockByNumber"
+	GetBlockByHash           string = "GetBlockByHash"
+	GetTransactionByID       string = "GetTransactionByID"
+	GetBlockByTxID           string = "GetBlockByTxID"
+	GetPvtDataStatus         string = "GetPvtDataStatus"
+	GetStateAtHeight         string = "GetStateAtHeight"
+	GetStateByRangeAtHeight  string = "GetStateByRangeAtHeight"
+	GetIndexStatus           string = "GetIndexStatus"
+	GetTransactionsByTime    string = "GetTransactionsByTime"
+	GetTransactionsByCreator string = "GetTransactionsByCreator"
 )
 
 // Init is called once per chain when the chain is created.
@@ -65,6 +77,12 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetPvtDataStatus: Return the private data status of the blocks from args[2] to args[3]
+// # GetStateAtHeight: Return the value of the key args[4] of the namespace args[3] as of block number args[2]
+// # GetStateByRangeAtHeight: Return the key-values of the namespace args[3] from args[4] to args[5] as of block number args[2]
+// # GetIndexStatus: Return the status of the state database indexes of the chaincode args[2]
+// # GetTransactionsByTime: Return at most args[5] transactions whose timestamp is from args[2] (inclusive) to args[3] (exclusive), starting after the bookmark args[4]; args[2] is required but may be empty, as may the other ones, which may also be omitted
+// # GetTransactionsByCreator: Return the transactions of the creator whose hash is args[2], selected by args[3] to args[6] as for GetTransactionsByTime
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -109,6 +127,18 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetPvtDataStatus:
+		return getPvtDataStatus(targetLedger, cid, args[2:])
+	case GetStateAtHeight:
+		return getStateAtHeight(targetLedger, args[2:])
+	case GetStateByRangeAtHeight:
+		return getStateByRangeAtHeight(targetLedger, args[2:])
+	case GetIndexStatus:
+		return getIndexStatus(targetLedger, args[2])
+	case GetTransactionsByTime:
+		return getTransactionsByTime(targetLedger, args[2:])
+	case GetTransactionsByCreator:
+		return getTransactionsByCreator(targetLedger, args[2:])
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
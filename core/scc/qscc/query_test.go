@@ -16,11 +16,15 @@ limitations under the License.
 package qscc
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt"
@@ -29,7 +33,9 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	ledger2 "github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/peer"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	peer2 "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -363,3 +369,265 @@ func TestMain(m *testing.M) {
 	aclmgmt.RegisterACLProvider(mockAclProvider)
 	os.Exit(m.Run())
 }
+
+func TestQueryGetPvtDataStatus(t *testing.T) {
+	require.NoError(t, msptesttools.LoadMSPSetupForTesting())
+	// the MSP manager of the test chain contains the local MSP
+	chainid := util.GetTestChainID()
+	path := tempDir(t, "test7")
+	defer os.RemoveAll(path)
+
+	stub, err := setupTestLedger(chainid, path)
+	require.NoError(t, err)
+
+	vledger := peer.GetLedger(chainid)
+	bg, _ := testutil.NewBlockGenerator(t, chainid, false)
+	simulatePvtWrite := func(ns, coll string) (*ledger2.TxSimulationResults, []byte) {
+		simulator, err := vledger.NewTxSimulator(util.GenerateUUID())
+		require.NoError(t, err)
+		require.NoError(t, simulator.SetPrivateData(ns, coll, "key", []byte("value")))
+		simulator.Done()
+		simRes, err := simulator.GetTxSimulationResults()
+		require.NoError(t, err)
+		pubSimResBytes, err := simRes.GetPubSimulationBytes()
+		require.NoError(t, err)
+		return simRes, pubSimResBytes
+	}
+	simRes1, pubSimResBytes1 := simulatePvtWrite("ns1", "coll1")
+	_, pubSimResBytes2 := simulatePvtWrite("ns1", "_implicit_org_SampleOrg")
+	_, pubSimResBytes3 := simulatePvtWrite("ns1", "_implicit_org_Org2MSP")
+	block1 := bg.NextBlock([][]byte{pubSimResBytes1, pubSimResBytes2, pubSimResBytes3})
+	// only the private data of the first transaction is committed along with the block
+	err = vledger.CommitWithPvtData(&ledger2.BlockAndPvtData{
+		Block: block1,
+		BlockPvtData: map[uint64]*ledger2.TxPvtData{
+			0: {SeqInBlock: 0, WriteSet: simRes1.PvtSimulationResults},
+		},
+	})
+	require.NoError(t, err)
+	simulator, err := vledger.NewTxSimulator(util.GenerateUUID())
+	require.NoError(t, err)
+	require.NoError(t, simulator.SetState("ns1", "key", []byte("value")))
+	simulator.Done()
+	simRes, err := simulator.GetTxSimulationResults()
+	require.NoError(t, err)
+	pubSimResBytes, err := simRes.GetPubSimulationBytes()
+	require.NoError(t, err)
+	require.NoError(t, vledger.CommitWithPvtData(&ledger2.BlockAndPvtData{Block: bg.NextBlock([][]byte{pubSimResBytes})}))
+
+	args := [][]byte{[]byte(GetPvtDataStatus), []byte(chainid), []byte("1"), []byte("2")}
+	prop := resetProvider(resources.QSCC_GetPvtDataStatus, chainid, &peer2.SignedProposal{}, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	status := &peer2.PvtDataStatusResponse{}
+	require.NoError(t, proto.Unmarshal(res.Payload, status))
+	require.Len(t, status.Blocks, 2)
+
+	// the private data of the implicit collections is missing, and the peer is only eligible for the one of its own org
+	assert.Equal(t, uint64(1), status.Blocks[0].BlockNum)
+	assert.False(t, status.Blocks[0].Purged)
+	require.Len(t, status.Blocks[0].Missing, 2)
+	assert.Equal(t, uint64(1), status.Blocks[0].Missing[0].TxNum)
+	assert.Equal(t, "ns1", status.Blocks[0].Missing[0].Namespace)
+	assert.Equal(t, "_implicit_org_SampleOrg", status.Blocks[0].Missing[0].Collection)
+	assert.True(t, status.Blocks[0].Missing[0].Eligible)
+	assert.Equal(t, uint64(2), status.Blocks[0].Missing[1].TxNum)
+	assert.Equal(t, "_implicit_org_Org2MSP", status.Blocks[0].Missing[1].Collection)
+	assert.False(t, status.Blocks[0].Missing[1].Eligible)
+
+	// the second block has no private data
+	assert.Equal(t, uint64(2), status.Blocks[1].BlockNum)
+	assert.Empty(t, status.Blocks[1].Missing)
+
+	// invalid block ranges are rejected
+	for _, blockRange := range [][]string{{"2", "1"}, {"a", "1"}, {"1", "b"}, {"0", "1000"}, {"1", "3"}} {
+		args = [][]byte{[]byte(GetPvtDataStatus), []byte(chainid), []byte(blockRange[0]), []byte(blockRange[1])}
+		prop = resetProvider(resources.QSCC_GetPvtDataStatus, chainid, &peer2.SignedProposal{}, nil)
+		res = stub.MockInvokeWithSignedProposal("2", args, prop)
+		assert.Equal(t, int32(shim.ERROR), res.Status, "GetPvtDataStatus should have failed for block range %v", blockRange)
+	}
+
+	args = [][]byte{[]byte(GetPvtDataStatus), []byte(chainid), []byte("1")}
+	prop = resetProvider(resources.QSCC_GetPvtDataStatus, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetPvtDataStatus should have failed without an end block")
+}
+
+func TestQueryGetStateAtHeight(t *testing.T) {
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+	defer viper.Set("ledger.history.enableHistoryDatabase", false)
+	chainid := "mytestchainid8"
+	path := tempDir(t, "test8")
+	defer os.RemoveAll(path)
+
+	stub, err := setupTestLedger(chainid, path)
+	require.NoError(t, err)
+
+	vledger := peer.GetLedger(chainid)
+	bg, _ := testutil.NewBlockGenerator(t, chainid, false)
+	commitWrites := func(kvs map[string]string) {
+		simulator, err := vledger.NewTxSimulator(util.GenerateUUID())
+		require.NoError(t, err)
+		for k, v := range kvs {
+			if v == "" {
+				require.NoError(t, simulator.DeleteState("ns1", k))
+				continue
+			}
+			require.NoError(t, simulator.SetState("ns1", k, []byte(v)))
+		}
+		simulator.Done()
+		simRes, err := simulator.GetTxSimulationResults()
+		require.NoError(t, err)
+		pubSimResBytes, err := simRes.GetPubSimulationBytes()
+		require.NoError(t, err)
+		require.NoError(t, vledger.CommitWithPvtData(&ledger2.BlockAndPvtData{Block: bg.NextBlock([][]byte{pubSimResBytes})}))
+	}
+	commitWrites(map[string]string{"key1": "value1_1", "key2": "value2_1"})
+	commitWrites(map[string]string{"key1": "value1_2", "key2": ""})
+
+	getStateAtHeight := func(blockNum, key string) peer2.Response {
+		args := [][]byte{[]byte(GetStateAtHeight), []byte(chainid), []byte(blockNum), []byte("ns1"), []byte(key)}
+		prop := resetProvider(resources.QSCC_GetStateAtHeight, chainid, &peer2.SignedProposal{}, nil)
+		return stub.MockInvokeWithSignedProposal("1", args, prop)
+	}
+	res := getStateAtHeight("1", "key1")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Equal(t, []byte("value1_1"), res.Payload)
+	res = getStateAtHeight("2", "key1")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Equal(t, []byte("value1_2"), res.Payload)
+	res = getStateAtHeight("2", "key2")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Nil(t, res.Payload)
+
+	for _, blockNum := range []string{"a", "3"} {
+		res = getStateAtHeight(blockNum, "key1")
+		assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateAtHeight should have failed for block %s", blockNum)
+	}
+
+	getStateByRangeAtHeight := func(blockNum string) []*queryresult.KV {
+		args := [][]byte{[]byte(GetStateByRangeAtHeight), []byte(chainid), []byte(blockNum), []byte("ns1"), []byte(""), []byte("")}
+		prop := resetProvider(resources.QSCC_GetStateByRangeAtHeight, chainid, &peer2.SignedProposal{}, nil)
+		res := stub.MockInvokeWithSignedProposal("2", args, prop)
+		require.Equal(t, int32(shim.OK), res.Status, res.Message)
+		queryResponse := &peer2.QueryResponse{}
+		require.NoError(t, proto.Unmarshal(res.Payload, queryResponse))
+		var kvs []*queryresult.KV
+		for _, r := range queryResponse.Results {
+			kv := &queryresult.KV{}
+			require.NoError(t, proto.Unmarshal(r.ResultBytes, kv))
+			kvs = append(kvs, kv)
+		}
+		return kvs
+	}
+	kvs := getStateByRangeAtHeight("1")
+	require.Len(t, kvs, 2)
+	assert.Equal(t, "key1", kvs[0].Key)
+	assert.Equal(t, []byte("value1_1"), kvs[0].Value)
+	assert.Equal(t, "key2", kvs[1].Key)
+	kvs = getStateByRangeAtHeight("2")
+	require.Len(t, kvs, 1)
+	assert.Equal(t, []byte("value1_2"), kvs[0].Value)
+
+	args := [][]byte{[]byte(GetStateAtHeight), []byte(chainid), []byte("1"), []byte("ns1")}
+	prop := resetProvider(resources.QSCC_GetStateAtHeight, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateAtHeight should have failed without a key")
+}
+
+func TestQueryGetIndexStatus(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := tempDir(t, "test9")
+	defer os.RemoveAll(path)
+
+	stub, err := setupTestLedger(chainid, path)
+	require.NoError(t, err)
+
+	getIndexStatus := func(ccname string) peer2.Response {
+		args := [][]byte{[]byte(GetIndexStatus), []byte(chainid), []byte(ccname)}
+		prop := resetProvider(resources.QSCC_GetIndexStatus, chainid, &peer2.SignedProposal{}, nil)
+		return stub.MockInvokeWithSignedProposal("1", args, prop)
+	}
+	res := getIndexStatus("")
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetIndexStatus should have failed without a chaincode name")
+	// the default state database does not create chaincode indexes
+	res = getIndexStatus("mycc")
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "the state database [goleveldb] does not create chaincode indexes")
+}
+
+func TestQueryGetTransactionsByTime(t *testing.T) {
+	chainid := "mytestchainid10"
+	path := tempDir(t, "test10")
+	defer os.RemoveAll(path)
+
+	viper.Set("ledger.blockchain.enableTxTimeIndex", true)
+	defer viper.Set("ledger.blockchain.enableTxTimeIndex", false)
+	stub, err := setupTestLedger(chainid, path)
+	require.NoError(t, err)
+	block1 := addBlockForTesting(t, chainid)
+
+	invoke := func(fname string, args ...string) (peer2.Response, *peer2.TxQueryResponse) {
+		invokeArgs := [][]byte{[]byte(fname), []byte(chainid)}
+		for _, arg := range args {
+			invokeArgs = append(invokeArgs, []byte(arg))
+		}
+		prop := resetProvider(getACLResource(fname), chainid, &peer2.SignedProposal{}, nil)
+		res := stub.MockInvokeWithSignedProposal("1", invokeArgs, prop)
+		txs := &peer2.TxQueryResponse{}
+		if res.Status == shim.OK {
+			require.NoError(t, proto.Unmarshal(res.Payload, txs))
+		}
+		return res, txs
+	}
+
+	// the genesis block and the two transactions of block 1
+	res, txs := invoke(GetTransactionsByTime, "")
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Len(t, txs.Transactions, 3)
+	assert.Empty(t, txs.Bookmark)
+	assert.Equal(t, uint64(0), txs.IndexedFromBlock)
+
+	res, txs = invoke(GetTransactionsByTime, "", "", "", "2")
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Len(t, txs.Transactions, 2)
+	assert.NotEmpty(t, txs.Bookmark)
+	res, txs = invoke(GetTransactionsByTime, "", "", txs.Bookmark, "2")
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Len(t, txs.Transactions, 1)
+	assert.Empty(t, txs.Bookmark)
+
+	// no transaction is in the future
+	res, txs = invoke(GetTransactionsByTime, time.Now().Add(time.Hour).Format(time.RFC3339))
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Empty(t, txs.Transactions)
+
+	env, err := utils.GetEnvelopeFromBlock(block1.Data.Data[0])
+	require.NoError(t, err)
+	payload, err := utils.GetPayload(env)
+	require.NoError(t, err)
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	require.NoError(t, err)
+	creatorHash := sha256.Sum256(shdr.Creator)
+	res, txs = invoke(GetTransactionsByCreator, hex.EncodeToString(creatorHash[:]))
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	require.Len(t, txs.Transactions, 2)
+	assert.Equal(t, uint64(1), txs.Transactions[0].BlockNum)
+	assert.Equal(t, creatorHash[:], txs.Transactions[0].CreatorHash)
+
+	for _, args := range [][]string{
+		{GetTransactionsByTime, "yesterday"},
+		{GetTransactionsByTime, "", "not a time"},
+		{GetTransactionsByTime, "2018-01-02T00:00:00Z", "2018-01-01T00:00:00Z"},
+		{GetTransactionsByTime, "", "", "", "0"},
+		{GetTransactionsByTime, "", "", "", "1001"},
+		{GetTransactionsByTime, "", "", "", "ten"},
+		{GetTransactionsByTime, "", "", "not a bookmark"},
+		{GetTransactionsByCreator, ""},
+		{GetTransactionsByCreator, "not hex"},
+		{GetTransactionsByCreator, "abcd"},
+	} {
+		res, _ = invoke(args[0], args[1:]...)
+		assert.Equal(t, int32(shim.ERROR), res.Status, "%s should have failed with arguments %q", args[0], args[1:])
+	}
+}
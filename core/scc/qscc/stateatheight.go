@@ -0,0 +1,98 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package qscc
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// maxStateByRangeAtHeightResults is the maximum number of key-values
+// a single GetStateByRangeAtHeight query may return
+const maxStateByRangeAtHeightResults = 1000
+
+func newHistoricalQueryExecutor(vledger ledger.PeerLedger, number []byte) (ledger.HistoricalQueryExecutor, error) {
+	if number == nil {
+		return nil, errors.New("Block number must not be nil")
+	}
+	bnum, err := strconv.ParseUint(string(number), 10, 64)
+	if err != nil {
+		return nil, errors.Errorf("Failed to parse block number with error %s", err)
+	}
+	q, err := vledger.NewHistoricalQueryExecutor(bnum)
+	if err != nil {
+		return nil, errors.Errorf("Failed to query the state at block number %d, error %s", bnum, err)
+	}
+	return q, nil
+}
+
+func getStateAtHeight(vledger ledger.PeerLedger, args [][]byte) pb.Response {
+	if len(args) < 3 || args[1] == nil || args[2] == nil {
+		return shim.Error("Block number, namespace and key must not be nil.")
+	}
+	q, err := newHistoricalQueryExecutor(vledger, args[0])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	ns, key := string(args[1]), string(args[2])
+	value, err := q.GetState(ns, key)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get the state of key %s in namespace %s, error %s", key, ns, err))
+	}
+
+	return shim.Success(value)
+}
+
+func getStateByRangeAtHeight(vledger ledger.PeerLedger, args [][]byte) pb.Response {
+	if len(args) < 4 || args[1] == nil {
+		return shim.Error("Block number, namespace, start key and end key must not be nil.")
+	}
+	q, err := newHistoricalQueryExecutor(vledger, args[0])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	ns, startKey, endKey := string(args[1]), string(args[2]), string(args[3])
+	itr, err := q.GetStateRangeScanIterator(ns, startKey, endKey)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get the state of range [%s, %s) in namespace %s, error %s", startKey, endKey, ns, err))
+	}
+	defer itr.Close()
+
+	res := &pb.QueryResponse{}
+	for {
+		qr, err := itr.Next()
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to get the state of range [%s, %s) in namespace %s, error %s", startKey, endKey, ns, err))
+		}
+		if qr == nil {
+			break
+		}
+		if len(res.Results) == maxStateByRangeAtHeightResults {
+			res.HasMore = true
+			break
+		}
+		kvBytes, err := proto.Marshal(qr.(*queryresult.KV))
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		res.Results = append(res.Results, &pb.QueryResultBytes{ResultBytes: kvBytes})
+	}
+
+	bytes, err := proto.Marshal(res)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package qscc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/ledger"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+const (
+	// defaultTxsByTimeLimit is the number of transactions returned by
+	// GetTransactionsByTime and GetTransactionsByCreator when no limit is given
+	defaultTxsByTimeLimit = 100
+	// maxTxsByTimeLimit is the maximum number of transactions a single
+	// GetTransactionsByTime or GetTransactionsByCreator query may return
+	maxTxsByTimeLimit = 1000
+)
+
+// getTransactionsByTime expects the optional arguments from, to, bookmark and limit,
+// from and to being RFC 3339 times and an empty time leaving the range unbounded
+func getTransactionsByTime(vledger ledger.PeerLedger, args [][]byte) pb.Response {
+	return queryTransactionsByTime(vledger, nil, args)
+}
+
+// getTransactionsByCreator expects the hex encoded SHA-256 hash of the serialized identity
+// of the creator, followed by the same optional arguments as getTransactionsByTime
+func getTransactionsByCreator(vledger ledger.PeerLedger, args [][]byte) pb.Response {
+	if len(args) == 0 || len(args[0]) == 0 {
+		return shim.Error("Creator hash must not be empty.")
+	}
+	creatorHash, err := hex.DecodeString(string(args[0]))
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse creator hash with error %s", err))
+	}
+	return queryTransactionsByTime(vledger, creatorHash, args[1:])
+}
+
+func queryTransactionsByTime(vledger ledger.PeerLedger, creatorHash []byte, args [][]byte) pb.Response {
+	// the missing trailing arguments are empty
+	args = append(args, make([][]byte, 4)...)
+	from, err := parseTime(args[0])
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse start time with error %s", err))
+	}
+	to, err := parseTime(args[1])
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse end time with error %s", err))
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return shim.Error(fmt.Sprintf("End time %s is before start time %s", to.Format(time.RFC3339), from.Format(time.RFC3339)))
+	}
+	bookmark := string(args[2])
+	limit := defaultTxsByTimeLimit
+	if len(args[3]) != 0 {
+		if limit, err = strconv.Atoi(string(args[3])); err != nil {
+			return shim.Error(fmt.Sprintf("Failed to parse limit with error %s", err))
+		}
+		if limit <= 0 || limit > maxTxsByTimeLimit {
+			return shim.Error(fmt.Sprintf("Limit %d is out of range, it must be between 1 and %d", limit, maxTxsByTimeLimit))
+		}
+	}
+
+	res, err := vledger.GetTransactionsByTime(creatorHash, from, to, bookmark, limit)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get the transactions by time, error %s", err))
+	}
+	bytes, err := utils.Marshal(res)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(bytes)
+}
+
+func parseTime(b []byte) (time.Time, error) {
+	if len(b) == 0 {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, string(b))
+}
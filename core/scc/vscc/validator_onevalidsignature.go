@@ -182,6 +182,17 @@ func (vscc *ValidatorOneValidSignature) Invoke(stub shim.ChaincodeStubInterface)
 			return shim.Error(fmt.Sprintf("VSCC error: endorsement policy failure, err: %s", err))
 		}
 
+		// evaluate the signature set against the endorsement policies
+		// of the collections the transaction writes private data to;
+		// peers without the V1_3 capability ignore these policies
+		if ac.Capabilities().CollectionEndorsementPolicies() {
+			err = vscc.validateCollectionEndorsementPolicies(chdr.ChannelId, cap, signatureSet)
+			if err != nil {
+				logger.Warningf("Collection endorsement policy failure for transaction txid=%s, err: %s", chdr.GetTxId(), err.Error())
+				return shim.Error(fmt.Sprintf("VSCC error: collection endorsement policy failure, err: %s", err))
+			}
+		}
+
 		hdrExt, err := utils.GetChaincodeHeaderExtension(payl.Header)
 		if err != nil {
 			logger.Errorf("VSCC error: GetChaincodeHeaderExtension failed, err %s", err)
@@ -205,6 +216,58 @@ func (vscc *ValidatorOneValidSignature) Invoke(stub shim.ChaincodeStubInterface)
 	return shim.Success(nil)
 }
 
+// validateCollectionEndorsementPolicies evaluates the signature set of a chaincode action against
+// the endorsement policies of the collections the action writes private data to
+func (vscc *ValidatorOneValidSignature) validateCollectionEndorsementPolicies(chid string, cap *pb.ChaincodeActionPayload, signatureSet []*common.SignedData) error {
+	if cap.Action == nil {
+		return errors.New("nil action")
+	}
+	pRespPayload, err := utils.GetProposalResponsePayload(cap.Action.ProposalResponsePayload)
+	if err != nil {
+		return errors.WithMessage(err, "GetProposalResponsePayload failed")
+	}
+	respPayload, err := utils.GetChaincodeAction(pRespPayload.Extension)
+	if err != nil {
+		return errors.WithMessage(err, "GetChaincodeAction failed")
+	}
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+		return errors.WithMessage(err, "txRWSet.FromProtoBytes failed")
+	}
+
+	for _, ns := range txRWSet.NsRwSets {
+		var collections *common.CollectionConfigPackage
+		for _, coll := range ns.CollHashedRwSets {
+			if coll.HashedRwSet == nil || len(coll.HashedRwSet.HashedWrites) == 0 {
+				continue
+			}
+			if collections == nil {
+				collections, err = vscc.collectionStore.RetrieveCollectionConfigPackage(common.CollectionCriteria{Channel: chid, Namespace: ns.NameSpace})
+				if _, noCollections := err.(privdata.NoSuchCollectionError); noCollections {
+					// only implicit collections, which have no endorsement policy, can be written to
+					break
+				}
+				if err != nil {
+					return errors.WithMessage(err, fmt.Sprintf("unable to retrieve the collections of chaincode %s", ns.NameSpace))
+				}
+			}
+			ep := privdata.EndorsementPolicyOfCollection(collections, coll.CollectionName)
+			if ep == nil {
+				continue
+			}
+			policyManager, _ := vscc.sccprovider.PolicyManager(chid)
+			policy, err := privdata.NewCollectionEndorsementPolicy(ep, mspmgmt.GetManagerForChain(chid), policyManager)
+			if err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("invalid endorsement policy of collection %s of chaincode %s", coll.CollectionName, ns.NameSpace))
+			}
+			if err = policy.Evaluate(signatureSet); err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("endorsement policy of collection %s of chaincode %s is not satisfied", coll.CollectionName, ns.NameSpace))
+			}
+		}
+	}
+	return nil
+}
+
 // checkInstantiationPolicy evaluates an instantiation policy against a signed proposal
 func (vscc *ValidatorOneValidSignature) checkInstantiationPolicy(chainName string, env *common.Envelope, instantiationPolicy []byte, payl *common.Payload) error {
 	// create a policy object from the policy bytes
@@ -248,6 +311,7 @@ func (vscc *ValidatorOneValidSignature) validateDeployRWSetAndCollection(
 	cdRWSet *ccprovider.ChaincodeData,
 	lsccArgs [][]byte,
 	chid, ccid string,
+	ac channelconfig.ApplicationCapabilities,
 ) error {
 	/********************************************/
 	/* security check 0.a - validation of rwset */
@@ -302,6 +366,14 @@ func (vscc *ValidatorOneValidSignature) validateDeployRWSetAndCollection(
 			return errors.Errorf("invalid collection configuration supplied for chaincode %s:%s",
 				cdRWSet.Name, cdRWSet.Version)
 		}
+		// peers without the V1_3 capability accept such collection configurations,
+		// so they are only rejected once all peers of the channel reject them
+		if ac.V1_3Validation() {
+			if err := privdata.ValidateCollectionConfigPackage(collections); err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("invalid collection configuration supplied for chaincode %s:%s",
+					cdRWSet.Name, cdRWSet.Version))
+			}
+		}
 	}
 
 	// TODO: FAB-6526 - to add validation of the collections object
@@ -443,7 +515,7 @@ func (vscc *ValidatorOneValidSignature) ValidateLSCCInvocation(
 			/****************************************************************************/
 			if ac.PrivateChannelData() {
 				// do extra validation for collections
-				err = vscc.validateDeployRWSetAndCollection(lsccrwset, cdRWSet, lsccArgs, chid, cdsArgs.ChaincodeSpec.ChaincodeId.Name)
+				err = vscc.validateDeployRWSetAndCollection(lsccrwset, cdRWSet, lsccArgs, chid, cdsArgs.ChaincodeSpec.ChaincodeId.Name, ac)
 				if err != nil {
 					return err
 				}
@@ -1600,6 +1600,7 @@ func TestValidateDeployRWSetAndCollection(t *testing.T) {
 	ccid := "cc"
 
 	cd := &ccprovider.ChaincodeData{Name: "mycc"}
+	ac := &mc.MockApplicationCapabilities{}
 
 	v := new(ValidatorOneValidSignature)
 	stub := shim.NewMockStub("validatoronevalidsignature", v)
@@ -1616,38 +1617,38 @@ func TestValidateDeployRWSetAndCollection(t *testing.T) {
 
 	rwset := &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a"}, {Key: "b"}, {Key: "c"}}}
 
-	err := v.validateDeployRWSetAndCollection(rwset, nil, nil, chid, ccid)
+	err := v.validateDeployRWSetAndCollection(rwset, nil, nil, chid, ccid, ac)
 	assert.Error(t, err)
 
 	rwset = &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a"}, {Key: "b"}}}
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, nil, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, nil, chid, ccid, ac)
 	assert.Error(t, err)
 
 	rwset = &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a"}}}
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, nil, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, nil, chid, ccid, ac)
 	assert.NoError(t, err)
 
 	lsccargs := [][]byte{nil, nil, nil, nil, nil, nil}
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid, ac)
 	assert.NoError(t, err)
 
 	rwset = &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a"}, {Key: privdata.BuildCollectionKVSKey("mycc")}}}
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid, ac)
 	assert.NoError(t, err)
 
 	lsccargs = [][]byte{nil, nil, nil, nil, nil, []byte("barf")}
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid, ac)
 	assert.Error(t, err)
 
 	lsccargs = [][]byte{nil, nil, nil, nil, nil, []byte("barf")}
 	rwset = &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a"}, {Key: privdata.BuildCollectionKVSKey("mycc"), Value: []byte("barf")}}}
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid, ac)
 	assert.Error(t, err)
 
 	cc := &common.CollectionConfig{Payload: &common.CollectionConfig_StaticCollectionConfig{&common.StaticCollectionConfig{Name: "mycollection"}}}
@@ -1656,23 +1657,146 @@ func TestValidateDeployRWSetAndCollection(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, ccpBytes)
 
+	implicitCC := &common.CollectionConfig{Payload: &common.CollectionConfig_StaticCollectionConfig{&common.StaticCollectionConfig{Name: "_implicit_org_SampleOrg"}}}
+	implicitCCPBytes, err := proto.Marshal(&common.CollectionConfigPackage{[]*common.CollectionConfig{cc, implicitCC}})
+	assert.NoError(t, err)
+
+	lsccargs = [][]byte{nil, nil, nil, nil, nil, implicitCCPBytes}
+	rwset = &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a"}, {Key: privdata.BuildCollectionKVSKey("mycc"), Value: implicitCCPBytes}}}
+
+	// Without the V1_3 capability, names reserved for implicit collections are accepted
+	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid, ac)
+	assert.NoError(t, err)
+
+	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid, &mc.MockApplicationCapabilities{V1_3ValidationRv: true})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "reserved for implicit collections")
+
 	lsccargs = [][]byte{nil, nil, nil, nil, nil, ccpBytes}
 	rwset = &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a"}, {Key: privdata.BuildCollectionKVSKey("mycc"), Value: ccpBytes}}}
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid, ac)
 	assert.NoError(t, err)
 
 	State["lscc"][(&collectionStoreSupport{v.sccprovider}).GetCollectionKVSKey(common.CollectionCriteria{Channel: chid, Namespace: ccid})] = []byte("barf")
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid, ac)
 	assert.Error(t, err)
 
 	State["lscc"][(&collectionStoreSupport{v.sccprovider}).GetCollectionKVSKey(common.CollectionCriteria{Channel: chid, Namespace: ccid})] = ccpBytes
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid, ac)
 	assert.Error(t, err)
 }
 
+func TestValidateCollectionEndorsementPolicies(t *testing.T) {
+	chid := util.GetTestChainID()
+	ccid := &peer.ChaincodeID{Name: "mycc", Version: "v1"}
+
+	v := new(ValidatorOneValidSignature)
+	stub := shim.NewMockStub("validatoronevalidsignature", v)
+
+	State := make(map[string]map[string][]byte)
+	State["lscc"] = make(map[string][]byte)
+	capabilities := &mc.MockApplicationCapabilities{}
+	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{
+		Qe:                    lm.NewMockQueryExecutor(State),
+		ApplicationConfigBool: true,
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: capabilities},
+	})
+	defer sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{
+		ApplicationConfigBool: true,
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+	})
+
+	r1 := stub.MockInit("1", [][]byte{})
+	if r1.Status != shim.OK {
+		fmt.Println("Init failed", string(r1.Message))
+		t.FailNow()
+	}
+
+	// createProposalResponse creates a proposal and a response to it which writes private data to the given collection
+	createProposalResponse := func(collection string) (*peer.Proposal, *peer.ProposalResponse) {
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		rwsetBuilder.AddToWriteSet("mycc", "key", []byte("value"))
+		err := rwsetBuilder.AddToPvtAndHashedWriteSet("mycc", collection, "key", []byte("value"))
+		assert.NoError(t, err)
+		sr, err := rwsetBuilder.GetTxSimulationResults()
+		assert.NoError(t, err)
+		res, err := sr.GetPubSimulationBytes()
+		assert.NoError(t, err)
+
+		cis := &peer.ChaincodeInvocationSpec{ChaincodeSpec: &peer.ChaincodeSpec{ChaincodeId: ccid}}
+		prop, _, err := utils.CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, chid, cis, sid)
+		assert.NoError(t, err)
+		presp, err := utils.CreateProposalResponse(prop.Header, prop.Payload, &peer.Response{Status: 200}, res, nil, ccid, nil, id)
+		assert.NoError(t, err)
+		return prop, presp
+	}
+
+	// createCAP creates an action which writes private data to the given collection
+	createCAP := func(collection string) *peer.ChaincodeActionPayload {
+		_, presp := createProposalResponse(collection)
+		return &peer.ChaincodeActionPayload{Action: &peer.ChaincodeEndorsedAction{ProposalResponsePayload: presp.Payload}}
+	}
+
+	data := []byte("data")
+	signature, err := id.Sign(data)
+	assert.NoError(t, err)
+	signatureSet := []*common.SignedData{{Data: data, Identity: sid, Signature: signature}}
+
+	collection := func(name string, ep *common.SignaturePolicyEnvelope) *common.CollectionConfig {
+		conf := &common.StaticCollectionConfig{Name: name}
+		if ep != nil {
+			conf.EndorsementPolicy = &common.CollectionEndorsementPolicy{
+				Payload: &common.CollectionEndorsementPolicy_SignaturePolicy{SignaturePolicy: ep},
+			}
+		}
+		return &common.CollectionConfig{Payload: &common.CollectionConfig_StaticCollectionConfig{StaticCollectionConfig: conf}}
+	}
+
+	// Chaincodes without collections can only write to implicit collections, which have no endorsement policy
+	err = v.validateCollectionEndorsementPolicies(chid, createCAP("_implicit_org_"+mspid), signatureSet)
+	assert.NoError(t, err)
+
+	ccp := &common.CollectionConfigPackage{Config: []*common.CollectionConfig{
+		collection("noPolicy", nil),
+		collection("satisfied", cauthdsl.SignedByMspMember(mspid)),
+		collection("unsatisfied", cauthdsl.SignedByMspMember("OtherOrg")),
+	}}
+	State["lscc"][privdata.BuildCollectionKVSKey("mycc")] = utils.MarshalOrPanic(ccp)
+
+	err = v.validateCollectionEndorsementPolicies(chid, createCAP("noPolicy"), signatureSet)
+	assert.NoError(t, err)
+
+	err = v.validateCollectionEndorsementPolicies(chid, createCAP("satisfied"), signatureSet)
+	assert.NoError(t, err)
+
+	err = v.validateCollectionEndorsementPolicies(chid, createCAP("unsatisfied"), signatureSet)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "endorsement policy of collection unsatisfied of chaincode mycc is not satisfied")
+
+	// Actions which don't write private data aren't affected by collection endorsement policies
+	err = v.validateCollectionEndorsementPolicies(chid, &peer.ChaincodeActionPayload{Action: &peer.ChaincodeEndorsedAction{}}, signatureSet)
+	assert.NoError(t, err)
+
+	// Without the V1_3 capability, the endorsement policies of collections aren't evaluated
+	policy, err := getSignedByMSPMemberPolicy(mspid)
+	assert.NoError(t, err)
+	prop, presp := createProposalResponse("unsatisfied")
+	env, err := utils.CreateSignedTx(prop, id, presp)
+	assert.NoError(t, err)
+	args := [][]byte{[]byte("dv"), utils.MarshalOrPanic(env), policy}
+
+	res := stub.MockInvoke("1", args)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	capabilities.CollectionEndorsementPoliciesRv = true
+	res = stub.MockInvoke("1", args)
+	assert.NotEqual(t, int32(shim.OK), res.Status)
+	assert.Contains(t, res.Message, "endorsement policy of collection unsatisfied of chaincode mycc is not satisfied")
+}
+
 var lccctestpath = "/tmp/lscc-validation-test"
 
 func TestMain(m *testing.M) {
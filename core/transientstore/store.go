@@ -69,9 +69,27 @@ type Store interface {
 	PurgeByHeight(maxBlockNumToRetain uint64) error
 	// GetMinTransientBlkHt returns the lowest block height remaining in transient store
 	GetMinTransientBlkHt() (uint64, error)
+	// AddToOutbox stores the private write set of a collection which the endorser didn't disseminate
+	// to enough peers, until it is removed from the outbox, or purged along with the private write
+	// sets of its transaction by PurgeByTxids() or PurgeByHeight()
+	AddToOutbox(entry *OutboxEntry) error
+	// GetOutbox returns the private write sets of the collections which weren't disseminated to enough peers
+	GetOutbox() ([]*OutboxEntry, error)
+	// RemoveFromOutbox removes the private write set of a collection of a transaction from the outbox
+	RemoveFromOutbox(txid string, namespace string, collection string) error
 	Shutdown()
 }
 
+// OutboxEntry captures the private write set of a collection
+// which the endorser didn't disseminate to enough peers
+type OutboxEntry struct {
+	TxID                  string
+	Namespace             string
+	CollectionName        string
+	ReceivedAtBlockHeight uint64
+	Rwset                 []byte
+}
+
 // EndorserPvtSimulationResults captures the details of the simulation results specific to an endorser
 type EndorserPvtSimulationResults struct {
 	ReceivedAtBlockHeight uint64
@@ -215,6 +233,13 @@ func (s *store) PurgeByTxids(txids []string) error {
 			dbBatch.Delete(compositeKeyPurgeIndexByTxid)
 		}
 		iter.Release()
+
+		// Remove the private write sets of the transaction which are still in the outbox
+		outboxIter := s.db.GetIterator(createOutboxRangeStartKey(txid), createOutboxRangeEndKey(txid))
+		for outboxIter.Next() {
+			dbBatch.Delete(outboxIter.Key())
+		}
+		outboxIter.Release()
 	}
 	// If peer fails before/while writing the batch to golevelDB, these entries will be
 	// removed as per BTL policy later by PurgeByHeight()
@@ -258,6 +283,16 @@ func (s *store) PurgeByHeight(maxBlockNumToRetain uint64) error {
 	}
 	iter.Release()
 
+	// The outbox is expected to be small, hence it isn't indexed by height
+	// and the entries received prior to maxBlockNumToRetain are found by a scan
+	outboxIter := s.db.GetIterator(createOutboxRangeStartKey(""), createOutboxRangeEndKey(""))
+	for outboxIter.Next() {
+		if blockHeight, _ := splitOutboxValue(outboxIter.Value()); blockHeight < maxBlockNumToRetain {
+			dbBatch.Delete(outboxIter.Key())
+		}
+	}
+	outboxIter.Release()
+
 	return s.db.WriteBatch(dbBatch, true)
 }
 
@@ -282,6 +317,41 @@ func (s *store) GetMinTransientBlkHt() (uint64, error) {
 	return 0, ErrStoreEmpty
 }
 
+// AddToOutbox stores the private write set of a collection which the endorser didn't disseminate
+// to enough peers, until it is removed from the outbox, or purged along with the private write
+// sets of its transaction by PurgeByTxids() or PurgeByHeight()
+func (s *store) AddToOutbox(entry *OutboxEntry) error {
+	logger.Debugf("Adding private data of collection %s:%s of txid = %s to the outbox", entry.Namespace, entry.CollectionName, entry.TxID)
+	key := createCompositeKeyForOutbox(entry.TxID, entry.Namespace, entry.CollectionName)
+	return s.db.Put(key, createOutboxValue(entry.ReceivedAtBlockHeight, entry.Rwset), true)
+}
+
+// GetOutbox returns the private write sets of the collections which weren't disseminated to enough peers
+func (s *store) GetOutbox() ([]*OutboxEntry, error) {
+	iter := s.db.GetIterator(createOutboxRangeStartKey(""), createOutboxRangeEndKey(""))
+	defer iter.Release()
+
+	var entries []*OutboxEntry
+	for iter.Next() {
+		txid, namespace, collection := splitCompositeKeyOfOutbox(iter.Key())
+		blockHeight, rwset := splitOutboxValue(iter.Value())
+		entries = append(entries, &OutboxEntry{
+			TxID:                  txid,
+			Namespace:             namespace,
+			CollectionName:        collection,
+			ReceivedAtBlockHeight: blockHeight,
+			// The iterator reuses its buffers, hence the private write set is copied
+			Rwset: append([]byte(nil), rwset...),
+		})
+	}
+	return entries, iter.Error()
+}
+
+// RemoveFromOutbox removes the private write set of a collection of a transaction from the outbox
+func (s *store) RemoveFromOutbox(txid string, namespace string, collection string) error {
+	return s.db.Delete(createCompositeKeyForOutbox(txid, namespace, collection), true)
+}
+
 func (s *store) Shutdown() {
 	// do nothing because shared db is used
 }
@@ -18,6 +18,7 @@ var (
 	prwsetPrefix             = []byte("P")[0] // key prefix for storing private write set in transient store.
 	purgeIndexByHeightPrefix = []byte("H")[0] // key prefix for storing index on private write set using received at block height.
 	purgeIndexByTxidPrefix   = []byte("T")[0] // key prefix for storing index on private write set using txid
+	outboxPrefix             = []byte("O")[0] // key prefix for storing private write sets which weren't disseminated to enough peers
 	compositeKeySep          = byte(0x00)
 )
 
@@ -174,6 +175,75 @@ func createPurgeIndexByTxidRangeEndKey(txid string) []byte {
 	return endKey
 }
 
+// createCompositeKeyForOutbox creates a key for storing the private write set of a collection
+// which wasn't disseminated to enough peers. The structure of the key is <outboxPrefix>~txid~namespace~collection.
+func createCompositeKeyForOutbox(txid string, namespace string, collection string) []byte {
+	var compositeKey []byte
+	compositeKey = append(compositeKey, outboxPrefix)
+	compositeKey = append(compositeKey, compositeKeySep)
+	compositeKey = append(compositeKey, []byte(txid)...)
+	compositeKey = append(compositeKey, compositeKeySep)
+	compositeKey = append(compositeKey, []byte(namespace)...)
+	compositeKey = append(compositeKey, compositeKeySep)
+	compositeKey = append(compositeKey, []byte(collection)...)
+
+	return compositeKey
+}
+
+// splitCompositeKeyOfOutbox splits the compositeKey (<outboxPrefix>~txid~namespace~collection)
+// into txid, namespace and collection.
+func splitCompositeKeyOfOutbox(compositeKey []byte) (txid string, namespace string, collection string) {
+	splits := bytes.SplitN(compositeKey[2:], []byte{compositeKeySep}, 3)
+	txid = string(splits[0])
+	namespace = string(splits[1])
+	collection = string(splits[2])
+	return
+}
+
+// createOutboxRangeStartKey returns a startKey to do a range query on the outbox,
+// on the entries of a given txid, or on all entries if the txid is empty
+func createOutboxRangeStartKey(txid string) []byte {
+	var startKey []byte
+	startKey = append(startKey, outboxPrefix)
+	startKey = append(startKey, compositeKeySep)
+	if txid != "" {
+		startKey = append(startKey, []byte(txid)...)
+		startKey = append(startKey, compositeKeySep)
+	}
+	return startKey
+}
+
+// createOutboxRangeEndKey returns a endKey to do a range query on the outbox,
+// on the entries of a given txid, or on all entries if the txid is empty
+func createOutboxRangeEndKey(txid string) []byte {
+	var endKey []byte
+	endKey = append(endKey, outboxPrefix)
+	if txid != "" {
+		endKey = append(endKey, compositeKeySep)
+		endKey = append(endKey, []byte(txid)...)
+	}
+	// As txid is a fixed length string (i.e., 128 bits long UUID), 0xff can be used as a stopper.
+	// Otherwise a super-string of a given txid would also fall under the end key of range query.
+	endKey = append(endKey, byte(0xff))
+	return endKey
+}
+
+// createOutboxValue creates the value of an outbox entry, which is
+// the received at block height followed by the private write set
+func createOutboxValue(blockHeight uint64, rwset []byte) []byte {
+	var value []byte
+	value = append(value, util.EncodeOrderPreservingVarUint64(blockHeight)...)
+	value = append(value, rwset...)
+	return value
+}
+
+// splitOutboxValue splits the value of an outbox entry into
+// the received at block height and the private write set
+func splitOutboxValue(value []byte) (blockHeight uint64, rwset []byte) {
+	blockHeight, n := util.DecodeOrderPreservingVarUint64(value)
+	return blockHeight, value[n:]
+}
+
 // GetTransientStorePath returns the filesystem path for temporarily storing the private rwset
 func GetTransientStorePath() string {
 	sysPath := config.GetPath("peer.fileSystemPath")
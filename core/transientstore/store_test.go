@@ -71,6 +71,21 @@ func TestRWSetKeyCodingEncoding(t *testing.T) {
 	}
 }
 
+func TestOutboxKeyCodingEncoding(t *testing.T) {
+	for _, txid := range []string{"txid", ""} {
+		outboxKey := createCompositeKeyForOutbox(txid, "ns-1", "coll-1")
+		txid1, namespace, collection := splitCompositeKeyOfOutbox(outboxKey)
+		assert.Equal(t, txid, txid1)
+		assert.Equal(t, "ns-1", namespace)
+		assert.Equal(t, "coll-1", collection)
+	}
+	for _, blkHt := range []uint64{0, 10, 20000} {
+		blkHt1, rwset := splitOutboxValue(createOutboxValue(blkHt, []byte("rwset")))
+		assert.Equal(t, blkHt, blkHt1)
+		assert.Equal(t, []byte("rwset"), rwset)
+	}
+}
+
 func TestTransientStorePersistAndRetrieve(t *testing.T) {
 	env := NewTestStoreEnv(t)
 	assert := assert.New(t)
@@ -451,6 +466,55 @@ func sortResults(res []*EndorserPvtSimulationResults) {
 	sort.SliceStable(res, sortCondition)
 }
 
+func TestTransientStoreOutbox(t *testing.T) {
+	env := NewTestStoreEnv(t)
+	defer env.Cleanup()
+	assert := assert.New(t)
+
+	entries, err := env.TestStore.GetOutbox()
+	assert.NoError(err)
+	assert.Empty(entries)
+
+	tx1Coll1 := &OutboxEntry{TxID: "txid-1", Namespace: "ns-1", CollectionName: "coll-1", ReceivedAtBlockHeight: 10, Rwset: []byte("tx1-ns1-coll1")}
+	tx1Coll2 := &OutboxEntry{TxID: "txid-1", Namespace: "ns-1", CollectionName: "coll-2", ReceivedAtBlockHeight: 10, Rwset: []byte("tx1-ns1-coll2")}
+	tx2Coll1 := &OutboxEntry{TxID: "txid-2", Namespace: "ns-2", CollectionName: "coll-1", ReceivedAtBlockHeight: 20, Rwset: []byte("tx2-ns2-coll1")}
+	tx3Coll1 := &OutboxEntry{TxID: "txid-3", Namespace: "ns-1", CollectionName: "coll-1", ReceivedAtBlockHeight: 30, Rwset: []byte("tx3-ns1-coll1")}
+	for _, entry := range []*OutboxEntry{tx1Coll1, tx1Coll2, tx2Coll1, tx3Coll1} {
+		assert.NoError(env.TestStore.AddToOutbox(entry))
+	}
+	// The outbox doesn't interfere with the private write sets of the transactions
+	err = env.TestStore.Persist("txid-1", 10, samplePvtData(t))
+	assert.NoError(err)
+
+	entries, err = env.TestStore.GetOutbox()
+	assert.NoError(err)
+	assert.Equal([]*OutboxEntry{tx1Coll1, tx1Coll2, tx2Coll1, tx3Coll1}, entries)
+
+	// Remove a single collection of a transaction
+	assert.NoError(env.TestStore.RemoveFromOutbox("txid-1", "ns-1", "coll-2"))
+	entries, err = env.TestStore.GetOutbox()
+	assert.NoError(err)
+	assert.Equal([]*OutboxEntry{tx1Coll1, tx2Coll1, tx3Coll1}, entries)
+
+	// Committed transactions are removed from the outbox along with their private write sets
+	assert.NoError(env.TestStore.PurgeByTxids([]string{"txid-1"}))
+	entries, err = env.TestStore.GetOutbox()
+	assert.NoError(err)
+	assert.Equal([]*OutboxEntry{tx2Coll1, tx3Coll1}, entries)
+	iter, err := env.TestStore.GetTxPvtRWSetByTxid("txid-1", nil)
+	assert.NoError(err)
+	res, err := iter.Next()
+	assert.NoError(err)
+	assert.Nil(res)
+	iter.Close()
+
+	// Expired transactions are removed from the outbox
+	assert.NoError(env.TestStore.PurgeByHeight(21))
+	entries, err = env.TestStore.GetOutbox()
+	assert.NoError(err)
+	assert.Equal([]*OutboxEntry{tx3Coll1}, entries)
+}
+
 func samplePvtData(t *testing.T) *rwset.TxPvtReadWriteSet {
 	pvtWriteSet := &rwset.TxPvtReadWriteSet{DataModel: rwset.TxReadWriteSet_KV}
 	pvtWriteSet.NsPvtRwset = []*rwset.NsPvtReadWriteSet{
@@ -72,6 +72,15 @@ type Gossip struct {
 	peersOfChannelReturnsOnCall map[int]struct {
 		result1 []discovery.NetworkMember
 	}
+	DeadPeersStub        func() []discovery.NetworkMember
+	deadPeersMutex       sync.RWMutex
+	deadPeersArgsForCall []struct{}
+	deadPeersReturns     struct {
+		result1 []discovery.NetworkMember
+	}
+	deadPeersReturnsOnCall map[int]struct {
+		result1 []discovery.NetworkMember
+	}
 	UpdateMetadataStub        func(metadata []byte)
 	updateMetadataMutex       sync.RWMutex
 	updateMetadataArgsForCall []struct {
@@ -147,6 +156,24 @@ type Gossip struct {
 	identityInfoReturnsOnCall map[int]struct {
 		result1 api.PeerIdentitySet
 	}
+	ConnectionStatsStub        func() []comm.ConnectionStats
+	connectionStatsMutex       sync.RWMutex
+	connectionStatsArgsForCall []struct{}
+	connectionStatsReturns     struct {
+		result1 []comm.ConnectionStats
+	}
+	connectionStatsReturnsOnCall map[int]struct {
+		result1 []comm.ConnectionStats
+	}
+	ReachabilityStub        func() gossip.ReachabilityStatus
+	reachabilityMutex       sync.RWMutex
+	reachabilityArgsForCall []struct{}
+	reachabilityReturns     struct {
+		result1 gossip.ReachabilityStatus
+	}
+	reachabilityReturnsOnCall map[int]struct {
+		result1 gossip.ReachabilityStatus
+	}
 	StopStub         func()
 	stopMutex        sync.RWMutex
 	stopArgsForCall  []struct{}
@@ -404,6 +431,46 @@ func (fake *Gossip) PeersOfChannelReturnsOnCall(i int, result1 []discovery.Netwo
 	}{result1}
 }
 
+func (fake *Gossip) DeadPeers() []discovery.NetworkMember {
+	fake.deadPeersMutex.Lock()
+	ret, specificReturn := fake.deadPeersReturnsOnCall[len(fake.deadPeersArgsForCall)]
+	fake.deadPeersArgsForCall = append(fake.deadPeersArgsForCall, struct{}{})
+	fake.recordInvocation("DeadPeers", []interface{}{})
+	fake.deadPeersMutex.Unlock()
+	if fake.DeadPeersStub != nil {
+		return fake.DeadPeersStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.deadPeersReturns.result1
+}
+
+func (fake *Gossip) DeadPeersCallCount() int {
+	fake.deadPeersMutex.RLock()
+	defer fake.deadPeersMutex.RUnlock()
+	return len(fake.deadPeersArgsForCall)
+}
+
+func (fake *Gossip) DeadPeersReturns(result1 []discovery.NetworkMember) {
+	fake.DeadPeersStub = nil
+	fake.deadPeersReturns = struct {
+		result1 []discovery.NetworkMember
+	}{result1}
+}
+
+func (fake *Gossip) DeadPeersReturnsOnCall(i int, result1 []discovery.NetworkMember) {
+	fake.DeadPeersStub = nil
+	if fake.deadPeersReturnsOnCall == nil {
+		fake.deadPeersReturnsOnCall = make(map[int]struct {
+			result1 []discovery.NetworkMember
+		})
+	}
+	fake.deadPeersReturnsOnCall[i] = struct {
+		result1 []discovery.NetworkMember
+	}{result1}
+}
+
 func (fake *Gossip) UpdateMetadata(metadata []byte) {
 	var metadataCopy []byte
 	if metadata != nil {
@@ -729,6 +796,86 @@ func (fake *Gossip) IdentityInfoReturnsOnCall(i int, result1 api.PeerIdentitySet
 	}{result1}
 }
 
+func (fake *Gossip) ConnectionStats() []comm.ConnectionStats {
+	fake.connectionStatsMutex.Lock()
+	ret, specificReturn := fake.connectionStatsReturnsOnCall[len(fake.connectionStatsArgsForCall)]
+	fake.connectionStatsArgsForCall = append(fake.connectionStatsArgsForCall, struct{}{})
+	fake.recordInvocation("ConnectionStats", []interface{}{})
+	fake.connectionStatsMutex.Unlock()
+	if fake.ConnectionStatsStub != nil {
+		return fake.ConnectionStatsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.connectionStatsReturns.result1
+}
+
+func (fake *Gossip) ConnectionStatsCallCount() int {
+	fake.connectionStatsMutex.RLock()
+	defer fake.connectionStatsMutex.RUnlock()
+	return len(fake.connectionStatsArgsForCall)
+}
+
+func (fake *Gossip) ConnectionStatsReturns(result1 []comm.ConnectionStats) {
+	fake.ConnectionStatsStub = nil
+	fake.connectionStatsReturns = struct {
+		result1 []comm.ConnectionStats
+	}{result1}
+}
+
+func (fake *Gossip) ConnectionStatsReturnsOnCall(i int, result1 []comm.ConnectionStats) {
+	fake.ConnectionStatsStub = nil
+	if fake.connectionStatsReturnsOnCall == nil {
+		fake.connectionStatsReturnsOnCall = make(map[int]struct {
+			result1 []comm.ConnectionStats
+		})
+	}
+	fake.connectionStatsReturnsOnCall[i] = struct {
+		result1 []comm.ConnectionStats
+	}{result1}
+}
+
+func (fake *Gossip) Reachability() gossip.ReachabilityStatus {
+	fake.reachabilityMutex.Lock()
+	ret, specificReturn := fake.reachabilityReturnsOnCall[len(fake.reachabilityArgsForCall)]
+	fake.reachabilityArgsForCall = append(fake.reachabilityArgsForCall, struct{}{})
+	fake.recordInvocation("Reachability", []interface{}{})
+	fake.reachabilityMutex.Unlock()
+	if fake.ReachabilityStub != nil {
+		return fake.ReachabilityStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.reachabilityReturns.result1
+}
+
+func (fake *Gossip) ReachabilityCallCount() int {
+	fake.reachabilityMutex.RLock()
+	defer fake.reachabilityMutex.RUnlock()
+	return len(fake.reachabilityArgsForCall)
+}
+
+func (fake *Gossip) ReachabilityReturns(result1 gossip.ReachabilityStatus) {
+	fake.ReachabilityStub = nil
+	fake.reachabilityReturns = struct {
+		result1 gossip.ReachabilityStatus
+	}{result1}
+}
+
+func (fake *Gossip) ReachabilityReturnsOnCall(i int, result1 gossip.ReachabilityStatus) {
+	fake.ReachabilityStub = nil
+	if fake.reachabilityReturnsOnCall == nil {
+		fake.reachabilityReturnsOnCall = make(map[int]struct {
+			result1 gossip.ReachabilityStatus
+		})
+	}
+	fake.reachabilityReturnsOnCall[i] = struct {
+		result1 gossip.ReachabilityStatus
+	}{result1}
+}
+
 func (fake *Gossip) Stop() {
 	fake.stopMutex.Lock()
 	fake.stopArgsForCall = append(fake.stopArgsForCall, struct{}{})
@@ -760,6 +907,8 @@ func (fake *Gossip) Invocations() map[string][][]interface{} {
 	defer fake.peersMutex.RUnlock()
 	fake.peersOfChannelMutex.RLock()
 	defer fake.peersOfChannelMutex.RUnlock()
+	fake.deadPeersMutex.RLock()
+	defer fake.deadPeersMutex.RUnlock()
 	fake.updateMetadataMutex.RLock()
 	defer fake.updateMetadataMutex.RUnlock()
 	fake.updateLedgerHeightMutex.RLock()
@@ -780,6 +929,10 @@ func (fake *Gossip) Invocations() map[string][][]interface{} {
 	defer fake.suspectPeersMutex.RUnlock()
 	fake.identityInfoMutex.RLock()
 	defer fake.identityInfoMutex.RUnlock()
+	fake.connectionStatsMutex.RLock()
+	defer fake.connectionStatsMutex.RUnlock()
+	fake.reachabilityMutex.RLock()
+	defer fake.reachabilityMutex.RUnlock()
 	fake.stopMutex.RLock()
 	defer fake.stopMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -66,6 +66,7 @@ type PeerIdentityInfo struct {
 	PKIId        common.PKIidType
 	Identity     PeerIdentityType
 	Organization OrgIdentityType
+	Expiration   time.Time // zero value in case the identity cannot expire
 }
 
 // PeerIdentitySet aggregates a PeerIdentityInfo slice
@@ -47,6 +47,9 @@ type Comm interface {
 	// CloseConn closes a connection to a certain endpoint
 	CloseConn(peer *RemotePeer)
 
+	// ConnectionStats returns the statistics of the open connections to remote peers
+	ConnectionStats() []ConnectionStats
+
 	// Stop stops the module
 	Stop()
 }
@@ -82,6 +82,7 @@ func NewCommInstanceWithServer(port int, idMapper identity.Mapper, peerIdentity
 		subscriptions:  make([]chan proto.ReceivedMessage, 0),
 		dialTimeout:    util.GetDurationOrDefault("peer.gossip.dialTimeout", defDialTimeout),
 		tlsCerts:       certs,
+		compression:    newCompressionConfig(),
 	}
 	commInst.connStore = newConnStore(commInst, commInst.logger)
 
@@ -135,6 +136,7 @@ type commImpl struct {
 	port           int
 	stopping       int32
 	dialTimeout    time.Duration
+	compression    compressionConfig
 }
 
 func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidType) (*connection, error) {
@@ -143,6 +145,7 @@ func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidT
 	var stream proto.Gossip_GossipStreamClient
 	var pkiID common.PKIidType
 	var connInfo *proto.ConnectionInfo
+	var connMsg *proto.ConnEstablish
 	var dialOpts []grpc.DialOption
 
 	c.logger.Debug("Entering", endpoint, expectedPKIID)
@@ -172,7 +175,7 @@ func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidT
 
 	ctx, cf := context.WithCancel(context.Background())
 	if stream, err = cl.GossipStream(ctx); err == nil {
-		connInfo, err = c.authenticateRemotePeer(stream, true)
+		connInfo, connMsg, err = c.authenticateRemotePeer(stream, true, false)
 		if err == nil {
 			pkiID = connInfo.ID
 			if expectedPKIID != nil && !bytes.Equal(pkiID, expectedPKIID) {
@@ -186,6 +189,7 @@ func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidT
 			conn.info = connInfo
 			conn.logger = c.logger
 			conn.cancel = cf
+			conn.compression = c.compression.negotiate(connMsg.Compression)
 
 			h := func(m *proto.SignedGossipMessage) {
 				c.logger.Debug("Got message:", m)
@@ -294,7 +298,7 @@ func (c *commImpl) Handshake(remotePeer *RemotePeer) (api.PeerIdentityType, erro
 	if err != nil {
 		return nil, err
 	}
-	connInfo, err := c.authenticateRemotePeer(stream, true)
+	connInfo, _, err := c.authenticateRemotePeer(stream, true, true)
 	if err != nil {
 		c.logger.Warningf("Authentication failed: %v", err)
 		return nil, err
@@ -349,6 +353,11 @@ func (c *commImpl) CloseConn(peer *RemotePeer) {
 	c.connStore.closeConn(peer)
 }
 
+// ConnectionStats returns the statistics of the open connections to remote peers
+func (c *commImpl) ConnectionStats() []ConnectionStats {
+	return c.connStore.connectionStats()
+}
+
 func (c *commImpl) emptySubscriptions() {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -395,7 +404,10 @@ func extractRemoteAddress(stream stream) string {
 	return remoteAddress
 }
 
-func (c *commImpl) authenticateRemotePeer(stream stream, initiator bool) (*proto.ConnectionInfo, error) {
+// authenticateRemotePeer performs the handshake with the remote peer, and returns its connection info
+// along with the connection message it sent. A handshake only connection is closed right after the
+// handshake, and only serves to learn the identity of the remote peer
+func (c *commImpl) authenticateRemotePeer(stream stream, initiator, handshakeOnly bool) (*proto.ConnectionInfo, *proto.ConnEstablish, error) {
 	ctx := stream.Context()
 	remoteAddress := extractRemoteAddress(stream)
 	remoteCertHash := extractCertificateHashFromContext(ctx)
@@ -419,12 +431,12 @@ func (c *commImpl) authenticateRemotePeer(stream stream, initiator bool) (*proto
 	// TLS enabled but not detected on other side
 	if useTLS && len(remoteCertHash) == 0 {
 		c.logger.Warningf("%s didn't send TLS certificate", remoteAddress)
-		return nil, fmt.Errorf("No TLS certificate")
+		return nil, nil, fmt.Errorf("No TLS certificate")
 	}
 
-	cMsg, err = c.createConnectionMsg(c.PKIID, selfCertHash, c.peerIdentity, signer)
+	cMsg, err = c.createConnectionMsg(c.PKIID, selfCertHash, c.peerIdentity, signer, handshakeOnly)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	c.logger.Debug("Sending", cMsg, "to", remoteAddress)
@@ -432,24 +444,24 @@ func (c *commImpl) authenticateRemotePeer(stream stream, initiator bool) (*proto
 	m, err := readWithTimeout(stream, util.GetDurationOrDefault("peer.gossip.connTimeout", defConnTimeout), remoteAddress)
 	if err != nil {
 		c.logger.Warningf("Failed reading messge from %s, reason: %v", remoteAddress, err)
-		return nil, err
+		return nil, nil, err
 	}
 	receivedMsg := m.GetConn()
 	if receivedMsg == nil {
 		c.logger.Warning("Expected connection message from", remoteAddress, "but got", receivedMsg)
-		return nil, fmt.Errorf("Wrong type")
+		return nil, nil, fmt.Errorf("Wrong type")
 	}
 
 	if receivedMsg.PkiId == nil {
 		c.logger.Warning("%s didn't send a pkiID", remoteAddress)
-		return nil, fmt.Errorf("No PKI-ID")
+		return nil, nil, fmt.Errorf("No PKI-ID")
 	}
 
 	c.logger.Debug("Received", receivedMsg, "from", remoteAddress)
 	err = c.idMapper.Put(receivedMsg.PkiId, receivedMsg.Identity)
 	if err != nil {
 		c.logger.Warningf("Identity store rejected %s : %v", remoteAddress, err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	connInfo := &proto.ConnectionInfo{
@@ -467,7 +479,7 @@ func (c *commImpl) authenticateRemotePeer(stream stream, initiator bool) (*proto
 		// If the remote peer sent its TLS certificate, make sure it actually matches the TLS cert
 		// that the peer used.
 		if !bytes.Equal(remoteCertHash, receivedMsg.TlsCertHash) {
-			return nil, errors.Errorf("Expected %v in remote hash of TLS cert, but got %v", remoteCertHash, receivedMsg.TlsCertHash)
+			return nil, nil, errors.Errorf("Expected %v in remote hash of TLS cert, but got %v", remoteCertHash, receivedMsg.TlsCertHash)
 		}
 	}
 	// Final step - verify the signature on the connection message itself
@@ -478,12 +490,12 @@ func (c *commImpl) authenticateRemotePeer(stream stream, initiator bool) (*proto
 	err = m.Verify(receivedMsg.Identity, verifier)
 	if err != nil {
 		c.logger.Errorf("Failed verifying signature from %s : %v", remoteAddress, err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	c.logger.Debug("Authenticated", remoteAddress)
 
-	return connInfo, nil
+	return connInfo, receivedMsg, nil
 }
 
 // SendWithAck sends a message to remote peers, waiting for acknowledgement from minAck of them, or until a certain timeout expires
@@ -547,14 +559,19 @@ func (c *commImpl) GossipStream(stream proto.Gossip_GossipStreamServer) error {
 	if c.isStopping() {
 		return fmt.Errorf("Shutting down")
 	}
-	connInfo, err := c.authenticateRemotePeer(stream, false)
+	connInfo, connMsg, err := c.authenticateRemotePeer(stream, false, false)
 	if err != nil {
 		c.logger.Errorf("Authentication failed: %v", err)
 		return err
 	}
+	// a handshake only connection mustn't replace the connection to the remote peer
+	if connMsg.HandshakeOnly {
+		c.logger.Debug("Handshake with", extractRemoteAddress(stream), "completed")
+		return nil
+	}
 	c.logger.Debug("Servicing", extractRemoteAddress(stream))
 
-	conn := c.connStore.onConnected(stream, connInfo)
+	conn := c.connStore.onConnected(stream, connInfo, c.compression.negotiate(connMsg.Compression))
 
 	// if connStore denied the connection, it means we already have a connection to that peer
 	// so close this stream
@@ -575,8 +592,7 @@ func (c *commImpl) GossipStream(stream proto.Gossip_GossipStreamServer) error {
 
 	defer func() {
 		c.logger.Debug("Client", extractRemoteAddress(stream), " disconnected")
-		c.connStore.closeByPKIid(connInfo.ID)
-		conn.close()
+		c.connStore.closeConnection(conn)
 	}()
 
 	return conn.serviceConnection()
@@ -630,15 +646,17 @@ func readWithTimeout(stream interface{}, timeout time.Duration, address string)
 	}
 }
 
-func (c *commImpl) createConnectionMsg(pkiID common.PKIidType, certHash []byte, cert api.PeerIdentityType, signer proto.Signer) (*proto.SignedGossipMessage, error) {
+func (c *commImpl) createConnectionMsg(pkiID common.PKIidType, certHash []byte, cert api.PeerIdentityType, signer proto.Signer, handshakeOnly bool) (*proto.SignedGossipMessage, error) {
 	m := &proto.GossipMessage{
 		Tag:   proto.GossipMessage_EMPTY,
 		Nonce: 0,
 		Content: &proto.GossipMessage_Conn{
 			Conn: &proto.ConnEstablish{
-				TlsCertHash: certHash,
-				Identity:    cert,
-				PkiId:       pkiID,
+				TlsCertHash:   certHash,
+				Identity:      cert,
+				PkiId:         pkiID,
+				Compression:   c.compression.algorithms,
+				HandshakeOnly: handshakeOnly,
 			},
 		},
 	}
@@ -163,7 +163,7 @@ func handshaker(endpoint string, comm Comm, t *testing.T, connMutator msgMutator
 		mac := hmac.New(sha256.New, hmacKey)
 		mac.Write(msg)
 		return mac.Sum(nil), nil
-	})
+	}, false)
 	// Mutate connection message to test negative paths
 	msg = connMutator(msg)
 	// Send your own connection message
@@ -352,6 +352,98 @@ func TestBasic(t *testing.T) {
 	waitForMessages(t, out, 2, "Didn't receive 2 messages")
 }
 
+func TestConnectionStats(t *testing.T) {
+	t.Parallel()
+	comm1, _ := newCommInstance(15611, naiveSec)
+	comm2, _ := newCommInstance(15612, naiveSec)
+	defer comm1.Stop()
+	defer comm2.Stop()
+	m2 := comm2.Accept(acceptAll)
+	out := make(chan uint64, 3)
+	go func() {
+		for m := range m2 {
+			out <- m.GetGossipMessage().Nonce
+		}
+	}()
+	assert.Empty(t, comm1.ConnectionStats())
+
+	for i := 0; i < 3; i++ {
+		comm1.Send(createGossipMsg(), remotePeer(15612))
+	}
+	waitForMessages(t, out, 3, "Didn't receive 3 messages")
+
+	stats := comm1.ConnectionStats()
+	assert.Len(t, stats, 1)
+	assert.Contains(t, stats[0].Endpoint, ":15612")
+	assert.True(t, stats[0].Outbound)
+	assert.Equal(t, uint64(3), stats[0].SentMsgs)
+	assert.Equal(t, uint64(0), stats[0].DroppedMsgs)
+	assert.False(t, stats[0].Established.IsZero())
+
+	stats = comm2.ConnectionStats()
+	assert.Len(t, stats, 1)
+	assert.False(t, stats[0].Outbound)
+	assert.Equal(t, uint64(3), stats[0].ReceivedMsgs)
+	assert.Equal(t, uint64(0), stats[0].SentMsgs)
+}
+
+func TestCompression(t *testing.T) {
+	t.Parallel()
+	comm1, _ := newCommInstance(16611, naiveSec)
+	comm2, _ := newCommInstance(16612, naiveSec)
+	comm3, _ := newCommInstance(16613, naiveSec)
+	defer comm1.Stop()
+	defer comm2.Stop()
+	defer comm3.Stop()
+	// comm1 and comm2 accept snappy, and comm3 doesn't advertise compression,
+	// like a peer which predates it
+	comm1.(*commImpl).compression.algorithms = []proto.CompressionType{proto.CompressionType_SNAPPY}
+	comm2.(*commImpl).compression.algorithms = []proto.CompressionType{proto.CompressionType_SNAPPY}
+
+	payload := bytes.Repeat([]byte{1, 2, 3, 4}, defCompressionMinSize)
+	createLargeMsg := func() *proto.SignedGossipMessage {
+		msg, _ := (&proto.GossipMessage{
+			Tag:   proto.GossipMessage_EMPTY,
+			Nonce: uint64(rand.Int()),
+			Content: &proto.GossipMessage_DataMsg{
+				DataMsg: &proto.DataMessage{Payload: &proto.Payload{SeqNum: 1, Data: payload}},
+			},
+		}).NoopSign()
+		return msg
+	}
+
+	receive := func(c Comm) chan uint64 {
+		out := make(chan uint64, 2)
+		inMsgs := c.Accept(acceptAll)
+		go func() {
+			for m := range inMsgs {
+				if bytes.Equal(payload, m.GetGossipMessage().GetDataMsg().Payload.Data) {
+					out <- m.GetGossipMessage().Nonce
+				}
+			}
+		}()
+		return out
+	}
+	out1, out2, out3 := receive(comm1), receive(comm2), receive(comm3)
+
+	compressionTo := func(c Comm, port int) proto.CompressionType {
+		conn, _ := c.(*commImpl).connStore.getConnection(remotePeer(port))
+		return conn.compression
+	}
+
+	comm1.Send(createLargeMsg(), remotePeer(16612))
+	waitForMessages(t, out2, 1, "Didn't receive a snappy compressed message")
+	assert.Equal(t, proto.CompressionType_SNAPPY, compressionTo(comm1, 16612))
+	comm2.Send(createLargeMsg(), remotePeer(16611))
+	waitForMessages(t, out1, 1, "Didn't receive a snappy compressed message")
+
+	comm1.Send(createLargeMsg(), remotePeer(16613))
+	waitForMessages(t, out3, 1, "Didn't receive an uncompressed message")
+	assert.Equal(t, proto.CompressionType_NO_COMPRESSION, compressionTo(comm1, 16613))
+	comm3.Send(createLargeMsg(), remotePeer(16611))
+	waitForMessages(t, out1, 1, "Didn't receive an uncompressed message")
+}
+
 func TestProdConstructor(t *testing.T) {
 	t.Parallel()
 	srv, lsnr, dialOpts, certs := createGRPCLayer(29000)
@@ -427,7 +519,7 @@ func TestCloseConn(t *testing.T) {
 		mac := hmac.New(sha256.New, hmacKey)
 		mac.Write(msg)
 		return mac.Sum(nil), nil
-	})
+	}, false)
 	assert.NoError(t, stream.Send(connMsg.Envelope))
 	stream.Send(createGossipMsg().Envelope)
 	select {
@@ -723,6 +815,44 @@ func TestProbe(t *testing.T) {
 	assert.Equal(t, api.PeerIdentityType("localhost:6612"), id)
 }
 
+func TestHandshakeKeepsConnection(t *testing.T) {
+	t.Parallel()
+	comm1, _ := newCommInstance(17611, naiveSec)
+	defer comm1.Stop()
+	comm2, _ := newCommInstance(17612, naiveSec)
+	defer comm2.Stop()
+
+	reader := func(out chan uint64, in <-chan proto.ReceivedMessage) {
+		for msg := range in {
+			out <- msg.GetGossipMessage().Nonce
+		}
+	}
+	out1 := make(chan uint64, 1)
+	go reader(out1, comm1.Accept(acceptAll))
+	out2 := make(chan uint64, 1)
+	go reader(out2, comm2.Accept(acceptAll))
+
+	connOf := func(c Comm, port int) *connection {
+		connStore := c.(*commImpl).connStore
+		connStore.RLock()
+		defer connStore.RUnlock()
+		return connStore.pki2Conn[string(remotePeer(port).PKIID)]
+	}
+
+	comm1.Send(createGossipMsg(), remotePeer(17612))
+	waitForMessages(t, out2, 1, "Comm2 didn't receive a message from comm1 in a timely manner")
+	conn := connOf(comm2, 17611)
+	assert.NotNil(t, conn)
+
+	// A handshake doesn't replace the connection the remote peer has with the peer
+	_, err := comm1.Handshake(remotePeer(17612))
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 500)
+	assert.True(t, conn == connOf(comm2, 17611))
+	comm2.Send(createGossipMsg(), remotePeer(17611))
+	waitForMessages(t, out1, 1, "Comm1 didn't receive a message from comm2 in a timely manner")
+}
+
 func TestPresumedDead(t *testing.T) {
 	t.Parallel()
 	comm1, _ := newCommInstance(44611, naiveSec)
@@ -0,0 +1,126 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"strings"
+
+	"github.com/golang/snappy"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	defCompressionMinSize = 1024
+
+	// maxDecompressedSize bounds the size of a decompressed payload,
+	// and matches the maximum size of a gRPC message the peer receives
+	maxDecompressedSize = 100 * 1024 * 1024
+)
+
+// compressor compresses and decompresses the payloads of envelopes
+type compressor interface {
+	compress(payload []byte) []byte
+	decompress(payload []byte) ([]byte, error)
+}
+
+var compressors = map[proto.CompressionType]compressor{
+	proto.CompressionType_SNAPPY: snappyCompressor{},
+}
+
+type snappyCompressor struct{}
+
+func (snappyCompressor) compress(payload []byte) []byte {
+	return snappy.Encode(nil, payload)
+}
+
+func (snappyCompressor) decompress(payload []byte) ([]byte, error) {
+	size, err := snappy.DecodedLen(payload)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if size > maxDecompressedSize {
+		return nil, errors.Errorf("decompressed payload size %d exceeds the maximum of %d bytes", size, maxDecompressedSize)
+	}
+	return snappy.Decode(nil, payload)
+}
+
+// compressionConfig determines which compression algorithms the peer accepts
+type compressionConfig struct {
+	// algorithms are the compression algorithms the peer accepts
+	// and compresses with, in order of preference
+	algorithms []proto.CompressionType
+}
+
+// newCompressionConfig reads the compression algorithms from peer.gossip.compression.algorithms.
+// Unknown algorithms are ignored, so compression is disabled unless configured.
+func newCompressionConfig() compressionConfig {
+	conf := compressionConfig{}
+	for _, name := range viper.GetStringSlice("peer.gossip.compression.algorithms") {
+		algo, exists := proto.CompressionType_value[strings.ToUpper(name)]
+		if !exists || algo == int32(proto.CompressionType_NO_COMPRESSION) {
+			continue
+		}
+		conf.algorithms = append(conf.algorithms, proto.CompressionType(algo))
+	}
+	return conf
+}
+
+// negotiate selects the most preferred algorithm
+// which the remote peer accepts, if any
+func (cc compressionConfig) negotiate(remoteAlgorithms []proto.CompressionType) proto.CompressionType {
+	for _, algo := range cc.algorithms {
+		for _, remoteAlgo := range remoteAlgorithms {
+			if algo == remoteAlgo {
+				return algo
+			}
+		}
+	}
+	return proto.CompressionType_NO_COMPRESSION
+}
+
+// compressEnvelope returns an envelope with the payload of the given envelope compressed
+// with the given algorithm, or the given envelope if its payload is too small to be compressed.
+// The given envelope is never modified, as it might be sent to several peers.
+func compressEnvelope(env *proto.Envelope, algo proto.CompressionType, minSize int) *proto.Envelope {
+	c, exists := compressors[algo]
+	if !exists || env.Compression != proto.CompressionType_NO_COMPRESSION || len(env.Payload) < minSize {
+		return env
+	}
+	compressed := c.compress(env.Payload)
+	if len(compressed) >= len(env.Payload) {
+		return env
+	}
+	return &proto.Envelope{
+		Payload:        compressed,
+		Signature:      env.Signature,
+		SecretEnvelope: env.SecretEnvelope,
+		Compression:    algo,
+	}
+}
+
+// decompressEnvelope returns an envelope with the payload of the given envelope
+// decompressed, or the given envelope if its payload isn't compressed
+func decompressEnvelope(env *proto.Envelope) (*proto.Envelope, error) {
+	if env.Compression == proto.CompressionType_NO_COMPRESSION {
+		return env, nil
+	}
+	c, exists := compressors[env.Compression]
+	if !exists {
+		return nil, errors.Errorf("unknown compression type %d", env.Compression)
+	}
+	payload, err := c.decompress(env.Payload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed decompressing payload")
+	}
+	return &proto.Envelope{
+		Payload:        payload,
+		Signature:      env.Signature,
+		SecretEnvelope: env.SecretEnvelope,
+	}, nil
+}
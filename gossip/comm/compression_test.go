@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"bytes"
+	"testing"
+
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCompressionConfig(t *testing.T) {
+	defer viper.Reset()
+	assert.Empty(t, newCompressionConfig().algorithms)
+
+	viper.Set("peer.gossip.compression.algorithms", []string{"zstd", "gzip", "NO_COMPRESSION", "Snappy"})
+	conf := newCompressionConfig()
+	assert.Equal(t, []proto.CompressionType{proto.CompressionType_SNAPPY}, conf.algorithms)
+}
+
+func TestNegotiateCompression(t *testing.T) {
+	conf := compressionConfig{algorithms: []proto.CompressionType{proto.CompressionType_SNAPPY}}
+	// A remote peer that doesn't advertise compression never receives compressed messages
+	assert.Equal(t, proto.CompressionType_NO_COMPRESSION, conf.negotiate(nil))
+	assert.Equal(t, proto.CompressionType_SNAPPY, conf.negotiate([]proto.CompressionType{proto.CompressionType_SNAPPY}))
+	// Algorithms we don't accept are never selected
+	assert.Equal(t, proto.CompressionType_SNAPPY, conf.negotiate([]proto.CompressionType{proto.CompressionType(42), proto.CompressionType_SNAPPY}))
+	assert.Equal(t, proto.CompressionType_NO_COMPRESSION, compressionConfig{}.negotiate([]proto.CompressionType{proto.CompressionType_SNAPPY}))
+}
+
+func TestCompressEnvelope(t *testing.T) {
+	payload := bytes.Repeat([]byte("gossip"), 1000)
+	for _, algo := range []proto.CompressionType{proto.CompressionType_SNAPPY} {
+		t.Run(algo.String(), func(t *testing.T) {
+			env := &proto.Envelope{Payload: payload, Signature: []byte("sig")}
+			compressed := compressEnvelope(env, algo, defCompressionMinSize)
+			assert.Equal(t, algo, compressed.Compression)
+			assert.True(t, len(compressed.Payload) < len(payload))
+			assert.Equal(t, []byte("sig"), compressed.Signature)
+			// The original envelope isn't modified
+			assert.Equal(t, payload, env.Payload)
+			assert.Equal(t, proto.CompressionType_NO_COMPRESSION, env.Compression)
+
+			decompressed, err := decompressEnvelope(compressed)
+			assert.NoError(t, err)
+			assert.Equal(t, payload, decompressed.Payload)
+			assert.Equal(t, []byte("sig"), decompressed.Signature)
+			assert.Equal(t, proto.CompressionType_NO_COMPRESSION, decompressed.Compression)
+		})
+	}
+
+	// Payloads below the minimum size aren't compressed
+	env := &proto.Envelope{Payload: payload}
+	assert.True(t, env == compressEnvelope(env, proto.CompressionType_SNAPPY, len(payload)+1))
+	// Nor are envelopes when compression wasn't negotiated
+	assert.True(t, env == compressEnvelope(env, proto.CompressionType_NO_COMPRESSION, 0))
+	// Nor are payloads which don't shrink
+	env = &proto.Envelope{Payload: []byte{1}}
+	assert.True(t, env == compressEnvelope(env, proto.CompressionType_SNAPPY, 0))
+	// Uncompressed envelopes are passed through
+	decompressed, err := decompressEnvelope(env)
+	assert.NoError(t, err)
+	assert.True(t, env == decompressed)
+}
+
+func TestDecompressEnvelopeFailure(t *testing.T) {
+	_, err := decompressEnvelope(&proto.Envelope{Payload: []byte{1, 2, 3}, Compression: proto.CompressionType(42)})
+	assert.EqualError(t, err, "unknown compression type 42")
+
+	// The decompressed size is bounded
+	_, err = decompressEnvelope(&proto.Envelope{Payload: []byte{0xff, 0xff, 0xff, 0xff, 0x0f}, Compression: proto.CompressionType_SNAPPY})
+	assert.EqualError(t, err, "failed decompressing payload: decompressed payload size 4294967295 exceeds the maximum of 104857600 bytes")
+
+	_, err = decompressEnvelope(&proto.Envelope{Payload: []byte{0xff, 0xff, 0xff, 0xff, 0x7f}, Compression: proto.CompressionType_SNAPPY})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed decompressing payload")
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/util"
@@ -139,6 +140,27 @@ func (cs *connectionStore) closeConn(peer *RemotePeer) {
 	}
 }
 
+// ConnectionStats describes the traffic over a connection to a remote peer
+type ConnectionStats struct {
+	PKIID        common.PKIidType // PKI-ID of the remote peer
+	Endpoint     string           // endpoint of the remote peer
+	Outbound     bool             // whether the connection was initiated by this peer
+	Established  time.Time        // when the connection was established
+	SentMsgs     uint64           // number of messages sent to the remote peer
+	ReceivedMsgs uint64           // number of messages received from the remote peer
+	DroppedMsgs  uint64           // number of messages dropped due to a full send buffer
+}
+
+func (cs *connectionStore) connectionStats() []ConnectionStats {
+	cs.RLock()
+	defer cs.RUnlock()
+	res := make([]ConnectionStats, 0, len(cs.pki2Conn))
+	for _, conn := range cs.pki2Conn {
+		res = append(res, conn.stats())
+	}
+	return res
+}
+
 func (cs *connectionStore) shutdown() {
 	cs.Lock()
 	cs.isClosing = true
@@ -161,7 +183,7 @@ func (cs *connectionStore) shutdown() {
 	wg.Wait()
 }
 
-func (cs *connectionStore) onConnected(serverStream proto.Gossip_GossipStreamServer, connInfo *proto.ConnectionInfo) *connection {
+func (cs *connectionStore) onConnected(serverStream proto.Gossip_GossipStreamServer, connInfo *proto.ConnectionInfo, compression proto.CompressionType) *connection {
 	cs.Lock()
 	defer cs.Unlock()
 
@@ -169,18 +191,30 @@ func (cs *connectionStore) onConnected(serverStream proto.Gossip_GossipStreamSer
 		c.close()
 	}
 
-	return cs.registerConn(connInfo, serverStream)
+	return cs.registerConn(connInfo, serverStream, compression)
 }
 
-func (cs *connectionStore) registerConn(connInfo *proto.ConnectionInfo, serverStream proto.Gossip_GossipStreamServer) *connection {
+func (cs *connectionStore) registerConn(connInfo *proto.ConnectionInfo, serverStream proto.Gossip_GossipStreamServer, compression proto.CompressionType) *connection {
 	conn := newConnection(nil, nil, nil, serverStream)
 	conn.pkiID = connInfo.ID
 	conn.info = connInfo
 	conn.logger = cs.logger
+	conn.compression = compression
 	cs.pki2Conn[string(connInfo.ID)] = conn
 	return conn
 }
 
+// closeConnection closes the given connection, and removes it from the store
+// unless a newer connection to the same peer already replaced it
+func (cs *connectionStore) closeConnection(conn *connection) {
+	cs.Lock()
+	defer cs.Unlock()
+	if c, exists := cs.pki2Conn[string(conn.pkiID)]; exists && c == conn {
+		delete(cs.pki2Conn, string(conn.pkiID))
+	}
+	conn.close()
+}
+
 func (cs *connectionStore) closeByPKIid(pkiID common.PKIidType) {
 	cs.Lock()
 	defer cs.Unlock()
@@ -199,11 +233,21 @@ func newConnection(cl proto.GossipClient, c *grpc.ClientConn, cs proto.Gossip_Go
 		serverStream: ss,
 		stopFlag:     int32(0),
 		stopChan:     make(chan struct{}, 1),
+		established:  time.Now(),
+
+		compressionMinSize: util.GetIntOrDefault("peer.gossip.compression.minSize", defCompressionMinSize),
 	}
 	return connection
 }
 
 type connection struct {
+	compression        proto.CompressionType // compression algorithm of messages sent to the remote peer
+	compressionMinSize int                   // minimum size of a message payload to be compressed
+
+	sentMsgs     uint64 // accessed atomically
+	receivedMsgs uint64 // accessed atomically
+	droppedMsgs  uint64 // accessed atomically
+	established  time.Time
 	cancel       context.CancelFunc
 	info         *proto.ConnectionInfo
 	outBuff      chan *msgSending
@@ -247,6 +291,21 @@ func (conn *connection) close() {
 	}
 }
 
+func (conn *connection) stats() ConnectionStats {
+	stats := ConnectionStats{
+		PKIID:        conn.pkiID,
+		Outbound:     conn.clientStream != nil,
+		Established:  conn.established,
+		SentMsgs:     atomic.LoadUint64(&conn.sentMsgs),
+		ReceivedMsgs: atomic.LoadUint64(&conn.receivedMsgs),
+		DroppedMsgs:  atomic.LoadUint64(&conn.droppedMsgs),
+	}
+	if conn.info != nil {
+		stats.Endpoint = conn.info.Endpoint
+	}
+	return stats
+}
+
 func (conn *connection) toDie() bool {
 	return atomic.LoadInt32(&(conn.stopFlag)) == int32(1)
 }
@@ -269,6 +328,7 @@ func (conn *connection) send(msg *proto.SignedGossipMessage, onErr func(error),
 			conn.logger.Debug("Buffer to", conn.info.Endpoint, "overflowed, dropping message", msg.String())
 		}
 		if !shouldBlock {
+			atomic.AddUint64(&conn.droppedMsgs, 1)
 			return
 		}
 	}
@@ -298,6 +358,7 @@ func (conn *connection) serviceConnection() error {
 		case err := <-errChan:
 			return err
 		case msg := <-msgChan:
+			atomic.AddUint64(&conn.receivedMsgs, 1)
 			conn.handler(msg)
 		}
 	}
@@ -313,11 +374,12 @@ func (conn *connection) writeToStream() {
 		}
 		select {
 		case m := <-conn.outBuff:
-			err := stream.Send(m.envelope)
+			err := stream.Send(compressEnvelope(m.envelope, conn.compression, conn.compressionMinSize))
 			if err != nil {
 				go m.onErr(err)
 				return
 			}
+			atomic.AddUint64(&conn.sentMsgs, 1)
 		case stop := <-conn.stopChan:
 			conn.logger.Debug("Closing writing to stream")
 			conn.stopChan <- stop
@@ -354,6 +416,12 @@ func (conn *connection) readFromStream(errChan chan error, msgChan chan *proto.S
 			conn.logger.Debugf("%v Got error, aborting: %v", err)
 			return
 		}
+		envelope, err = decompressEnvelope(envelope)
+		if err != nil {
+			errChan <- err
+			conn.logger.Warningf("%v Got error, aborting: %v", conn.pkiID, err)
+			return
+		}
 		msg, err := envelope.ToGossipMessage()
 		if err != nil {
 			errChan <- err
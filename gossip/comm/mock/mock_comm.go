@@ -185,6 +185,11 @@ func (mock *commMock) CloseConn(peer *comm.RemotePeer) {
 	// NOOP
 }
 
+// ConnectionStats returns the statistics of the open connections to remote peers
+func (mock *commMock) ConnectionStats() []comm.ConnectionStats {
+	return nil
+}
+
 // Stop stops the module
 func (mock *commMock) Stop() {
 	logger.Debug("Stopping communication module, closing all accepting channels.")
@@ -76,6 +76,10 @@ type NetworkMember struct {
 	InternalEndpoint string
 	Properties       *proto.Properties
 	*proto.Envelope
+
+	// Unreachable indicates whether the member reported
+	// that its endpoint isn't reachable from other organizations
+	Unreachable bool
 }
 
 // String returns a string representation of the NetworkMember
@@ -118,12 +122,19 @@ type Discovery interface {
 	// UpdateEndpoint updates this instance's endpoint
 	UpdateEndpoint(string)
 
+	// UpdateReachability updates whether this instance's endpoint
+	// is reachable from other organizations
+	UpdateReachability(reachable bool)
+
 	// Stops this instance
 	Stop()
 
 	// GetMembership returns the alive members in the view
 	GetMembership() []NetworkMember
 
+	// GetDeadMembership returns the members in the view which are considered dead
+	GetDeadMembership() []NetworkMember
+
 	// InitiateSync makes the instance ask a given number of peers
 	// for their membership information
 	InitiateSync(peerNum int)
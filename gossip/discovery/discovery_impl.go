@@ -577,6 +577,7 @@ func (d *gossipDiscoveryImpl) resurrectMember(am *proto.SignedGossipMessage, t p
 		Metadata:         member.Metadata,
 		PKIid:            member.PkiId,
 		InternalEndpoint: internalEndpoint,
+		Unreachable:      am.GetAliveMsg().Unreachable,
 	}
 
 	delete(d.deadLastTS, string(pkiID))
@@ -741,6 +742,7 @@ func (d *gossipDiscoveryImpl) aliveMsgAndInternalEndpoint() (*proto.GossipMessag
 	meta := d.self.Metadata
 	pkiID := d.self.PKIid
 	internalEndpoint := d.self.InternalEndpoint
+	unreachable := d.self.Unreachable
 	msg := &proto.GossipMessage{
 		Tag: proto.GossipMessage_EMPTY,
 		Content: &proto.GossipMessage_AliveMsg{
@@ -754,6 +756,7 @@ func (d *gossipDiscoveryImpl) aliveMsgAndInternalEndpoint() (*proto.GossipMessag
 					IncNum: uint64(d.incTime),
 					SeqNum: seqNum,
 				},
+				Unreachable: unreachable,
 			},
 		},
 	}
@@ -806,6 +809,10 @@ func (d *gossipDiscoveryImpl) learnExistingMembers(aliveArr []*proto.SignedGossi
 		member.Endpoint = am.Membership.Endpoint
 		member.Metadata = am.Membership.Metadata
 		member.InternalEndpoint = internalEndpoint
+		if am.Unreachable && !member.Unreachable {
+			d.logger.Warning(am.Membership, "reports that its endpoint isn't reachable from other organizations")
+		}
+		member.Unreachable = am.Unreachable
 
 		if _, isKnownAsDead := d.deadLastTS[string(am.Membership.PkiId)]; isKnownAsDead {
 			d.logger.Warning(am.Membership, "has already expired")
@@ -894,6 +901,7 @@ func (d *gossipDiscoveryImpl) learnNewMembers(aliveMembers []*proto.SignedGossip
 				Metadata:         member.Membership.Metadata,
 				PKIid:            member.Membership.PkiId,
 				InternalEndpoint: internalEndpoint,
+				Unreachable:      member.Unreachable,
 			}
 		}
 	}
@@ -906,8 +914,23 @@ func (d *gossipDiscoveryImpl) GetMembership() []NetworkMember {
 	d.lock.RLock()
 	defer d.lock.RUnlock()
 
+	return d.membersOf(d.aliveMembership)
+}
+
+// GetDeadMembership returns the members in the view which are considered dead
+func (d *gossipDiscoveryImpl) GetDeadMembership() []NetworkMember {
+	if d.toDie() {
+		return []NetworkMember{}
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	return d.membersOf(d.deadMembership)
+}
+
+func (d *gossipDiscoveryImpl) membersOf(store *util.MembershipStore) []NetworkMember {
 	response := []NetworkMember{}
-	for _, m := range d.aliveMembership.ToSlice() {
+	for _, m := range store.ToSlice() {
 		member := m.GetAliveMsg()
 		response = append(response, NetworkMember{
 			PKIid:            member.Membership.PkiId,
@@ -915,10 +938,10 @@ func (d *gossipDiscoveryImpl) GetMembership() []NetworkMember {
 			Metadata:         member.Membership.Metadata,
 			InternalEndpoint: d.id2Member[string(m.GetAliveMsg().Membership.PkiId)].InternalEndpoint,
 			Envelope:         m.Envelope,
+			Unreachable:      member.Unreachable,
 		})
 	}
 	return response
-
 }
 
 func tsToTime(ts uint64) time.Time {
@@ -938,6 +961,13 @@ func (d *gossipDiscoveryImpl) UpdateEndpoint(endpoint string) {
 	d.self.Endpoint = endpoint
 }
 
+func (d *gossipDiscoveryImpl) UpdateReachability(reachable bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.self.Unreachable = !reachable
+}
+
 func (d *gossipDiscoveryImpl) Self() NetworkMember {
 	var env *proto.Envelope
 	msg, _ := d.aliveMsgAndInternalEndpoint()
@@ -949,10 +979,11 @@ func (d *gossipDiscoveryImpl) Self() NetworkMember {
 	}
 	mem := msg.GetAliveMsg().Membership
 	return NetworkMember{
-		Endpoint: mem.Endpoint,
-		Metadata: mem.Metadata,
-		PKIid:    mem.PkiId,
-		Envelope: env,
+		Endpoint:    mem.Endpoint,
+		Metadata:    mem.Metadata,
+		PKIid:       mem.PkiId,
+		Envelope:    env,
+		Unreachable: msg.GetAliveMsg().Unreachable,
 	}
 }
 
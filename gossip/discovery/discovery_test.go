@@ -502,6 +502,7 @@ func TestUpdate(t *testing.T) {
 
 	instances[0].UpdateMetadata([]byte("bla bla"))
 	instances[nodeNum-1].UpdateEndpoint("localhost:15511")
+	instances[0].UpdateReachability(false)
 
 	checkMembership := func() bool {
 		for _, member := range instances[nodeNum-1].GetMembership() {
@@ -511,6 +512,14 @@ func TestUpdate(t *testing.T) {
 				}
 			}
 		}
+		if member := instances[nodeNum-1].Lookup(instances[0].Self().PKIid); member == nil || !member.Unreachable {
+			return false
+		}
+		for _, member := range instances[nodeNum-1].GetMembership() {
+			if bytes.Equal(member.PKIid, instances[0].Self().PKIid) && !member.Unreachable {
+				return false
+			}
+		}
 
 		for _, member := range instances[0].GetMembership() {
 			if string(member.PKIid) == instances[nodeNum-1].comm.id {
@@ -566,6 +575,12 @@ func TestSelf(t *testing.T) {
 
 	assert.Equal(t, "localhost:13463", inst.Self().Endpoint)
 	assert.Equal(t, common.PKIidType("localhost:13463"), inst.Self().PKIid)
+	assert.False(t, inst.Self().Unreachable)
+
+	inst.UpdateReachability(false)
+	assert.True(t, inst.Self().Unreachable)
+	inst.UpdateReachability(true)
+	assert.False(t, inst.Self().Unreachable)
 }
 
 func TestExpiration(t *testing.T) {
@@ -593,6 +608,13 @@ func TestExpiration(t *testing.T) {
 
 	assertMembership(t, instances[:len(instances)-2], nodeNum-3)
 
+	waitUntilOrFail(t, func() bool {
+		return len(instances[0].GetDeadMembership()) == 2
+	})
+	deadPorts := portsOfMembers(instances[0].GetDeadMembership())
+	assert.Contains(t, deadPorts, 2610+nodeNum)
+	assert.Contains(t, deadPorts, 2610+nodeNum-1)
+
 	stopAction := &sync.WaitGroup{}
 	for i, inst := range instances {
 		if i+2 == nodeNum {
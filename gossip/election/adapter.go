@@ -34,6 +34,10 @@ func (mi *msgImpl) IsDeclaration() bool {
 	return mi.msg.GetLeadershipMsg().IsDeclaration
 }
 
+func (mi *msgImpl) Weight() uint32 {
+	return mi.msg.GetLeadershipMsg().Weight
+}
+
 type peerImpl struct {
 	member discovery.NetworkMember
 }
@@ -63,6 +67,8 @@ type adapterImpl struct {
 	incTime uint64
 	seqNum  uint64
 
+	weight uint32
+
 	channel common.ChainID
 
 	logger *logging.Logger
@@ -80,6 +86,8 @@ func NewAdapter(gossip gossip, pkiid common.PKIidType, channel common.ChainID) L
 		incTime: uint64(time.Now().UnixNano()),
 		seqNum:  uint64(0),
 
+		weight: getLeadershipWeight(),
+
 		channel: channel,
 
 		logger: util.GetLogger(util.LoggingElectionModule, ""),
@@ -131,6 +139,7 @@ func (ai *adapterImpl) CreateMessage(isDeclaration bool) Msg {
 			IncNum: ai.incTime,
 			SeqNum: seqNum,
 		},
+		Weight: ai.weight,
 	}
 
 	msg := &proto.GossipMessage{
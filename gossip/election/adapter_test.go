@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/util"
 	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/spf13/viper"
 )
 
 func init() {
@@ -66,6 +67,14 @@ func TestAdapterImpl_CreateMessage(t *testing.T) {
 	if !msg.IsProposal() || msg.IsDeclaration() {
 		t.Error("Newly created msg should be Proposal msg")
 	}
+
+	viper.Set("peer.gossip.election.weight", 3)
+	defer viper.Set("peer.gossip.election.weight", 0)
+	adapter = NewAdapter(mockGossip, selfNetworkMember.PKIid, []byte("channel0"))
+	msg = adapter.CreateMessage(false)
+	if msg.Weight() != 3 {
+		t.Error("Newly created msg should carry the configured weight")
+	}
 }
 
 func TestAdapterImpl_Peers(t *testing.T) {
@@ -19,7 +19,7 @@ import (
 
 // Gossip leader election module
 // Algorithm properties:
-// - Peers break symmetry by comparing IDs
+// - Peers break symmetry by comparing weights, and then IDs
 // - Each peer is either a leader or a follower,
 //   and the aim is to have exactly 1 leader if the membership view
 //   is the same for all peers
@@ -27,6 +27,9 @@ import (
 //   is the number of network partitions, but when the partition heals,
 //   only 1 leader should be left eventually
 // - Peers communicate by gossiping leadership proposal or declaration messages
+// - A peer with a greater weight than the leader takes over the leadership
+//   after following the leader for a stickiness period, so that preferred peers
+//   eventually lead without the leadership flapping while peers restart
 
 // The Algorithm, in pseudo code:
 //
@@ -57,6 +60,9 @@ import (
 //			If haven't received a leadership declaration within
 // 			a time threshold:
 //				set leaderKnown to false
+//			If the leader has a lower weight than yourself, and
+//			you have been following it for the stickiness period:
+//				become a leader
 //
 // LeaderElection():
 // 	Gossip leadership proposal message
@@ -64,8 +70,8 @@ import (
 //	If received a leadership declaration:
 //		return
 //	Iterate over all proposal messages collected.
-// 	If a proposal message from a peer with an ID lower
-// 	than yourself was received, return.
+// 	If a proposal message from a peer with a greater weight,
+// 	or the same weight and an ID lower than yourself was received, return.
 //	Else, declare yourself a leader
 
 // LeaderElectionAdapter is used by the leader election module
@@ -97,6 +103,10 @@ type LeaderElectionService interface {
 	// Yield relinquishes the leadership until a new leader is elected,
 	// or a timeout expires
 	Yield()
+
+	// Leader returns the ID of the peer last known to be the leader,
+	// or nil if no leader is currently known
+	Leader() []byte
 }
 
 type peerID []byte
@@ -115,6 +125,14 @@ type Msg interface {
 	IsProposal() bool
 	// IsDeclaration returns whether this message is a leadership declaration
 	IsDeclaration() bool
+	// Weight returns the leadership weight of the peer sent the message
+	Weight() uint32
+}
+
+// candidate is a peer which proposed itself as a leader
+type candidate struct {
+	id     string
+	weight uint32
 }
 
 func noopCallback(_ bool) {
@@ -122,11 +140,16 @@ func noopCallback(_ bool) {
 
 // NewLeaderElectionService returns a new LeaderElectionService
 func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback leadershipCallback) LeaderElectionService {
+	return newLeaderElectionService(adapter, id, getLeadershipWeight(), callback)
+}
+
+func newLeaderElectionService(adapter LeaderElectionAdapter, id string, weight uint32, callback leadershipCallback) LeaderElectionService {
 	if len(id) == 0 {
 		panic("Empty id")
 	}
 	le := &leaderElectionSvcImpl{
 		id:            peerID(id),
+		weight:        weight,
 		proposals:     util.NewSet(),
 		adapter:       adapter,
 		stopChan:      make(chan struct{}, 1),
@@ -160,6 +183,10 @@ type leaderElectionSvcImpl struct {
 	logger        *logging.Logger
 	callback      leadershipCallback
 	yieldTimer    *time.Timer
+	leaderID      atomic.Value
+
+	weight         uint32    // leadership weight of the peer
+	followingSince time.Time // time the peer started following the current leader
 }
 
 func (le *leaderElectionSvcImpl) start() {
@@ -199,21 +226,53 @@ func (le *leaderElectionSvcImpl) handleMessage(msg Msg) {
 	defer le.Unlock()
 
 	if msg.IsProposal() {
-		le.proposals.Add(string(msg.SenderID()))
+		le.proposals.Add(candidate{id: string(msg.SenderID()), weight: msg.Weight()})
 	} else if msg.IsDeclaration() {
 		atomic.StoreInt32(&le.leaderExists, int32(1))
 		if le.sleeping && len(le.interruptChan) == 0 {
 			le.interruptChan <- struct{}{}
 		}
-		if bytes.Compare(msg.SenderID(), le.id) < 0 && le.IsLeader() {
+		if le.isBetterCandidate(msg.SenderID(), msg.Weight()) && le.IsLeader() {
 			le.stopBeingLeader()
 		}
+		// A leader only acknowledges declarations of better candidates
+		if !le.IsLeader() {
+			le.follow(msg.SenderID(), msg.Weight())
+		}
 	} else {
 		// We shouldn't get here
 		le.logger.Error("Got a message that's not a proposal and not a declaration")
 	}
 }
 
+// follow records the given peer as the leader, and takes over the leadership if the
+// leader has a lower weight and the peer has been following it for the stickiness period
+func (le *leaderElectionSvcImpl) follow(leader peerID, weight uint32) {
+	if !bytes.Equal(le.Leader(), leader) {
+		le.leaderID.Store(leader)
+		le.followingSince = time.Now()
+		return
+	}
+	if weight >= le.weight || le.isYielding() || time.Since(le.followingSince) < getStickiness() {
+		return
+	}
+	le.logger.Info(le.id, ": Taking over the leadership from", leader, "which has a lower weight of", weight)
+	le.beLeader()
+	// Wake up the follower, so it declares the leadership right away
+	if len(le.interruptChan) == 0 {
+		le.interruptChan <- struct{}{}
+	}
+}
+
+// isBetterCandidate returns whether the peer of the given ID and weight
+// is a better candidate than us for being a leader
+func (le *leaderElectionSvcImpl) isBetterCandidate(id peerID, weight uint32) bool {
+	if weight != le.weight {
+		return weight > le.weight
+	}
+	return bytes.Compare(id, le.id) < 0
+}
+
 // waitForInterrupt sleeps until the interrupt channel is triggered
 // or given timeout expires
 func (le *leaderElectionSvcImpl) waitForInterrupt(timeout time.Duration) {
@@ -269,6 +328,7 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 	if le.isYielding() {
 		return
 	}
+	le.leaderID.Store(peerID(nil))
 	// Propose ourselves as a leader
 	le.propose()
 	// Collect other proposals
@@ -287,8 +347,8 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 	// Leader doesn't exist, let's see if there is a better candidate than us
 	// for being a leader
 	for _, o := range le.proposals.ToArray() {
-		id := o.(string)
-		if bytes.Compare(peerID(id), le.id) < 0 {
+		c := o.(candidate)
+		if le.isBetterCandidate(peerID(c.id), c.weight) {
 			return
 		}
 	}
@@ -314,6 +374,7 @@ func (le *leaderElectionSvcImpl) follower() {
 	atomic.StoreInt32(&le.leaderExists, int32(0))
 	select {
 	case <-time.After(getLeaderAliveThreshold()):
+	case <-le.interruptChan:
 	case <-le.stopChan:
 		le.stopChan <- struct{}{}
 	}
@@ -371,15 +432,26 @@ func (le *leaderElectionSvcImpl) IsLeader() bool {
 	return isLeader
 }
 
+// Leader returns the ID of the peer last known to be the leader,
+// or nil if no leader is currently known
+func (le *leaderElectionSvcImpl) Leader() []byte {
+	id, _ := le.leaderID.Load().(peerID)
+	return id
+}
+
 func (le *leaderElectionSvcImpl) beLeader() {
 	le.logger.Info(le.id, ": Becoming a leader")
 	atomic.StoreInt32(&le.isLeader, int32(1))
+	le.leaderID.Store(le.id)
 	le.callback(true)
 }
 
 func (le *leaderElectionSvcImpl) stopBeingLeader() {
 	le.logger.Info(le.id, "Stopped being a leader")
 	atomic.StoreInt32(&le.isLeader, int32(0))
+	if bytes.Equal(le.Leader(), le.id) {
+		le.leaderID.Store(peerID(nil))
+	}
 	le.callback(false)
 }
 
@@ -451,6 +523,12 @@ func SetLeaderElectionDuration(t time.Duration) {
 	viper.Set("peer.gossip.election.leaderElectionDuration", t)
 }
 
+// SetStickiness configures the time a peer follows a leader with a lower weight
+// before it takes over the leadership
+func SetStickiness(t time.Duration) {
+	viper.Set("peer.gossip.election.stickiness", t)
+}
+
 func getStartupGracePeriod() time.Duration {
 	return util.GetDurationOrDefault("peer.gossip.election.startupGracePeriod", time.Second*15)
 }
@@ -471,6 +549,18 @@ func getLeaderElectionDuration() time.Duration {
 	return util.GetDurationOrDefault("peer.gossip.election.leaderElectionDuration", time.Second*5)
 }
 
+func getStickiness() time.Duration {
+	return util.GetDurationOrDefault("peer.gossip.election.stickiness", time.Minute)
+}
+
+func getLeadershipWeight() uint32 {
+	weight := viper.GetInt("peer.gossip.election.weight")
+	if weight < 0 {
+		return 0
+	}
+	return uint32(weight)
+}
+
 // GetMsgExpirationTimeout return leadership message expiration timeout
 func GetMsgExpirationTimeout() time.Duration {
 	return getLeaderAliveThreshold() * 10
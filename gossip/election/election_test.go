@@ -32,11 +32,13 @@ func init() {
 	SetMembershipSampleInterval(time.Millisecond * 100)
 	SetLeaderAliveThreshold(time.Millisecond * 500)
 	SetLeaderElectionDuration(time.Millisecond * 500)
+	SetStickiness(time.Second * 2)
 }
 
 type msg struct {
 	sender   string
 	proposal bool
+	weight   uint32
 }
 
 func (m *msg) SenderID() peerID {
@@ -51,10 +53,15 @@ func (m *msg) IsDeclaration() bool {
 	return !m.proposal
 }
 
+func (m *msg) Weight() uint32 {
+	return m.weight
+}
+
 type peer struct {
 	mockedMethods map[string]struct{}
 	mock.Mock
 	id                 string
+	weight             uint32
 	peers              map[string]*peer
 	sharedLock         *sync.RWMutex
 	msgChan            chan Msg
@@ -104,7 +111,7 @@ func (p *peer) Accept() <-chan Msg {
 }
 
 func (p *peer) CreateMessage(isDeclaration bool) Msg {
-	return &msg{proposal: !isDeclaration, sender: p.id}
+	return &msg{proposal: !isDeclaration, sender: p.id, weight: p.weight}
 }
 
 func (p *peer) Peers() []Peer {
@@ -157,10 +164,14 @@ func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
 }
 
 func createPeer(id int, peerMap map[string]*peer, l *sync.RWMutex) *peer {
+	return createWeightedPeer(id, 0, peerMap, l)
+}
+
+func createWeightedPeer(id int, weight uint32, peerMap map[string]*peer, l *sync.RWMutex) *peer {
 	idStr := fmt.Sprintf("p%d", id)
 	c := make(chan Msg, 100)
-	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false}
-	p.LeaderElectionService = NewLeaderElectionService(p, idStr, p.leaderCallback)
+	p := &peer{id: idStr, weight: weight, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false}
+	p.LeaderElectionService = newLeaderElectionService(p, idStr, weight, p.leaderCallback)
 	l.Lock()
 	peerMap[idStr] = p
 	l.Unlock()
@@ -289,6 +300,10 @@ func TestConvergence(t *testing.T) {
 				waitForBoolFunc(t, p.isCallbackInvoked, true, "Leadership callback wasn't invoked for ", p.id)
 			}
 		}
+		knowsLeader := func() bool {
+			return string(p.Leader()) == finalLeaders[0]
+		}
+		waitForBoolFunc(t, knowsLeader, true, "Peer ", p.id, " doesn't know the leader")
 	}
 }
 
@@ -308,6 +323,63 @@ func TestLeadershipTakeover(t *testing.T) {
 	assert.Equal(t, "p2", leaders[0])
 }
 
+func TestWeightedElection(t *testing.T) {
+	t.Parallel()
+	// Scenario: Peers are spawned at the same time, and p3 has a greater weight than the rest
+	// expected outcome: p3 is the leader although its ID isn't the lowest
+	peerMap := make(map[string]*peer)
+	l := &sync.RWMutex{}
+	var peers []*peer
+	for _, id := range []int{0, 1, 2} {
+		peers = append(peers, createPeer(id, peerMap, l))
+	}
+	peers = append(peers, createWeightedPeer(3, 1, peerMap, l))
+	time.Sleep(getStartupGracePeriod() + getLeaderElectionDuration())
+	leaders := waitForLeaderElection(t, peers)
+	assert.Equal(t, []string{"p3"}, leaders)
+	for _, p := range peers {
+		knowsLeader := func() bool {
+			return string(p.Leader()) == "p3"
+		}
+		waitForBoolFunc(t, knowsLeader, true, "Peer ", p.id, " doesn't know the leader")
+	}
+}
+
+func TestWeightedTakeover(t *testing.T) {
+	t.Parallel()
+	// Scenario: Peers with the same weight elect p0 as a leader,
+	// and then p5 with a greater weight is spawned.
+	// expected outcome: p5 follows p0 for the stickiness period,
+	// and then takes over the leadership
+	peerMap := make(map[string]*peer)
+	l := &sync.RWMutex{}
+	peers := []*peer{createPeer(2, peerMap, l), createPeer(1, peerMap, l), createPeer(0, peerMap, l)}
+	leaders := waitForLeaderElection(t, peers)
+	assert.Equal(t, []string{"p0"}, leaders)
+
+	p5 := createWeightedPeer(5, 1, peerMap, l)
+	peers = append(peers, p5)
+	knowsLeader := func() bool {
+		return string(p5.Leader()) == "p0"
+	}
+	waitForBoolFunc(t, knowsLeader, true, "p5 doesn't know the leader")
+	assert.False(t, p5.IsLeader(), "p5 took over the leadership before the stickiness period passed")
+
+	time.Sleep(getStickiness())
+	waitForBoolFunc(t, p5.IsLeader, true, "p5 didn't take over the leadership")
+	waitForBoolFunc(t, peers[2].IsLeader, false, "p0 didn't stop being a leader")
+	waitForBoolFunc(t, p5.isLeaderFromCallback, true, "Leadership callback result is wrong for p5")
+	waitForBoolFunc(t, peers[2].isLeaderFromCallback, false, "Leadership callback result is wrong for p0")
+	leaders = waitForLeaderElection(t, peers)
+	assert.Equal(t, []string{"p5"}, leaders)
+	for _, p := range peers {
+		knowsLeader := func() bool {
+			return string(p.Leader()) == "p5"
+		}
+		waitForBoolFunc(t, knowsLeader, true, "Peer ", p.id, " doesn't know the leader")
+	}
+}
+
 func TestYield(t *testing.T) {
 	t.Parallel()
 	// Scenario: Peers spawn and a leader is elected.
@@ -422,6 +494,7 @@ func TestConfigFromFile(t *testing.T) {
 	preMembershipSampleInterval := getMembershipSampleInterval()
 	preLeaderAliveThreshold := getLeaderAliveThreshold()
 	preLeaderElectionDuration := getLeaderElectionDuration()
+	preStickiness := getStickiness()
 
 	// Recover the config values in order to avoid impacting other tests
 	defer func() {
@@ -429,6 +502,7 @@ func TestConfigFromFile(t *testing.T) {
 		SetMembershipSampleInterval(preMembershipSampleInterval)
 		SetLeaderAliveThreshold(preLeaderAliveThreshold)
 		SetLeaderElectionDuration(preLeaderElectionDuration)
+		SetStickiness(preStickiness)
 	}()
 
 	// Verify if using default values when config is missing
@@ -438,6 +512,8 @@ func TestConfigFromFile(t *testing.T) {
 	assert.Equal(t, time.Second*10, getLeaderAliveThreshold())
 	assert.Equal(t, time.Second*5, getLeaderElectionDuration())
 	assert.Equal(t, getLeaderAliveThreshold()/2, getLeadershipDeclarationInterval())
+	assert.Equal(t, time.Minute, getStickiness())
+	assert.Equal(t, uint32(0), getLeadershipWeight())
 
 	//Verify reading the values from config file
 	viper.Reset()
@@ -453,6 +529,8 @@ func TestConfigFromFile(t *testing.T) {
 	assert.Equal(t, time.Second*10, getLeaderAliveThreshold())
 	assert.Equal(t, time.Second*5, getLeaderElectionDuration())
 	assert.Equal(t, getLeaderAliveThreshold()/2, getLeadershipDeclarationInterval())
+	assert.Equal(t, time.Minute, getStickiness())
+	assert.Equal(t, uint32(0), getLeadershipWeight())
 }
 
 func waitForBoolFunc(t *testing.T, f func() bool, expectedValue bool, msgAndArgs ...interface{}) {
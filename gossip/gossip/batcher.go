@@ -18,7 +18,8 @@ type emitBatchCallback func([]interface{})
 
 //batchingEmitter is used for the gossip push/forwarding phase.
 // Messages are added into the batchingEmitter, and they are forwarded periodically T times in batches and then discarded.
-// If the batchingEmitter's stored message count reaches a certain capacity, that also triggers a message dispatch
+// If the batchingEmitter's stored message count or total message size reaches a certain capacity,
+// that also triggers a message dispatch
 type batchingEmitter interface {
 	// Add adds a message to be batched
 	Add(interface{})
@@ -33,9 +34,10 @@ type batchingEmitter interface {
 // newBatchingEmitter accepts the following parameters:
 // iterations: number of times each message is forwarded
 // burstSize: a threshold that triggers a forwarding because of message count
+// burstBytes: a threshold that triggers a forwarding because of total message size, or 0 for none
 // latency: the maximum delay that each message can be stored without being forwarded
 // cb: a callback that is called in order for the forwarding to take place
-func newBatchingEmitter(iterations, burstSize int, latency time.Duration, cb emitBatchCallback) batchingEmitter {
+func newBatchingEmitter(iterations, burstSize, burstBytes int, latency time.Duration, cb emitBatchCallback) batchingEmitter {
 	if iterations < 0 {
 		panic(errors.Errorf("Got a negative iterations number"))
 	}
@@ -45,6 +47,7 @@ func newBatchingEmitter(iterations, burstSize int, latency time.Duration, cb emi
 		delay:      latency,
 		iterations: iterations,
 		burstSize:  burstSize,
+		burstBytes: burstBytes,
 		lock:       &sync.Mutex{},
 		buff:       make([]*batchedMessage, 0),
 		stopFlag:   int32(0),
@@ -88,6 +91,7 @@ func (p *batchingEmitterImpl) decrementCounters() {
 		msg := p.buff[i]
 		msg.iterationsLeft--
 		if msg.iterationsLeft == 0 {
+			p.buffBytes -= msg.size
 			p.buff = append(p.buff[:i], p.buff[i+1:]...)
 			n--
 			i--
@@ -107,11 +111,22 @@ type batchingEmitterImpl struct {
 	lock       *sync.Mutex
 	buff       []*batchedMessage
 	stopFlag   int32
+
+	burstBytes int // threshold of the total size of the buffered messages
+	buffBytes  int // total size of the buffered messages
 }
 
 type batchedMessage struct {
 	data           interface{}
 	iterationsLeft int
+	size           int
+}
+
+// sizedMessage is a message whose size counts
+// towards the burst bytes threshold
+type sizedMessage interface {
+	// Size returns the size of the message in bytes
+	Size() int
 }
 
 func (p *batchingEmitterImpl) Stop() {
@@ -131,9 +146,14 @@ func (p *batchingEmitterImpl) Add(message interface{}) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
-	p.buff = append(p.buff, &batchedMessage{data: message, iterationsLeft: p.iterations})
+	msg := &batchedMessage{data: message, iterationsLeft: p.iterations}
+	if sm, isSized := message.(sizedMessage); isSized {
+		msg.size = sm.Size()
+	}
+	p.buff = append(p.buff, msg)
+	p.buffBytes += msg.size
 
-	if len(p.buff) >= p.burstSize {
+	if len(p.buff) >= p.burstSize || (p.burstBytes > 0 && p.buffBytes >= p.burstBytes) {
 		p.emit()
 	}
 }
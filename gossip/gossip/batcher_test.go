@@ -21,7 +21,7 @@ func init() {
 }
 
 func TestBatchingEmitterAddAndSize(t *testing.T) {
-	emitter := newBatchingEmitter(1, 10, time.Second, func(a []interface{}) {})
+	emitter := newBatchingEmitter(1, 10, 0, time.Second, func(a []interface{}) {})
 	defer emitter.Stop()
 	emitter.Add(1)
 	emitter.Add(2)
@@ -36,7 +36,7 @@ func TestBatchingEmitterStop(t *testing.T) {
 		atomic.AddInt32(&disseminationAttempts, int32(1))
 	}
 
-	emitter := newBatchingEmitter(10, 1, time.Duration(100)*time.Millisecond, cb)
+	emitter := newBatchingEmitter(10, 1, 0, time.Duration(100)*time.Millisecond, cb)
 	emitter.Add(1)
 	time.Sleep(time.Duration(100) * time.Millisecond)
 	emitter.Stop()
@@ -52,7 +52,7 @@ func TestBatchingEmitterExpiration(t *testing.T) {
 		atomic.AddInt32(&disseminationAttempts, int32(1))
 	}
 
-	emitter := newBatchingEmitter(10, 1, time.Duration(10)*time.Millisecond, cb)
+	emitter := newBatchingEmitter(10, 1, 0, time.Duration(10)*time.Millisecond, cb)
 	defer emitter.Stop()
 
 	emitter.Add(1)
@@ -78,7 +78,7 @@ func TestBatchingEmitterCounter(t *testing.T) {
 		}
 	}
 
-	emitter := newBatchingEmitter(5, 100, time.Duration(500)*time.Millisecond, cb)
+	emitter := newBatchingEmitter(5, 100, 0, time.Duration(500)*time.Millisecond, cb)
 	defer emitter.Stop()
 
 	for i := 1; i <= 5; i++ {
@@ -104,7 +104,7 @@ func TestBatchingEmitterBurstSizeCap(t *testing.T) {
 	cb := func(a []interface{}) {
 		atomic.AddInt32(&disseminationAttempts, int32(1))
 	}
-	emitter := newBatchingEmitter(1, 10, time.Duration(800)*time.Millisecond, cb)
+	emitter := newBatchingEmitter(1, 10, 0, time.Duration(800)*time.Millisecond, cb)
 	defer emitter.Stop()
 
 	for i := 0; i < 50; i++ {
@@ -112,3 +112,34 @@ func TestBatchingEmitterBurstSizeCap(t *testing.T) {
 	}
 	assert.Equal(t, int32(5), atomic.LoadInt32(&disseminationAttempts))
 }
+
+type sizedMsg int
+
+func (m sizedMsg) Size() int {
+	return int(m)
+}
+
+// TestBatchingEmitterBurstBytesCap tests that the emitter emits
+// once the total size of the buffered messages reaches the threshold
+func TestBatchingEmitterBurstBytesCap(t *testing.T) {
+	disseminationAttempts := int32(0)
+	cb := func(a []interface{}) {
+		atomic.AddInt32(&disseminationAttempts, int32(1))
+	}
+	emitter := newBatchingEmitter(1, 10, 1000, time.Duration(800)*time.Millisecond, cb)
+	defer emitter.Stop()
+
+	for i := 0; i < 3; i++ {
+		emitter.Add(sizedMsg(400))
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&disseminationAttempts))
+	assert.Equal(t, 0, emitter.Size())
+
+	// Messages which aren't sized only count towards the burst size
+	emitter.Add(sizedMsg(999))
+	emitter.Add(1)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&disseminationAttempts))
+	emitter.Add(sizedMsg(1))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&disseminationAttempts))
+	assert.Equal(t, 0, emitter.Size())
+}
@@ -40,6 +40,9 @@ type Gossip interface {
 	// and also subscribed to the channel given
 	PeersOfChannel(common.ChainID) []discovery.NetworkMember
 
+	// DeadPeers returns the NetworkMembers considered dead
+	DeadPeers() []discovery.NetworkMember
+
 	// UpdateMetadata updates the self metadata of the discovery layer
 	// the peer publishes to other peers
 	UpdateMetadata(metadata []byte)
@@ -81,6 +84,13 @@ type Gossip interface {
 	// IdentityInfo returns information known peer identities
 	IdentityInfo() api.PeerIdentitySet
 
+	// ConnectionStats returns statistics about the connections to remote peers
+	ConnectionStats() []comm.ConnectionStats
+
+	// Reachability returns the outcome of the last verification that
+	// the external endpoint of the peer is reachable from other organizations
+	Reachability() ReachabilityStatus
+
 	// Stop stops the gossip component
 	Stop()
 }
@@ -92,6 +102,14 @@ type emittedGossipMessage struct {
 	filter func(id common.PKIidType) bool
 }
 
+// Size returns the size of the payload of the message
+func (m *emittedGossipMessage) Size() int {
+	if m.Envelope == nil {
+		return 0
+	}
+	return len(m.Envelope.Payload)
+}
+
 // SendCriteria defines how to send a specific message
 type SendCriteria struct {
 	Timeout    time.Duration        // Timeout defines the time to wait for acknowledgements
@@ -107,6 +125,19 @@ func (sc SendCriteria) String() string {
 	return fmt.Sprintf("channel: %s, tout: %v, minAck: %d, maxPeers: %d", sc.Channel, sc.Timeout, sc.MinAck, sc.MaxPeers)
 }
 
+// AckError is returned by SendByCriteria when fewer peers
+// than required by the SendCriteria acknowledged the message
+type AckError struct {
+	// AckCount is the number of peers that acknowledged the message
+	AckCount int
+	msg      string
+}
+
+// Error returns the reason fewer peers than required acknowledged the message
+func (e *AckError) Error() string {
+	return e.msg
+}
+
 // Config is the configuration of the gossip component
 type Config struct {
 	BindPort            int      // Port we bind to, used only for tests
@@ -119,6 +150,7 @@ type Config struct {
 
 	MaxPropagationBurstSize    int           // Max number of messages stored until it triggers a push to remote peers
 	MaxPropagationBurstLatency time.Duration // Max time between consecutive message pushes
+	MaxPropagationBurstBytes   int           // Max total size of messages stored until it triggers a push, or 0 for no limit
 
 	PullInterval time.Duration // Determines frequency of pull phases
 	PullPeerNum  int           // Number of peers to pull from
@@ -133,4 +165,12 @@ type Config struct {
 
 	InternalEndpoint string // Endpoint we publish to peers in our organization
 	ExternalEndpoint string // Peer publishes this endpoint instead of SelfEndpoint to foreign organizations
+
+	ReachabilityProbeInterval time.Duration // Determines frequency of verifying the external endpoint is reachable, or 0 to disable
+	ReachabilityProbeTimeout  time.Duration // Time to wait for peers of other organizations to probe the external endpoint
+	ReachabilityProbePeerNum  int           // Number of peers of other organizations asked to probe the external endpoint
+
+	SigVerificationWorkers   int // Number of goroutines verifying the signatures of alive, state info and leadership messages
+	SigVerificationQueueSize int // Max number of received messages waiting for their signatures to be verified
+	SigVerificationCacheSize int // Max number of successful signature verifications cached
 }
@@ -61,6 +61,11 @@ type gossipServiceImpl struct {
 	mcs               api.MessageCryptoService
 	stateInfoMsgStore msgstore.MessageStore
 	certPuller        pull.Mediator
+
+	reachability *reachabilityProber
+
+	sigCache    *sigVerificationCache
+	sigVerifier *sigVerificationPool
 }
 
 // NewGossipService creates a gossip instance attached to a gRPC server
@@ -87,10 +92,13 @@ func NewGossipService(conf *Config, s *grpc.Server, secAdvisor api.SecurityAdvis
 		includeIdentityPeriod: time.Now().Add(conf.PublishCertPeriod),
 	}
 	g.stateInfoMsgStore = g.newStateInfoMsgStore()
+	g.sigCache = newSigVerificationCache(conf.SigVerificationCacheSize)
 
 	g.idMapper = identity.NewIdentityMapper(mcs, selfIdentity, func(pkiID common.PKIidType, identity api.PeerIdentityType) {
 		g.comm.CloseConn(&comm.RemotePeer{PKIID: pkiID})
 		g.certPuller.Remove(string(pkiID))
+		// Signatures of purged identities may no longer be valid
+		g.sigCache.purge()
 	}, secAdvisor)
 
 	if s == nil {
@@ -105,8 +113,9 @@ func NewGossipService(conf *Config, s *grpc.Server, secAdvisor api.SecurityAdvis
 	}
 
 	g.chanState = newChannelState(g)
+	g.sigVerifier = newSigVerificationPool(conf.SigVerificationWorkers, conf.SigVerificationQueueSize, g.handleMessage, lgr)
 	g.emitter = newBatchingEmitter(conf.PropagateIterations,
-		conf.MaxPropagationBurstSize, conf.MaxPropagationBurstLatency,
+		conf.MaxPropagationBurstSize, conf.MaxPropagationBurstBytes, conf.MaxPropagationBurstLatency,
 		g.sendGossipBatch)
 
 	g.discAdapter = g.newDiscoveryAdapter()
@@ -117,8 +126,11 @@ func NewGossipService(conf *Config, s *grpc.Server, secAdvisor api.SecurityAdvis
 	g.certPuller = g.createCertStorePuller()
 	g.certStore = newCertStore(g.certPuller, g.idMapper, selfIdentity, mcs)
 
+	g.reachability = newReachabilityProber(g)
 	if g.conf.ExternalEndpoint == "" {
 		g.logger.Warning("External endpoint is empty, peer will not be accessible outside of its organization")
+	} else if g.conf.ReachabilityProbeInterval > 0 {
+		go g.reachability.run()
 	}
 
 	go g.start()
@@ -322,6 +334,10 @@ func (g *gossipServiceImpl) acceptMessages(incMsgs <-chan proto.ReceivedMessage)
 			g.toDieChan <- s
 			return
 		case msg := <-incMsgs:
+			if needsSigVerification(msg) {
+				g.sigVerifier.submit(msg)
+				continue
+			}
 			g.handleMessage(msg)
 		}
 	}
@@ -346,6 +362,11 @@ func (g *gossipServiceImpl) handleMessage(m proto.ReceivedMessage) {
 		return
 	}
 
+	if msg.IsReachabilityMsg() {
+		g.reachability.handleMessage(m)
+		return
+	}
+
 	if msg.IsChannelRestricted() {
 		if gc := g.chanState.lookupChannelForMsg(m); gc == nil {
 			// If we're not in the channel, we should still forward to peers of our org
@@ -609,6 +630,17 @@ func (g *gossipServiceImpl) IdentityInfo() api.PeerIdentitySet {
 	return g.idMapper.IdentityInfo()
 }
 
+// ConnectionStats returns statistics about the connections to remote peers
+func (g *gossipServiceImpl) ConnectionStats() []comm.ConnectionStats {
+	return g.comm.ConnectionStats()
+}
+
+// Reachability returns the outcome of the last verification that
+// the external endpoint of the peer is reachable from other organizations
+func (g *gossipServiceImpl) Reachability() ReachabilityStatus {
+	return g.reachability.Status()
+}
+
 // SendByCriteria sends a given message to all peers that match the given SendCriteria
 func (g *gossipServiceImpl) SendByCriteria(msg *proto.SignedGossipMessage, criteria SendCriteria) error {
 	if criteria.Timeout == 0 {
@@ -634,7 +666,7 @@ func (g *gossipServiceImpl) SendByCriteria(msg *proto.SignedGossipMessage, crite
 
 	peers2send := filter.SelectPeers(criteria.MaxPeers, membership, criteria.IsEligible)
 	if len(peers2send) < criteria.MinAck {
-		return fmt.Errorf("Requested to send to at least %d peers, but know only of %d suitable peers", criteria.MinAck, len(peers2send))
+		return &AckError{msg: fmt.Sprintf("Requested to send to at least %d peers, but know only of %d suitable peers", criteria.MinAck, len(peers2send))}
 	}
 
 	results := g.comm.SendWithAck(msg, criteria.Timeout, criteria.MinAck, peers2send...)
@@ -647,7 +679,7 @@ func (g *gossipServiceImpl) SendByCriteria(msg *proto.SignedGossipMessage, crite
 	}
 
 	if results.AckCount() < criteria.MinAck {
-		return errors.New(results.String())
+		return &AckError{AckCount: results.AckCount(), msg: results.String()}
 	}
 	return nil
 }
@@ -715,6 +747,11 @@ func (g *gossipServiceImpl) Peers() []discovery.NetworkMember {
 	return g.disc.GetMembership()
 }
 
+// DeadPeers returns the NetworkMembers considered dead
+func (g *gossipServiceImpl) DeadPeers() []discovery.NetworkMember {
+	return g.disc.GetDeadMembership()
+}
+
 // PeersOfChannel returns the NetworkMembers considered alive
 // and also subscribed to the channel given
 func (g *gossipServiceImpl) PeersOfChannel(channel common.ChainID) []discovery.NetworkMember {
@@ -758,6 +795,7 @@ func (g *gossipServiceImpl) Stop() {
 	}
 	atomic.StoreInt32(&g.stopFlag, int32(1))
 	g.logger.Info("Stopping gossip")
+	g.sigVerifier.stop()
 	comWG := sync.WaitGroup{}
 	comWG.Add(1)
 	go func() {
@@ -982,6 +1020,7 @@ type discoverySecurityAdapter struct {
 	mcs                   api.MessageCryptoService
 	c                     comm.Comm
 	logger                *logging.Logger
+	sigCache              *sigVerificationCache
 }
 
 func (g *gossipServiceImpl) newDiscoverySecurityAdapter() *discoverySecurityAdapter {
@@ -991,6 +1030,7 @@ func (g *gossipServiceImpl) newDiscoverySecurityAdapter() *discoverySecurityAdap
 		mcs:                   g.mcs,
 		c:                     g.comm,
 		logger:                g.logger,
+		sigCache:              g.sigCache,
 		includeIdentityPeriod: g.includeIdentityPeriod,
 		identity:              g.selfIdentity,
 	}
@@ -1061,9 +1101,9 @@ func (sa *discoverySecurityAdapter) SignMessage(m *proto.GossipMessage, internal
 func (sa *discoverySecurityAdapter) validateAliveMsgSignature(m *proto.SignedGossipMessage, identity api.PeerIdentityType) bool {
 	am := m.GetAliveMsg()
 	// At this point we got the certificate of the peer, proceed to verifying the AliveMessage
-	verifier := func(peerIdentity []byte, signature, message []byte) error {
+	verifier := sa.sigCache.wrap(func(peerIdentity []byte, signature, message []byte) error {
 		return sa.mcs.Verify(api.PeerIdentityType(peerIdentity), signature, message)
-	}
+	})
 
 	// We verify the signature on the message
 	err := m.Verify(identity, verifier)
@@ -1207,19 +1247,19 @@ func (g *gossipServiceImpl) validateLeadershipMessage(msg *proto.SignedGossipMes
 	if err != nil {
 		return errors.Wrap(err, "Unable to fetch PKI-ID from id-mapper")
 	}
-	return msg.Verify(identity, func(peerIdentity []byte, signature, message []byte) error {
+	return msg.Verify(identity, g.sigCache.wrap(func(peerIdentity []byte, signature, message []byte) error {
 		return g.mcs.Verify(identity, signature, message)
-	})
+	}))
 }
 
 func (g *gossipServiceImpl) validateStateInfoMsg(msg *proto.SignedGossipMessage) error {
-	verifier := func(identity []byte, signature, message []byte) error {
+	verifier := g.sigCache.wrap(func(identity []byte, signature, message []byte) error {
 		pkiID := g.idMapper.GetPKIidOfCert(api.PeerIdentityType(identity))
 		if pkiID == nil {
 			return errors.New("PKI-ID not found in identity mapper")
 		}
 		return g.idMapper.Verify(pkiID, signature, message)
-	}
+	})
 	identity, err := g.idMapper.Get(msg.GetStateInfo().PkiId)
 	if err != nil {
 		return errors.WithStack(err)
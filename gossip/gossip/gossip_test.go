@@ -53,6 +53,7 @@ var tests = []func(t *testing.T){
 	TestAnchorPeer,
 	TestBootstrapPeerMisConfiguration,
 	TestNoMessagesSelfLoop,
+	TestExternalEndpointReachability,
 }
 
 func init() {
@@ -1169,6 +1170,8 @@ func TestSendByCriteria(t *testing.T) {
 	err = g1.SendByCriteria(msg, criteria)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "Requested to send to at least 10 peers, but know only of")
+	assert.IsType(t, &AckError{}, err)
+	assert.Equal(t, 0, err.(*AckError).AckCount)
 
 	// We send to a minimum of 3 peers with acknowledgement, while no peer acknowledges the messages.
 	// Wait until g1 sees the rest of the peers in the channel
@@ -1211,6 +1214,8 @@ func TestSendByCriteria(t *testing.T) {
 	err = g1.SendByCriteria(msg, criteria)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "uh oh")
+	assert.IsType(t, &AckError{}, err)
+	assert.Equal(t, 1, err.(*AckError).AckCount)
 
 	// We try to send to either g2 or g3, but neither would ack us, so we would fail.
 	// However - what we actually check in this test is that we send to peers according to the
@@ -0,0 +1,339 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/util"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/op/go-logging"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defReachabilityRetryInterval is the time to wait before verifying the
+	// external endpoint again, when no peer of another organization answered
+	defReachabilityRetryInterval = 10 * time.Second
+
+	// maxConcurrentReachabilityProbes is the maximum number of probes the peer
+	// performs concurrently on behalf of peers of other organizations
+	maxConcurrentReachabilityProbes = 5
+)
+
+// Reachability is the outcome of verifying that the external endpoint
+// of the peer is reachable from peers of other organizations
+type Reachability int
+
+const (
+	// ReachabilityUnknown means the external endpoint wasn't verified yet,
+	// either because no peer of another organization answered, or because
+	// the peer has no external endpoint
+	ReachabilityUnknown Reachability = iota
+	// Reachable means that a peer of another organization dialed the
+	// external endpoint and got a response from the peer through it
+	Reachable
+	// Unreachable means that none of the peers of other organizations
+	// which were asked could reach the peer through its external endpoint
+	Unreachable
+)
+
+func (r Reachability) String() string {
+	switch r {
+	case Reachable:
+		return "reachable"
+	case Unreachable:
+		return "unreachable"
+	default:
+		return "unknown"
+	}
+}
+
+// ReachabilityStatus is the outcome of the last verification
+// of the external endpoint of the peer
+type ReachabilityStatus struct {
+	// Endpoint is the external endpoint of the peer
+	Endpoint string
+	// Reachability is the outcome of the last verification
+	Reachability Reachability
+	// LastVerified is the time of the last verification
+	LastVerified time.Time
+	// Errors are the errors peers of other organizations
+	// encountered when probing the external endpoint
+	Errors []string
+}
+
+// reachabilityProber verifies that the external endpoint of the peer is reachable,
+// by asking peers of other organizations to dial it and probe the peer through it,
+// and probes the external endpoints of peers of other organizations on their behalf
+type reachabilityProber struct {
+	g        *gossipServiceImpl
+	logger   *logging.Logger
+	probeSem chan struct{}
+
+	statusLock sync.RWMutex
+	status     ReachabilityStatus
+
+	pendingLock sync.Mutex
+	pending     map[uint64]chan *proto.ReachabilityResponse
+}
+
+func newReachabilityProber(g *gossipServiceImpl) *reachabilityProber {
+	return &reachabilityProber{
+		g:        g,
+		logger:   g.logger,
+		probeSem: make(chan struct{}, maxConcurrentReachabilityProbes),
+		status:   ReachabilityStatus{Endpoint: g.conf.ExternalEndpoint},
+		pending:  make(map[uint64]chan *proto.ReachabilityResponse),
+	}
+}
+
+// Status returns the outcome of the last verification of the external endpoint
+func (rp *reachabilityProber) Status() ReachabilityStatus {
+	rp.statusLock.RLock()
+	defer rp.statusLock.RUnlock()
+	status := rp.status
+	status.Errors = append([]string(nil), rp.status.Errors...)
+	return status
+}
+
+// run periodically verifies the external endpoint of the peer,
+// and retries sooner as long as no peer of another organization answers
+func (rp *reachabilityProber) run() {
+	defer rp.logger.Debug("Exiting")
+	rp.g.stopSignal.Add(1)
+	defer rp.g.stopSignal.Done()
+
+	interval := rp.g.conf.ReachabilityProbeInterval
+	retryInterval := defReachabilityRetryInterval
+	if interval < retryInterval {
+		retryInterval = interval
+	}
+	wait := retryInterval
+	for {
+		select {
+		case s := <-rp.g.toDieChan:
+			rp.g.toDieChan <- s
+			return
+		case <-time.After(wait):
+		}
+		if rp.verify() {
+			wait = interval
+		} else {
+			wait = retryInterval
+		}
+	}
+}
+
+// verify asks peers of other organizations to probe the external endpoint of the peer,
+// and returns whether any of them answered
+func (rp *reachabilityProber) verify() bool {
+	endpoint := rp.g.conf.ExternalEndpoint
+	peers := rp.probingPeers()
+	if len(peers) == 0 {
+		rp.logger.Debugf("No peers of other organizations are known, can't verify external endpoint %s", endpoint)
+		return false
+	}
+
+	responses := make([]*proto.ReachabilityResponse, len(peers))
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(i int, peer discovery.NetworkMember) {
+			defer wg.Done()
+			res, err := rp.requestProbe(peer, endpoint)
+			if err != nil {
+				rp.logger.Debugf("Failed asking %s to probe external endpoint %s: %v", peer.Endpoint, endpoint, err)
+				return
+			}
+			responses[i] = res
+		}(i, peer)
+	}
+	wg.Wait()
+
+	var errs []string
+	answered := false
+	reachable := false
+	for i, res := range responses {
+		if res == nil {
+			continue
+		}
+		answered = true
+		if res.Reachable {
+			reachable = true
+			continue
+		}
+		errs = append(errs, fmt.Sprintf("%s: %s", peers[i].Endpoint, res.Error))
+	}
+	if !answered {
+		rp.logger.Debugf("No peers of other organizations answered, can't verify external endpoint %s", endpoint)
+		return false
+	}
+	rp.update(endpoint, reachable, errs)
+	return true
+}
+
+// update records the outcome of verifying the external endpoint, publishes it in
+// the alive messages of the peer, and warns if the external endpoint is unreachable
+func (rp *reachabilityProber) update(endpoint string, reachable bool, errs []string) {
+	rp.statusLock.Lock()
+	prev := rp.status.Reachability
+	rp.status = ReachabilityStatus{
+		Endpoint:     endpoint,
+		Reachability: Unreachable,
+		LastVerified: time.Now(),
+		Errors:       errs,
+	}
+	if reachable {
+		rp.status.Reachability = Reachable
+	}
+	rp.statusLock.Unlock()
+
+	rp.g.disc.UpdateReachability(reachable)
+	if !reachable {
+		rp.logger.Warningf("External endpoint %s is NOT reachable from peers of other organizations, "+
+			"check peer.gossip.externalEndpoint and the network configuration: %s", endpoint, strings.Join(errs, "; "))
+		return
+	}
+	if prev == Unreachable {
+		rp.logger.Infof("External endpoint %s is reachable again from peers of other organizations", endpoint)
+		return
+	}
+	rp.logger.Debugf("External endpoint %s is reachable from peers of other organizations", endpoint)
+}
+
+// probingPeers selects alive peers of other organizations to probe the external endpoint
+func (rp *reachabilityProber) probingPeers() []discovery.NetworkMember {
+	var candidates []discovery.NetworkMember
+	for _, member := range rp.g.disc.GetMembership() {
+		if member.Endpoint == "" || rp.g.getOrgOfPeer(member.PKIid) == nil || rp.g.isInMyorg(member) {
+			continue
+		}
+		candidates = append(candidates, member)
+	}
+	peerNum := rp.g.conf.ReachabilityProbePeerNum
+	if peerNum <= 0 || peerNum > len(candidates) {
+		peerNum = len(candidates)
+	}
+	var peers []discovery.NetworkMember
+	for _, i := range util.GetRandomIndices(peerNum, len(candidates)-1) {
+		peers = append(peers, candidates[i])
+	}
+	return peers
+}
+
+// requestProbe asks the given peer to probe the given endpoint, and waits for its response
+func (rp *reachabilityProber) requestProbe(peer discovery.NetworkMember, endpoint string) (*proto.ReachabilityResponse, error) {
+	nonce := util.RandomUInt64()
+	resCh := make(chan *proto.ReachabilityResponse, 1)
+	rp.pendingLock.Lock()
+	rp.pending[nonce] = resCh
+	rp.pendingLock.Unlock()
+	defer func() {
+		rp.pendingLock.Lock()
+		delete(rp.pending, nonce)
+		rp.pendingLock.Unlock()
+	}()
+
+	msg, err := (&proto.GossipMessage{
+		Nonce: nonce,
+		Tag:   proto.GossipMessage_EMPTY,
+		Content: &proto.GossipMessage_ReachabilityReq{
+			ReachabilityReq: &proto.ReachabilityRequest{Endpoint: endpoint},
+		},
+	}).NoopSign()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	rp.g.comm.Send(msg, &comm.RemotePeer{Endpoint: peer.PreferredEndpoint(), PKIID: peer.PKIid})
+
+	select {
+	case res := <-resCh:
+		return res, nil
+	case <-time.After(rp.g.conf.ReachabilityProbeTimeout):
+		return nil, errors.New("timed out waiting for a response")
+	case s := <-rp.g.toDieChan:
+		rp.g.toDieChan <- s
+		return nil, errors.New("stopping")
+	}
+}
+
+// handleMessage handles reachability requests and responses
+func (rp *reachabilityProber) handleMessage(m proto.ReceivedMessage) {
+	msg := m.GetGossipMessage()
+	if res := msg.GetReachabilityRes(); res != nil {
+		rp.pendingLock.Lock()
+		resCh, exists := rp.pending[msg.Nonce]
+		rp.pendingLock.Unlock()
+		if !exists {
+			rp.logger.Debug("Got reachability response from", m.GetConnectionInfo(), "with unknown nonce", msg.Nonce)
+			return
+		}
+		select {
+		case resCh <- res:
+		default:
+		}
+		return
+	}
+
+	req := msg.GetReachabilityReq()
+	if req == nil {
+		return
+	}
+	select {
+	case rp.probeSem <- struct{}{}:
+	default:
+		rp.logger.Debug("Too many reachability probes in progress, dropping request from", m.GetConnectionInfo())
+		return
+	}
+	go func() {
+		defer func() { <-rp.probeSem }()
+		requester := m.GetConnectionInfo()
+		res := &proto.GossipMessage{
+			Nonce:   msg.Nonce,
+			Tag:     proto.GossipMessage_EMPTY,
+			Content: &proto.GossipMessage_ReachabilityRes{ReachabilityRes: rp.probe(requester)},
+		}
+		// The handshake of the probe doesn't replace the connection
+		// the request arrived on, so the response is sent through it
+		m.Respond(res)
+	}()
+}
+
+// probe dials the endpoint the peer that asked to probe it advertises in its alive messages,
+// rather than the endpoint in the request, so that peers can't be used to dial arbitrary endpoints
+func (rp *reachabilityProber) probe(requester *proto.ConnectionInfo) *proto.ReachabilityResponse {
+	member := rp.g.disc.Lookup(requester.ID)
+	if member == nil || member.Endpoint == "" {
+		return &proto.ReachabilityResponse{Error: "no endpoint is known for the requesting peer"}
+	}
+	if err := rp.handshake(member.Endpoint, requester.ID); err != nil {
+		rp.logger.Debugf("Failed probing %s through %s: %v", requester.Endpoint, member.Endpoint, err)
+		return &proto.ReachabilityResponse{Error: err.Error()}
+	}
+	return &proto.ReachabilityResponse{Reachable: true}
+}
+
+// handshake handshakes with the peer listening on the given endpoint,
+// and verifies that it is the peer with the given PKI-ID
+func (rp *reachabilityProber) handshake(endpoint string, pkiID common.PKIidType) error {
+	identity, err := rp.g.comm.Handshake(&comm.RemotePeer{Endpoint: endpoint})
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(rp.g.mcs.GetPKIidOfCert(identity), pkiID) {
+		return errors.Errorf("PKI-ID of the peer listening on %s doesn't match the PKI-ID of the requesting peer", endpoint)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/common"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+func newGossipInstanceWithReachabilityProbing(portPrefix int, id int, mcs *configurableCryptoService, externalEndpoint string, boot ...int) Gossip {
+	port := id + portPrefix
+	conf := &Config{
+		BindPort:                   port,
+		BootstrapPeers:             bootPeers(portPrefix, boot...),
+		ID:                         fmt.Sprintf("p%d", id),
+		MaxBlockCountToStore:       100,
+		MaxPropagationBurstLatency: time.Duration(500) * time.Millisecond,
+		MaxPropagationBurstSize:    20,
+		PropagateIterations:        1,
+		PropagatePeerNum:           3,
+		PullInterval:               time.Duration(2) * time.Second,
+		PullPeerNum:                5,
+		InternalEndpoint:           fmt.Sprintf("localhost:%d", port),
+		ExternalEndpoint:           externalEndpoint,
+		PublishCertPeriod:          time.Duration(4) * time.Second,
+		PublishStateInfoInterval:   time.Duration(1) * time.Second,
+		RequestStateInfoInterval:   time.Duration(1) * time.Second,
+		ReachabilityProbeInterval:  time.Duration(2) * time.Second,
+		ReachabilityProbeTimeout:   time.Duration(10) * time.Second,
+		ReachabilityProbePeerNum:   3,
+	}
+	selfID := api.PeerIdentityType(conf.InternalEndpoint)
+	return NewGossipServiceWithServer(conf, mcs, mcs, selfID, nil)
+}
+
+func TestExternalEndpointReachability(t *testing.T) {
+	t.Parallel()
+	defer testWG.Done()
+	// Scenario: create 2 organizations, the first with 2 peers and the second with a single peer.
+	// The second peer of the first organization publishes an external endpoint nothing listens on.
+	// Ensure that:
+	// - The peers with correct external endpoints find out they are reachable
+	// - The peer with the misconfigured external endpoint finds out it is unreachable,
+	//   and the other peers learn about it from its alive messages
+	cs := &configurableCryptoService{m: make(map[string]api.OrgIdentityType)}
+	portPrefix := 44610
+	orgA := "orgA"
+	orgB := "orgB"
+	cs.putInOrg(portPrefix, orgA)
+	cs.putInOrg(portPrefix+1, orgB)
+	cs.putInOrg(portPrefix+2, orgA)
+
+	p0 := newGossipInstanceWithReachabilityProbing(portPrefix, 0, cs, fmt.Sprintf("localhost:%d", portPrefix))
+	p1 := newGossipInstanceWithReachabilityProbing(portPrefix, 1, cs, fmt.Sprintf("localhost:%d", portPrefix+1))
+	p2 := newGossipInstanceWithReachabilityProbing(portPrefix, 2, cs, fmt.Sprintf("localhost:%d", portPrefix+99), 0)
+	peers := []Gossip{p0, p1, p2}
+	defer stopPeers(peers)
+
+	for _, p := range peers {
+		assert.Equal(t, ReachabilityUnknown, p.Reachability().Reachability)
+	}
+
+	jcm := &joinChanMsg{
+		members2AnchorPeers: map[string][]api.AnchorPeer{
+			orgA: {{Host: "localhost", Port: portPrefix}},
+			orgB: {{Host: "localhost", Port: portPrefix + 1}},
+		},
+	}
+	for _, p := range peers {
+		p.JoinChan(jcm, common.ChainID("TEST"))
+	}
+
+	isUnreachable := func(p Gossip, endpoint string) bool {
+		for _, member := range p.Peers() {
+			if member.Endpoint == endpoint {
+				return member.Unreachable
+			}
+		}
+		return false
+	}
+
+	waitUntilOrFail(t, func() bool {
+		return p0.Reachability().Reachability == Reachable &&
+			p1.Reachability().Reachability == Reachable &&
+			p2.Reachability().Reachability == Unreachable
+	})
+	status := p2.Reachability()
+	assert.Equal(t, fmt.Sprintf("localhost:%d", portPrefix+99), status.Endpoint)
+	assert.False(t, status.LastVerified.IsZero())
+	assert.NotEmpty(t, status.Errors)
+	assert.Empty(t, p0.Reachability().Errors)
+
+	// p1 can't dial p2, so only p0 which is in the same organization keeps p2 alive
+	waitUntilOrFail(t, func() bool {
+		return isUnreachable(p0, fmt.Sprintf("localhost:%d", portPrefix+99))
+	})
+	assert.True(t, p2.SelfMembershipInfo().Unreachable)
+	for _, p := range []Gossip{p1, p2} {
+		assert.False(t, isUnreachable(p, fmt.Sprintf("localhost:%d", portPrefix)))
+	}
+	assert.False(t, isUnreachable(p0, fmt.Sprintf("localhost:%d", portPrefix+1)))
+
+	// p1 probes the endpoint p0 advertises, and only if p0 is a member it knows of
+	prober := p1.(*gossipServiceImpl).reachability
+	p0PKIID := p0.(*gossipServiceImpl).comm.GetPKIid()
+	assert.Equal(t, &proto.ReachabilityResponse{Reachable: true}, prober.probe(&proto.ConnectionInfo{ID: p0PKIID}))
+	res := prober.probe(&proto.ConnectionInfo{ID: common.PKIidType("unknown")})
+	assert.Equal(t, &proto.ReachabilityResponse{Error: "no endpoint is known for the requesting peer"}, res)
+	// The peer listening on the endpoint has to be the requesting peer
+	err := prober.handshake(fmt.Sprintf("localhost:%d", portPrefix), p1.(*gossipServiceImpl).comm.GetPKIid())
+	assert.EqualError(t, err, fmt.Sprintf("PKI-ID of the peer listening on localhost:%d doesn't match the PKI-ID of the requesting peer", portPrefix))
+	assert.NoError(t, prober.handshake(fmt.Sprintf("localhost:%d", portPrefix), p0PKIID))
+}
+
+func TestReachabilityString(t *testing.T) {
+	assert.Equal(t, "unknown", ReachabilityUnknown.String())
+	assert.Equal(t, "reachable", Reachable.String())
+	assert.Equal(t, "unreachable", Unreachable.String())
+}
+
+func TestReachabilityProbingPeers(t *testing.T) {
+	cs := &configurableCryptoService{m: make(map[string]api.OrgIdentityType)}
+	g := newGossipInstanceWithExternalEndpoint(45610, 0, cs, "localhost:45610").(*gossipServiceImpl)
+	defer g.Stop()
+	// Without a probe interval, the external endpoint isn't verified.
+	// Without peers of other organizations, it can't be verified.
+	assert.Equal(t, ReachabilityStatus{Endpoint: "localhost:45610"}, g.Reachability())
+	assert.Empty(t, g.reachability.probingPeers())
+	assert.False(t, g.reachability.verify())
+}
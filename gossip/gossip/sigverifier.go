@@ -0,0 +1,196 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/hyperledger/fabric/common/metrics"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/op/go-logging"
+)
+
+const (
+	defSigVerificationWorkers   = 4
+	defSigVerificationQueueSize = 1000
+	defSigVerificationCacheSize = 10000
+)
+
+// sigVerificationCache caches the digests of the (identity, signature, message)
+// tuples whose signature was successfully verified, so that messages which are
+// received several times, such as alive and state info messages gossiped by
+// different peers, are only verified once.
+// Failed verifications aren't cached, as they may succeed later on,
+// i.e once the identity of the signer is known
+type sigVerificationCache struct {
+	sync.Mutex
+	cache *lru.Cache
+	hits  uint64
+}
+
+func newSigVerificationCache(size int) *sigVerificationCache {
+	if size <= 0 {
+		size = defSigVerificationCacheSize
+	}
+	return &sigVerificationCache{cache: lru.New(size)}
+}
+
+// wrap returns a verifier that consults the cache before calling the given verifier
+func (c *sigVerificationCache) wrap(verify proto.Verifier) proto.Verifier {
+	return func(peerIdentity []byte, signature, message []byte) error {
+		key := sigVerificationDigest(peerIdentity, signature, message)
+		c.Lock()
+		_, verified := c.cache.Get(key)
+		c.Unlock()
+		if verified {
+			atomic.AddUint64(&c.hits, 1)
+			return nil
+		}
+		if err := verify(peerIdentity, signature, message); err != nil {
+			return err
+		}
+		c.Lock()
+		c.cache.Add(key, struct{}{})
+		c.Unlock()
+		return nil
+	}
+}
+
+// purge removes all verification results from the cache,
+// it is called when identities are purged or revoked
+func (c *sigVerificationCache) purge() {
+	c.Lock()
+	defer c.Unlock()
+	c.cache.Clear()
+}
+
+func sigVerificationDigest(peerIdentity []byte, signature, message []byte) string {
+	h := sha256.New()
+	for _, b := range [][]byte{peerIdentity, signature, message} {
+		// Prefix each part with its length, so that different tuples never share a digest
+		length := make([]byte, 8)
+		binary.BigEndian.PutUint64(length, uint64(len(b)))
+		h.Write(length)
+		h.Write(b)
+	}
+	return string(h.Sum(nil))
+}
+
+// sigVerificationPool handles the messages whose signatures need to be verified,
+// i.e alive, state info and leadership messages, in a bounded pool of workers
+// instead of in the goroutine that receives messages, so that verifying them
+// doesn't hold back the handling of data messages.
+// When the pool can't keep up, alive and state info messages are dropped, since
+// they are periodically re-sent, while leadership messages wait for a free slot
+type sigVerificationPool struct {
+	handle func(proto.ReceivedMessage)
+	queue  chan proto.ReceivedMessage
+	// lowPriorityLimit is the length of the queue from which low priority messages are dropped;
+	// the rest of the queue is reserved for leadership messages
+	lowPriorityLimit int
+	dropped          uint64
+	stopChan         chan struct{}
+	stopOnce         sync.Once
+	wg               sync.WaitGroup
+	logger           *logging.Logger
+}
+
+func newSigVerificationPool(workers, queueSize int, handle func(proto.ReceivedMessage), logger *logging.Logger) *sigVerificationPool {
+	if workers <= 0 {
+		workers = defSigVerificationWorkers
+	}
+	if queueSize <= 0 {
+		queueSize = defSigVerificationQueueSize
+	}
+	p := &sigVerificationPool{
+		handle:           handle,
+		queue:            make(chan proto.ReceivedMessage, queueSize),
+		lowPriorityLimit: queueSize - queueSize/4,
+		stopChan:         make(chan struct{}),
+		logger:           logger,
+	}
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// needsSigVerification returns whether the signature of the given message is verified when it's handled
+func needsSigVerification(msg proto.ReceivedMessage) bool {
+	if msg == nil {
+		return false
+	}
+	m := msg.GetGossipMessage()
+	if m == nil {
+		return false
+	}
+	return m.IsAliveMsg() || m.IsStateInfoMsg() || m.IsLeadershipMsg()
+}
+
+// submit enqueues the given message to be handled by one of the workers.
+// It returns false if the message was dropped
+func (p *sigVerificationPool) submit(msg proto.ReceivedMessage) bool {
+	select {
+	case <-p.stopChan:
+		return false
+	default:
+	}
+	if !msg.GetGossipMessage().IsLeadershipMsg() {
+		if len(p.queue) >= p.lowPriorityLimit {
+			p.drop(msg)
+			return false
+		}
+		select {
+		case p.queue <- msg:
+			return true
+		case <-p.stopChan:
+			return false
+		default:
+			p.drop(msg)
+			return false
+		}
+	}
+	select {
+	case p.queue <- msg:
+		return true
+	case <-p.stopChan:
+		return false
+	}
+}
+
+func (p *sigVerificationPool) drop(msg proto.ReceivedMessage) {
+	atomic.AddUint64(&p.dropped, 1)
+	p.logger.Debug("Signature verification queue is full, dropping", msg.GetGossipMessage())
+	if metrics.RootScope != nil {
+		metrics.RootScope.SubScope("gossip_sig_verification").Counter("dropped_messages").Inc(1)
+	}
+}
+
+func (p *sigVerificationPool) work() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.stopChan:
+			return
+		case msg := <-p.queue:
+			p.handle(msg)
+		}
+	}
+}
+
+// stop stops the workers, and waits for them to finish handling their current messages
+func (p *sigVerificationPool) stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopChan)
+	})
+	p.wg.Wait()
+}
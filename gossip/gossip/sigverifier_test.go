@@ -0,0 +1,213 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/gossip/util"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+type sigVerificationTestMsg struct {
+	*proto.SignedGossipMessage
+}
+
+func (m *sigVerificationTestMsg) Respond(msg *proto.GossipMessage) {}
+
+func (m *sigVerificationTestMsg) GetGossipMessage() *proto.SignedGossipMessage {
+	return m.SignedGossipMessage
+}
+
+func (m *sigVerificationTestMsg) GetSourceEnvelope() *proto.Envelope {
+	return m.Envelope
+}
+
+func (m *sigVerificationTestMsg) GetConnectionInfo() *proto.ConnectionInfo {
+	return &proto.ConnectionInfo{}
+}
+
+func (m *sigVerificationTestMsg) Ack(err error) {}
+
+func newSigVerificationTestMsg(t *testing.T, msg *proto.GossipMessage) proto.ReceivedMessage {
+	sMsg, err := msg.NoopSign()
+	assert.NoError(t, err)
+	return &sigVerificationTestMsg{SignedGossipMessage: sMsg}
+}
+
+func aliveTestMsg(t *testing.T) proto.ReceivedMessage {
+	return newSigVerificationTestMsg(t, &proto.GossipMessage{
+		Tag:     proto.GossipMessage_EMPTY,
+		Content: &proto.GossipMessage_AliveMsg{AliveMsg: &proto.AliveMessage{}},
+	})
+}
+
+func leadershipTestMsg(t *testing.T) proto.ReceivedMessage {
+	return newSigVerificationTestMsg(t, &proto.GossipMessage{
+		Tag:     proto.GossipMessage_CHAN_AND_ORG,
+		Content: &proto.GossipMessage_LeadershipMsg{LeadershipMsg: &proto.LeadershipMessage{}},
+	})
+}
+
+func dataTestMsg(t *testing.T) proto.ReceivedMessage {
+	return newSigVerificationTestMsg(t, &proto.GossipMessage{
+		Tag:     proto.GossipMessage_CHAN_AND_ORG,
+		Content: &proto.GossipMessage_DataMsg{DataMsg: &proto.DataMessage{}},
+	})
+}
+
+func TestSigVerificationCache(t *testing.T) {
+	var verifications int32
+	verificationErr := errors.New("bad signature")
+	cache := newSigVerificationCache(2)
+	verify := cache.wrap(func(peerIdentity []byte, signature, message []byte) error {
+		atomic.AddInt32(&verifications, 1)
+		return verificationErr
+	})
+
+	// Failed verifications aren't cached
+	assert.Error(t, verify([]byte("p1"), []byte("sig"), []byte("msg")))
+	verificationErr = nil
+	assert.NoError(t, verify([]byte("p1"), []byte("sig"), []byte("msg")))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&verifications))
+
+	// Successful verifications are
+	assert.NoError(t, verify([]byte("p1"), []byte("sig"), []byte("msg")))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&verifications))
+	assert.Equal(t, uint64(1), atomic.LoadUint64(&cache.hits))
+
+	// Different tuples with the same concatenation don't share an entry
+	assert.NoError(t, verify([]byte("p1s"), []byte("ig"), []byte("msg")))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&verifications))
+
+	// The least recently used entry is evicted
+	assert.NoError(t, verify([]byte("p2"), []byte("sig"), []byte("msg")))
+	assert.NoError(t, verify([]byte("p1"), []byte("sig"), []byte("msg")))
+	assert.Equal(t, int32(5), atomic.LoadInt32(&verifications))
+
+	// Purging the cache removes all entries
+	cache.purge()
+	assert.NoError(t, verify([]byte("p2"), []byte("sig"), []byte("msg")))
+	assert.Equal(t, int32(6), atomic.LoadInt32(&verifications))
+}
+
+func TestNeedsSigVerification(t *testing.T) {
+	assert.True(t, needsSigVerification(aliveTestMsg(t)))
+	assert.True(t, needsSigVerification(leadershipTestMsg(t)))
+	assert.True(t, needsSigVerification(newSigVerificationTestMsg(t, &proto.GossipMessage{
+		Tag:     proto.GossipMessage_CHAN_OR_ORG,
+		Content: &proto.GossipMessage_StateInfo{StateInfo: &proto.StateInfo{}},
+	})))
+	assert.False(t, needsSigVerification(dataTestMsg(t)))
+	assert.False(t, needsSigVerification(nil))
+}
+
+func TestSigVerificationPool(t *testing.T) {
+	handled := make(chan proto.ReceivedMessage, 10)
+	pool := newSigVerificationPool(2, 4, func(msg proto.ReceivedMessage) {
+		handled <- msg
+	}, util.GetLogger(util.LoggingGossipModule, "p0"))
+	defer pool.stop()
+
+	msg := aliveTestMsg(t)
+	assert.True(t, pool.submit(msg))
+	select {
+	case m := <-handled:
+		assert.Equal(t, msg, m)
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "Message wasn't handled")
+	}
+}
+
+func TestSigVerificationPoolBackPressure(t *testing.T) {
+	release := make(chan struct{})
+	var handled int32
+	pool := newSigVerificationPool(1, 4, func(msg proto.ReceivedMessage) {
+		<-release
+		atomic.AddInt32(&handled, 1)
+	}, util.GetLogger(util.LoggingGossipModule, "p0"))
+
+	// The worker is blocked on the first message,
+	// and the low priority messages fill 3 of the 4 slots of the queue
+	assert.True(t, pool.submit(aliveTestMsg(t)))
+	for i := 0; i < 3; i++ {
+		assert.True(t, waitSubmit(pool, aliveTestMsg(t)))
+	}
+	// Further low priority messages are dropped
+	assert.False(t, pool.submit(aliveTestMsg(t)))
+	assert.Equal(t, uint64(1), atomic.LoadUint64(&pool.dropped))
+	// while leadership messages still have room
+	assert.True(t, pool.submit(leadershipTestMsg(t)))
+
+	// Once the queue is full, leadership messages wait for a free slot
+	submitted := make(chan bool)
+	go func() {
+		submitted <- pool.submit(leadershipTestMsg(t))
+	}()
+	select {
+	case <-submitted:
+		assert.Fail(t, "Leadership message shouldn't have been enqueued while the queue is full")
+	case <-time.After(time.Millisecond * 200):
+	}
+	close(release)
+	assert.True(t, <-submitted)
+
+	pool.stop()
+	assert.True(t, atomic.LoadInt32(&handled) > 0)
+	// Messages aren't enqueued once the pool is stopped
+	assert.False(t, pool.submit(leadershipTestMsg(t)))
+}
+
+func TestSigVerificationDroppedMetrics(t *testing.T) {
+	server, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")})
+	assert.NoError(t, err)
+	defer server.Close()
+
+	assert.NoError(t, metrics.Init(metrics.Opts{
+		Enabled:  true,
+		Reporter: "statsd",
+		Interval: 100 * time.Millisecond,
+		StatsdReporterOpts: metrics.StatsdReporterOpts{
+			Address:       server.LocalAddr().String(),
+			FlushInterval: 100 * time.Millisecond,
+			FlushBytes:    512,
+		},
+	}))
+	assert.NoError(t, metrics.Start())
+	defer metrics.Shutdown()
+
+	pool := newSigVerificationPool(1, 4, func(msg proto.ReceivedMessage) {}, util.GetLogger(util.LoggingGossipModule, "p0"))
+	defer pool.stop()
+	pool.drop(aliveTestMsg(t))
+	pool.drop(aliveTestMsg(t))
+
+	var reported string
+	buffer := make([]byte, 4096)
+	server.SetReadDeadline(time.Now().Add(10 * time.Second))
+	for !strings.Contains(reported, "hyperledger_fabric.gossip_sig_verification.dropped_messages:2|c") {
+		n, err := server.Read(buffer)
+		if !assert.NoError(t, err, "dropped messages weren't reported, got: %s", reported) {
+			return
+		}
+		reported += string(buffer[:n])
+	}
+}
+
+// waitSubmit submits the given message once the worker picked up the messages already in the queue
+func waitSubmit(pool *sigVerificationPool, msg proto.ReceivedMessage) bool {
+	for i := 0; i < 100 && len(pool.queue) >= pool.lowPriorityLimit; i++ {
+		time.Sleep(time.Millisecond * 10)
+	}
+	return pool.submit(msg)
+}
@@ -143,7 +143,7 @@ func (is *identityMapperImpl) Put(pkiID common.PKIidType, identity api.PeerIdent
 		})
 	}
 
-	is.pkiID2Cert[string(id)] = newStoredIdentity(pkiID, identity, expirationTimer, is.sa.OrgByPeerIdentity(identity))
+	is.pkiID2Cert[string(id)] = newStoredIdentity(pkiID, identity, expirationDate, expirationTimer, is.sa.OrgByPeerIdentity(identity))
 	return nil
 }
 
@@ -225,6 +225,7 @@ func (is *identityMapperImpl) IdentityInfo() api.PeerIdentitySet {
 			Identity:     storedIdentity.peerIdentity,
 			PKIId:        storedIdentity.pkiID,
 			Organization: storedIdentity.orgId,
+			Expiration:   storedIdentity.expiration,
 		})
 	}
 	return res
@@ -242,14 +243,16 @@ type storedIdentity struct {
 	lastAccessTime  int64
 	peerIdentity    api.PeerIdentityType
 	orgId           api.OrgIdentityType
+	expiration      time.Time
 	expirationTimer *time.Timer
 }
 
-func newStoredIdentity(pkiID common.PKIidType, identity api.PeerIdentityType, expirationTimer *time.Timer, org api.OrgIdentityType) *storedIdentity {
+func newStoredIdentity(pkiID common.PKIidType, identity api.PeerIdentityType, expiration time.Time, expirationTimer *time.Timer, org api.OrgIdentityType) *storedIdentity {
 	return &storedIdentity{
 		pkiID:           pkiID,
 		lastAccessTime:  time.Now().UnixNano(),
 		peerIdentity:    identity,
+		expiration:      expiration,
 		expirationTimer: expirationTimer,
 		orgId:           org,
 	}
@@ -324,7 +324,8 @@ func TestIdentityInfo(t *testing.T) {
 	cs.On("OrgByPeerIdentity", dummyID).Return(api.OrgIdentityType("D"))
 	cs.On("OrgByPeerIdentity", alice).Return(api.OrgIdentityType("A"))
 	cs.On("OrgByPeerIdentity", bob).Return(api.OrgIdentityType("B"))
-	cs.On("Expiration", mock.Anything).Return(time.Now().Add(time.Minute), nil)
+	expiration := time.Now().Add(time.Minute)
+	cs.On("Expiration", mock.Anything).Return(expiration, nil)
 	idStore := NewIdentityMapper(cs, dummyID, noopPurgeTrigger, cs)
 	idStore.Put(aliceID, alice)
 	idStore.Put(bobId, bob)
@@ -335,5 +336,6 @@ func TestIdentityInfo(t *testing.T) {
 		assert.Equal(t, org, orgId)
 		assert.Equal(t, strings.ToLower(org), string(identity[0]))
 		assert.Equal(t, strings.ToLower(org), string(pkiID[0]))
+		assert.Equal(t, expiration, id[0].Expiration)
 	}
 }
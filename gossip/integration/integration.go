@@ -8,6 +8,7 @@ package integration
 
 import (
 	"net"
+	"runtime"
 	"strconv"
 	"time"
 
@@ -41,6 +42,7 @@ func newConfig(selfEndpoint string, externalEndpoint string, certs *common.TLSCe
 		MaxBlockCountToStore:       util.GetIntOrDefault("peer.gossip.maxBlockCountToStore", 100),
 		MaxPropagationBurstLatency: util.GetDurationOrDefault("peer.gossip.maxPropagationBurstLatency", 10*time.Millisecond),
 		MaxPropagationBurstSize:    util.GetIntOrDefault("peer.gossip.maxPropagationBurstSize", 10),
+		MaxPropagationBurstBytes:   util.GetIntOrDefault("peer.gossip.maxPropagationBurstBytes", 0),
 		PropagateIterations:        util.GetIntOrDefault("peer.gossip.propagateIterations", 1),
 		PropagatePeerNum:           util.GetIntOrDefault("peer.gossip.propagatePeerNum", 3),
 		PullInterval:               util.GetDurationOrDefault("peer.gossip.pullInterval", 4*time.Second),
@@ -52,6 +54,15 @@ func newConfig(selfEndpoint string, externalEndpoint string, certs *common.TLSCe
 		PublishStateInfoInterval:   util.GetDurationOrDefault("peer.gossip.publishStateInfoInterval", 4*time.Second),
 		SkipBlockVerification:      viper.GetBool("peer.gossip.skipBlockVerification"),
 		TLSCerts:                   certs,
+		ReachabilityProbeTimeout:   util.GetDurationOrDefault("peer.gossip.reachability.probeTimeout", 10*time.Second),
+		ReachabilityProbePeerNum:   util.GetIntOrDefault("peer.gossip.reachability.probePeerNum", 3),
+		SigVerificationWorkers:     util.GetIntOrDefault("peer.gossip.sigVerification.workers", runtime.NumCPU()),
+		SigVerificationQueueSize:   util.GetIntOrDefault("peer.gossip.sigVerification.queueSize", 1000),
+		SigVerificationCacheSize:   util.GetIntOrDefault("peer.gossip.sigVerification.cacheSize", 10000),
+	}
+
+	if viper.GetBool("peer.gossip.reachability.enabled") {
+		conf.ReachabilityProbeInterval = util.GetDurationOrDefault("peer.gossip.reachability.probeInterval", 5*time.Minute)
 	}
 
 	return conf, nil
@@ -63,6 +63,9 @@ type TransientStore interface {
 	// after successful block commit, PurgeByHeight() is still required to remove orphan entries (as
 	// transaction that gets endorsed may not be submitted by the client for commit)
 	PurgeByHeight(maxBlockNumToRetain uint64) error
+
+	// OutboxStore persists the private data the distributor didn't disseminate to enough peers
+	OutboxStore
 }
 
 // Coordinator orchestrates the flow of the new
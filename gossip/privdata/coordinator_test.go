@@ -90,6 +90,19 @@ func (store *mockTransientStore) PurgeByHeight(maxBlockNumToRetain uint64) error
 	return store.Called(maxBlockNumToRetain).Error(0)
 }
 
+func (store *mockTransientStore) AddToOutbox(entry *transientstore.OutboxEntry) error {
+	return store.Called(entry).Error(0)
+}
+
+func (store *mockTransientStore) GetOutbox() ([]*transientstore.OutboxEntry, error) {
+	args := store.Called()
+	return args.Get(0).([]*transientstore.OutboxEntry), args.Error(1)
+}
+
+func (store *mockTransientStore) RemoveFromOutbox(txid string, namespace string, collection string) error {
+	return store.Called(txid, namespace, collection).Error(0)
+}
+
 func (store *mockTransientStore) GetTxPvtRWSetByTxid(txid string, filter ledger.PvtNsCollFilter) (transientstore.RWSetScanner, error) {
 	store.lastReqTxID = txid
 	store.lastReqFilter = filter
@@ -39,12 +39,17 @@ type gossipAdapter interface {
 type PvtDataDistributor interface {
 	// Distribute broadcast reliably private data read write set based on policies
 	Distribute(txID string, privData *rwset.TxPvtReadWriteSet, cs privdata.CollectionStore) error
+
+	// Close stops retrying to disseminate the private data in the outbox, if any
+	Close()
 }
 
 // distributorImpl the implementation of the private data distributor interface
 type distributorImpl struct {
 	chainID string
 	gossipAdapter
+	// outbox is nil unless the distributor was created with an outbox
+	outbox *outbox
 }
 
 // NewDistributor a constructor for private data distributor capable to send
@@ -75,31 +80,7 @@ func (d *distributorImpl) computeDisseminationPlan(txID string, privData *rwset.
 	for _, pvtRwset := range privData.NsPvtRwset {
 		namespace := pvtRwset.Namespace
 		for _, collection := range pvtRwset.CollectionPvtRwset {
-			collectionName := collection.CollectionName
-			cc := common.CollectionCriteria{
-				Namespace:  namespace,
-				Collection: collectionName,
-				TxId:       txID,
-				Channel:    d.chainID,
-			}
-			colAP, err := cs.RetrieveCollectionAccessPolicy(cc)
-			if err != nil {
-				logger.Error("Could not find collection access policy for", cc, "error", err)
-				return nil, errors.WithMessage(err, fmt.Sprintf("collection access policy for %v not found", cc))
-			}
-
-			colFilter := colAP.AccessFilter()
-			if colFilter == nil {
-				logger.Error("Collection access policy for", cc, "has no filter")
-				return nil, errors.Errorf("No collection access policy filter computed for %v", cc)
-			}
-
-			pvtDataMsg, err := d.createPrivateDataMessage(txID, namespace, collection.CollectionName, collection.Rwset)
-			if err != nil {
-				return nil, errors.WithStack(err)
-			}
-
-			dPlan, err := d.disseminationPlanForMsg(colAP, colFilter, pvtDataMsg)
+			dPlan, err := d.disseminationPlanForCollection(txID, namespace, collection.CollectionName, collection.Rwset, cs)
 			if err != nil {
 				return nil, errors.WithStack(err)
 			}
@@ -109,6 +90,37 @@ func (d *distributorImpl) computeDisseminationPlan(txID string, privData *rwset.
 	return disseminationPlan, nil
 }
 
+func (d *distributorImpl) disseminationPlanForCollection(txID, namespace, collectionName string, rwset []byte, cs privdata.CollectionStore) ([]*dissemination, error) {
+	cc := common.CollectionCriteria{
+		Namespace:  namespace,
+		Collection: collectionName,
+		TxId:       txID,
+		Channel:    d.chainID,
+	}
+	colAP, err := cs.RetrieveCollectionAccessPolicy(cc)
+	if err != nil {
+		logger.Error("Could not find collection access policy for", cc, "error", err)
+		return nil, errors.WithMessage(err, fmt.Sprintf("collection access policy for %v not found", cc))
+	}
+
+	colFilter := colAP.AccessFilter()
+	if colFilter == nil {
+		logger.Error("Collection access policy for", cc, "has no filter")
+		return nil, errors.Errorf("No collection access policy filter computed for %v", cc)
+	}
+
+	pvtDataMsg, err := d.createPrivateDataMessage(txID, namespace, collectionName, rwset)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	dPlan, err := d.disseminationPlanForMsg(colAP, colFilter, pvtDataMsg)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return dPlan, nil
+}
+
 func (d *distributorImpl) disseminationPlanForMsg(colAP privdata.CollectionAccessPolicy, colFilter privdata.Filter, pvtDataMsg *proto.SignedGossipMessage) ([]*dissemination, error) {
 	var disseminationPlan []*dissemination
 	routingFilter, err := d.gossipAdapter.PeerFilter(gossipCommon.ChainID(d.chainID), func(signature api.PeerSignature) bool {
@@ -140,6 +152,13 @@ func (d *distributorImpl) disseminationPlanForMsg(colAP privdata.CollectionAcces
 	return disseminationPlan, nil
 }
 
+// Close stops retrying to disseminate the private data in the outbox, if any
+func (d *distributorImpl) Close() {
+	if d.outbox != nil {
+		d.outbox.stop()
+	}
+}
+
 func (d *distributorImpl) disseminate(disseminationPlan []*dissemination) error {
 	var failures uint32
 	var wg sync.WaitGroup
@@ -148,11 +167,21 @@ func (d *distributorImpl) disseminate(disseminationPlan []*dissemination) error
 		go func(dis *dissemination) {
 			defer wg.Done()
 			err := d.SendByCriteria(dis.msg, dis.criteria)
-			if err != nil {
-				atomic.AddUint32(&failures, 1)
-				m := dis.msg.GetPrivateData().Payload
-				logger.Error("Failed disseminating private RWSet for TxID", m.TxId, ", namespace", m.Namespace, "collection", m.CollectionName, ":", err)
+			if err == nil {
+				return
+			}
+			m := dis.msg.GetPrivateData().Payload
+			if d.outbox != nil {
+				outboxErr := d.outbox.add(m, dis.criteria.MinAck, err)
+				if outboxErr == nil {
+					logger.Warning("Failed disseminating private RWSet for TxID", m.TxId, ", namespace", m.Namespace, "collection", m.CollectionName, ":", err,
+						", will retry disseminating it until the transaction is committed")
+					return
+				}
+				logger.Error("Failed adding private RWSet for TxID", m.TxId, ", namespace", m.Namespace, "collection", m.CollectionName, "to the outbox:", outboxErr)
 			}
+			atomic.AddUint32(&failures, 1)
+			logger.Error("Failed disseminating private RWSet for TxID", m.TxId, ", namespace", m.Namespace, "collection", m.CollectionName, ":", err)
 		}(dis)
 	}
 	wg.Wait()
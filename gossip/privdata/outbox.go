@@ -0,0 +1,246 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/transientstore"
+	gossip2 "github.com/hyperledger/fabric/gossip/gossip"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	outboxRetryIntervalConfigKey = "peer.gossip.pvtData.outbox.retryInterval"
+	defOutboxRetryInterval       = 10 * time.Second
+)
+
+// OutboxStore persists the private data which the distributor didn't disseminate to enough peers
+type OutboxStore interface {
+	// AddToOutbox stores the private write set of a collection which wasn't disseminated to enough peers
+	AddToOutbox(entry *transientstore.OutboxEntry) error
+
+	// GetOutbox returns the private write sets of the collections which weren't disseminated to enough peers
+	GetOutbox() ([]*transientstore.OutboxEntry, error)
+
+	// RemoveFromOutbox removes the private write set of a collection of a transaction from the outbox
+	RemoveFromOutbox(txid string, namespace string, collection string) error
+}
+
+// OutboxSupport aggregates the APIs the outbox of the distributor requires
+type OutboxSupport struct {
+	// Store persists the outbox, and purges the private data of committed
+	// or expired transactions from it
+	Store OutboxStore
+	// CollectionStore resolves the collection access policies
+	// of the private data which is disseminated again
+	CollectionStore privdata.CollectionStore
+	// LedgerHeight returns the height of the ledger,
+	// which the private data added to the outbox is associated with
+	LedgerHeight func() (uint64, error)
+}
+
+// outboxKey identifies the private write set of a collection of a transaction in the outbox
+type outboxKey struct {
+	txID       string
+	namespace  string
+	collection string
+}
+
+// collectionKey identifies a collection of a namespace
+type collectionKey struct {
+	namespace  string
+	collection string
+}
+
+// outbox keeps the private data which wasn't disseminated to enough peers at endorsement time
+// in the transient store, and tracks how many acknowledgements are missing for it
+type outbox struct {
+	OutboxSupport
+	chainID  string
+	stopChan chan struct{}
+	stopOnce sync.Once
+
+	lock sync.Mutex
+	// unacked is the number of acknowledgements missing at the
+	// last dissemination attempt of each entry of the outbox
+	unacked map[outboxKey]int
+	// reported are the collections the number of missing acknowledgements was reported for
+	reported map[collectionKey]struct{}
+}
+
+// NewDistributorWithOutbox creates a private data distributor which keeps the private data
+// it doesn't disseminate to enough peers in an outbox persisted in the transient store, and retries
+// disseminating it in the background until the transaction commits or the private data expires
+func NewDistributorWithOutbox(chainID string, gossip gossipAdapter, support OutboxSupport) PvtDataDistributor {
+	d := &distributorImpl{
+		chainID:       chainID,
+		gossipAdapter: gossip,
+		outbox: &outbox{
+			OutboxSupport: support,
+			chainID:       chainID,
+			stopChan:      make(chan struct{}),
+			unacked:       make(map[outboxKey]int),
+			reported:      make(map[collectionKey]struct{}),
+		},
+	}
+	retryInterval := viper.GetDuration(outboxRetryIntervalConfigKey)
+	if retryInterval == 0 {
+		retryInterval = defOutboxRetryInterval
+	}
+	go d.retryOutbox(retryInterval)
+	return d
+}
+
+// retryOutbox periodically disseminates the private data in the outbox again, until the outbox is stopped
+func (d *distributorImpl) retryOutbox(retryInterval time.Duration) {
+	for {
+		select {
+		case <-d.outbox.stopChan:
+			return
+		case <-time.After(retryInterval):
+		}
+		d.disseminateOutbox()
+	}
+}
+
+// disseminateOutbox disseminates the private data in the outbox again,
+// and removes the private data which was disseminated to enough peers from the outbox
+func (d *distributorImpl) disseminateOutbox() {
+	d.outbox.lock.Lock()
+	previousKeys := make(map[outboxKey]struct{}, len(d.outbox.unacked))
+	for key := range d.outbox.unacked {
+		previousKeys[key] = struct{}{}
+	}
+	d.outbox.lock.Unlock()
+
+	entries, err := d.outbox.Store.GetOutbox()
+	if err != nil {
+		logger.Warning("Failed retrieving the outbox of channel", d.chainID, ":", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(entries))
+	for _, entry := range entries {
+		key := outboxKey{txID: entry.TxID, namespace: entry.Namespace, collection: entry.CollectionName}
+		delete(previousKeys, key)
+		go func(entry *transientstore.OutboxEntry, key outboxKey) {
+			defer wg.Done()
+			d.disseminateOutboxEntry(entry, key)
+		}(entry, key)
+	}
+	wg.Wait()
+
+	// The remaining entries were purged from the outbox, as their
+	// transactions were committed or their private data expired
+	d.outbox.lock.Lock()
+	for key := range previousKeys {
+		delete(d.outbox.unacked, key)
+	}
+	d.outbox.lock.Unlock()
+	d.outbox.reportUnacked()
+}
+
+func (d *distributorImpl) disseminateOutboxEntry(entry *transientstore.OutboxEntry, key outboxKey) {
+	dPlan, err := d.disseminationPlanForCollection(entry.TxID, entry.Namespace, entry.CollectionName, entry.Rwset, d.outbox.CollectionStore)
+	if err != nil {
+		logger.Warning("Failed computing dissemination plan of private RWSet for TxID", entry.TxID, ", namespace", entry.Namespace,
+			"collection", entry.CollectionName, ":", err)
+		return
+	}
+	for _, dis := range dPlan {
+		if err := d.SendByCriteria(dis.msg, dis.criteria); err != nil {
+			logger.Debug("Failed disseminating private RWSet for TxID", entry.TxID, ", namespace", entry.Namespace,
+				"collection", entry.CollectionName, "again:", err)
+			d.outbox.lock.Lock()
+			d.outbox.unacked[key] = missingAcks(dis.criteria.MinAck, err)
+			d.outbox.lock.Unlock()
+			return
+		}
+	}
+	if err := d.outbox.Store.RemoveFromOutbox(entry.TxID, entry.Namespace, entry.CollectionName); err != nil {
+		logger.Warning("Failed removing private RWSet for TxID", entry.TxID, ", namespace", entry.Namespace,
+			"collection", entry.CollectionName, "from the outbox:", err)
+		return
+	}
+	logger.Info("Disseminated private RWSet for TxID", entry.TxID, ", namespace", entry.Namespace, "collection", entry.CollectionName,
+		"from the outbox to enough peers")
+	d.outbox.lock.Lock()
+	delete(d.outbox.unacked, key)
+	d.outbox.lock.Unlock()
+}
+
+// add adds private data which wasn't disseminated to enough peers to the outbox
+func (o *outbox) add(payload *proto.PrivatePayload, minAck int, sendErr error) error {
+	height, err := o.LedgerHeight()
+	if err != nil {
+		return errors.Wrap(err, "failed obtaining ledger height")
+	}
+	err = o.Store.AddToOutbox(&transientstore.OutboxEntry{
+		TxID:                  payload.TxId,
+		Namespace:             payload.Namespace,
+		CollectionName:        payload.CollectionName,
+		ReceivedAtBlockHeight: height,
+		Rwset:                 payload.PrivateRwset,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	o.lock.Lock()
+	o.unacked[outboxKey{txID: payload.TxId, namespace: payload.Namespace, collection: payload.CollectionName}] = missingAcks(minAck, sendErr)
+	o.lock.Unlock()
+	o.reportUnacked()
+	return nil
+}
+
+// reportUnacked reports the number of acknowledgements missing
+// for the private data of each collection in the outbox as metrics
+func (o *outbox) reportUnacked() {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	unacked := make(map[collectionKey]int)
+	// Collections which have no private data in the outbox anymore are reported as well
+	for key := range o.reported {
+		unacked[key] = 0
+	}
+	for key, count := range o.unacked {
+		unacked[collectionKey{namespace: key.namespace, collection: key.collection}] += count
+	}
+	if metrics.RootScope == nil {
+		return
+	}
+	for key, count := range unacked {
+		o.reported[key] = struct{}{}
+		scope := metrics.RootScope.SubScope("gossip_privdata_outbox").Tagged(map[string]string{
+			"channel":    o.chainID,
+			"namespace":  key.namespace,
+			"collection": key.collection,
+		})
+		scope.Gauge("unacked_peers").Update(float64(count))
+	}
+}
+
+func (o *outbox) stop() {
+	o.stopOnce.Do(func() {
+		close(o.stopChan)
+	})
+}
+
+// missingAcks returns the number of acknowledgements which were missing
+// for a dissemination that required the given number of acknowledgements
+func missingAcks(minAck int, sendErr error) int {
+	if ackErr, isAckErr := errors.Cause(sendErr).(*gossip2.AckError); isAckErr {
+		return minAck - ackErr.AckCount
+	}
+	return minAck
+}
@@ -0,0 +1,270 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"errors"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/transientstore"
+	"github.com/hyperledger/fabric/gossip/api"
+	gossip2 "github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/protos/common"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+type outboxStoreMock struct {
+	sync.Mutex
+	entries map[outboxKey]*transientstore.OutboxEntry
+}
+
+func (s *outboxStoreMock) AddToOutbox(entry *transientstore.OutboxEntry) error {
+	s.Lock()
+	defer s.Unlock()
+	s.entries[outboxKey{txID: entry.TxID, namespace: entry.Namespace, collection: entry.CollectionName}] = entry
+	return nil
+}
+
+func (s *outboxStoreMock) GetOutbox() ([]*transientstore.OutboxEntry, error) {
+	s.Lock()
+	defer s.Unlock()
+	var entries []*transientstore.OutboxEntry
+	for _, entry := range s.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].TxID+entries[i].Namespace+entries[i].CollectionName < entries[j].TxID+entries[j].Namespace+entries[j].CollectionName
+	})
+	return entries, nil
+}
+
+func (s *outboxStoreMock) RemoveFromOutbox(txid string, namespace string, collection string) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.entries, outboxKey{txID: txid, namespace: namespace, collection: collection})
+	return nil
+}
+
+func (s *outboxStoreMock) purge(txid string) {
+	s.Lock()
+	defer s.Unlock()
+	for key := range s.entries {
+		if key.txID == txid {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// unreliableGossip fails sending messages as long as it is failing
+type unreliableGossip struct {
+	*gossipMock
+	failing int32
+	sent    uint32
+}
+
+func (g *unreliableGossip) SendByCriteria(message *proto.SignedGossipMessage, criteria gossip2.SendCriteria) error {
+	atomic.AddUint32(&g.sent, 1)
+	if atomic.LoadInt32(&g.failing) == 1 {
+		return &gossip2.AckError{}
+	}
+	return nil
+}
+
+func (g *unreliableGossip) setFailing(failing bool) {
+	if failing {
+		atomic.StoreInt32(&g.failing, 1)
+		return
+	}
+	atomic.StoreInt32(&g.failing, 0)
+}
+
+func waitUntilOrFail(t *testing.T, pred func() bool) {
+	deadline := time.Now().Add(time.Second * 10)
+	for time.Now().Before(deadline) {
+		if pred() {
+			return
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	assert.Fail(t, "Timeout expired!")
+}
+
+func TestDistributorOutbox(t *testing.T) {
+	viper.Set(outboxRetryIntervalConfigKey, 100*time.Millisecond)
+	defer viper.Set(outboxRetryIntervalConfigKey, nil)
+
+	g := &unreliableGossip{
+		gossipMock: &gossipMock{
+			PeerSignature: api.PeerSignature{
+				Signature:    []byte{3, 4, 5},
+				Message:      []byte{6, 7, 8},
+				PeerIdentity: []byte{0, 1, 2},
+			},
+		},
+	}
+	cs := createcollectionStore(common.SignedData{
+		Identity:  []byte{0, 1, 2},
+		Signature: []byte{3, 4, 5},
+		Data:      []byte{6, 7, 8},
+	}).thatAcceptsAll()
+	store := &outboxStoreMock{entries: make(map[outboxKey]*transientstore.OutboxEntry)}
+	var ledgerHeightErr atomic.Value
+	ledgerHeightErr.Store(false)
+	d := NewDistributorWithOutbox("test", g, OutboxSupport{
+		Store:           store,
+		CollectionStore: cs,
+		LedgerHeight: func() (uint64, error) {
+			if ledgerHeightErr.Load().(bool) {
+				return 0, errors.New("ledger is down")
+			}
+			return 10, nil
+		},
+	}).(*distributorImpl)
+	defer d.Close()
+
+	outboxLen := func() int {
+		entries, _ := store.GetOutbox()
+		return len(entries)
+	}
+	unacked := func() map[outboxKey]int {
+		d.outbox.lock.Lock()
+		defer d.outbox.lock.Unlock()
+		res := make(map[outboxKey]int)
+		for key, count := range d.outbox.unacked {
+			res[key] = count
+		}
+		return res
+	}
+
+	// Scenario I: private data which isn't disseminated to enough peers
+	// is added to the outbox, and the endorsement doesn't fail
+	g.setFailing(true)
+	pvtData := (&pvtDataFactory{}).addRWSet().addNSRWSet("ns1", "c1", "c2").addRWSet().addNSRWSet("ns2", "c1").create()
+	err := d.Distribute("tx1", pvtData[0].WriteSet, cs)
+	assert.NoError(t, err)
+	entries, _ := store.GetOutbox()
+	assert.Len(t, entries, 2)
+	for i, coll := range []string{"c1", "c2"} {
+		assert.Equal(t, "tx1", entries[i].TxID)
+		assert.Equal(t, "ns1", entries[i].Namespace)
+		assert.Equal(t, coll, entries[i].CollectionName)
+		assert.Equal(t, uint64(10), entries[i].ReceivedAtBlockHeight)
+		assert.Equal(t, pvtData[0].WriteSet.NsPvtRwset[0].CollectionPvtRwset[i].Rwset, entries[i].Rwset)
+	}
+	// The collections require an acknowledgement from a single peer
+	assert.Equal(t, map[outboxKey]int{
+		{txID: "tx1", namespace: "ns1", collection: "c1"}: 1,
+		{txID: "tx1", namespace: "ns1", collection: "c2"}: 1,
+	}, unacked())
+
+	// The private data stays in the outbox as long as it isn't disseminated to enough peers
+	sent := atomic.LoadUint32(&g.sent)
+	waitUntilOrFail(t, func() bool {
+		return atomic.LoadUint32(&g.sent) >= sent+4
+	})
+	assert.Equal(t, 2, outboxLen())
+
+	// Scenario II: private data which is disseminated again is removed from the outbox
+	g.setFailing(false)
+	waitUntilOrFail(t, func() bool {
+		return outboxLen() == 0 && len(unacked()) == 0
+	})
+
+	// Scenario III: private data purged from the outbox, as its transaction
+	// committed, isn't tracked anymore
+	g.setFailing(true)
+	err = d.Distribute("tx2", pvtData[1].WriteSet, cs)
+	assert.NoError(t, err)
+	assert.Len(t, unacked(), 1)
+	store.purge("tx2")
+	waitUntilOrFail(t, func() bool {
+		return len(unacked()) == 0
+	})
+
+	// Scenario IV: private data which can't be added to the outbox fails the endorsement
+	ledgerHeightErr.Store(true)
+	err = d.Distribute("tx1", pvtData[0].WriteSet, cs)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed disseminating 2 out of 2 private RWSets")
+	assert.Equal(t, 0, outboxLen())
+}
+
+func TestOutboxUnackedMetrics(t *testing.T) {
+	server, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")})
+	assert.NoError(t, err)
+	defer server.Close()
+
+	assert.NoError(t, metrics.Init(metrics.Opts{
+		Enabled:  true,
+		Reporter: "statsd",
+		Interval: 100 * time.Millisecond,
+		StatsdReporterOpts: metrics.StatsdReporterOpts{
+			Address:       server.LocalAddr().String(),
+			FlushInterval: 100 * time.Millisecond,
+			FlushBytes:    512,
+		},
+	}))
+	assert.NoError(t, metrics.Start())
+	defer metrics.Shutdown()
+
+	// awaitReported waits until all the given metrics are reported
+	awaitReported := func(expected ...string) {
+		var reported string
+		buffer := make([]byte, 4096)
+		server.SetReadDeadline(time.Now().Add(10 * time.Second))
+		for _, metric := range expected {
+			for !strings.Contains(reported, metric) {
+				n, err := server.Read(buffer)
+				if !assert.NoError(t, err, "%s wasn't reported, got: %s", metric, reported) {
+					return
+				}
+				reported += string(buffer[:n])
+			}
+		}
+	}
+
+	o := &outbox{
+		chainID: "test",
+		unacked: map[outboxKey]int{
+			{txID: "tx1", namespace: "ns1", collection: "c1"}: 1,
+			{txID: "tx2", namespace: "ns1", collection: "c1"}: 2,
+			{txID: "tx1", namespace: "ns1", collection: "c2"}: 1,
+		},
+		reported: make(map[collectionKey]struct{}),
+	}
+	// The missing acknowledgements are summed up per collection
+	o.reportUnacked()
+	awaitReported(
+		"hyperledger_fabric.gossip_privdata_outbox.unacked_peers.channel-test.collection-c1.namespace-ns1:3|g",
+		"hyperledger_fabric.gossip_privdata_outbox.unacked_peers.channel-test.collection-c2.namespace-ns1:1|g",
+	)
+
+	// Collections which have no private data in the outbox anymore are reported with no missing acknowledgements
+	o.unacked = map[outboxKey]int{
+		{txID: "tx2", namespace: "ns1", collection: "c1"}: 2,
+	}
+	o.reportUnacked()
+	awaitReported(
+		"hyperledger_fabric.gossip_privdata_outbox.unacked_peers.channel-test.collection-c1.namespace-ns1:2|g",
+		"hyperledger_fabric.gossip_privdata_outbox.unacked_peers.channel-test.collection-c2.namespace-ns1:0|g",
+	)
+}
+
+func TestMissingAcks(t *testing.T) {
+	assert.Equal(t, 2, missingAcks(3, &gossip2.AckError{AckCount: 1}))
+	assert.Equal(t, 3, missingAcks(3, &gossip2.AckError{}))
+	assert.Equal(t, 3, missingAcks(3, errors.New("no such channel")))
+}
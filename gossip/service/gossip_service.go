@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package service
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/hyperledger/fabric/core/committer"
@@ -15,7 +16,9 @@ import (
 	"github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
 	gossipCommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/election"
 	"github.com/hyperledger/fabric/gossip/gossip"
 	"github.com/hyperledger/fabric/gossip/integration"
@@ -50,6 +53,51 @@ type GossipService interface {
 	InitializeChannel(chainID string, endpoints []string, support Support)
 	// AddPayload appends message payload to for given chain
 	AddPayload(chainID string, payload *gproto.Payload) error
+	// OrdererHealth returns the health of the ordering service endpoints
+	// the blocks of the given chain are delivered from
+	OrdererHealth(chainID string) ([]blocksprovider.EndpointHealth, error)
+	// Inspect returns a snapshot of the gossip membership and channel state of the peer
+	Inspect() *GossipState
+	// YieldLeadership relinquishes the leadership of the peer in the given chain,
+	// until another peer is elected as the leader
+	YieldLeadership(chainID string) error
+}
+
+// GossipState is a snapshot of the gossip membership and channel state of the peer
+type GossipState struct {
+	// Self is the membership information of the peer
+	Self discovery.NetworkMember
+	// Alive are the peers considered alive
+	Alive []discovery.NetworkMember
+	// Dead are the peers considered dead
+	Dead []discovery.NetworkMember
+	// Channels is the state of the channels the peer joined, sorted by channel name
+	Channels []ChannelState
+	// Identities are the identities of the peers known to the peer
+	Identities api.PeerIdentitySet
+	// Connections are the statistics of the connections to remote peers
+	Connections []comm.ConnectionStats
+	// Reachability is the outcome of the last verification that the
+	// external endpoint of the peer is reachable from other organizations
+	Reachability gossip.ReachabilityStatus
+}
+
+// ChannelState is a snapshot of the gossip state of a channel
+type ChannelState struct {
+	// ChannelID is the name of the channel
+	ChannelID string
+	// LedgerHeight is the ledger height the peer publishes in the channel
+	LedgerHeight uint64
+	// Leader is the PKI-ID of the peer known to be the leader of the
+	// organization in the channel, nil if no leader is known
+	Leader gossipCommon.PKIidType
+	// IsLeader indicates whether the peer is the leader in the channel
+	IsLeader bool
+	// Peers are the alive peers of the channel, with their published properties
+	Peers []discovery.NetworkMember
+	// LeadershipEvents are the recent changes of the leadership status
+	// of the peer in the channel, from the oldest to the newest
+	LeadershipEvents []LeadershipEvent
 }
 
 // DeliveryServiceFactory factory to create and initialize delivery service instance
@@ -80,6 +128,7 @@ type privateHandler struct {
 
 func (p privateHandler) close() {
 	p.coordinator.Close()
+	p.distributor.Close()
 }
 
 type gossipServiceImpl struct {
@@ -93,6 +142,8 @@ type gossipServiceImpl struct {
 	mcs             api.MessageCryptoService
 	peerIdentity    []byte
 	secAdv          api.SecurityAdvisor
+
+	leadership leadershipHistory
 }
 
 // This is an implementation of api.JoinChannelMessage.
@@ -165,8 +216,12 @@ func InitGossipServiceCustomDeliveryFactory(peerIdentity []byte, endpoint string
 	return errors.WithStack(err)
 }
 
-// GetGossipService returns an instance of gossip service
+// GetGossipService returns an instance of gossip service,
+// or nil if the gossip service wasn't initialized yet
 func GetGossipService() GossipService {
+	if gossipServiceInstance == nil {
+		return nil
+	}
 	return gossipServiceInstance
 }
 
@@ -240,10 +295,19 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, endpoints []string
 		Fetcher:         fetcher,
 	}, g.createSelfSignedData())
 
+	distributor := privdata2.NewDistributor(chainID, g)
+	if viper.GetBool("peer.gossip.pvtData.outbox.enabled") {
+		distributor = privdata2.NewDistributorWithOutbox(chainID, g, privdata2.OutboxSupport{
+			Store:           support.Store,
+			CollectionStore: support.Cs,
+			LedgerHeight:    support.Committer.LedgerHeight,
+		})
+	}
+
 	g.privateHandlers[chainID] = privateHandler{
 		support:     support,
 		coordinator: coordinator,
-		distributor: privdata2.NewDistributor(chainID, g),
+		distributor: distributor,
 	}
 	g.chains[chainID] = state.NewGossipStateProvider(chainID, servicesAdapter, coordinator)
 	if g.deliveryService[chainID] == nil {
@@ -341,6 +405,77 @@ func (g *gossipServiceImpl) AddPayload(chainID string, payload *gproto.Payload)
 	return g.chains[chainID].AddPayload(payload)
 }
 
+// OrdererHealth returns the health of the ordering service endpoints
+// the blocks of the given chain are delivered from
+func (g *gossipServiceImpl) OrdererHealth(chainID string) ([]blocksprovider.EndpointHealth, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	ds, exists := g.deliveryService[chainID]
+	if !exists || ds == nil {
+		return nil, errors.Errorf("no delivery service for channel %s", chainID)
+	}
+	return ds.OrdererHealth(chainID)
+}
+
+// Inspect returns a snapshot of the gossip membership and channel state of the peer
+func (g *gossipServiceImpl) Inspect() *GossipState {
+	self := g.SelfMembershipInfo()
+	state := &GossipState{
+		Self:         self,
+		Alive:        g.Peers(),
+		Dead:         g.DeadPeers(),
+		Identities:   g.IdentityInfo(),
+		Connections:  g.ConnectionStats(),
+		Reachability: g.Reachability(),
+	}
+
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	for chainID := range g.chains {
+		chState := ChannelState{
+			ChannelID: chainID,
+			Peers:     g.PeersOfChannel(gossipCommon.ChainID(chainID)),
+		}
+		if stateInfo := g.SelfChannelInfo(gossipCommon.ChainID(chainID)); stateInfo != nil {
+			chState.LedgerHeight = stateInfo.GetStateInfo().GetProperties().GetLedgerHeight()
+		}
+		if le, exists := g.leaderElection[chainID]; exists {
+			chState.Leader = gossipCommon.PKIidType(le.Leader())
+			chState.IsLeader = le.IsLeader()
+			chState.LeadershipEvents = g.leadership.eventsOf(chainID)
+		} else if g.deliveryService[chainID] != nil && viper.GetBool("peer.gossip.orgLeader") {
+			chState.Leader = self.PKIid
+			chState.IsLeader = true
+		}
+		state.Channels = append(state.Channels, chState)
+	}
+	sort.Slice(state.Channels, func(i, j int) bool {
+		return state.Channels[i].ChannelID < state.Channels[j].ChannelID
+	})
+	return state
+}
+
+// YieldLeadership relinquishes the leadership of the peer in the given chain,
+// until another peer is elected as the leader
+func (g *gossipServiceImpl) YieldLeadership(chainID string) error {
+	g.lock.RLock()
+	_, joined := g.chains[chainID]
+	le, exists := g.leaderElection[chainID]
+	g.lock.RUnlock()
+	if !joined {
+		return errors.Errorf("peer hasn't joined channel %s", chainID)
+	}
+	if !exists {
+		return errors.Errorf("leader election isn't used in channel %s", chainID)
+	}
+	if !le.IsLeader() {
+		return errors.Errorf("peer isn't the leader in channel %s", chainID)
+	}
+	logger.Info("Yielding the leadership in channel", chainID)
+	le.Yield()
+	return nil
+}
+
 // Stop stops the gossip component
 func (g *gossipServiceImpl) Stop() {
 	g.lock.Lock()
@@ -379,6 +514,7 @@ func (g *gossipServiceImpl) amIinChannel(myOrg string, config Config) bool {
 
 func (g *gossipServiceImpl) onStatusChangeFactory(chainID string, committer blocksprovider.LedgerInfo) func(bool) {
 	return func(isLeader bool) {
+		g.leadership.record(chainID, isLeader)
 		if isLeader {
 			yield := func() {
 				g.lock.RLock()
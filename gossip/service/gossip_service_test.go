@@ -50,6 +50,18 @@ func (*mockTransientStore) PurgeByHeight(maxBlockNumToRetain uint64) error {
 	return nil
 }
 
+func (*mockTransientStore) AddToOutbox(entry *transientstore.OutboxEntry) error {
+	panic("implement me")
+}
+
+func (*mockTransientStore) GetOutbox() ([]*transientstore.OutboxEntry, error) {
+	panic("implement me")
+}
+
+func (*mockTransientStore) RemoveFromOutbox(txid string, namespace string, collection string) error {
+	panic("implement me")
+}
+
 func (*mockTransientStore) Persist(txid string, blockHeight uint64, privateSimulationResults *rwset.TxPvtReadWriteSet) error {
 	panic("implement me")
 }
@@ -161,6 +173,29 @@ func TestLeaderElectionWithDeliverClient(t *testing.T) {
 
 	assert.Equal(t, 1, startsNum, "Only for one peer delivery client should start")
 
+	// Force the leader to yield, and ensure it stops delivering blocks
+	leader := -1
+	for i := 0; i < n; i++ {
+		if services[i].IsLeader() {
+			leader = i
+		} else {
+			assert.Error(t, gossips[i].YieldLeadership(channelName), "Only the leader should be able to yield")
+		}
+	}
+	assert.NotEqual(t, -1, leader, "No leader was found")
+	assert.Error(t, gossips[leader].YieldLeadership("nonExistentChannel"))
+	events := gossips[leader].Inspect().Channels[0].LeadershipEvents
+	assert.Len(t, events, 1)
+	assert.True(t, events[0].IsLeader)
+
+	assert.NoError(t, gossips[leader].YieldLeadership(channelName))
+	assert.False(t, services[leader].IsLeader())
+	assert.False(t, gossips[leader].(*gossipServiceImpl).deliveryService[channelName].(*mockDeliverService).running[channelName],
+		"Delivery client should stop after yielding")
+	events = gossips[leader].Inspect().Channels[0].LeadershipEvents
+	assert.Len(t, events, 2)
+	assert.False(t, events[1].IsLeader)
+
 	stopPeers(gossips)
 }
 
@@ -221,6 +256,21 @@ func TestWithStaticDeliverClientLeader(t *testing.T) {
 		assert.True(t, gossips[i].(*gossipServiceImpl).deliveryService[channelName].(*mockDeliverService).running[channelName], "Block deliverer not started for peer %d", i)
 	}
 
+	for i := 0; i < n; i++ {
+		state := gossips[i].Inspect()
+		self := gossips[i].SelfMembershipInfo()
+		assert.Equal(t, self.PKIid, state.Self.PKIid)
+		assert.NotEmpty(t, state.Identities)
+		assert.Len(t, state.Channels, 2)
+		assert.Equal(t, "chanA", state.Channels[0].ChannelID)
+		assert.Equal(t, "chanB", state.Channels[1].ChannelID)
+		for _, chState := range state.Channels {
+			assert.True(t, chState.IsLeader, "Static leader should be the leader of channel %s", chState.ChannelID)
+			assert.Equal(t, self.PKIid, chState.Leader)
+			assert.Error(t, gossips[i].YieldLeadership(chState.ChannelID), "Static leader shouldn't be able to yield")
+		}
+	}
+
 	stopPeers(gossips)
 }
 
@@ -316,6 +366,10 @@ func (ds *mockDeliverService) UpdateEndpoints(chainID string, endpoints []string
 	panic("implement me")
 }
 
+func (ds *mockDeliverService) OrdererHealth(chainID string) ([]blocksprovider.EndpointHealth, error) {
+	panic("implement me")
+}
+
 func (ds *mockDeliverService) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo, finalizer func()) error {
 	ds.running[chainID] = true
 	return nil
@@ -29,6 +29,18 @@ func (*transientStoreMock) PurgeByHeight(maxBlockNumToRetain uint64) error {
 	return nil
 }
 
+func (*transientStoreMock) AddToOutbox(entry *transientstore.OutboxEntry) error {
+	panic("implement me")
+}
+
+func (*transientStoreMock) GetOutbox() ([]*transientstore.OutboxEntry, error) {
+	panic("implement me")
+}
+
+func (*transientStoreMock) RemoveFromOutbox(txid string, namespace string, collection string) error {
+	panic("implement me")
+}
+
 func (*transientStoreMock) Persist(txid string, blockHeight uint64, privateSimulationResults *rwset.TxPvtReadWriteSet) error {
 	panic("implement me")
 }
@@ -104,6 +104,18 @@ func (g *gossipMock) IdentityInfo() api.PeerIdentitySet {
 	panic("implement me")
 }
 
+func (g *gossipMock) ConnectionStats() []comm.ConnectionStats {
+	panic("implement me")
+}
+
+func (g *gossipMock) Reachability() gossip.ReachabilityStatus {
+	panic("implement me")
+}
+
+func (g *gossipMock) DeadPeers() []discovery.NetworkMember {
+	panic("implement me")
+}
+
 func (*gossipMock) Stop() {
 	panic("implement me")
 }
@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+// maxLeadershipEvents is the number of recent leadership events kept per channel
+const maxLeadershipEvents = 10
+
+// LeadershipEvent is a change of the leadership status of the peer in a channel
+type LeadershipEvent struct {
+	// IsLeader indicates whether the peer became the leader, or stopped being the leader
+	IsLeader bool
+	// Time is the time the leadership status changed
+	Time time.Time
+}
+
+// leadershipHistory keeps the recent leadership events of the peer in each channel,
+// and reports the leadership status of the peer as metrics
+type leadershipHistory struct {
+	sync.Mutex
+	events map[string][]LeadershipEvent
+}
+
+// record records a change of the leadership status of the peer in the given channel
+func (lh *leadershipHistory) record(chainID string, isLeader bool) {
+	lh.Lock()
+	defer lh.Unlock()
+	if lh.events == nil {
+		lh.events = make(map[string][]LeadershipEvent)
+	}
+	events := append(lh.events[chainID], LeadershipEvent{IsLeader: isLeader, Time: time.Now()})
+	if len(events) > maxLeadershipEvents {
+		events = events[len(events)-maxLeadershipEvents:]
+	}
+	lh.events[chainID] = events
+
+	if metrics.RootScope == nil {
+		return
+	}
+	scope := metrics.RootScope.SubScope("gossip_leader_election").Tagged(map[string]string{"channel": chainID})
+	status := float64(0)
+	if isLeader {
+		status = 1
+	}
+	scope.Gauge("leader").Update(status)
+	scope.Counter("leadership_changes").Inc(1)
+}
+
+// eventsOf returns the recent leadership events of the peer
+// in the given channel, from the oldest to the newest
+func (lh *leadershipHistory) eventsOf(chainID string) []LeadershipEvent {
+	lh.Lock()
+	defer lh.Unlock()
+	return append([]LeadershipEvent(nil), lh.events[chainID]...)
+}
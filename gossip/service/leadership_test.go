@@ -0,0 +1,81 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeadershipHistory(t *testing.T) {
+	lh := &leadershipHistory{}
+	assert.Empty(t, lh.eventsOf("chanA"))
+
+	for i := 0; i < maxLeadershipEvents+3; i++ {
+		lh.record("chanA", i%2 == 0)
+	}
+	lh.record("chanB", true)
+
+	events := lh.eventsOf("chanA")
+	assert.Len(t, events, maxLeadershipEvents)
+	// The oldest events are dropped
+	assert.False(t, events[0].IsLeader)
+	assert.True(t, events[len(events)-1].IsLeader)
+	for i := 1; i < len(events); i++ {
+		assert.False(t, events[i].Time.Before(events[i-1].Time))
+	}
+	assert.Len(t, lh.eventsOf("chanB"), 1)
+
+	// Returned events aren't affected by later events
+	lh.record("chanB", false)
+	assert.Len(t, lh.eventsOf("chanB"), 2)
+	assert.Len(t, events, maxLeadershipEvents)
+}
+
+func TestLeadershipMetrics(t *testing.T) {
+	server, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")})
+	assert.NoError(t, err)
+	defer server.Close()
+
+	assert.NoError(t, metrics.Init(metrics.Opts{
+		Enabled:  true,
+		Reporter: "statsd",
+		Interval: 100 * time.Millisecond,
+		StatsdReporterOpts: metrics.StatsdReporterOpts{
+			Address:       server.LocalAddr().String(),
+			FlushInterval: 100 * time.Millisecond,
+			FlushBytes:    512,
+		},
+	}))
+	assert.NoError(t, metrics.Start())
+	defer metrics.Shutdown()
+
+	lh := &leadershipHistory{}
+	lh.record("chanA", true)
+
+	expected := []string{
+		"hyperledger_fabric.gossip_leader_election.leader.channel-chanA:1|g",
+		"hyperledger_fabric.gossip_leader_election.leadership_changes.channel-chanA:1|c",
+	}
+	var reported string
+	buffer := make([]byte, 4096)
+	server.SetReadDeadline(time.Now().Add(10 * time.Second))
+	for _, metric := range expected {
+		for !strings.Contains(reported, metric) {
+			n, err := server.Read(buffer)
+			if !assert.NoError(t, err, "%s wasn't reported, got: %s", metric, reported) {
+				return
+			}
+			reported += string(buffer[:n])
+		}
+	}
+}
@@ -61,6 +61,10 @@ func (g *GossipMock) Peers() []discovery.NetworkMember {
 	return g.Called().Get(0).([]discovery.NetworkMember)
 }
 
+func (g *GossipMock) DeadPeers() []discovery.NetworkMember {
+	panic("not implemented")
+}
+
 func (g *GossipMock) PeersOfChannel(chainID common.ChainID) []discovery.NetworkMember {
 	args := g.Called(chainID)
 	return args.Get(0).([]discovery.NetworkMember)
@@ -90,6 +94,14 @@ func (g *GossipMock) IdentityInfo() api.PeerIdentitySet {
 	panic("not implemented")
 }
 
+func (g *GossipMock) ConnectionStats() []comm.ConnectionStats {
+	panic("not implemented")
+}
+
+func (g *GossipMock) Reachability() gossip.ReachabilityStatus {
+	panic("not implemented")
+}
+
 func (g *GossipMock) Stop() {
 
 }
@@ -8,8 +8,8 @@ package state
 
 import (
 	"bytes"
+	"sort"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	pb "github.com/golang/protobuf/proto"
@@ -40,6 +40,8 @@ const (
 	defAntiEntropyInterval             = 10 * time.Second
 	defAntiEntropyStateResponseTimeout = 3 * time.Second
 	defAntiEntropyBatchSize            = 10
+	defAntiEntropyParallelism          = 4
+	defMaxStateResponseBytes           = 20 * 1024 * 1024
 
 	defChannelBufferSize     = 100
 	defAntiEntropyMaxRetries = 3
@@ -52,6 +54,8 @@ const (
 	enqueueRetryInterval = time.Millisecond * 100
 )
 
+var errStopped = errors.New("state provider has been stopped")
+
 // GossipAdapter defines gossip/communication required interface for state provider
 type GossipAdapter interface {
 	// Send sends a message to remote peers
@@ -135,8 +139,6 @@ type GossipStateProviderImpl struct {
 
 	ledger ledgerResources
 
-	stateResponseCh chan proto.ReceivedMessage
-
 	stateRequestCh chan proto.ReceivedMessage
 
 	stopCh chan struct{}
@@ -145,7 +147,22 @@ type GossipStateProviderImpl struct {
 
 	once sync.Once
 
-	stateTransferActive int32
+	// Channels of state requests awaiting a response, by nonce of the request
+	pendingStateRequests map[uint64]chan proto.ReceivedMessage
+
+	pendingStateRequestsLock sync.Mutex
+
+	// Max total size of blocks and private data sent in a state response
+	maxStateResponseBytes int
+
+	// Number of blocks requested in a single state request
+	batchSize int
+
+	// Number of state requests sent to peers in parallel
+	parallelism int
+
+	// Time to wait for a peer to respond to a state request
+	responseTimeout time.Duration
 }
 
 var logger = util.GetLogger(util.LoggingStateModule, "")
@@ -214,15 +231,21 @@ func NewGossipStateProvider(chainID string, services *ServicesMediator, ledger l
 
 		ledger: ledger,
 
-		stateResponseCh: make(chan proto.ReceivedMessage, defChannelBufferSize),
-
 		stateRequestCh: make(chan proto.ReceivedMessage, defChannelBufferSize),
 
 		stopCh: make(chan struct{}, 1),
 
-		stateTransferActive: 0,
-
 		once: sync.Once{},
+
+		pendingStateRequests: make(map[uint64]chan proto.ReceivedMessage),
+
+		maxStateResponseBytes: util.GetIntOrDefault("peer.gossip.state.maxResponseBytes", defMaxStateResponseBytes),
+
+		batchSize: util.GetIntOrDefault("peer.gossip.state.batchSize", defAntiEntropyBatchSize),
+
+		parallelism: util.GetIntOrDefault("peer.gossip.state.parallelism", defAntiEntropyParallelism),
+
+		responseTimeout: util.GetDurationOrDefault("peer.gossip.state.responseTimeout", defAntiEntropyStateResponseTimeout),
 	}
 
 	logger.Infof("Updating metadata information, "+
@@ -338,11 +361,17 @@ func (s *GossipStateProviderImpl) directMessage(msg proto.ReceivedMessage) {
 			s.stateRequestCh <- msg
 		}
 	} else if incoming.GetStateResponse() != nil {
-		// If no state transfer procedure activate there is
-		// no reason to process the message
-		if atomic.LoadInt32(&s.stateTransferActive) == 1 {
-			// Send signal of state response message
-			s.stateResponseCh <- msg
+		// If no state request awaits the response
+		// there is no reason to process the message
+		s.pendingStateRequestsLock.Lock()
+		responseCh, exists := s.pendingStateRequests[incoming.Nonce]
+		s.pendingStateRequestsLock.Unlock()
+		if exists {
+			select {
+			case responseCh <- msg:
+			default:
+				logger.Debug("Already received a response for state request with nonce", incoming.Nonce)
+			}
 		}
 	}
 }
@@ -369,10 +398,16 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 	}
 	request := msg.GetGossipMessage().GetStateRequest()
 
+	// Requests of the default batch size are always served,
+	// as peers might not be configured alike
+	maxBatchSize := uint64(defAntiEntropyBatchSize)
+	if s.batchSize > defAntiEntropyBatchSize {
+		maxBatchSize = uint64(s.batchSize)
+	}
 	batchSize := request.EndSeqNum - request.StartSeqNum
-	if batchSize > defAntiEntropyBatchSize {
+	if batchSize > maxBatchSize {
 		logger.Errorf("Requesting blocks batchSize size (%d) greater than configured allowed"+
-			" (%d) batching for anti-entropy. Ignoring request...", batchSize, defAntiEntropyBatchSize)
+			" (%d) batching for anti-entropy. Ignoring request...", batchSize, maxBatchSize)
 		return
 	}
 
@@ -394,6 +429,7 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 	endSeqNum := min(currentHeight, request.EndSeqNum)
 
 	response := &proto.RemoteStateResponse{Payloads: make([]*proto.Payload, 0)}
+	responseBytes := 0
 	for seqNum := request.StartSeqNum; seqNum <= endSeqNum; seqNum++ {
 		logger.Debug("Reading block ", seqNum, " with private data from the coordinator service")
 		connInfo := msg.GetConnectionInfo()
@@ -432,6 +468,19 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 			}
 		}
 
+		payloadBytes := len(blockBytes)
+		for _, pvt := range pvtBytes {
+			payloadBytes += len(pvt)
+		}
+		// The response always contains at least one block, and the requesting peer
+		// asks for the blocks which didn't fit in the response in its next request
+		if len(response.Payloads) > 0 && responseBytes+payloadBytes > s.maxStateResponseBytes {
+			logger.Debugf("State response reached %d bytes, sending blocks [%d...%d] instead of [%d...%d]",
+				responseBytes, request.StartSeqNum, seqNum-1, request.StartSeqNum, endSeqNum)
+			break
+		}
+		responseBytes += payloadBytes
+
 		// Appending result to the response
 		response.Payloads = append(response.Payloads, &proto.Payload{
 			SeqNum:      seqNum,
@@ -449,31 +498,35 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 	})
 }
 
-func (s *GossipStateProviderImpl) handleStateResponse(msg proto.ReceivedMessage) (uint64, error) {
-	max := uint64(0)
-	// Send signal that response for given nonce has been received
+// handleStateResponse verifies the payloads of a state response, and returns
+// those which are in the range [start...end] ordered by their sequence numbers
+func (s *GossipStateProviderImpl) handleStateResponse(msg proto.ReceivedMessage, start uint64, end uint64) ([]*proto.Payload, error) {
 	response := msg.GetGossipMessage().GetStateResponse()
-	// Extract payloads, verify and push into buffer
+	// Extract payloads and verify them
 	if len(response.GetPayloads()) == 0 {
-		return uint64(0), errors.New("Received state transfer response without payload")
+		return nil, errors.New("Received state transfer response without payload")
 	}
+	var payloads []*proto.Payload
 	for _, payload := range response.GetPayloads() {
 		logger.Debugf("Received payload with sequence number %d.", payload.SeqNum)
+		if payload.SeqNum < start || payload.SeqNum > end {
+			logger.Warningf("Received payload with sequence number %d which wasn't requested, skipping it", payload.SeqNum)
+			continue
+		}
 		if err := s.mediator.VerifyBlock(common2.ChainID(s.chainID), payload.SeqNum, payload.Data); err != nil {
 			err = errors.WithStack(err)
 			logger.Warningf("Error verifying block with sequence number %d, due to %+v", payload.SeqNum, err)
-			return uint64(0), err
-		}
-		if max < payload.SeqNum {
-			max = payload.SeqNum
-		}
-
-		err := s.addPayload(payload, blocking)
-		if err != nil {
-			logger.Warningf("Payload with sequence number %d wasn't added to payload buffer: %v", payload.SeqNum, err)
+			return nil, err
 		}
+		payloads = append(payloads, payload)
+	}
+	if len(payloads) == 0 {
+		return nil, errors.New("Received state transfer response without requested payload")
 	}
-	return max, nil
+	sort.Slice(payloads, func(i, j int) bool {
+		return payloads[i].SeqNum < payloads[j].SeqNum
+	})
+	return payloads, nil
 }
 
 // Stop function send halting signal to all go routines
@@ -487,7 +540,6 @@ func (s *GossipStateProviderImpl) Stop() {
 		// Close all resources
 		s.ledger.Close()
 		close(s.stateRequestCh)
-		close(s.stateResponseCh)
 		close(s.stopCh)
 	})
 }
@@ -606,61 +658,122 @@ func (s *GossipStateProviderImpl) maxAvailableLedgerHeight() uint64 {
 	return max
 }
 
-// GetBlocksInRange capable to acquire blocks with sequence
-// numbers in the range [start...end].
-func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64) {
-	atomic.StoreInt32(&s.stateTransferActive, 1)
-	defer atomic.StoreInt32(&s.stateTransferActive, 0)
+// blockRange is a range of blocks [start...end]
+type blockRange struct {
+	start uint64
+	end   uint64
+}
 
+// requestBlocksInRange capable to acquire blocks with sequence
+// numbers in the range [start...end]. The range is split into chunks
+// which are requested from several peers in parallel, and the blocks
+// are handed to the payload buffer in order.
+func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64) {
 	for prev := start; prev <= end; {
-		next := min(end, prev+defAntiEntropyBatchSize)
-
-		gossipMsg := s.stateRequestMessage(prev, next)
-
-		responseReceived := false
-		tryCounts := 0
+		var chunks []blockRange
+		for len(chunks) < s.parallelism && prev <= end {
+			next := min(end, prev+uint64(s.batchSize))
+			chunks = append(chunks, blockRange{start: prev, end: next})
+			prev = next + 1
+		}
 
-		for !responseReceived {
-			if tryCounts > defAntiEntropyMaxRetries {
-				logger.Warningf("Wasn't  able to get blocks in range [%d...%d], after %d retries",
-					prev, next, tryCounts)
+		payloads := make([][]*proto.Payload, len(chunks))
+		errs := make([]error, len(chunks))
+		var wg sync.WaitGroup
+		wg.Add(len(chunks))
+		for i, chunk := range chunks {
+			go func(i int, chunk blockRange) {
+				defer wg.Done()
+				payloads[i], errs[i] = s.requestChunk(chunk)
+			}(i, chunk)
+		}
+		wg.Wait()
+
+		// Blocks of a chunk are handed over only after the blocks of the preceding chunks,
+		// so that they don't fill up the payload buffer while a preceding chunk is missing
+		for i, chunk := range chunks {
+			for _, payload := range payloads[i] {
+				if err := s.addPayload(payload, blocking); err != nil {
+					logger.Warningf("Payload with sequence number %d wasn't added to payload buffer: %v", payload.SeqNum, err)
+				}
+			}
+			if errs[i] == errStopped {
 				return
 			}
-			// Select peers to ask for blocks
-			peer, err := s.selectPeerToRequestFrom(next)
-			if err != nil {
-				logger.Warningf("Cannot send state request for blocks in range [%d...%d], due to %+v",
-					prev, next, errors.WithStack(err))
+			if errs[i] != nil {
+				logger.Warningf("Wasn't able to get blocks in range [%d...%d], due to %+v", chunk.start, chunk.end, errs[i])
 				return
 			}
+		}
+	}
+}
 
-			logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d], "+
-				"for chainID %s", peer.Endpoint, prev, next, s.chainID)
+// requestChunk requests the blocks of the given chunk from peers which have them, and returns the
+// blocks it received. Peers which fail to respond aren't asked again, unless no other peer has the blocks.
+func (s *GossipStateProviderImpl) requestChunk(chunk blockRange) ([]*proto.Payload, error) {
+	var payloads []*proto.Payload
+	failedPeers := make(map[string]struct{})
+	tryCounts := 0
 
-			s.mediator.Send(gossipMsg, peer)
-			tryCounts++
+	for prev := chunk.start; prev <= chunk.end; {
+		if tryCounts > defAntiEntropyMaxRetries {
+			return payloads, errors.Errorf("no valid response after %d retries", tryCounts)
+		}
+		// Select peers to ask for blocks
+		peer, err := s.selectPeerToRequestFrom(chunk.end, failedPeers)
+		if err != nil {
+			return payloads, errors.WithStack(err)
+		}
+		tryCounts++
 
-			// Wait until timeout or response arrival
-			select {
-			case msg := <-s.stateResponseCh:
-				if msg.GetGossipMessage().Nonce != gossipMsg.Nonce {
-					continue
-				}
-				// Got corresponding response for state request, can continue
-				index, err := s.handleStateResponse(msg)
-				if err != nil {
-					logger.Warningf("Wasn't able to process state response for "+
-						"blocks [%d...%d], due to %+v", prev, next, errors.WithStack(err))
-					continue
-				}
-				prev = index + 1
-				responseReceived = true
-			case <-time.After(defAntiEntropyStateResponseTimeout):
-			case <-s.stopCh:
-				s.stopCh <- struct{}{}
-				return
-			}
+		logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d], "+
+			"for chainID %s", peer.Endpoint, prev, chunk.end, s.chainID)
+
+		received, err := s.requestBlocks(peer, prev, chunk.end)
+		if err == errStopped {
+			return payloads, err
+		}
+		if err != nil {
+			logger.Warningf("Wasn't able to get blocks in range [%d...%d] from %s, due to %+v",
+				prev, chunk.end, peer.Endpoint, err)
+			failedPeers[string(peer.PKIID)] = struct{}{}
+			continue
 		}
+		payloads = append(payloads, received...)
+		// The peer might have responded with only a part of the blocks,
+		// so request the remaining ones in the next iteration
+		prev = received[len(received)-1].SeqNum + 1
+		tryCounts = 0
+	}
+	return payloads, nil
+}
+
+// requestBlocks sends a state request for blocks in the range [start...end]
+// to the given peer, and returns the verified blocks of its response
+func (s *GossipStateProviderImpl) requestBlocks(peer *comm.RemotePeer, start uint64, end uint64) ([]*proto.Payload, error) {
+	gossipMsg := s.stateRequestMessage(start, end)
+
+	responseCh := make(chan proto.ReceivedMessage, 1)
+	s.pendingStateRequestsLock.Lock()
+	s.pendingStateRequests[gossipMsg.Nonce] = responseCh
+	s.pendingStateRequestsLock.Unlock()
+	defer func() {
+		s.pendingStateRequestsLock.Lock()
+		delete(s.pendingStateRequests, gossipMsg.Nonce)
+		s.pendingStateRequestsLock.Unlock()
+	}()
+
+	s.mediator.Send(gossipMsg, peer)
+
+	// Wait until timeout or response arrival
+	select {
+	case msg := <-responseCh:
+		return s.handleStateResponse(msg, start, end)
+	case <-time.After(s.responseTimeout):
+		return nil, errors.Errorf("no response within %v", s.responseTimeout)
+	case <-s.stopCh:
+		s.stopCh <- struct{}{}
+		return nil, errStopped
 	}
 }
 
@@ -679,8 +792,9 @@ func (s *GossipStateProviderImpl) stateRequestMessage(beginSeq uint64, endSeq ui
 	}
 }
 
-// Select peer which has required blocks to ask missing blocks from
-func (s *GossipStateProviderImpl) selectPeerToRequestFrom(height uint64) (*comm.RemotePeer, error) {
+// Select peer which has required blocks to ask missing blocks from,
+// preferring peers which aren't among the given failed peers
+func (s *GossipStateProviderImpl) selectPeerToRequestFrom(height uint64, failedPeers map[string]struct{}) (*comm.RemotePeer, error) {
 	// Filter peers which posses required range of missing blocks
 	peers := s.filterPeers(s.hasRequiredHeight(height))
 
@@ -689,6 +803,17 @@ func (s *GossipStateProviderImpl) selectPeerToRequestFrom(height uint64) (*comm.
 		return nil, errors.New("there are no peers to ask for missing blocks from")
 	}
 
+	var preferredPeers []*comm.RemotePeer
+	for _, peer := range peers {
+		if _, failed := failedPeers[string(peer.PKIID)]; !failed {
+			preferredPeers = append(preferredPeers, peer)
+		}
+	}
+	if len(preferredPeers) > 0 {
+		peers = preferredPeers
+		n = len(peers)
+	}
+
 	// Select peer to ask for blocks
 	return peers[util.RandomInt(n)], nil
 }
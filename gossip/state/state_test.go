@@ -175,6 +175,18 @@ func (*mockTransientStore) PurgeByHeight(maxBlockNumToRetain uint64) error {
 	return nil
 }
 
+func (*mockTransientStore) AddToOutbox(entry *transientstore.OutboxEntry) error {
+	panic("implement me")
+}
+
+func (*mockTransientStore) GetOutbox() ([]*transientstore.OutboxEntry, error) {
+	panic("implement me")
+}
+
+func (*mockTransientStore) RemoveFromOutbox(txid string, namespace string, collection string) error {
+	panic("implement me")
+}
+
 func (*mockTransientStore) Persist(txid string, blockHeight uint64, privateSimulationResults *rwset.TxPvtReadWriteSet) error {
 	panic("implement me")
 }
@@ -1479,6 +1491,154 @@ var peers = map[string]testPeer{
 	},
 }
 
+func TestStateResponseSizeCap(t *testing.T) {
+	t.Parallel()
+	coord := new(coordinatorMock)
+	coord.On("LedgerHeight", mock.Anything).Return(uint64(10), nil)
+	for seqNum := uint64(1); seqNum < 10; seqNum++ {
+		block := &pcomm.Block{
+			Header: &pcomm.BlockHeader{Number: seqNum},
+			Data:   &pcomm.BlockData{Data: [][]byte{make([]byte, 1000)}},
+		}
+		coord.On("GetPvtDataAndBlockByNum", seqNum).Return(block, gutil.PvtDataCollections{}, nil)
+	}
+
+	s := &GossipStateProviderImpl{chainID: "testChainID", ledger: coord}
+	stateRequest := func(start, end uint64) []*proto.Payload {
+		msg, _ := (&proto.GossipMessage{
+			Nonce:   1,
+			Tag:     proto.GossipMessage_CHAN_OR_ORG,
+			Channel: []byte("testChainID"),
+			Content: &proto.GossipMessage_StateRequest{StateRequest: &proto.RemoteStateRequest{
+				StartSeqNum: start,
+				EndSeqNum:   end,
+			}},
+		}).NoopSign()
+		requestMsg := new(receivedMessageMock)
+		requestMsg.On("GetGossipMessage").Return(msg)
+		requestMsg.On("GetConnectionInfo").Return(&proto.ConnectionInfo{Auth: &proto.AuthInfo{}})
+		var payloads []*proto.Payload
+		requestMsg.On("Respond", mock.Anything).Run(func(args mock.Arguments) {
+			payloads = args.Get(0).(*proto.GossipMessage).GetStateResponse().Payloads
+		})
+		s.handleStateRequest(requestMsg)
+		return payloads
+	}
+
+	// Blocks which exceed the cap are left out of the response
+	s.maxStateResponseBytes = 2500
+	payloads := stateRequest(1, 9)
+	assert.Len(t, payloads, 2)
+	assert.Equal(t, uint64(1), payloads[0].SeqNum)
+	assert.Equal(t, uint64(2), payloads[1].SeqNum)
+
+	// A response contains at least one block, even if it exceeds the cap
+	s.maxStateResponseBytes = 1
+	payloads = stateRequest(3, 9)
+	assert.Len(t, payloads, 1)
+	assert.Equal(t, uint64(3), payloads[0].SeqNum)
+
+	s.maxStateResponseBytes = defMaxStateResponseBytes
+	assert.Len(t, stateRequest(1, 9), 9)
+}
+
+type forgedBlockRejectingMCS struct {
+	*cryptoServiceMock
+}
+
+func (forgedBlockRejectingMCS) VerifyBlock(_ common.ChainID, _ uint64, signedBlock []byte) error {
+	if bytes.Equal(signedBlock, []byte("forged")) {
+		return errors.New("forged block")
+	}
+	return nil
+}
+
+func TestParallelStateTransfer(t *testing.T) {
+	// Scenario: the peer fetches blocks [1...60] from 3 peers in parallel.
+	// Peer a responds properly, peer b responds with blocks which fail verification,
+	// and peer c doesn't respond at all. All blocks are expected to be fetched from peer a,
+	// and handed to the payload buffer in order.
+	t.Parallel()
+	chainID := util.GetTestChainID()
+	g := &mocks.GossipMock{}
+	var membership []discovery.NetworkMember
+	for _, id := range []string{"a", "b", "c"} {
+		membership = append(membership, discovery.NetworkMember{
+			PKIid:      common.PKIidType(id),
+			Endpoint:   id,
+			Properties: &proto.Properties{LedgerHeight: 100},
+		})
+	}
+	g.On("PeersOfChannel", mock.Anything).Return(membership)
+	coord := new(coordinatorMock)
+	coord.On("LedgerHeight", mock.Anything).Return(uint64(1), nil)
+
+	s := &GossipStateProviderImpl{
+		chainID:              chainID,
+		mediator:             &ServicesMediator{GossipAdapter: g, MCSAdapter: forgedBlockRejectingMCS{&cryptoServiceMock{}}},
+		payloads:             NewPayloadsBuffer(1),
+		ledger:               coord,
+		stopCh:               make(chan struct{}, 1),
+		pendingStateRequests: make(map[uint64]chan proto.ReceivedMessage),
+		batchSize:            defAntiEntropyBatchSize,
+		parallelism:          3,
+		responseTimeout:      time.Second,
+	}
+
+	var lock sync.Mutex
+	requestsByPeer := make(map[string]int)
+	var inFlight, maxInFlight int32
+	g.On("Send", mock.Anything, mock.Anything).Run(func(arguments mock.Arguments) {
+		msg := arguments.Get(0).(*proto.GossipMessage)
+		peer := arguments.Get(1).([]*comm.RemotePeer)[0]
+		lock.Lock()
+		requestsByPeer[peer.Endpoint]++
+		lock.Unlock()
+		if peer.Endpoint == "c" {
+			return
+		}
+		if n := atomic.AddInt32(&inFlight, 1); n > atomic.LoadInt32(&maxInFlight) {
+			atomic.StoreInt32(&maxInFlight, n)
+		}
+		req := msg.GetStateRequest()
+		res := &proto.GossipMessage{
+			Nonce:   msg.Nonce,
+			Channel: []byte(chainID),
+			Content: &proto.GossipMessage_StateResponse{
+				StateResponse: &proto.RemoteStateResponse{},
+			},
+		}
+		for seq := req.StartSeqNum; seq <= req.EndSeqNum; seq++ {
+			b, _ := pb.Marshal(pcomm.NewBlock(seq, []byte{}))
+			if peer.Endpoint == "b" {
+				b = []byte("forged")
+			}
+			res.GetStateResponse().Payloads = append(res.GetStateResponse().Payloads, &proto.Payload{SeqNum: seq, Data: b})
+		}
+		sMsg, _ := res.NoopSign()
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			s.directMessage(&comm.ReceivedMessageImpl{SignedGossipMessage: sMsg})
+		}()
+	})
+
+	s.requestBlocksInRange(1, 60)
+
+	for seq := uint64(1); seq <= 60; seq++ {
+		payload := s.payloads.Pop()
+		assert.NotNil(t, payload)
+		assert.Equal(t, seq, payload.SeqNum)
+		assert.NotEqual(t, []byte("forged"), payload.Data)
+	}
+	assert.Nil(t, s.payloads.Pop())
+	assert.True(t, atomic.LoadInt32(&maxInFlight) > 1, "state requests weren't sent in parallel")
+	assert.Empty(t, s.pendingStateRequests)
+	lock.Lock()
+	defer lock.Unlock()
+	assert.True(t, requestsByPeer["a"] >= 6)
+}
+
 func TestTransferOfPvtDataBetweenPeers(t *testing.T) {
 	/*
 	   This test covers pretty basic scenario, there are two peers: "peer1" and "peer2",
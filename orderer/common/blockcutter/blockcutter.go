@@ -7,8 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package blockcutter
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/common/channelconfig"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/op/go-logging"
@@ -28,25 +31,51 @@ type Receiver interface {
 	// Each batch in `messageBatches` will be wrapped into a block.
 	// `pending` indicates if there are still messages pending in the receiver. It
 	// is useful for Kafka orderer to determine the `LastOffsetPersisted` of block.
+	// When batches are cut and `pending` is still true, the pending messages make up a
+	// new batch, for which the consenters restart the batch timer.
 	Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, pending bool)
 
 	// Cut returns the current batch and starts a new one
 	Cut() []*cb.Envelope
+
+	// BatchTimeout returns the amount of time to wait for the pending batch to fill
+	// before it is cut, which depends on the configured batching strategy
+	BatchTimeout() time.Duration
+}
+
+// OrdererConfigFetcher retrieves the current orderer config of a channel
+type OrdererConfigFetcher interface {
+	// OrdererConfig returns the config.Orderer for the channel
+	// and whether the Orderer config exists
+	OrdererConfig() (channelconfig.Orderer, bool)
 }
 
 type receiver struct {
-	sharedConfigManager   channelconfig.Orderer
+	ordererConfigFetcher  OrdererConfigFetcher
 	pendingBatch          []*cb.Envelope
 	pendingBatchSizeBytes uint32
+	pendingHeaderType     int32
+	ingress               ingressRate
+	now                   func() time.Time
 }
 
-// NewReceiverImpl creates a Receiver implementation based on the given configtxorderer manager
-func NewReceiverImpl(sharedConfigManager channelconfig.Orderer) Receiver {
+// NewReceiverImpl creates a Receiver implementation based on the given orderer config fetcher.
+// The orderer config is fetched for every message, so that config updates take effect immediately.
+func NewReceiverImpl(ordererConfigFetcher OrdererConfigFetcher) Receiver {
 	return &receiver{
-		sharedConfigManager: sharedConfigManager,
+		ordererConfigFetcher: ordererConfigFetcher,
+		now:                  time.Now,
 	}
 }
 
+func (r *receiver) ordererConfig() channelconfig.Orderer {
+	ordererConfig, ok := r.ordererConfigFetcher.OrdererConfig()
+	if !ok {
+		logger.Panicf("Could not retrieve orderer config to use with the block cutter")
+	}
+	return ordererConfig
+}
+
 // Ordered should be invoked sequentially as messages are ordered
 //
 // messageBatches length: 0, pending: false
@@ -57,16 +86,40 @@ func NewReceiverImpl(sharedConfigManager channelconfig.Orderer) Receiver {
 //   - the message count reaches BatchSize.MaxMessageCount
 // messageBatches length: 1, pending: true
 //   - the current message will cause the pending batch size in bytes to exceed BatchSize.PreferredMaxBytes.
+//   - with the TYPE_ISOLATION strategy, the current message is of a different type than the pending batch.
 // messageBatches length: 2, pending: false
 //   - the current message size in bytes exceeds BatchSize.PreferredMaxBytes, therefore isolated in its own batch.
+//   - with the CONFIG_LANE strategy, the current message is a PEER_RESOURCE_UPDATE, therefore isolated in its own batch.
 // messageBatches length: 2, pending: true
 //   - impossible
 //
 // Note that messageBatches can not be greater than 2.
 func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, pending bool) {
+	ordererConfig := r.ordererConfig()
+	batchSize := ordererConfig.BatchSize()
+	strategy := ordererConfig.BatchingStrategy().GetType()
+	r.ingress.observe(r.now())
+
+	headerType := unknownHeaderType
+	if strategy == ab.BatchingStrategy_CONFIG_LANE || strategy == ab.BatchingStrategy_TYPE_ISOLATION {
+		headerType = messageHeaderType(msg)
+	}
+	if strategy == ab.BatchingStrategy_CONFIG_LANE && isResourceUpdateHeaderType(headerType) {
+		logger.Debugf("The current message is of type %s and will be isolated.", cb.HeaderType(headerType))
+
+		if len(r.pendingBatch) > 0 {
+			messageBatch := r.Cut()
+			messageBatches = append(messageBatches, messageBatch)
+		}
+
+		messageBatches = append(messageBatches, []*cb.Envelope{msg})
+
+		return
+	}
+
 	messageSizeBytes := messageSizeBytes(msg)
-	if messageSizeBytes > r.sharedConfigManager.BatchSize().PreferredMaxBytes {
-		logger.Debugf("The current message, with %v bytes, is larger than the preferred batch size of %v bytes and will be isolated.", messageSizeBytes, r.sharedConfigManager.BatchSize().PreferredMaxBytes)
+	if messageSizeBytes > batchSize.PreferredMaxBytes {
+		logger.Debugf("The current message, with %v bytes, is larger than the preferred batch size of %v bytes and will be isolated.", messageSizeBytes, batchSize.PreferredMaxBytes)
 
 		// cut pending batch, if it has any messages
 		if len(r.pendingBatch) > 0 {
@@ -80,7 +133,13 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 		return
 	}
 
-	messageWillOverflowBatchSizeBytes := r.pendingBatchSizeBytes+messageSizeBytes > r.sharedConfigManager.BatchSize().PreferredMaxBytes
+	if strategy == ab.BatchingStrategy_TYPE_ISOLATION && len(r.pendingBatch) > 0 && headerType != r.pendingHeaderType {
+		logger.Debugf("The current message of type %s differs from the pending batch of type %s, cutting batch now.", cb.HeaderType(headerType), cb.HeaderType(r.pendingHeaderType))
+		messageBatch := r.Cut()
+		messageBatches = append(messageBatches, messageBatch)
+	}
+
+	messageWillOverflowBatchSizeBytes := r.pendingBatchSizeBytes+messageSizeBytes > batchSize.PreferredMaxBytes
 
 	if messageWillOverflowBatchSizeBytes {
 		logger.Debugf("The current message, with %v bytes, will overflow the pending batch of %v bytes.", messageSizeBytes, r.pendingBatchSizeBytes)
@@ -92,9 +151,10 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 	logger.Debugf("Enqueuing message into batch")
 	r.pendingBatch = append(r.pendingBatch, msg)
 	r.pendingBatchSizeBytes += messageSizeBytes
+	r.pendingHeaderType = headerType
 	pending = true
 
-	if uint32(len(r.pendingBatch)) >= r.sharedConfigManager.BatchSize().MaxMessageCount {
+	if uint32(len(r.pendingBatch)) >= batchSize.MaxMessageCount {
 		logger.Debugf("Batch size met, cutting batch")
 		messageBatch := r.Cut()
 		messageBatches = append(messageBatches, messageBatch)
@@ -112,6 +172,28 @@ func (r *receiver) Cut() []*cb.Envelope {
 	return batch
 }
 
+// BatchTimeout returns the amount of time to wait for the pending batch to fill before it is cut.
+// With the ADAPTIVE_TIMEOUT strategy, the configured BatchTimeout is shortened when the ingress
+// rate is too low to fill a batch within it, as waiting any longer only adds latency.
+func (r *receiver) BatchTimeout() time.Duration {
+	ordererConfig := r.ordererConfig()
+	batchTimeout := ordererConfig.BatchTimeout()
+	strategy := ordererConfig.BatchingStrategy()
+	if strategy.GetType() != ab.BatchingStrategy_ADAPTIVE_TIMEOUT {
+		return batchTimeout
+	}
+
+	minBatchTimeout := batchTimeout / 10
+	if strategy.MinBatchTimeout != "" {
+		var err error
+		if minBatchTimeout, err = time.ParseDuration(strategy.MinBatchTimeout); err != nil {
+			// The value is validated when the config is applied, so this should never happen
+			logger.Panicf("Invalid min batch timeout %s: %s", strategy.MinBatchTimeout, err)
+		}
+	}
+	return r.ingress.batchTimeout(batchTimeout, minBatchTimeout, ordererConfig.BatchSize().MaxMessageCount)
+}
+
 func messageSizeBytes(message *cb.Envelope) uint32 {
 	return uint32(len(message.Payload) + len(message.Signature))
 }
@@ -38,7 +38,7 @@ func TestNormalBatch(t *testing.T) {
 	maxMessageCount := uint32(2)
 	absoluteMaxBytes := uint32(1000)
 	preferredMaxBytes := uint32(100)
-	r := NewReceiverImpl(&mockconfig.Orderer{BatchSizeVal: &ab.BatchSize{MaxMessageCount: maxMessageCount, AbsoluteMaxBytes: absoluteMaxBytes, PreferredMaxBytes: preferredMaxBytes}})
+	r := NewReceiverImpl(&mockconfig.Resources{OrdererConfigVal: &mockconfig.Orderer{BatchSizeVal: &ab.BatchSize{MaxMessageCount: maxMessageCount, AbsoluteMaxBytes: absoluteMaxBytes, PreferredMaxBytes: preferredMaxBytes}}})
 
 	batches, pending := r.Ordered(tx)
 	assert.Nil(t, batches, "Should not have created batch")
@@ -58,7 +58,7 @@ func TestBatchSizePreferredMaxBytesOverflow(t *testing.T) {
 	// set message count > 9
 	maxMessageCount := uint32(20)
 
-	r := NewReceiverImpl(&mockconfig.Orderer{BatchSizeVal: &ab.BatchSize{MaxMessageCount: maxMessageCount, AbsoluteMaxBytes: preferredMaxBytes * 2, PreferredMaxBytes: preferredMaxBytes}})
+	r := NewReceiverImpl(&mockconfig.Resources{OrdererConfigVal: &mockconfig.Orderer{BatchSizeVal: &ab.BatchSize{MaxMessageCount: maxMessageCount, AbsoluteMaxBytes: preferredMaxBytes * 2, PreferredMaxBytes: preferredMaxBytes}}})
 
 	// enqueue 9 messages
 	for i := 0; i < 9; i++ {
@@ -89,7 +89,7 @@ func TestBatchSizePreferredMaxBytesOverflowNoPending(t *testing.T) {
 	// set message count > 1
 	maxMessageCount := uint32(20)
 
-	r := NewReceiverImpl(&mockconfig.Orderer{BatchSizeVal: &ab.BatchSize{MaxMessageCount: maxMessageCount, AbsoluteMaxBytes: preferredMaxBytes * 3, PreferredMaxBytes: preferredMaxBytes}})
+	r := NewReceiverImpl(&mockconfig.Resources{OrdererConfigVal: &mockconfig.Orderer{BatchSizeVal: &ab.BatchSize{MaxMessageCount: maxMessageCount, AbsoluteMaxBytes: preferredMaxBytes * 3, PreferredMaxBytes: preferredMaxBytes}}})
 
 	// submit normal message
 	batches, pending := r.Ordered(tx)
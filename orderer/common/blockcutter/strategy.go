@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"time"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// ingressSmoothing is the weight given to the most recent inter-arrival time
+// when updating the moving average of the ingress rate
+const ingressSmoothing = 0.2
+
+// unknownHeaderType is used for the messages whose channel header cannot be decoded
+const unknownHeaderType = int32(-1)
+
+// ingressRate tracks an exponentially weighted moving average of the time
+// between the arrivals of consecutive messages
+type ingressRate struct {
+	lastArrival  time.Time
+	meanInterval time.Duration
+}
+
+func (ir *ingressRate) observe(now time.Time) {
+	if !ir.lastArrival.IsZero() {
+		interval := now.Sub(ir.lastArrival)
+		if ir.meanInterval == 0 {
+			ir.meanInterval = interval
+		} else {
+			ir.meanInterval = time.Duration(ingressSmoothing*float64(interval) + (1-ingressSmoothing)*float64(ir.meanInterval))
+		}
+	}
+	ir.lastArrival = now
+}
+
+// batchTimeout returns batchTimeout if, at the current ingress rate, a batch of maxMessageCount
+// messages is expected to fill within it. Otherwise, the timeout is shortened in proportion to how
+// much longer filling the batch is expected to take, down to minBatchTimeout.
+func (ir *ingressRate) batchTimeout(batchTimeout, minBatchTimeout time.Duration, maxMessageCount uint32) time.Duration {
+	if ir.meanInterval <= 0 || maxMessageCount <= 1 {
+		return batchTimeout
+	}
+
+	fillTime := float64(ir.meanInterval) * float64(maxMessageCount-1)
+	if fillTime <= float64(batchTimeout) {
+		return batchTimeout
+	}
+
+	timeout := time.Duration(float64(batchTimeout) * float64(batchTimeout) / fillTime)
+	if timeout < minBatchTimeout {
+		timeout = minBatchTimeout
+	}
+	logger.Debugf("Ingress rate of one message per %s is too low to fill a batch, shortening batch timeout to %s", ir.meanInterval, timeout)
+	return timeout
+}
+
+func messageHeaderType(msg *cb.Envelope) int32 {
+	chdr, err := utils.ChannelHeader(msg)
+	if err != nil {
+		return unknownHeaderType
+	}
+	return chdr.Type
+}
+
+// isResourceUpdateHeaderType returns whether messages of the given type update the peer resources.
+// These are the only config-related messages the block cutter sees: the consenters cut the channel
+// config messages (CONFIG and ORDERER_TRANSACTION, which CONFIG_UPDATE is turned into) in blocks of
+// their own without passing them to Ordered.
+func isResourceUpdateHeaderType(headerType int32) bool {
+	return cb.HeaderType(headerType) == cb.HeaderType_PEER_RESOURCE_UPDATE
+}
@@ -0,0 +1,142 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"testing"
+	"time"
+
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTxOfType(headerType cb.HeaderType) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{Type: int32(headerType)}),
+			},
+		}),
+	}
+}
+
+func newStrategyReceiver(strategy *ab.BatchingStrategy, maxMessageCount uint32, batchTimeout time.Duration) *receiver {
+	return NewReceiverImpl(&mockconfig.Resources{
+		OrdererConfigVal: &mockconfig.Orderer{
+			BatchSizeVal:        &ab.BatchSize{MaxMessageCount: maxMessageCount, AbsoluteMaxBytes: 10000, PreferredMaxBytes: 1000},
+			BatchTimeoutVal:     batchTimeout,
+			BatchingStrategyVal: strategy,
+		},
+	}).(*receiver)
+}
+
+func TestConfigLane(t *testing.T) {
+	endorserTx := makeTxOfType(cb.HeaderType_ENDORSER_TRANSACTION)
+	resourceUpdateTx := makeTxOfType(cb.HeaderType_PEER_RESOURCE_UPDATE)
+
+	t.Run("Default", func(t *testing.T) {
+		r := newStrategyReceiver(nil, 10, time.Second)
+		r.Ordered(endorserTx)
+		batches, pending := r.Ordered(resourceUpdateTx)
+		assert.Empty(t, batches)
+		assert.True(t, pending)
+	})
+
+	t.Run("Isolated", func(t *testing.T) {
+		r := newStrategyReceiver(&ab.BatchingStrategy{Type: ab.BatchingStrategy_CONFIG_LANE}, 10, time.Second)
+		r.Ordered(endorserTx)
+		batches, pending := r.Ordered(resourceUpdateTx)
+		assert.Equal(t, [][]*cb.Envelope{{endorserTx}, {resourceUpdateTx}}, batches)
+		assert.False(t, pending)
+
+		batches, pending = r.Ordered(resourceUpdateTx)
+		assert.Equal(t, [][]*cb.Envelope{{resourceUpdateTx}}, batches, "Nothing pending to cut ahead of the resource update")
+		assert.False(t, pending)
+	})
+}
+
+func TestTypeIsolation(t *testing.T) {
+	endorserTx := makeTxOfType(cb.HeaderType_ENDORSER_TRANSACTION)
+	messageTx := makeTxOfType(cb.HeaderType_MESSAGE)
+
+	r := newStrategyReceiver(&ab.BatchingStrategy{Type: ab.BatchingStrategy_TYPE_ISOLATION}, 3, time.Second)
+	batches, pending := r.Ordered(endorserTx)
+	assert.Empty(t, batches)
+	assert.True(t, pending)
+	batches, pending = r.Ordered(endorserTx)
+	assert.Empty(t, batches)
+	assert.True(t, pending)
+
+	batches, pending = r.Ordered(messageTx)
+	assert.Equal(t, [][]*cb.Envelope{{endorserTx, endorserTx}}, batches)
+	assert.True(t, pending)
+
+	batches, pending = r.Ordered(endorserTx)
+	assert.Equal(t, [][]*cb.Envelope{{messageTx}}, batches)
+	assert.True(t, pending)
+
+	assert.Equal(t, []*cb.Envelope{endorserTx}, r.Cut())
+}
+
+func TestAdaptiveTimeout(t *testing.T) {
+	batchTimeout := time.Second
+
+	t.Run("Default", func(t *testing.T) {
+		r := newStrategyReceiver(nil, 10, batchTimeout)
+		assert.Equal(t, batchTimeout, r.BatchTimeout())
+	})
+
+	t.Run("Adaptive", func(t *testing.T) {
+		r := newStrategyReceiver(&ab.BatchingStrategy{Type: ab.BatchingStrategy_ADAPTIVE_TIMEOUT}, 11, batchTimeout)
+		now := time.Now()
+		r.now = func() time.Time { return now }
+		assert.Equal(t, batchTimeout, r.BatchTimeout(), "No ingress rate estimate yet")
+
+		tick := func(interval time.Duration) {
+			now = now.Add(interval)
+			r.Ordered(tx)
+			r.Cut()
+		}
+
+		// at one message per 10ms a batch fills in 100ms, within the batch timeout
+		tick(0)
+		tick(10 * time.Millisecond)
+		assert.Equal(t, batchTimeout, r.BatchTimeout())
+
+		// at one message per 200ms a batch fills in 2s, twice the batch timeout
+		r.ingress = ingressRate{}
+		tick(0)
+		tick(200 * time.Millisecond)
+		assert.Equal(t, batchTimeout/2, r.BatchTimeout())
+
+		// at very low rates the timeout is bounded by the min batch timeout, a tenth of the batch timeout by default
+		r.ingress = ingressRate{}
+		tick(0)
+		tick(time.Minute)
+		assert.Equal(t, batchTimeout/10, r.BatchTimeout())
+	})
+
+	t.Run("MinBatchTimeout", func(t *testing.T) {
+		r := newStrategyReceiver(&ab.BatchingStrategy{Type: ab.BatchingStrategy_ADAPTIVE_TIMEOUT, MinBatchTimeout: "250ms"}, 11, batchTimeout)
+		r.ingress = ingressRate{meanInterval: time.Minute}
+		assert.Equal(t, 250*time.Millisecond, r.BatchTimeout())
+	})
+}
+
+func TestIngressRate(t *testing.T) {
+	ir := &ingressRate{}
+	now := time.Now()
+	ir.observe(now)
+	assert.Equal(t, time.Duration(0), ir.meanInterval)
+	ir.observe(now.Add(time.Second))
+	assert.Equal(t, time.Second, ir.meanInterval)
+	ir.observe(now.Add(3 * time.Second))
+	assert.Equal(t, 1200*time.Millisecond, ir.meanInterval)
+}
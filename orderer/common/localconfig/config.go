@@ -120,8 +120,20 @@ type Profile struct {
 
 // FileLedger contains configuration for the file-based ledger.
 type FileLedger struct {
-	Location string
-	Prefix   string
+	Location  string
+	Prefix    string
+	Retention Retention
+}
+
+// Retention contains configuration for archiving the old block files of the
+// file-based ledger.
+type Retention struct {
+	Enabled       bool
+	BlocksToKeep  uint64
+	MaxAge        time.Duration
+	ArchiveDir    string
+	Compress      bool
+	CheckInterval time.Duration
 }
 
 // RAMLedger contains configuration for the RAM ledger.
@@ -214,6 +226,12 @@ var defaults = TopLevel{
 	FileLedger: FileLedger{
 		Location: "/var/hyperledger/production/orderer",
 		Prefix:   "hyperledger-fabric-ordererledger",
+		Retention: Retention{
+			Enabled:       false,
+			BlocksToKeep:  100000,
+			ArchiveDir:    "/var/hyperledger/production/orderer/archive",
+			CheckInterval: 1 * time.Hour,
+		},
 	},
 	Kafka: Kafka{
 		Retry: Retry{
@@ -342,6 +360,12 @@ func (c *TopLevel) completeInitialization(configDir string) {
 		case c.FileLedger.Prefix == "":
 			logger.Infof("FileLedger.Prefix unset, setting to %s", defaults.FileLedger.Prefix)
 			c.FileLedger.Prefix = defaults.FileLedger.Prefix
+		case c.FileLedger.Retention.Enabled && c.FileLedger.Retention.ArchiveDir == "":
+			logger.Infof("FileLedger.Retention.ArchiveDir unset, setting to %s", defaults.FileLedger.Retention.ArchiveDir)
+			c.FileLedger.Retention.ArchiveDir = defaults.FileLedger.Retention.ArchiveDir
+		case c.FileLedger.Retention.Enabled && c.FileLedger.Retention.CheckInterval == 0:
+			logger.Infof("FileLedger.Retention.CheckInterval unset, setting to %s", defaults.FileLedger.Retention.CheckInterval)
+			c.FileLedger.Retention.CheckInterval = defaults.FileLedger.Retention.CheckInterval
 
 		case c.Kafka.Retry.ShortInterval == 0:
 			logger.Infof("Kafka.Retry.ShortInterval unset, setting to %v", defaults.Kafka.Retry.ShortInterval)
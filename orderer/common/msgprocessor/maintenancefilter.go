@@ -0,0 +1,141 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policies"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// ErrMaintenanceMode is returned by the maintenance filter for transactions which
+// are not permitted while the channel is in maintenance mode.
+var ErrMaintenanceMode = errors.New("channel is in maintenance mode")
+
+// MaintenanceFilterSupport provides the resources required for the maintenance filter.
+type MaintenanceFilterSupport interface {
+	// OrdererConfig returns the config.Orderer for the channel
+	// and whether the Orderer config exists
+	OrdererConfig() (channelconfig.Orderer, bool)
+
+	// PolicyManager returns a reference to the current policy manager
+	PolicyManager() policies.Manager
+}
+
+// MaintenanceFilter enforces the rules of the consensus-type migration, given the capability is active.
+// While a channel is in maintenance mode, normal transactions and channel creation requests are rejected,
+// and config updates must satisfy the channel orderer admins policy. The consensus type may only be changed
+// while the channel is in maintenance mode, and must not be changed in the same update which enters or
+// exits maintenance mode.
+type MaintenanceFilter struct {
+	support       MaintenanceFilterSupport
+	ordererAdmins *SigFilter
+}
+
+// NewMaintenanceFilter creates a new maintenance filter
+func NewMaintenanceFilter(support MaintenanceFilterSupport) *MaintenanceFilter {
+	return &MaintenanceFilter{
+		support:       support,
+		ordererAdmins: NewSigFilter(policies.ChannelOrdererAdmins, support),
+	}
+}
+
+// Apply rejects the messages which are not permitted in the current consensus-type state.
+func (mf *MaintenanceFilter) Apply(message *cb.Envelope) error {
+	ordererConf, ok := mf.support.OrdererConfig()
+	if !ok {
+		logger.Panic("Programming error: orderer config not found")
+	}
+	if !ordererConf.Capabilities().ConsensusTypeMigration() {
+		return nil
+	}
+
+	payload, err := utils.UnmarshalPayload(message.Payload)
+	if err != nil {
+		return errors.Errorf("bad payload: %s", err)
+	}
+	if payload.Header == nil {
+		return errors.Errorf("missing payload header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return errors.Errorf("bad channel header: %s", err)
+	}
+
+	inMaintenance := ordererConf.ConsensusState() == ab.ConsensusType_STATE_MAINTENANCE
+
+	switch chdr.Type {
+	case int32(cb.HeaderType_CONFIG_UPDATE):
+		if !inMaintenance {
+			return nil
+		}
+		if err := mf.ordererAdmins.Apply(message); err != nil {
+			return errors.WithMessage(err, "config updates in maintenance mode are restricted to orderer admins")
+		}
+		return nil
+	case int32(cb.HeaderType_CONFIG):
+		configEnvelope := &cb.ConfigEnvelope{}
+		if err := proto.Unmarshal(payload.Data, configEnvelope); err != nil {
+			return errors.Errorf("bad config envelope: %s", err)
+		}
+		next, err := ConsensusTypeFromConfig(configEnvelope.Config)
+		if err != nil {
+			return err
+		}
+		return inspectTransition(ordererConf, next)
+	default:
+		if inMaintenance {
+			return errors.WithStack(ErrMaintenanceMode)
+		}
+		return nil
+	}
+}
+
+func inspectTransition(current channelconfig.Orderer, next *ab.ConsensusType) error {
+	currentState := current.ConsensusState()
+	if _, ok := ab.ConsensusType_State_name[int32(next.State)]; !ok {
+		return errors.Errorf("unknown consensus-type state: %d", next.State)
+	}
+
+	if current.ConsensusType() == next.Type {
+		return nil
+	}
+
+	if currentState != ab.ConsensusType_STATE_MAINTENANCE {
+		return errors.Errorf("attempted to change consensus type from %s to %s while not in maintenance mode",
+			current.ConsensusType(), next.Type)
+	}
+	if next.State != ab.ConsensusType_STATE_MAINTENANCE {
+		return errors.Errorf("attempted to change consensus type from %s to %s while exiting maintenance mode",
+			current.ConsensusType(), next.Type)
+	}
+	return nil
+}
+
+// ConsensusTypeFromConfig extracts the ConsensusType value from the orderer group of a config.
+func ConsensusTypeFromConfig(config *cb.Config) (*ab.ConsensusType, error) {
+	if config == nil || config.ChannelGroup == nil {
+		return nil, errors.New("config is missing the channel group")
+	}
+	ordererGroup, ok := config.ChannelGroup.Groups[channelconfig.OrdererGroupKey]
+	if !ok {
+		return nil, errors.New("config is missing the orderer group")
+	}
+	value, ok := ordererGroup.Values[channelconfig.ConsensusTypeKey]
+	if !ok {
+		return nil, errors.New("config is missing the consensus type")
+	}
+	consensusType := &ab.ConsensusType{}
+	if err := proto.Unmarshal(value.Value, consensusType); err != nil {
+		return nil, errors.Wrap(err, "bad consensus type")
+	}
+	return consensusType, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	mockchannelconfig "github.com/hyperledger/fabric/common/mocks/config"
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeMaintenanceResources(migration bool, consensusType string, state ab.ConsensusType_State, policyErr error) *mockchannelconfig.Resources {
+	return &mockchannelconfig.Resources{
+		OrdererConfigVal: &mockchannelconfig.Orderer{
+			ConsensusTypeVal:  consensusType,
+			ConsensusStateVal: state,
+			CapabilitiesVal: &mockchannelconfig.OrdererCapabilities{
+				ConsensusTypeMigrationVal: migration,
+			},
+		},
+		PolicyManagerVal: &mockpolicies.Manager{Policy: &mockpolicies.Policy{Err: policyErr}},
+	}
+}
+
+func makeEnvelopeOfType(headerType cb.HeaderType, data []byte) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader:   utils.MarshalOrPanic(&cb.ChannelHeader{Type: int32(headerType), ChannelId: "foo"}),
+				SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{}),
+			},
+			Data: data,
+		}),
+	}
+}
+
+func makeConfigEnvelope(consensusType string, state ab.ConsensusType_State) *cb.Envelope {
+	return makeEnvelopeOfType(cb.HeaderType_CONFIG, utils.MarshalOrPanic(&cb.ConfigEnvelope{
+		Config: &cb.Config{
+			ChannelGroup: &cb.ConfigGroup{
+				Groups: map[string]*cb.ConfigGroup{
+					channelconfig.OrdererGroupKey: {
+						Values: map[string]*cb.ConfigValue{
+							channelconfig.ConsensusTypeKey: {
+								Value: utils.MarshalOrPanic(&ab.ConsensusType{Type: consensusType, State: state}),
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+}
+
+func TestMaintenanceFilterCapabilityDisabled(t *testing.T) {
+	mf := NewMaintenanceFilter(makeMaintenanceResources(false, "solo", ab.ConsensusType_STATE_MAINTENANCE, nil))
+	assert.NoError(t, mf.Apply(makeEnvelopeOfType(cb.HeaderType_ENDORSER_TRANSACTION, nil)))
+	assert.NoError(t, mf.Apply(makeConfigEnvelope("kafka", ab.ConsensusType_STATE_NORMAL)))
+}
+
+func TestMaintenanceFilterNormalState(t *testing.T) {
+	mf := NewMaintenanceFilter(makeMaintenanceResources(true, "solo", ab.ConsensusType_STATE_NORMAL, fmt.Errorf("not an admin")))
+
+	assert.NoError(t, mf.Apply(makeEnvelopeOfType(cb.HeaderType_ENDORSER_TRANSACTION, nil)))
+	assert.NoError(t, mf.Apply(makeEnvelopeOfType(cb.HeaderType_ORDERER_TRANSACTION, nil)))
+	assert.NoError(t, mf.Apply(makeEnvelopeOfType(cb.HeaderType_CONFIG_UPDATE, nil)), "Orderer admins policy only applies in maintenance mode")
+	assert.NoError(t, mf.Apply(makeConfigEnvelope("solo", ab.ConsensusType_STATE_NORMAL)))
+	assert.NoError(t, mf.Apply(makeConfigEnvelope("solo", ab.ConsensusType_STATE_MAINTENANCE)), "Entering maintenance mode")
+
+	err := mf.Apply(makeConfigEnvelope("kafka", ab.ConsensusType_STATE_NORMAL))
+	assert.EqualError(t, err, "attempted to change consensus type from solo to kafka while not in maintenance mode")
+	err = mf.Apply(makeConfigEnvelope("kafka", ab.ConsensusType_STATE_MAINTENANCE))
+	assert.EqualError(t, err, "attempted to change consensus type from solo to kafka while not in maintenance mode")
+	err = mf.Apply(makeConfigEnvelope("solo", ab.ConsensusType_State(7)))
+	assert.EqualError(t, err, "unknown consensus-type state: 7")
+}
+
+func TestMaintenanceFilterMaintenanceState(t *testing.T) {
+	mf := NewMaintenanceFilter(makeMaintenanceResources(true, "solo", ab.ConsensusType_STATE_MAINTENANCE, nil))
+
+	err := mf.Apply(makeEnvelopeOfType(cb.HeaderType_ENDORSER_TRANSACTION, nil))
+	assert.Equal(t, ErrMaintenanceMode, errors.Cause(err))
+	err = mf.Apply(makeEnvelopeOfType(cb.HeaderType_ORDERER_TRANSACTION, nil))
+	assert.Equal(t, ErrMaintenanceMode, errors.Cause(err))
+
+	assert.NoError(t, mf.Apply(makeEnvelopeOfType(cb.HeaderType_CONFIG_UPDATE, nil)))
+	assert.NoError(t, mf.Apply(makeConfigEnvelope("kafka", ab.ConsensusType_STATE_MAINTENANCE)), "Changing type in maintenance mode")
+	assert.NoError(t, mf.Apply(makeConfigEnvelope("solo", ab.ConsensusType_STATE_NORMAL)), "Exiting maintenance mode")
+
+	err = mf.Apply(makeConfigEnvelope("kafka", ab.ConsensusType_STATE_NORMAL))
+	assert.EqualError(t, err, "attempted to change consensus type from solo to kafka while exiting maintenance mode")
+}
+
+func TestMaintenanceFilterOrdererAdmins(t *testing.T) {
+	mf := NewMaintenanceFilter(makeMaintenanceResources(true, "solo", ab.ConsensusType_STATE_MAINTENANCE, fmt.Errorf("not an admin")))
+	err := mf.Apply(makeEnvelopeOfType(cb.HeaderType_CONFIG_UPDATE, nil))
+	assert.Equal(t, ErrPermissionDenied, errors.Cause(err))
+	assert.Contains(t, err.Error(), "config updates in maintenance mode are restricted to orderer admins")
+}
+
+func TestMaintenanceFilterBadMessages(t *testing.T) {
+	mf := NewMaintenanceFilter(makeMaintenanceResources(true, "solo", ab.ConsensusType_STATE_NORMAL, nil))
+
+	err := mf.Apply(&cb.Envelope{Payload: []byte("garbage")})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "bad payload")
+
+	err = mf.Apply(&cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{})})
+	assert.EqualError(t, err, "missing payload header")
+
+	err = mf.Apply(makeEnvelopeOfType(cb.HeaderType_CONFIG, utils.MarshalOrPanic(&cb.ConfigEnvelope{Config: &cb.Config{}})))
+	assert.EqualError(t, err, "config is missing the channel group")
+}
+
+func TestConsensusTypeFromConfig(t *testing.T) {
+	_, err := ConsensusTypeFromConfig(&cb.Config{ChannelGroup: &cb.ConfigGroup{}})
+	assert.EqualError(t, err, "config is missing the orderer group")
+
+	_, err = ConsensusTypeFromConfig(&cb.Config{ChannelGroup: &cb.ConfigGroup{
+		Groups: map[string]*cb.ConfigGroup{channelconfig.OrdererGroupKey: {}},
+	}})
+	assert.EqualError(t, err, "config is missing the consensus type")
+}
@@ -51,6 +51,7 @@ func CreateSystemChannelFilters(chainCreator ChainCreator, ledgerResources chann
 		NewExpirationRejectRule(ledgerResources),
 		NewSizeFilter(ordererConfig),
 		NewSigFilter(policies.ChannelWriters, ledgerResources),
+		NewMaintenanceFilter(ledgerResources),
 		NewSystemChannelFilter(ledgerResources, chainCreator),
 	})
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
 
@@ -109,6 +110,7 @@ func (bw *BlockWriter) WriteConfigBlock(block *cb.Block, encodedMetadataValue []
 		logger.Panicf("Told to write a config block with an invalid channel header: %s", err)
 	}
 
+	consensusTypeChanged := false
 	switch chdr.Type {
 	case int32(cb.HeaderType_ORDERER_TRANSACTION):
 		newChannelConfig, err := utils.UnmarshalEnvelope(payload.Data)
@@ -132,12 +134,45 @@ func (bw *BlockWriter) WriteConfigBlock(block *cb.Block, encodedMetadataValue []
 			logger.Panicf("Told to write a config block with a new config, but could not convert it to a bundle: %s", err)
 		}
 
+		consensusTypeChanged = bw.consensusTypeChanged(configEnvelope.Config)
 		bw.support.Update(bundle)
 	default:
 		logger.Panicf("Told to write a config block with unknown header type: %v", chdr.Type)
 	}
 
+	if consensusTypeChanged {
+		// The consenter metadata is specific to the consensus type which produced it,
+		// so it must not be handed to the consenter which takes over the chain.
+		encodedMetadataValue = nil
+	}
+
 	bw.WriteBlock(block, encodedMetadataValue)
+
+	if consensusTypeChanged {
+		// The chain which wrote this block is halted by the registrar, so the switch
+		// must not be performed from the calling (consenter) go routine.
+		go bw.registrar.switchConsenter(chdr.ChannelId)
+	}
+}
+
+// consensusTypeChanged reports whether the given config changes the consensus type
+// of the current config. It must be invoked before the new config takes effect.
+func (bw *BlockWriter) consensusTypeChanged(config *cb.Config) bool {
+	current, err := msgprocessor.ConsensusTypeFromConfig(bw.support.ConfigProto())
+	if err != nil {
+		logger.Debugf("[channel: %s] Could not extract current consensus type: %s", bw.support.ChainID(), err)
+		return false
+	}
+	next, err := msgprocessor.ConsensusTypeFromConfig(config)
+	if err != nil {
+		logger.Debugf("[channel: %s] Could not extract new consensus type: %s", bw.support.ChainID(), err)
+		return false
+	}
+	if current.Type == next.Type {
+		return false
+	}
+	logger.Infof("[channel: %s] Config changes consensus type from %s to %s", bw.support.ChainID(), current.Type, next.Type)
+	return true
 }
 
 // WriteBlock should be invoked for blocks which contain normal transactions.
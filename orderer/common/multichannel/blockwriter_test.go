@@ -25,6 +25,7 @@ import (
 	mockconfigtx "github.com/hyperledger/fabric/common/mocks/configtx"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -192,3 +193,26 @@ func TestGoodWriteConfig(t *testing.T) {
 	omd := utils.GetMetadataFromBlockOrPanic(block, cb.BlockMetadataIndex_ORDERER)
 	assert.Equal(t, consenterMetadata, omd.Value)
 }
+
+func TestConsensusTypeChanged(t *testing.T) {
+	configWithType := func(consensusType string) *cb.Config {
+		group := cb.NewConfigGroup()
+		group.Groups[newchannelconfig.OrdererGroupKey] = cb.NewConfigGroup()
+		group.Groups[newchannelconfig.OrdererGroupKey].Values[newchannelconfig.ConsensusTypeKey] = &cb.ConfigValue{
+			Value: utils.MarshalOrPanic(&ab.ConsensusType{Type: consensusType}),
+		}
+		return &cb.Config{ChannelGroup: group}
+	}
+
+	bw := &BlockWriter{
+		support: &mockBlockWriterSupport{
+			Validator: &mockconfigtx.Validator{ConfigProtoVal: configWithType("solo")},
+		},
+	}
+	assert.False(t, bw.consensusTypeChanged(configWithType("solo")))
+	assert.True(t, bw.consensusTypeChanged(configWithType("kafka")))
+	assert.False(t, bw.consensusTypeChanged(&cb.Config{}), "Config without consensus type")
+
+	bw.support = &mockBlockWriterSupport{Validator: &mockconfigtx.Validator{}}
+	assert.False(t, bw.consensusTypeChanged(configWithType("kafka")), "Current config without consensus type")
+}
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package multichannel
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
@@ -24,7 +26,8 @@ type ChainSupport struct {
 	msgprocessor.Processor
 	*BlockWriter
 	consensus.Chain
-	cutter blockcutter.Receiver
+	cutter     blockcutter.Receiver
+	consenters map[string]consensus.Consenter
 	crypto.LocalSigner
 }
 
@@ -48,7 +51,8 @@ func newChainSupport(
 	cs := &ChainSupport{
 		ledgerResources: ledgerResources,
 		LocalSigner:     signer,
-		cutter:          blockcutter.NewReceiverImpl(ledgerResources.SharedConfig()),
+		cutter:          blockcutter.NewReceiverImpl(ledgerResources),
+		consenters:      consenters,
 	}
 
 	// Set up the msgprocessor
@@ -92,6 +96,11 @@ func (cs *ChainSupport) BlockCutter() blockcutter.Receiver {
 	return cs.cutter
 }
 
+// BatchTimeout returns the batch timeout advised by the block cutter for this channel.
+func (cs *ChainSupport) BatchTimeout() time.Duration {
+	return cs.cutter.BatchTimeout()
+}
+
 // Validate passes through to the underlying configtx.Validator
 func (cs *ChainSupport) Validate(configEnv *cb.ConfigEnvelope) error {
 	return cs.ConfigtxValidator().Validate(configEnv)
@@ -113,6 +122,12 @@ func (cs *ChainSupport) ProposeConfigUpdate(configtx *cb.Envelope) (*cb.ConfigEn
 		return nil, errors.Wrap(err, "config update is not compatible")
 	}
 
+	if oc, ok := bundle.OrdererConfig(); ok {
+		if _, ok := cs.consenters[oc.ConsensusType()]; !ok {
+			return nil, errors.Errorf("config update requires unknown consensus type %s", oc.ConsensusType())
+		}
+	}
+
 	return env, cs.ValidateNew(bundle)
 }
 
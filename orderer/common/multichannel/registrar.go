@@ -11,6 +11,7 @@ package multichannel
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/configtx"
@@ -104,6 +105,7 @@ type Registrar struct {
 	systemChannel   *ChainSupport
 	templator       msgprocessor.ChannelConfigTemplator
 	callbacks       []func(bundle *channelconfig.Bundle)
+	lock            sync.Mutex
 }
 
 func getConfigTx(reader blockledger.Reader) *cb.Envelope {
@@ -153,8 +155,7 @@ func NewRegistrar(ledgerFactory blockledger.Factory, consenters map[string]conse
 				ledgerResources,
 				consenters,
 				signer)
-			r.templator = msgprocessor.NewDefaultTemplator(chain)
-			chain.Processor = msgprocessor.NewSystemChannel(chain, r.templator, msgprocessor.CreateSystemChannelFilters(r, chain))
+			r.initSystemChannel(chain)
 
 			// Retrieve genesis block to log its hash. See FAB-5450 for the purpose
 			iter, pos := rl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{Oldest: &ab.SeekOldest{}}})
@@ -193,6 +194,13 @@ func NewRegistrar(ledgerFactory blockledger.Factory, consenters map[string]conse
 	return r
 }
 
+// initSystemChannel replaces the message processor of the system channel chain, and
+// sets up the templator used for the creation of new channels.
+func (r *Registrar) initSystemChannel(chain *ChainSupport) {
+	r.templator = msgprocessor.NewDefaultTemplator(chain)
+	chain.Processor = msgprocessor.NewSystemChannel(chain, r.templator, msgprocessor.CreateSystemChannelFilters(r, chain))
+}
+
 // SystemChannelID returns the ChannelID for the system channel.
 func (r *Registrar) SystemChannelID() string {
 	return r.systemChannelID
@@ -269,6 +277,9 @@ func (r *Registrar) newLedgerResources(configTx *cb.Envelope) *ledgerResources {
 }
 
 func (r *Registrar) newChain(configtx *cb.Envelope) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
 	ledgerResources := r.newLedgerResources(configtx)
 	ledgerResources.Append(blockledger.CreateNextBlock(ledgerResources, []*cb.Envelope{configtx}))
 
@@ -289,6 +300,44 @@ func (r *Registrar) newChain(configtx *cb.Envelope) {
 	r.chains = newChains
 }
 
+// switchConsenter halts the chain of the given channel, and re-creates it with the consenter
+// matching the consensus type of the current channel config. It is invoked once the config block
+// which changed the consensus type has been handed to the block writer.
+func (r *Registrar) switchConsenter(chainID string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	cs, ok := r.chains[chainID]
+	if !ok {
+		logger.Panicf("Told to switch the consenter of channel %s, which does not exist", chainID)
+	}
+
+	logger.Infof("[channel: %s] Halting chain to switch to consensus type %s", chainID, cs.SharedConfig().ConsensusType())
+	cs.Halt()
+
+	// Wait for the config block which changed the consensus type to be committed
+	cs.committingBlock.Lock()
+	cs.committingBlock.Unlock()
+
+	newCS := newChainSupport(r, cs.ledgerResources, r.consenters, r.signer)
+	if chainID == r.systemChannelID {
+		r.initSystemChannel(newCS)
+		r.systemChannel = newCS
+	}
+
+	// Copy the map to allow concurrent reads from broadcast/deliver while the chainSupport is replaced
+	newChains := make(map[string]*ChainSupport)
+	for key, value := range r.chains {
+		newChains[key] = value
+	}
+	newChains[chainID] = newCS
+
+	logger.Infof("[channel: %s] Starting chain with consensus type %s", chainID, newCS.SharedConfig().ConsensusType())
+	newCS.start()
+
+	r.chains = newChains
+}
+
 // ChannelsCount returns the count of the current total number of channels.
 func (r *Registrar) ChannelsCount() int {
 	return len(r.chains)
@@ -12,8 +12,11 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/genesis"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	ramledger "github.com/hyperledger/fabric/common/ledger/blockledger/ram"
 	mockchannelconfig "github.com/hyperledger/fabric/common/mocks/config"
@@ -21,7 +24,9 @@ import (
 	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -347,3 +352,99 @@ func TestResourcesCheck(t *testing.T) {
 		})
 	})
 }
+
+func TestSwitchConsenter(t *testing.T) {
+	lf, _ := NewRAMLedgerAndFactory(10)
+
+	consenters := make(map[string]consensus.Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+
+	manager := NewRegistrar(lf, consenters, mockCrypto())
+	oldChain, ok := manager.GetChain(genesisconfig.TestChainID)
+	assert.True(t, ok)
+
+	manager.switchConsenter(genesisconfig.TestChainID)
+
+	select {
+	case <-oldChain.Chain.(*mockChain).done:
+	case <-time.After(time.Second):
+		t.Fatalf("Old chain was not halted")
+	}
+
+	newChain, ok := manager.GetChain(genesisconfig.TestChainID)
+	assert.True(t, ok)
+	assert.NotEqual(t, oldChain, newChain)
+	assert.Equal(t, newChain, manager.systemChannel)
+	assert.IsType(t, &msgprocessor.SystemChannel{}, newChain.Processor)
+
+	assert.Panics(t, func() { manager.switchConsenter("Fake") })
+}
+
+func TestConsensusTypeMigrationConfigUpdate(t *testing.T) {
+	newRegistrar := func(state ab.ConsensusType_State, ordererCapability string) *Registrar {
+		migrationConf := genesisconfig.Load(genesisconfig.SampleInsecureSoloProfile)
+		migrationConf.Orderer.Capabilities = map[string]bool{ordererCapability: true}
+		channelGroup, err := encoder.NewChannelGroup(migrationConf)
+		assert.NoError(t, err)
+		channelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.ConsensusTypeKey].Value =
+			utils.MarshalOrPanic(&ab.ConsensusType{Type: migrationConf.Orderer.OrdererType, State: state})
+		block, err := genesis.NewFactoryImpl(channelGroup).Block(genesisconfig.TestChainID)
+		assert.NoError(t, err)
+
+		lf := ramledger.New(10)
+		rl, err := lf.GetOrCreate(genesisconfig.TestChainID)
+		assert.NoError(t, err)
+		assert.NoError(t, rl.Append(block))
+
+		consenters := map[string]consensus.Consenter{
+			migrationConf.Orderer.OrdererType: &mockConsenter{},
+			"kafka":                           &mockConsenter{},
+		}
+		return NewRegistrar(lf, consenters, mockCrypto())
+	}
+
+	// proposeConsensusType submits a config update of the consensus type of the system channel
+	proposeConsensusType := func(manager *Registrar, consensusType *ab.ConsensusType) error {
+		cs, ok := manager.GetChain(genesisconfig.TestChainID)
+		assert.True(t, ok)
+		original := cs.ConfigProto()
+		updated := proto.Clone(original).(*cb.Config)
+		updated.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.ConsensusTypeKey].Value =
+			utils.MarshalOrPanic(consensusType)
+		configUpdate, err := update.Compute(original, updated)
+		assert.NoError(t, err)
+		configUpdate.ChannelId = genesisconfig.TestChainID
+		env, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, genesisconfig.TestChainID, mockCrypto(),
+			&cb.ConfigUpdateEnvelope{ConfigUpdate: utils.MarshalOrPanic(configUpdate)}, msgVersion, epoch)
+		assert.NoError(t, err)
+		_, err = cs.ProposeConfigUpdate(env)
+		return err
+	}
+
+	t.Run("InMaintenance", func(t *testing.T) {
+		manager := newRegistrar(ab.ConsensusType_STATE_MAINTENANCE, capabilities.OrdererV1_2)
+		err := proposeConsensusType(manager, &ab.ConsensusType{Type: "kafka", State: ab.ConsensusType_STATE_MAINTENANCE})
+		assert.NoError(t, err)
+	})
+
+	t.Run("NotInMaintenance", func(t *testing.T) {
+		manager := newRegistrar(ab.ConsensusType_STATE_NORMAL, capabilities.OrdererV1_2)
+		err := proposeConsensusType(manager, &ab.ConsensusType{Type: "kafka", State: ab.ConsensusType_STATE_MAINTENANCE})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Attempted to change consensus type from solo to kafka")
+	})
+
+	t.Run("ExitingMaintenance", func(t *testing.T) {
+		manager := newRegistrar(ab.ConsensusType_STATE_MAINTENANCE, capabilities.OrdererV1_2)
+		err := proposeConsensusType(manager, &ab.ConsensusType{Type: "kafka", State: ab.ConsensusType_STATE_NORMAL})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Attempted to change consensus type from solo to kafka")
+	})
+
+	t.Run("NoMigrationCapability", func(t *testing.T) {
+		manager := newRegistrar(ab.ConsensusType_STATE_MAINTENANCE, capabilities.OrdererV1_1)
+		err := proposeConsensusType(manager, &ab.ConsensusType{Type: "kafka", State: ab.ConsensusType_STATE_MAINTENANCE})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Attempted to change consensus type from solo to kafka")
+	})
+}
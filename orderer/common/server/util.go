@@ -29,7 +29,19 @@ func createLedgerFactory(conf *config.TopLevel) (blockledger.Factory, string) {
 			ld = createTempDir(conf.FileLedger.Prefix)
 		}
 		logger.Debug("Ledger dir:", ld)
-		lf = fileledger.New(ld)
+		if conf.FileLedger.Retention.Enabled {
+			retention := conf.FileLedger.Retention
+			logger.Infof("File ledger retention enabled, archiving old block files to %s", retention.ArchiveDir)
+			lf = fileledger.NewWithRetention(ld, &fileledger.RetentionPolicy{
+				BlocksToKeep:  retention.BlocksToKeep,
+				MaxAge:        retention.MaxAge,
+				ArchiveDir:    retention.ArchiveDir,
+				Compress:      retention.Compress,
+				CheckInterval: retention.CheckInterval,
+			})
+		} else {
+			lf = fileledger.New(ld)
+		}
 		// The file-based ledger stores the blocks for each channel
 		// in a fsblkstorage.ChainsDir sub-directory that we have
 		// to create separately. Otherwise the call to the ledger
@@ -534,6 +534,12 @@ func newProducerMessage(channel channel, pld []byte) *sarama.ProducerMessage {
 	}
 }
 
+func (chain *chainImpl) startBatchTimer() {
+	batchTimeout := chain.BatchTimeout()
+	chain.timer = time.After(batchTimeout)
+	logger.Debugf("[channel: %s] Just began %s batch timer", chain.ChainID(), batchTimeout.String())
+}
+
 func (chain *chainImpl) processConnect(channelName string) error {
 	logger.Debugf("[channel: %s] It's a connect message - ignoring", channelName)
 	return nil
@@ -554,13 +560,16 @@ func (chain *chainImpl) processRegular(regularMessage *ab.KafkaMessageRegular, r
 			// If no block is cut, we update the `lastOriginalOffsetProcessed`, start the timer if necessary and return
 			chain.lastOriginalOffsetProcessed = newOffset
 			if chain.timer == nil {
-				chain.timer = time.After(chain.SharedConfig().BatchTimeout())
-				logger.Debugf("[channel: %s] Just began %s batch timer", chain.ChainID(), chain.SharedConfig().BatchTimeout().String())
+				chain.startBatchTimer()
 			}
 			return
 		}
 
 		chain.timer = nil
+		if pending {
+			// A batch was cut but the newest envelope is still pending, so it needs a timer of its own
+			defer chain.startBatchTimer()
+		}
 
 		offset := receivedOffset
 		if pending || len(batches) == 2 {
@@ -1861,6 +1861,65 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 				assert.Equal(t, uint64(1), counts[indexRecvPass], "Expected 2 message received and unmarshaled")
 				assert.Equal(t, uint64(1), counts[indexProcessRegularPass], "Expected 1 REGULAR message processed")
 			})
+
+			// A batch cut with the newest message left pending must start a fresh batch timer for it,
+			// instead of leaving it to wait for a later message
+			t.Run("PendingAfterCutRestartsTimer", func(t *testing.T) {
+				lastCutBlockNumber := uint64(3)
+
+				mockSupport := &mockmultichannel.ConsenterSupport{
+					Blocks:         make(chan *cb.Block, 1), // WriteBlock will post here
+					BlockCutterVal: mockblockcutter.NewReceiver(),
+					ChainIDVal:     mockChannel.topic(),
+					HeightVal:      lastCutBlockNumber, // Incremented during the WriteBlock call
+					SharedConfigVal: &mockconfig.Orderer{
+						BatchTimeoutVal: longTimeout,
+						CapabilitiesVal: &mockconfig.OrdererCapabilities{
+							ResubmissionVal: false,
+						},
+					},
+				}
+				defer close(mockSupport.BlockCutterVal.Block)
+
+				bareMinimumChain := &chainImpl{
+					channel:                     mockChannel,
+					ConsenterSupport:            mockSupport,
+					lastCutBlockNumber:          lastCutBlockNumber,
+					lastOriginalOffsetProcessed: lastOriginalOffsetProcessed,
+				}
+
+				processRegular := func(offset int64) {
+					go func() {
+						mockSupport.BlockCutterVal.Block <- struct{}{} // Let the `mockblockcutter.Ordered` call return
+					}()
+					msg := newNormalMessage(utils.MarshalOrPanic(newMockEnvelope("fooMessage")), uint64(0), int64(0))
+					assert.NoError(t, bareMinimumChain.processRegular(msg.GetRegular(), offset))
+				}
+
+				processRegular(int64(5))
+				assert.NotNil(t, bareMinimumChain.timer, "Expected the batch timer to be started for the pending message")
+				firstTimer := bareMinimumChain.timer
+
+				// The second message overflows the pending batch, which is cut, and is left pending itself
+				mockSupport.BlockCutterVal.CutAncestors = true
+				mockSupport.SharedConfigVal.BatchTimeoutVal = extraShortTimeout
+				processRegular(int64(6))
+
+				select {
+				case <-mockSupport.Blocks:
+				case <-time.After(shortTimeout):
+					t.Fatalf("Expected a block to be cut for the messages preceding the pending one")
+				}
+				assert.Equal(t, lastCutBlockNumber+1, bareMinimumChain.lastCutBlockNumber, "Expected lastCutBlockNumber to be bumped up by one")
+
+				assert.NotNil(t, bareMinimumChain.timer, "Expected the batch timer to be restarted for the pending message")
+				assert.True(t, bareMinimumChain.timer != firstTimer, "Expected a fresh batch timer for the pending message")
+				select {
+				case <-bareMinimumChain.timer:
+				case <-time.After(shortTimeout):
+					t.Fatalf("Expected the restarted batch timer to use the current batch timeout")
+				}
+			})
 		})
 
 		// This ensures regular kafka messages of type CONFIG are handled properly
@@ -3381,6 +3440,11 @@ func (r *mockReceiver) Cut() []*cb.Envelope {
 	return args.Get(0).([]*cb.Envelope)
 }
 
+func (r *mockReceiver) BatchTimeout() time.Duration {
+	args := r.Called()
+	return args.Get(0).(time.Duration)
+}
+
 type mockConsenterSupport struct {
 	mock.Mock
 }
@@ -3425,6 +3489,11 @@ func (c *mockConsenterSupport) SharedConfig() channelconfig.Orderer {
 	return args.Get(0).(channelconfig.Orderer)
 }
 
+func (c *mockConsenterSupport) BatchTimeout() time.Duration {
+	args := c.Called()
+	return args.Get(0).(time.Duration)
+}
+
 func (c *mockConsenterSupport) CreateNextBlock(messages []*cb.Envelope) *cb.Block {
 	args := c.Called(messages)
 	return args.Get(0).(*cb.Block)
@@ -134,17 +134,26 @@ func (ch *chain) main() {
 						continue
 					}
 				}
-				batches, _ := ch.support.BlockCutter().Ordered(msg.normalMsg)
-				if len(batches) == 0 && timer == nil {
-					timer = time.After(ch.support.SharedConfig().BatchTimeout())
-					continue
-				}
+				batches, pending := ch.support.BlockCutter().Ordered(msg.normalMsg)
 				for _, batch := range batches {
 					block := ch.support.CreateNextBlock(batch)
 					ch.support.WriteBlock(block, nil)
 				}
-				if len(batches) > 0 {
+
+				switch {
+				case timer != nil && !pending:
+					// Timer is already running but there are no messages pending, stop the timer
 					timer = nil
+				case len(batches) > 0 && pending:
+					// A batch was cut but messages remain pending, restart the timer for them
+					timer = time.After(ch.support.BatchTimeout())
+				case timer == nil && pending:
+					// Timer is not already running and there are messages pending, so start it
+					timer = time.After(ch.support.BatchTimeout())
+				default:
+					// Do nothing when:
+					// 1. Timer is already running and there are messages pending
+					// 2. Timer is not set and there are no messages pending
 				}
 			} else {
 				// ConfigMsg
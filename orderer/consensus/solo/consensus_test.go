@@ -209,6 +209,46 @@ func TestBatchTimerHaltOnFilledBatch(t *testing.T) {
 	}
 }
 
+func TestBatchTimerPendingAfterCut(t *testing.T) {
+	batchTimeout, _ := time.ParseDuration("1h")
+	support := &mockmultichannel.ConsenterSupport{
+		Blocks:          make(chan *cb.Block),
+		BlockCutterVal:  mockblockcutter.NewReceiver(),
+		SharedConfigVal: &mockconfig.Orderer{BatchTimeoutVal: batchTimeout},
+	}
+	defer close(support.BlockCutterVal.Block)
+
+	bs := newChain(support)
+	wg := goWithWait(bs.main)
+	defer bs.Halt()
+
+	syncQueueMessage(testMessage, bs, support.BlockCutterVal)
+
+	// Change the batch timeout to be near instant, the message left pending after the cut must get a fresh timer
+	support.SharedConfigVal.BatchTimeoutVal = time.Millisecond
+	support.BlockCutterVal.CutAncestors = true
+	syncQueueMessage(testMessage, bs, support.BlockCutterVal)
+
+	select {
+	case <-support.Blocks:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected a block to be cut for the messages preceding the pending one")
+	}
+
+	select {
+	case <-support.Blocks:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the pending message to be cut by the batch timer")
+	}
+
+	bs.Halt()
+	select {
+	case <-time.After(time.Second):
+		t.Fatalf("Should have exited")
+	case <-wg.done:
+	}
+}
+
 func TestLargeMsgStyleMultiBatch(t *testing.T) {
 	batchTimeout, _ := time.ParseDuration("1h")
 	support := &mockmultichannel.ConsenterSupport{
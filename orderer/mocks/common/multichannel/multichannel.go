@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package multichannel
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/common/channelconfig"
 	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
@@ -72,6 +74,11 @@ func (mcs *ConsenterSupport) SharedConfig() channelconfig.Orderer {
 	return mcs.SharedConfigVal
 }
 
+// BatchTimeout returns the BatchTimeout of SharedConfigVal
+func (mcs *ConsenterSupport) BatchTimeout() time.Duration {
+	return mcs.SharedConfigVal.BatchTimeout()
+}
+
 // CreateNextBlock creates a simple block structure with the given data
 func (mcs *ConsenterSupport) CreateNextBlock(data []*cb.Envelope) *cb.Block {
 	block := cb.NewBlock(0, nil)
@@ -108,6 +108,8 @@ func resetFlags() {
 		"Get the installed chaincodes on a peer")
 	flags.BoolVarP(&getInstantiatedChaincodes, "instantiated", "", false,
 		"Get the instantiated chaincodes on a channel")
+	flags.BoolVarP(&getChaincodeIndexes, "indexes", "", false,
+		"Get the state database indexes of a chaincode on a channel")
 	flags.StringVar(&collectionsConfigFile, "collections-config", common.UndefinedParamValue,
 		fmt.Sprint("The file containing the configuration for the chaincode's collection"))
 }
@@ -159,10 +159,18 @@ func chaincodeInvokeOrQuery(cmd *cobra.Command, args []string, invoke bool, cf *
 }
 
 type collectionConfigJson struct {
-	Name          string `json:"name"`
-	Policy        string `json:"policy"`
-	RequiredCount int32  `json:"requiredPeerCount"`
-	MaxPeerCount  int32  `json:"maxPeerCount"`
+	Name              string                           `json:"name"`
+	Policy            string                           `json:"policy"`
+	RequiredCount     int32                            `json:"requiredPeerCount"`
+	MaxPeerCount      int32                            `json:"maxPeerCount"`
+	EndorsementPolicy *collectionEndorsementPolicyJson `json:"endorsementPolicy,omitempty"`
+}
+
+// collectionEndorsementPolicyJson defines the endorsement policy of a collection,
+// either as a signature policy or as a reference to a policy in the channel config
+type collectionEndorsementPolicyJson struct {
+	SignaturePolicy     string `json:"signaturePolicy,omitempty"`
+	ChannelConfigPolicy string `json:"channelConfigPolicy,omitempty"`
 }
 
 // getCollectionConfig retrieves the collection configuration
@@ -200,6 +208,11 @@ func getCollectionConfigFromBytes(cconfBytes []byte) ([]byte, error) {
 			},
 		}
 
+		ep, err := getCollectionEndorsementPolicy(cconfitem.EndorsementPolicy)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("invalid endorsement policy of collection %s", cconfitem.Name))
+		}
+
 		cc := &pcommon.CollectionConfig{
 			Payload: &pcommon.CollectionConfig_StaticCollectionConfig{
 				StaticCollectionConfig: &pcommon.StaticCollectionConfig{
@@ -207,6 +220,7 @@ func getCollectionConfigFromBytes(cconfBytes []byte) ([]byte, error) {
 					MemberOrgsPolicy:  cpc,
 					RequiredPeerCount: cconfitem.RequiredCount,
 					MaximumPeerCount:  cconfitem.MaxPeerCount,
+					EndorsementPolicy: ep,
 				},
 			},
 		}
@@ -218,6 +232,32 @@ func getCollectionConfigFromBytes(cconfBytes []byte) ([]byte, error) {
 	return proto.Marshal(ccp)
 }
 
+// getCollectionEndorsementPolicy converts the endorsement policy of a collection
+// in the collection configuration file into a CollectionEndorsementPolicy
+func getCollectionEndorsementPolicy(epJson *collectionEndorsementPolicyJson) (*pcommon.CollectionEndorsementPolicy, error) {
+	if epJson == nil {
+		return nil, nil
+	}
+	switch {
+	case epJson.SignaturePolicy != "" && epJson.ChannelConfigPolicy != "":
+		return nil, errors.New("either a signature policy or a channel config policy must be specified, not both")
+	case epJson.SignaturePolicy != "":
+		p, err := cauthdsl.FromString(epJson.SignaturePolicy)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("invalid policy %s", epJson.SignaturePolicy))
+		}
+		return &pcommon.CollectionEndorsementPolicy{
+			Payload: &pcommon.CollectionEndorsementPolicy_SignaturePolicy{SignaturePolicy: p},
+		}, nil
+	case epJson.ChannelConfigPolicy != "":
+		return &pcommon.CollectionEndorsementPolicy{
+			Payload: &pcommon.CollectionEndorsementPolicy_ChannelConfigPolicyReference{ChannelConfigPolicyReference: epJson.ChannelConfigPolicy},
+		}, nil
+	default:
+		return nil, errors.New("either a signature policy or a channel config policy must be specified")
+	}
+}
+
 func checkChaincodeCmdParams(cmd *cobra.Command) error {
 	//we need chaincode name for everything, including deploy
 	if chaincodeName == common.UndefinedParamValue {
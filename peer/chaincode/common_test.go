@@ -195,6 +195,59 @@ const sampleCollectionConfigBad = `[
 	}
 ]`
 
+const sampleCollectionConfigEndorsementPolicies = `[
+	{
+		"name": "foo",
+		"policy": "OR('A.member', 'B.member')",
+		"requiredPeerCount": 1,
+		"maxPeerCount": 2,
+		"endorsementPolicy": {
+			"signaturePolicy": "AND('A.member', 'B.member')"
+		}
+	},
+	{
+		"name": "bar",
+		"policy": "OR('A.member', 'B.member')",
+		"requiredPeerCount": 1,
+		"maxPeerCount": 2,
+		"endorsementPolicy": {
+			"channelConfigPolicy": "/Channel/Application/Endorsement"
+		}
+	}
+]`
+
+const sampleCollectionConfigBadEndorsementPolicy = `[
+	{
+		"name": "foo",
+		"policy": "OR('A.member', 'B.member')",
+		"requiredPeerCount": 1,
+		"maxPeerCount": 2,
+		"endorsementPolicy": {
+			"signaturePolicy": "AND('A.member', 'B.member')",
+			"channelConfigPolicy": "/Channel/Application/Endorsement"
+		}
+	}
+]`
+
+func TestCollectionEndorsementPolicyParsing(t *testing.T) {
+	cc, err := getCollectionConfigFromBytes([]byte(sampleCollectionConfigEndorsementPolicies))
+	assert.NoError(t, err)
+	ccp := &common2.CollectionConfigPackage{}
+	assert.NoError(t, proto.Unmarshal(cc, ccp))
+	pol, _ := cauthdsl.FromString("AND('A.member', 'B.member')")
+	assert.Equal(t, pol, ccp.Config[0].GetStaticCollectionConfig().EndorsementPolicy.GetSignaturePolicy())
+	assert.Equal(t, "/Channel/Application/Endorsement", ccp.Config[1].GetStaticCollectionConfig().EndorsementPolicy.GetChannelConfigPolicyReference())
+
+	cc, err = getCollectionConfigFromBytes([]byte(sampleCollectionConfigBadEndorsementPolicy))
+	assert.Error(t, err)
+	assert.Nil(t, cc)
+
+	cc, err = getCollectionConfigFromBytes([]byte(sampleCollectionConfigGood))
+	assert.NoError(t, err)
+	assert.NoError(t, proto.Unmarshal(cc, ccp))
+	assert.Nil(t, ccp.Config[0].GetStaticCollectionConfig().EndorsementPolicy)
+}
+
 func TestCollectionParsing(t *testing.T) {
 	cc, err := getCollectionConfigFromBytes([]byte(sampleCollectionConfigGood))
 	assert.NoError(t, err)
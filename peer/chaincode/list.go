@@ -14,6 +14,10 @@ import (
 	"reflect"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/scc/qscc"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/spf13/cobra"
@@ -22,6 +26,7 @@ import (
 
 var getInstalledChaincodes bool
 var getInstantiatedChaincodes bool
+var getChaincodeIndexes bool
 var chaincodeListCmd *cobra.Command
 
 const list_cmdname = "list"
@@ -31,7 +36,8 @@ func listCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 	chaincodeListCmd = &cobra.Command{
 		Use:   "list",
 		Short: "Get the instantiated chaincodes on a channel or installed chaincodes on a peer.",
-		Long:  "Get the instantiated chaincodes in the channel if specify channel, or get installed chaincodes on the peer",
+		Long: "Get the instantiated chaincodes in the channel if specify channel, or get installed chaincodes on the peer, " +
+			"or get the state database indexes of a chaincode in the channel if specify channel and chaincode name",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return getChaincodes(cmd, cf)
 		},
@@ -41,6 +47,8 @@ func listCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 		"channelID",
 		"installed",
 		"instantiated",
+		"indexes",
+		"name",
 	}
 	attachFlags(chaincodeListCmd, flagList)
 
@@ -48,6 +56,9 @@ func listCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 }
 
 func getChaincodes(cmd *cobra.Command, cf *ChaincodeCmdFactory) error {
+	if getChaincodeIndexes {
+		return getIndexes(cf)
+	}
 	if getInstantiatedChaincodes && channelID == "" {
 		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
 	}
@@ -105,6 +116,85 @@ func getChaincodes(cmd *cobra.Command, cf *ChaincodeCmdFactory) error {
 	return nil
 }
 
+func getIndexes(cf *ChaincodeCmdFactory) error {
+	if getInstalledChaincodes || getInstantiatedChaincodes {
+		return errors.New("\"--indexes\" cannot be combined with \"--installed\" or \"--instantiated\"")
+	}
+	if channelID == "" {
+		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+	if chaincodeName == common.UndefinedParamValue {
+		return errors.New("The required parameter 'name' is empty. Rerun the command with -n flag")
+	}
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(true, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	creator, err := cf.Signer.Serialize()
+	if err != nil {
+		return fmt.Errorf("Error serializing identity for %s: %s", cf.Signer.GetIdentifier(), err)
+	}
+
+	invocation := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_GOLANG,
+			ChaincodeId: &pb.ChaincodeID{Name: "qscc"},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(qscc.GetIndexStatus), []byte(channelID), []byte(chaincodeName)}},
+		},
+	}
+	prop, _, err := utils.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", invocation, creator)
+	if err != nil {
+		return fmt.Errorf("Error creating proposal %s: %s", chainFuncName, err)
+	}
+
+	signedProp, err := utils.GetSignedProposal(prop, cf.Signer)
+	if err != nil {
+		return fmt.Errorf("Error creating signed proposal  %s: %s", chainFuncName, err)
+	}
+
+	proposalResponse, err := cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return fmt.Errorf("Error endorsing %s: %s", chainFuncName, err)
+	}
+	if proposalResponse.Response == nil {
+		return errors.New("Received empty response")
+	}
+	if proposalResponse.Response.Status != shim.OK {
+		return fmt.Errorf("Received bad response, status %d: %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
+	}
+
+	status := &pb.IndexStatusResponse{}
+	err = proto.Unmarshal(proposalResponse.Response.Payload, status)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Get indexes of chaincode %s on channel %s:\n", chaincodeName, channelID)
+	for _, index := range status.Indexes {
+		fmt.Printf("%s\n", indexInfo{index})
+	}
+	return nil
+}
+
+type indexInfo struct {
+	*pb.IndexStatus
+}
+
+func (ii indexInfo) String() string {
+	s := fmt.Sprintf("DesignDoc: %s, Name: %s, Status: %s", ii.DesignDoc, ii.Name, ii.Status)
+	if ii.File != "" {
+		s += fmt.Sprintf(", File: %s", ii.File)
+	}
+	if ii.Error != "" {
+		s += fmt.Sprintf(", Error: %s", ii.Error)
+	}
+	return s
+}
+
 type ccInfo struct {
 	*pb.ChaincodeInfo
 }
@@ -124,3 +124,63 @@ func TestString(t *testing.T) {
 	}
 	assert.Equal(t, "Name: ccName, Version: 1.0, Input: input, Escc: escc, Vscc: vscc, Id: 0102030405", ccInf.String())
 }
+
+func TestChaincodeListIndexesCmd(t *testing.T) {
+	InitMSP()
+	resetFlags()
+	defer resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	status := &pb.IndexStatusResponse{
+		Indexes: []*pb.IndexStatus{
+			{DesignDoc: "indexOwnerDoc", Name: "indexOwner", File: "META-INF/statedb/couchdb/indexes/indexOwner.json", Status: pb.IndexStatus_CREATED},
+			{DesignDoc: "indexSizeDoc", Name: "indexSize", Status: pb.IndexStatus_FAILED, Error: "bad index"},
+		},
+	}
+	statusBytes, err := proto.Marshal(status)
+	assert.NoError(t, err)
+
+	mockCF := &ChaincodeCmdFactory{
+		EndorserClient: common.GetMockEndorserClient(&pb.ProposalResponse{
+			Response:    &pb.Response{Status: 200, Payload: statusBytes},
+			Endorsement: &pb.Endorsement{},
+		}, nil),
+		Signer: signer,
+	}
+	channelID = ""
+
+	cmd := listCmd(mockCF)
+	cmd.SetArgs([]string{"--indexes", "-n", "mycc"})
+	assert.EqualError(t, cmd.Execute(), "The required parameter 'channelID' is empty. Rerun the command with -C flag")
+
+	resetFlags()
+	cmd = listCmd(mockCF)
+	cmd.SetArgs([]string{"--indexes", "-C", "mychannel"})
+	assert.EqualError(t, cmd.Execute(), "The required parameter 'name' is empty. Rerun the command with -n flag")
+
+	resetFlags()
+	cmd = listCmd(mockCF)
+	cmd.SetArgs([]string{"--indexes", "--instantiated", "-C", "mychannel", "-n", "mycc"})
+	assert.Error(t, cmd.Execute())
+
+	resetFlags()
+	cmd = listCmd(mockCF)
+	cmd.SetArgs([]string{"--indexes", "-C", "mychannel", "-n", "mycc"})
+	assert.NoError(t, cmd.Execute())
+
+	resetFlags()
+	mockCF.EndorserClient = common.GetMockEndorserClient(&pb.ProposalResponse{
+		Response:    &pb.Response{Status: 500, Message: "the state database [goleveldb] does not create chaincode indexes"},
+		Endorsement: &pb.Endorsement{},
+	}, nil)
+	cmd = listCmd(mockCF)
+	cmd.SetArgs([]string{"--indexes", "-C", "mychannel", "-n", "mycc"})
+	assert.EqualError(t, cmd.Execute(), "Received bad response, status 500: the state database [goleveldb] does not create chaincode indexes")
+}
+
+func TestIndexInfoString(t *testing.T) {
+	info := indexInfo{&pb.IndexStatus{DesignDoc: "indexSizeDoc", Name: "indexSize", Status: pb.IndexStatus_FAILED, Error: "bad index"}}
+	assert.Equal(t, "DesignDoc: indexSizeDoc, Name: indexSize, Status: FAILED, Error: bad index", info.String())
+}
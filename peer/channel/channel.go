@@ -53,6 +53,19 @@ var (
 	channelID     string
 	channelTxFile string
 	timeout       int
+
+	// getpvtdatastatus related variables
+	startBlock uint64
+	endBlock   uint64
+
+	// listtxs related variables
+	txsFrom         string
+	txsTo           string
+	creatorHash     string
+	creatorCertFile string
+	creatorMSPID    string
+	txsLimit        int
+	txsBookmark     string
 )
 
 // Cmd returns the cobra command for Node
@@ -66,6 +79,8 @@ func Cmd(cf *ChannelCmdFactory) *cobra.Command {
 	channelCmd.AddCommand(updateCmd(cf))
 	channelCmd.AddCommand(signconfigtxCmd(cf))
 	channelCmd.AddCommand(getinfoCmd(cf))
+	channelCmd.AddCommand(getpvtdatastatusCmd(cf))
+	channelCmd.AddCommand(listtxsCmd(cf))
 
 	return channelCmd
 }
@@ -89,6 +104,15 @@ func resetFlags() {
 	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "In case of a newChain command, the channel ID to create.")
 	flags.StringVarP(&channelTxFile, "file", "f", "", "Configuration transaction file generated by a tool such as configtxgen for submitting to orderer")
 	flags.IntVarP(&timeout, "timeout", "t", 5, "Channel creation timeout")
+	flags.Uint64Var(&startBlock, "startBlock", 0, "The number of the first block of the range to query")
+	flags.Uint64Var(&endBlock, "endBlock", 0, "The number of the last block of the range to query")
+	flags.StringVar(&txsFrom, "from", "", "The RFC 3339 time the transactions to list were created from, unbounded if not set")
+	flags.StringVar(&txsTo, "to", "", "The RFC 3339 time the transactions to list were created before, unbounded if not set")
+	flags.StringVar(&creatorHash, "creatorHash", "", "The hex encoded SHA-256 hash of the serialized identity of the creator of the transactions to list")
+	flags.StringVar(&creatorCertFile, "creatorCert", "", "Path to the PEM encoded certificate of the creator of the transactions to list")
+	flags.StringVar(&creatorMSPID, "mspID", "", "The MSP ID of the certificate given by --creatorCert")
+	flags.IntVar(&txsLimit, "limit", 0, "The maximum number of transactions to list, 100 if not set")
+	flags.StringVar(&txsBookmark, "bookmark", "", "The bookmark returned along with the previous page of transactions")
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
@@ -0,0 +1,122 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/scc/qscc"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func getpvtdatastatusCmd(cf *ChannelCmdFactory) *cobra.Command {
+	getpvtdatastatusCmd := &cobra.Command{
+		Use:   "getpvtdatastatus",
+		Short: "get the private data status of a range of blocks of a specified channel.",
+		Long: "get the collections with missing private data, whether the peer is eligible for them, " +
+			"and whether the private data was purged, for a range of blocks of a specified channel. " +
+			"Requires '-c', '--startBlock' and '--endBlock'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getpvtdatastatus(cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"startBlock",
+		"endBlock",
+	}
+	attachFlags(getpvtdatastatusCmd, flagList)
+
+	return getpvtdatastatusCmd
+}
+
+func (cc *endorserClient) getPvtDataStatus(start, end uint64) (*pb.PvtDataStatusResponse, error) {
+	invocation := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
+			ChaincodeId: &pb.ChaincodeID{Name: "qscc"},
+			Input: &pb.ChaincodeInput{Args: [][]byte{
+				[]byte(qscc.GetPvtDataStatus),
+				[]byte(channelID),
+				[]byte(strconv.FormatUint(start, 10)),
+				[]byte(strconv.FormatUint(end, 10)),
+			}},
+		},
+	}
+
+	c, _ := cc.cf.Signer.Serialize()
+	prop, _, err := utils.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", invocation, c)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot create proposal")
+	}
+
+	signedProp, err := utils.GetSignedProposal(prop, cc.cf.Signer)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot create signed proposal")
+	}
+
+	proposalResp, err := cc.cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed sending proposal")
+	}
+
+	if proposalResp.Response == nil {
+		return nil, errors.New("received empty response")
+	}
+	if proposalResp.Response.Status != 200 {
+		return nil, errors.Errorf("received bad response, status %d: %s", proposalResp.Response.Status, proposalResp.Response.Message)
+	}
+
+	status := &pb.PvtDataStatusResponse{}
+	if err := proto.Unmarshal(proposalResp.Response.Payload, status); err != nil {
+		return nil, errors.Wrap(err, "cannot read qscc response")
+	}
+
+	return status, nil
+}
+
+func getpvtdatastatus(cf *ChannelCmdFactory) error {
+	//the global chainID filled by the "-c" command
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+	if endBlock < startBlock {
+		return errors.Errorf("end block %d must not be smaller than start block %d", endBlock, startBlock)
+	}
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+
+	client := &endorserClient{cf}
+
+	status, err := client.getPvtDataStatus(startBlock, endBlock)
+	if err != nil {
+		return err
+	}
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Private data status: %s\n", string(jsonBytes))
+
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPvtDataStatus(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	mockStatus := &pb.PvtDataStatusResponse{
+		Blocks: []*pb.BlockPvtDataStatus{
+			{
+				BlockNum: 1,
+				Missing: []*pb.MissingPvtData{
+					{TxNum: 0, TxId: "txid", Namespace: "mycc", Collection: "mycollection", Eligible: true},
+				},
+			},
+			{BlockNum: 2, Purged: true},
+		},
+	}
+	mockPayload, err := proto.Marshal(mockStatus)
+	assert.NoError(t, err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response: &pb.Response{
+			Status:  200,
+			Payload: mockPayload,
+		},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := getpvtdatastatusCmd(mockCF)
+	AddFlags(cmd)
+
+	args := []string{"-c", mockChannel, "--startBlock", "1", "--endBlock", "2"}
+	cmd.SetArgs(args)
+
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, uint64(1), startBlock)
+	assert.Equal(t, uint64(2), endBlock)
+}
+
+func TestGetPvtDataStatusBadResponse(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	mockResponse := &pb.ProposalResponse{
+		Response: &pb.Response{
+			Status:  500,
+			Message: "Invalid chain ID",
+		},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := getpvtdatastatusCmd(mockCF)
+	AddFlags(cmd)
+
+	cmd.SetArgs([]string{"-c", mockChannel, "--startBlock", "1", "--endBlock", "2"})
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid chain ID")
+}
+
+func TestGetPvtDataStatusInvalidArgs(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &ChannelCmdFactory{
+		Signer: signer,
+	}
+
+	// missing channel ID
+	cmd := getpvtdatastatusCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--startBlock", "1", "--endBlock", "2"})
+	assert.Error(t, cmd.Execute())
+
+	// end block smaller than start block
+	resetFlags()
+	cmd = getpvtdatastatusCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel, "--startBlock", "2", "--endBlock", "1"})
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must not be smaller than start block")
+}
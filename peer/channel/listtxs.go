@@ -0,0 +1,173 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/scc/qscc"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func listtxsCmd(cf *ChannelCmdFactory) *cobra.Command {
+	listtxsCmd := &cobra.Command{
+		Use:   "listtxs",
+		Short: "list the transactions of a time range of a specified channel.",
+		Long: "list the transactions of a specified channel whose timestamp is between '--from' (inclusive) and '--to' (exclusive), " +
+			"optionally restricted to the transactions created by the identity given by '--creatorHash', " +
+			"or by '--creatorCert' and '--mspID'. The transactions are listed one page at a time, the next page " +
+			"being listed by passing the bookmark of the current page to '--bookmark'. " +
+			"Requires '-c' and the index of the transactions by time to be enabled on the peer.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listtxs(cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"from",
+		"to",
+		"creatorHash",
+		"creatorCert",
+		"mspID",
+		"limit",
+		"bookmark",
+	}
+	attachFlags(listtxsCmd, flagList)
+
+	return listtxsCmd
+}
+
+func (cc *endorserClient) getTransactionsByTime(creatorHash []byte, from, to, bookmark string, limit int) (*pb.TxQueryResponse, error) {
+	args := [][]byte{[]byte(qscc.GetTransactionsByTime), []byte(channelID)}
+	if creatorHash != nil {
+		args = [][]byte{[]byte(qscc.GetTransactionsByCreator), []byte(channelID), []byte(hex.EncodeToString(creatorHash))}
+	}
+	args = append(args, []byte(from), []byte(to), []byte(bookmark))
+	if limit > 0 {
+		args = append(args, []byte(strconv.Itoa(limit)))
+	}
+	invocation := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
+			ChaincodeId: &pb.ChaincodeID{Name: "qscc"},
+			Input:       &pb.ChaincodeInput{Args: args},
+		},
+	}
+
+	c, _ := cc.cf.Signer.Serialize()
+	prop, _, err := utils.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", invocation, c)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot create proposal")
+	}
+
+	signedProp, err := utils.GetSignedProposal(prop, cc.cf.Signer)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot create signed proposal")
+	}
+
+	proposalResp, err := cc.cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed sending proposal")
+	}
+
+	if proposalResp.Response == nil {
+		return nil, errors.New("received empty response")
+	}
+	if proposalResp.Response.Status != 200 {
+		return nil, errors.Errorf("received bad response, status %d: %s", proposalResp.Response.Status, proposalResp.Response.Message)
+	}
+
+	txs := &pb.TxQueryResponse{}
+	if err := proto.Unmarshal(proposalResp.Response.Payload, txs); err != nil {
+		return nil, errors.Wrap(err, "cannot read qscc response")
+	}
+
+	return txs, nil
+}
+
+// creatorHashFromFlags returns the hash of the creator given on the command line, nil if none is given.
+// The hash of a certificate is the SHA-256 hash of the serialized identity made of the MSP ID and the PEM encoded certificate
+func creatorHashFromFlags() ([]byte, error) {
+	if creatorHash != "" && creatorCertFile != "" {
+		return nil, errors.New("only one of --creatorHash and --creatorCert may be supplied")
+	}
+	if creatorHash != "" {
+		hash, err := hex.DecodeString(creatorHash)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, errors.Errorf("invalid creator hash %s, the hex encoded SHA-256 hash of a serialized identity is expected", creatorHash)
+		}
+		return hash, nil
+	}
+	if creatorCertFile == "" {
+		return nil, nil
+	}
+	if creatorMSPID == "" {
+		return nil, errors.New("must supply the MSP ID of the creator certificate with --mspID")
+	}
+	cert, err := ioutil.ReadFile(creatorCertFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read creator certificate")
+	}
+	identity, err := proto.Marshal(&msp.SerializedIdentity{Mspid: creatorMSPID, IdBytes: cert})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot serialize creator identity")
+	}
+	hash := sha256.Sum256(identity)
+	return hash[:], nil
+}
+
+func listtxs(cf *ChannelCmdFactory) error {
+	//the global chainID filled by the "-c" command
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+	hash, err := creatorHashFromFlags()
+	if err != nil {
+		return err
+	}
+
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+
+	client := &endorserClient{cf}
+
+	txs, err := client.getTransactionsByTime(hash, txsFrom, txsTo, txsBookmark, txsLimit)
+	if err != nil {
+		return err
+	}
+	jsonBytes, err := json.Marshal(txs)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Transactions: %s\n", string(jsonBytes))
+	if txs.IndexedFromBlock > 0 {
+		fmt.Printf("The transactions of the blocks before block %d are not indexed\n", txs.IndexedFromBlock)
+	}
+	if txs.Bookmark != "" {
+		fmt.Printf("More transactions are available with --bookmark %s\n", txs.Bookmark)
+	}
+
+	return nil
+}
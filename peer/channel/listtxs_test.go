@@ -0,0 +1,144 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListTxs(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	mockTxs := &pb.TxQueryResponse{
+		Transactions: []*pb.IndexedTransaction{
+			{TxId: "txid1", BlockNum: 1, TxNum: 0},
+			{TxId: "txid2", BlockNum: 1, TxNum: 1, ValidationCode: pb.TxValidationCode_MVCC_READ_CONFLICT},
+		},
+		Bookmark:         "6d01",
+		IndexedFromBlock: 1,
+	}
+	mockPayload, err := proto.Marshal(mockTxs)
+	assert.NoError(t, err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response: &pb.Response{
+			Status:  200,
+			Payload: mockPayload,
+		},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := listtxsCmd(mockCF)
+	AddFlags(cmd)
+
+	hash := sha256.Sum256([]byte("creator"))
+	args := []string{"-c", mockChannel, "--from", "2018-01-01T00:00:00Z", "--to", "2018-01-02T00:00:00Z",
+		"--creatorHash", hex.EncodeToString(hash[:]), "--limit", "2", "--bookmark", "6d00"}
+	cmd.SetArgs(args)
+
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "2018-01-01T00:00:00Z", txsFrom)
+	assert.Equal(t, "2018-01-02T00:00:00Z", txsTo)
+	assert.Equal(t, 2, txsLimit)
+	assert.Equal(t, "6d00", txsBookmark)
+}
+
+func TestListTxsBadResponse(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	mockResponse := &pb.ProposalResponse{
+		Response: &pb.Response{
+			Status:  500,
+			Message: "the transactions are not indexed by time",
+		},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := listtxsCmd(mockCF)
+	AddFlags(cmd)
+
+	cmd.SetArgs([]string{"-c", mockChannel})
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the transactions are not indexed by time")
+}
+
+func TestListTxsInvalidArgs(t *testing.T) {
+	InitMSP()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &ChannelCmdFactory{
+		Signer: signer,
+	}
+
+	for _, args := range [][]string{
+		// missing channel ID
+		{"--from", "2018-01-01T00:00:00Z"},
+		{"-c", mockChannel, "--creatorHash", "not hex"},
+		{"-c", mockChannel, "--creatorHash", "abcd"},
+		{"-c", mockChannel, "--creatorHash", "abcd", "--creatorCert", "cert.pem", "--mspID", "SampleOrg"},
+		{"-c", mockChannel, "--creatorCert", "cert.pem"},
+		{"-c", mockChannel, "--creatorCert", "missing.pem", "--mspID", "SampleOrg"},
+	} {
+		resetFlags()
+		cmd := listtxsCmd(mockCF)
+		AddFlags(cmd)
+		cmd.SetArgs(args)
+		assert.Error(t, cmd.Execute(), "listtxs should have failed with arguments %q", args)
+	}
+}
+
+func TestCreatorHashFromCert(t *testing.T) {
+	resetFlags()
+	dir, err := ioutil.TempDir("", "listtxs")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cert := []byte("-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n")
+	creatorCertFile = filepath.Join(dir, "cert.pem")
+	assert.NoError(t, ioutil.WriteFile(creatorCertFile, cert, 0644))
+	creatorMSPID = "SampleOrg"
+
+	identity, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "SampleOrg", IdBytes: cert})
+	assert.NoError(t, err)
+	expectedHash := sha256.Sum256(identity)
+	hash, err := creatorHashFromFlags()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedHash[:], hash)
+}
@@ -102,3 +102,15 @@ func (m *mockAdminClient) SetModuleLogLevel(ctx context.Context, env *cb.Envelop
 func (m *mockAdminClient) RevertLogLevels(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*empty.Empty, error) {
 	return &empty.Empty{}, m.err
 }
+
+func (m *mockAdminClient) GetOrdererHealth(ctx context.Context, env *cb.Envelope, opts ...grpc.CallOption) (*pb.OrdererHealthResponse, error) {
+	return &pb.OrdererHealthResponse{}, m.err
+}
+
+func (m *mockAdminClient) GetGossipState(ctx context.Context, env *cb.Envelope, opts ...grpc.CallOption) (*pb.GossipStateResponse, error) {
+	return &pb.GossipStateResponse{}, m.err
+}
+
+func (m *mockAdminClient) YieldLeadership(ctx context.Context, env *cb.Envelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, m.err
+}
@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var backfillChannelID string
+
+func backfillTxIndexCmd() *cobra.Command {
+	nodeBackfillTxIndexCmd.Flags().StringVarP(&backfillChannelID, "channelID", "c", "", "The channel whose index of the transactions by time is backfilled")
+	return nodeBackfillTxIndexCmd
+}
+
+var nodeBackfillTxIndexCmd = &cobra.Command{
+	Use:   "backfill-txindex",
+	Short: "Backfills the index of the transactions by time of a channel.",
+	Long: `Indexes by time, and by creator and time, the transactions of a channel committed before the index ` +
+		`was enabled through ledger.blockchain.enableTxTimeIndex. The node must be stopped.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return backfillTxIndex(backfillChannelID, os.Stdout)
+	},
+}
+
+// txTimeIndexBackfiller is implemented by the block stores that index the transactions by time
+type txTimeIndexBackfiller interface {
+	BackfillTxTimeIndex() (uint64, error)
+}
+
+// backfillTxIndex backfills the index of the transactions by time of the given channel and writes the outcome to out
+func backfillTxIndex(channelID string, out io.Writer) error {
+	if channelID == "" {
+		return errors.New("must supply the channel ID")
+	}
+	if !ledgerconfig.IsTxTimeIndexEnabled() {
+		return errors.New("the index of the transactions by time is not enabled, it is enabled through ledger.blockchain.enableTxTimeIndex")
+	}
+	exists, _, err := util.FileExists(filepath.Join(ledgerconfig.GetBlockStorePath(), fsblkstorage.ChainsDir, channelID))
+	if err != nil {
+		return errors.Wrapf(err, "failed checking the block store of channel %s", channelID)
+	}
+	if !exists {
+		return errors.Errorf("no block store found for channel %s", channelID)
+	}
+
+	// the block store is opened through the ledger storage so as to be indexed as when the node runs
+	provider := ledgerstorage.NewProvider()
+	defer provider.Close()
+	store, err := provider.Open(channelID)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed opening the block store of channel %s", channelID))
+	}
+	defer store.Shutdown()
+	backfiller, ok := store.BlockStore.(txTimeIndexBackfiller)
+	if !ok {
+		return errors.Errorf("the block store of channel %s does not support backfilling the index of the transactions by time", channelID)
+	}
+	numBlocks, err := backfiller.BackfillTxTimeIndex()
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed backfilling the index of the transactions by time of channel %s", channelID))
+	}
+	fmt.Fprintf(out, "Channel %s: %d blocks backfilled\n", channelID, numBlocks)
+	return nil
+}
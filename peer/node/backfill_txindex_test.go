@@ -0,0 +1,58 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackfillTxIndex(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "backfilltxindex")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	viper.Set("peer.fileSystemPath", tempDir)
+	defer viper.Set("peer.fileSystemPath", "")
+
+	out := &bytes.Buffer{}
+	assert.EqualError(t, backfillTxIndex("", out), "must supply the channel ID")
+	assert.EqualError(t, backfillTxIndex("channel1", out),
+		"the index of the transactions by time is not enabled, it is enabled through ledger.blockchain.enableTxTimeIndex")
+
+	// the blocks are committed before the index is enabled
+	provider := ledgerstorage.NewProvider()
+	store, err := provider.Open("channel1")
+	assert.NoError(t, err)
+	for _, block := range testutil.ConstructTestBlocks(t, 5) {
+		assert.NoError(t, store.AddBlock(block))
+	}
+	store.Shutdown()
+	provider.Close()
+
+	viper.Set("ledger.blockchain.enableTxTimeIndex", true)
+	defer viper.Set("ledger.blockchain.enableTxTimeIndex", false)
+	assert.EqualError(t, backfillTxIndex("channel2", out), "no block store found for channel channel2")
+	assert.NoError(t, backfillTxIndex("channel1", out))
+	assert.Equal(t, "Channel channel1: 5 blocks backfilled\n", out.String())
+
+	out.Reset()
+	assert.NoError(t, backfillTxIndex("channel1", out))
+	assert.Equal(t, "Channel channel1: 0 blocks backfilled\n", out.String())
+}
+
+func TestBackfillTxIndexCmd(t *testing.T) {
+	cmd := backfillTxIndexCmd()
+	cmd.SetArgs([]string{"extra"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected")
+}
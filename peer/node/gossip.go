@@ -0,0 +1,190 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/peer/common"
+	common2 "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+var gossipOutput string
+
+func gossipCmd() *cobra.Command {
+	nodeGossipCmd.Flags().StringVar(&gossipOutput, "output", outputTable, "Output format, either table or json")
+	return nodeGossipCmd
+}
+
+var nodeGossipCmd = &cobra.Command{
+	Use:   "gossip",
+	Short: "Returns the gossip state of the node.",
+	Long:  `Returns the gossip membership, the channels, the known identities and the connections of the running node.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return gossipState(gossipOutput, os.Stdout)
+	},
+}
+
+func gossipState(output string, w io.Writer) error {
+	if output != outputTable && output != outputJSON {
+		return errors.Errorf("unknown output format %s, expected %s or %s", output, outputTable, outputJSON)
+	}
+
+	adminClient, err := common.GetAdminClient()
+	if err != nil {
+		return err
+	}
+	signer, err := common.GetDefaultSignerFnc()
+	if err != nil {
+		return errors.Errorf("failed obtaining default signer: %v", err)
+	}
+
+	localSigner := crypto.NewSignatureHeaderCreator(signer)
+	env, err := utils.CreateSignedEnvelope(common2.HeaderType_PEER_ADMIN_OPERATION, "", localSigner, &pb.AdminOperation{}, 0, 0)
+	if err != nil {
+		return errors.Errorf("failed signing: %v", err)
+	}
+
+	state, err := adminClient.GetGossipState(context.Background(), env)
+	if err != nil {
+		return errors.Errorf("failed retrieving the gossip state from the local peer: %s", err)
+	}
+
+	if output == outputJSON {
+		return printGossipStateJSON(state, w)
+	}
+	return printGossipStateTable(state, w)
+}
+
+func printGossipStateJSON(state proto.Message, w io.Writer) error {
+	marshaler := &jsonpb.Marshaler{Indent: "  ", OrigName: true, EmitDefaults: true}
+	if err := marshaler.Marshal(w, state); err != nil {
+		return errors.Wrap(err, "failed marshaling the gossip state")
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+func printGossipStateTable(state *pb.GossipStateResponse, w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "MEMBERSHIP")
+	fmt.Fprintln(tw, "PKI-ID\tENDPOINT\tINTERNAL ENDPOINT\tSTATUS")
+	if state.Self != nil {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", state.Self.PkiId, state.Self.Endpoint, state.Self.InternalEndpoint, "self")
+	}
+	for _, member := range state.Alive {
+		status := "alive"
+		if member.Unreachable {
+			status = "alive (unreachable)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", member.PkiId, member.Endpoint, member.InternalEndpoint, status)
+	}
+	for _, member := range state.Dead {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", member.PkiId, member.Endpoint, member.InternalEndpoint, "dead")
+	}
+
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "EXTERNAL ENDPOINT REACHABILITY")
+	fmt.Fprintln(tw, "ENDPOINT\tSTATUS\tLAST VERIFIED\tERRORS")
+	if r := state.Reachability; r != nil {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", orNone(r.Endpoint), r.Status, formatTimestamp(r.LastVerified), orNone(strings.Join(r.Errors, "; ")))
+	}
+
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "CHANNELS")
+	fmt.Fprintln(tw, "CHANNEL\tHEIGHT\tLEADER\tIS LEADER\tPEERS")
+	for _, channel := range state.Channels {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%t\t%d\n", channel.ChannelId, channel.LedgerHeight, orNone(channel.LeaderPkiId), channel.IsLeader, len(channel.Peers))
+	}
+
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "CHANNEL PEERS")
+	fmt.Fprintln(tw, "CHANNEL\tPKI-ID\tENDPOINT\tHEIGHT")
+	for _, channel := range state.Channels {
+		for _, peer := range channel.Peers {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\n", channel.ChannelId, peer.PkiId, peer.Endpoint, peer.LedgerHeight)
+		}
+	}
+
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "LEADERSHIP EVENTS")
+	fmt.Fprintln(tw, "CHANNEL\tEVENT\tTIME")
+	for _, channel := range state.Channels {
+		for _, event := range channel.LeadershipEvents {
+			description := "stopped being leader"
+			if event.IsLeader {
+				description = "became leader"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", channel.ChannelId, description, formatTimestamp(event.Time))
+		}
+	}
+
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "IDENTITIES")
+	fmt.Fprintln(tw, "PKI-ID\tORGANIZATION\tEXPIRATION")
+	for _, identity := range state.Identities {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", identity.PkiId, identity.Organization, formatTimestamp(identity.Expiration))
+	}
+
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "CONNECTIONS")
+	fmt.Fprintln(tw, "PKI-ID\tENDPOINT\tDIRECTION\tESTABLISHED\tSENT\tRECEIVED\tDROPPED")
+	for _, conn := range state.Connections {
+		direction := "inbound"
+		if conn.Outbound {
+			direction = "outbound"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%d\t%d\n", conn.PkiId, conn.Endpoint, direction,
+			formatTimestamp(conn.Established), conn.SentMsgs, conn.ReceivedMsgs, conn.DroppedMsgs)
+	}
+
+	return tw.Flush()
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func formatTimestamp(ts *timestamp.Timestamp) string {
+	if ts == nil {
+		return "-"
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,135 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/hyperledger/fabric/core/admin"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/gossip/api"
+	gcomm "github.com/hyperledger/fabric/gossip/comm"
+	gcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/service"
+	"github.com/hyperledger/fabric/msp"
+	common2 "github.com/hyperledger/fabric/peer/common"
+	"github.com/hyperledger/fabric/peer/mocks"
+	gproto "github.com/hyperledger/fabric/protos/gossip"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockGossipService struct {
+	state    *service.GossipState
+	yieldErr error
+	yielded  []string
+}
+
+func (*mockGossipService) OrdererHealth(chainID string) ([]blocksprovider.EndpointHealth, error) {
+	return nil, nil
+}
+
+func (gs *mockGossipService) Inspect() *service.GossipState {
+	return gs.state
+}
+
+func (gs *mockGossipService) Reachability() gossip.ReachabilityStatus {
+	return gs.state.Reachability
+}
+
+func (gs *mockGossipService) YieldLeadership(chainID string) error {
+	gs.yielded = append(gs.yielded, chainID)
+	return gs.yieldErr
+}
+
+func TestGossipState(t *testing.T) {
+	signer := &mocks.Signer{}
+	common2.GetDefaultSignerFnc = func() (msp.SigningIdentity, error) {
+		return signer, nil
+	}
+	viper.Set("peer.address", "localhost:7074")
+	peerServer, err := peer.NewPeerServer("localhost:7074", comm.ServerConfig{})
+	if err != nil {
+		t.Fatalf("Failed to create peer server (%s)", err)
+	}
+	gs := &mockGossipService{
+		state: &service.GossipState{
+			Self:  discovery.NetworkMember{PKIid: gcommon.PKIidType("p0"), Endpoint: "p0:7051"},
+			Alive: []discovery.NetworkMember{{PKIid: gcommon.PKIidType("p1"), Endpoint: "p1:7051", Unreachable: true}},
+			Dead:  []discovery.NetworkMember{{PKIid: gcommon.PKIidType("p2"), Endpoint: "p2:7051"}},
+			Channels: []service.ChannelState{
+				{
+					ChannelID:    "mychannel",
+					LedgerHeight: 10,
+					Leader:       gcommon.PKIidType("p0"),
+					IsLeader:     true,
+					LeadershipEvents: []service.LeadershipEvent{
+						{IsLeader: true, Time: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+					},
+					Peers: []discovery.NetworkMember{
+						{PKIid: gcommon.PKIidType("p1"), Endpoint: "p1:7051", Properties: &gproto.Properties{LedgerHeight: 9}},
+					},
+				},
+			},
+			Identities: api.PeerIdentitySet{
+				{PKIId: gcommon.PKIidType("p0"), Organization: api.OrgIdentityType("Org1MSP"), Expiration: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			Connections: []gcomm.ConnectionStats{
+				{PKIID: gcommon.PKIidType("p1"), Endpoint: "p1:7051", Outbound: true, SentMsgs: 5},
+			},
+			Reachability: gossip.ReachabilityStatus{
+				Endpoint:     "p0:7051",
+				Reachability: gossip.Unreachable,
+				LastVerified: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				Errors:       []string{"p1:7051: connection refused"},
+			},
+		},
+	}
+	pb.RegisterAdminServer(peerServer.Server(), admin.NewAdminServer(&mockEvaluator{}, gs))
+	go peerServer.Start()
+	defer peerServer.Stop()
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, gossipState(outputTable, buf))
+	output := buf.String()
+	assert.Contains(t, output, "MEMBERSHIP")
+	assert.Regexp(t, `7031\s+p1:7051\s+alive \(unreachable\)`, output)
+	assert.Regexp(t, `p0:7051\s+UNREACHABLE\s+2020-01-01T00:00:00Z\s+p1:7051: connection refused`, output)
+	assert.Regexp(t, `7032\s+p2:7051\s+dead`, output)
+	assert.Regexp(t, `mychannel\s+10\s+7030\s+true\s+1`, output)
+	assert.Regexp(t, `mychannel\s+7031\s+p1:7051\s+9`, output)
+	assert.Regexp(t, `mychannel\s+became leader\s+2020-01-01T00:00:00Z`, output)
+	assert.Regexp(t, `7030\s+Org1MSP\s+2030-01-01T00:00:00Z`, output)
+	assert.Regexp(t, `7031\s+p1:7051\s+outbound\s+-\s+5\s+0\s+0`, output)
+
+	buf.Reset()
+	assert.NoError(t, gossipState(outputJSON, buf))
+	state := &pb.GossipStateResponse{}
+	assert.NoError(t, jsonpb.Unmarshal(buf, state))
+	assert.Equal(t, "7030", state.Self.PkiId)
+	assert.Len(t, state.Channels, 1)
+	assert.Equal(t, "7030", state.Channels[0].LeaderPkiId)
+
+	assert.EqualError(t, gossipState("yaml", buf), "unknown output format yaml, expected table or json")
+}
+
+func TestGossipStateNoPeer(t *testing.T) {
+	signer := &mocks.Signer{}
+	common2.GetDefaultSignerFnc = func() (msp.SigningIdentity, error) {
+		return signer, nil
+	}
+	viper.Set("peer.address", "")
+	assert.Error(t, gossipState(outputTable, &bytes.Buffer{}))
+}
@@ -34,6 +34,10 @@ var logger = flogging.MustGetLogger("nodeCmd")
 func Cmd() *cobra.Command {
 	nodeCmd.AddCommand(startCmd())
 	nodeCmd.AddCommand(statusCmd())
+	nodeCmd.AddCommand(gossipCmd())
+	nodeCmd.AddCommand(yieldCmd())
+	nodeCmd.AddCommand(verifyBlockstoreCmd())
+	nodeCmd.AddCommand(backfillTxIndexCmd())
 
 	return nodeCmd
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric/common/deliver"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/viperutil"
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/admin"
@@ -27,14 +28,17 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/accesscontrol"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	"github.com/hyperledger/fabric/core/endorser"
 	authHandler "github.com/hyperledger/fabric/core/handlers/auth"
 	"github.com/hyperledger/fabric/core/handlers/library"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/scc"
 	"github.com/hyperledger/fabric/events/producer"
 	common2 "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/gossip"
 	"github.com/hyperledger/fabric/gossip/service"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
@@ -107,12 +111,34 @@ func serve(args []string) error {
 
 	logger.Infof("Starting %s", version.GetInfo())
 
+	// metrics are disabled unless metrics.enabled is set, in which case they're reported
+	// through the configured reporter. The prom reporter serves until it's shut down,
+	// so the reporter is started in the background.
+	if err := metrics.Init(metrics.NewOpts()); err != nil {
+		return errors.WithMessage(err, "failed initializing metrics")
+	}
+	go func() {
+		if err := metrics.Start(); err != nil {
+			logger.Errorf("Failed starting metrics reporter: %s", err)
+		}
+	}()
+	defer metrics.Shutdown()
+
 	//startup aclmgmt with default ACL providers (resource based and default 1.0 policies based).
 	//Users can pass in their own ACLProvider to RegisterACLProvider (currently unit tests do this)
 	aclmgmt.RegisterACLProvider(nil)
 
+	// the handlers are loaded before the ledgers are initialized, as the commit listeners
+	// have to be notified of the blocks committed from the ledgers initialization on
+	libConf := library.Config{}
+	if err := viperutil.EnhancedExactUnmarshalKey("peer.handlers", &libConf); err != nil {
+		return errors.WithMessage(err, "could not load YAML config")
+	}
+	handlerRegistry := library.InitRegistry(libConf)
+
 	//initialize resource management exit
-	ledgermgmt.Initialize(peer.ConfigTxProcessors)
+	commitListeners := handlerRegistry.Lookup(library.CommitListener).([]ledger.CommitListener)
+	ledgermgmt.Initialize(peer.ConfigTxProcessors, commitListeners...)
 
 	// Parameter overrides must be processed before any parameters are
 	// cached. Failures to cache cause the server to terminate immediately.
@@ -211,11 +237,7 @@ func serve(args []string) error {
 			PeerSupport: peer.DefaultSupport,
 		},
 	)
-	libConf := library.Config{}
-	if err = viperutil.EnhancedExactUnmarshalKey("peer.handlers", &libConf); err != nil {
-		return errors.WithMessage(err, "could not load YAML config")
-	}
-	authFilters := library.InitRegistry(libConf).Lookup(library.Auth).([]authHandler.Filter)
+	authFilters := handlerRegistry.Lookup(library.Auth).([]authHandler.Filter)
 	auth := authHandler.ChainFilters(serverEndorser, authFilters...)
 	// Register the Endorser server
 	pb.RegisterEndorserServer(peerServer.Server(), auth)
@@ -596,7 +618,43 @@ func startAdminServer(peerListenAddr string, peerServer *grpc.Server) {
 		}()
 	}
 
-	pb.RegisterAdminServer(gRPCService, admin.NewAdminServer(adminPolicy))
+	pb.RegisterAdminServer(gRPCService, admin.NewAdminServer(adminPolicy, &gossipServiceProvider{}))
+}
+
+// gossipServiceProvider forwards to the gossip service,
+// which is initialized only after the admin server is started
+type gossipServiceProvider struct{}
+
+func (*gossipServiceProvider) OrdererHealth(chainID string) ([]blocksprovider.EndpointHealth, error) {
+	gs := service.GetGossipService()
+	if gs == nil {
+		return nil, errors.New("gossip not initialized")
+	}
+	return gs.OrdererHealth(chainID)
+}
+
+func (*gossipServiceProvider) Inspect() *service.GossipState {
+	gs := service.GetGossipService()
+	if gs == nil {
+		return &service.GossipState{}
+	}
+	return gs.Inspect()
+}
+
+func (*gossipServiceProvider) YieldLeadership(chainID string) error {
+	gs := service.GetGossipService()
+	if gs == nil {
+		return errors.New("gossip not initialized")
+	}
+	return gs.YieldLeadership(chainID)
+}
+
+func (*gossipServiceProvider) Reachability() gossip.ReachabilityStatus {
+	gs := service.GetGossipService()
+	if gs == nil {
+		return gossip.ReachabilityStatus{}
+	}
+	return gs.Reachability()
 }
 
 func initializeEventsServerConfig(mutualTLS bool) *producer.EventsServerConfig {
@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var verifyChannelID string
+
+func verifyBlockstoreCmd() *cobra.Command {
+	nodeVerifyBlockstoreCmd.Flags().StringVarP(&verifyChannelID, "channelID", "c", "", "The channel whose block store is verified, all the channels of the peer if not set")
+	return nodeVerifyBlockstoreCmd
+}
+
+var nodeVerifyBlockstoreCmd = &cobra.Command{
+	Use:   "verify-blockstore",
+	Short: "Verifies the block files of the node.",
+	Long: `Verifies the block files of the channels of the node against the checksums of the blocks and the hashes of the block headers, ` +
+		`and reports the corruptions without modifying the block files. The node must be stopped.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return verifyBlockstore(verifyChannelID, os.Stdout)
+	},
+}
+
+// verifyBlockstore verifies the block store of the given channel, or of all the channels if none is given,
+// writes the outcome to out, and returns an error if any block store is corrupted
+func verifyBlockstore(channelID string, out io.Writer) error {
+	conf := fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize())
+	channelIDs := []string{channelID}
+	if channelID == "" {
+		chainsDir := filepath.Join(ledgerconfig.GetBlockStorePath(), fsblkstorage.ChainsDir)
+		exists, _, err := util.FileExists(chainsDir)
+		if err != nil {
+			return errors.Wrap(err, "failed listing the channels of the node")
+		}
+		channelIDs = nil
+		if exists {
+			if channelIDs, err = util.ListSubdirs(chainsDir); err != nil {
+				return errors.Wrap(err, "failed listing the channels of the node")
+			}
+		}
+		if len(channelIDs) == 0 {
+			fmt.Fprintln(out, "No block store found")
+			return nil
+		}
+	}
+
+	var corrupted []string
+	for _, id := range channelIDs {
+		report, err := fsblkstorage.VerifyBlockStore(conf, id)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed verifying the block store of channel %s", id))
+		}
+		if report.NumBlocks == 0 {
+			fmt.Fprintf(out, "Channel %s: no block found\n", id)
+		} else {
+			fmt.Fprintf(out, "Channel %s: %d blocks verified, from block %d to block %d, %d of them stored without checksum\n",
+				id, report.NumBlocks, report.FirstBlockNum, report.LastBlockNum, report.NumBlocksWithoutChecksum)
+		}
+		for _, problem := range report.Problems {
+			fmt.Fprintf(out, "  %s\n", problem)
+		}
+		if report.Corrupted() {
+			corrupted = append(corrupted, id)
+		}
+	}
+	if len(corrupted) > 0 {
+		return errors.Errorf("corruptions detected in the block store of channels %s", strings.Join(corrupted, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyBlockstore(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "verifyblockstore")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	viper.Set("peer.fileSystemPath", tempDir)
+	defer viper.Set("peer.fileSystemPath", "")
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, verifyBlockstore("", out))
+	assert.Equal(t, "No block store found\n", out.String())
+
+	conf := fsblkstorage.NewConfWithCompression(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize(), "snappy")
+	provider := fsblkstorage.NewProvider(conf, &blkstorage.IndexConfig{AttrsToIndex: []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum}})
+	for _, channelID := range []string{"channel1", "channel2"} {
+		store, err := provider.OpenBlockStore(channelID)
+		assert.NoError(t, err)
+		for _, block := range testutil.ConstructTestBlocks(t, 5) {
+			assert.NoError(t, store.AddBlock(block))
+		}
+		store.Shutdown()
+	}
+	provider.Close()
+
+	out.Reset()
+	assert.NoError(t, verifyBlockstore("", out))
+	assert.Equal(t, "Channel channel1: 5 blocks verified, from block 0 to block 4, 0 of them stored without checksum\n"+
+		"Channel channel2: 5 blocks verified, from block 0 to block 4, 0 of them stored without checksum\n", out.String())
+
+	// the last byte of the block file of channel2 is flipped
+	filePath := filepath.Join(ledgerconfig.GetBlockStorePath(), fsblkstorage.ChainsDir, "channel2", "blockfile_000000")
+	fileBytes, err := ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+	fileBytes[len(fileBytes)-1] ^= 0xff
+	assert.NoError(t, ioutil.WriteFile(filePath, fileBytes, 0644))
+
+	out.Reset()
+	assert.NoError(t, verifyBlockstore("channel1", out))
+	assert.Equal(t, "Channel channel1: 5 blocks verified, from block 0 to block 4, 0 of them stored without checksum\n", out.String())
+
+	out.Reset()
+	assert.EqualError(t, verifyBlockstore("channel2", out), "corruptions detected in the block store of channels channel2")
+	assert.Contains(t, out.String(), "Channel channel2: 4 blocks verified, from block 0 to block 3, 0 of them stored without checksum\n")
+	assert.Contains(t, out.String(), "stored block does not match its checksum")
+
+	err = verifyBlockstore("channel3", out)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed verifying the block store of channel channel3")
+}
+
+func TestVerifyBlockstoreCmd(t *testing.T) {
+	cmd := verifyBlockstoreCmd()
+	cmd.SetArgs([]string{"extra"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected")
+}
@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/peer/common"
+	common2 "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var yieldChannelID string
+
+func yieldCmd() *cobra.Command {
+	nodeYieldCmd.Flags().StringVarP(&yieldChannelID, "channelID", "c", "", "The channel in which the node relinquishes its leadership")
+	return nodeYieldCmd
+}
+
+var nodeYieldCmd = &cobra.Command{
+	Use:   "yield",
+	Short: "Relinquishes the leadership of the node in a channel.",
+	Long:  `Makes the running node step down from being the leader peer of its organization in a channel, so that another peer of the organization takes over.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		if yieldChannelID == "" {
+			return errors.New("must supply channel ID")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return yieldLeadership(yieldChannelID)
+	},
+}
+
+func yieldLeadership(channelID string) error {
+	adminClient, err := common.GetAdminClient()
+	if err != nil {
+		return err
+	}
+	signer, err := common.GetDefaultSignerFnc()
+	if err != nil {
+		return errors.Errorf("failed obtaining default signer: %v", err)
+	}
+
+	op := &pb.AdminOperation{
+		Content: &pb.AdminOperation_YieldLeadershipReq{
+			YieldLeadershipReq: &pb.YieldLeadershipRequest{ChannelId: channelID},
+		},
+	}
+	localSigner := crypto.NewSignatureHeaderCreator(signer)
+	env, err := utils.CreateSignedEnvelope(common2.HeaderType_PEER_ADMIN_OPERATION, "", localSigner, op, 0, 0)
+	if err != nil {
+		return errors.Errorf("failed signing: %v", err)
+	}
+
+	if _, err := adminClient.YieldLeadership(context.Background(), env); err != nil {
+		return errors.Errorf("failed yielding leadership in channel %s: %s", channelID, err)
+	}
+	logger.Infof("Yielded leadership in channel %s", channelID)
+	return nil
+}
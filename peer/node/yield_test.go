@@ -0,0 +1,56 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/admin"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/msp"
+	common2 "github.com/hyperledger/fabric/peer/common"
+	"github.com/hyperledger/fabric/peer/mocks"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYieldLeadership(t *testing.T) {
+	signer := &mocks.Signer{}
+	common2.GetDefaultSignerFnc = func() (msp.SigningIdentity, error) {
+		return signer, nil
+	}
+	viper.Set("peer.address", "localhost:7075")
+	peerServer, err := peer.NewPeerServer("localhost:7075", comm.ServerConfig{})
+	if err != nil {
+		t.Fatalf("Failed to create peer server (%s)", err)
+	}
+	gs := &mockGossipService{}
+	pb.RegisterAdminServer(peerServer.Server(), admin.NewAdminServer(&mockEvaluator{}, gs))
+	go peerServer.Start()
+	defer peerServer.Stop()
+
+	assert.NoError(t, yieldLeadership("mychannel"))
+	assert.Equal(t, []string{"mychannel"}, gs.yielded)
+
+	gs.yieldErr = errors.New("peer isn't the leader in channel mychannel")
+	err = yieldLeadership("mychannel")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed yielding leadership in channel mychannel")
+	assert.Contains(t, err.Error(), "peer isn't the leader in channel mychannel")
+}
+
+func TestYieldCmd(t *testing.T) {
+	cmd := yieldCmd()
+	cmd.SetArgs([]string{"extra"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected")
+
+	cmd.SetArgs([]string{})
+	assert.EqualError(t, cmd.Execute(), "must supply channel ID")
+}
@@ -16,6 +16,7 @@ It has these top-level messages:
 	CollectionConfigPackage
 	CollectionConfig
 	StaticCollectionConfig
+	CollectionEndorsementPolicy
 	CollectionPolicyConfig
 	CollectionCriteria
 	LastConfig
@@ -199,6 +200,10 @@ type StaticCollectionConfig struct {
 	// The maximum number of peers that private data will be sent to
 	// upon endorsement. This number has to be bigger than required_peer_count.
 	MaximumPeerCount int32 `protobuf:"varint,4,opt,name=maximum_peer_count,json=maximumPeerCount" json:"maximum_peer_count,omitempty"`
+	// The endorsement policy transactions writing private data to this
+	// collection must satisfy, in addition to the endorsement policy of the
+	// chaincode. If unset, only the endorsement policy of the chaincode applies.
+	EndorsementPolicy *CollectionEndorsementPolicy `protobuf:"bytes,5,opt,name=endorsement_policy,json=endorsementPolicy" json:"endorsement_policy,omitempty"`
 }
 
 func (m *StaticCollectionConfig) Reset()                    { *m = StaticCollectionConfig{} }
@@ -234,6 +239,134 @@ func (m *StaticCollectionConfig) GetMaximumPeerCount() int32 {
 	return 0
 }
 
+func (m *StaticCollectionConfig) GetEndorsementPolicy() *CollectionEndorsementPolicy {
+	if m != nil {
+		return m.EndorsementPolicy
+	}
+	return nil
+}
+
+// CollectionEndorsementPolicy defines the endorsement policy of a collection;
+// it is either a signature policy, or a reference to a policy residing in
+// the config of the channel.
+type CollectionEndorsementPolicy struct {
+	// Types that are valid to be assigned to Payload:
+	//	*CollectionEndorsementPolicy_SignaturePolicy
+	//	*CollectionEndorsementPolicy_ChannelConfigPolicyReference
+	Payload isCollectionEndorsementPolicy_Payload `protobuf_oneof:"payload"`
+}
+
+func (m *CollectionEndorsementPolicy) Reset()                    { *m = CollectionEndorsementPolicy{} }
+func (m *CollectionEndorsementPolicy) String() string            { return proto.CompactTextString(m) }
+func (*CollectionEndorsementPolicy) ProtoMessage()               {}
+func (*CollectionEndorsementPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+type isCollectionEndorsementPolicy_Payload interface {
+	isCollectionEndorsementPolicy_Payload()
+}
+
+type CollectionEndorsementPolicy_SignaturePolicy struct {
+	SignaturePolicy *SignaturePolicyEnvelope `protobuf:"bytes,1,opt,name=signature_policy,json=signaturePolicy,oneof"`
+}
+type CollectionEndorsementPolicy_ChannelConfigPolicyReference struct {
+	ChannelConfigPolicyReference string `protobuf:"bytes,2,opt,name=channel_config_policy_reference,json=channelConfigPolicyReference,oneof"`
+}
+
+func (*CollectionEndorsementPolicy_SignaturePolicy) isCollectionEndorsementPolicy_Payload() {}
+func (*CollectionEndorsementPolicy_ChannelConfigPolicyReference) isCollectionEndorsementPolicy_Payload() {
+}
+
+func (m *CollectionEndorsementPolicy) GetPayload() isCollectionEndorsementPolicy_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *CollectionEndorsementPolicy) GetSignaturePolicy() *SignaturePolicyEnvelope {
+	if x, ok := m.GetPayload().(*CollectionEndorsementPolicy_SignaturePolicy); ok {
+		return x.SignaturePolicy
+	}
+	return nil
+}
+
+func (m *CollectionEndorsementPolicy) GetChannelConfigPolicyReference() string {
+	if x, ok := m.GetPayload().(*CollectionEndorsementPolicy_ChannelConfigPolicyReference); ok {
+		return x.ChannelConfigPolicyReference
+	}
+	return ""
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*CollectionEndorsementPolicy) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _CollectionEndorsementPolicy_OneofMarshaler, _CollectionEndorsementPolicy_OneofUnmarshaler, _CollectionEndorsementPolicy_OneofSizer, []interface{}{
+		(*CollectionEndorsementPolicy_SignaturePolicy)(nil),
+		(*CollectionEndorsementPolicy_ChannelConfigPolicyReference)(nil),
+	}
+}
+
+func _CollectionEndorsementPolicy_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*CollectionEndorsementPolicy)
+	// payload
+	switch x := m.Payload.(type) {
+	case *CollectionEndorsementPolicy_SignaturePolicy:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SignaturePolicy); err != nil {
+			return err
+		}
+	case *CollectionEndorsementPolicy_ChannelConfigPolicyReference:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.ChannelConfigPolicyReference)
+	case nil:
+	default:
+		return fmt.Errorf("CollectionEndorsementPolicy.Payload has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _CollectionEndorsementPolicy_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*CollectionEndorsementPolicy)
+	switch tag {
+	case 1: // payload.signature_policy
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SignaturePolicyEnvelope)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CollectionEndorsementPolicy_SignaturePolicy{msg}
+		return true, err
+	case 2: // payload.channel_config_policy_reference
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Payload = &CollectionEndorsementPolicy_ChannelConfigPolicyReference{x}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _CollectionEndorsementPolicy_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*CollectionEndorsementPolicy)
+	// payload
+	switch x := m.Payload.(type) {
+	case *CollectionEndorsementPolicy_SignaturePolicy:
+		s := proto.Size(x.SignaturePolicy)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CollectionEndorsementPolicy_ChannelConfigPolicyReference:
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(len(x.ChannelConfigPolicyReference)))
+		n += len(x.ChannelConfigPolicyReference)
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
 // Collection policy configuration. Initially, the configuration can only
 // contain a SignaturePolicy. In the future, the SignaturePolicy may be a
 // more general Policy. Instead of containing the actual policy, the
@@ -247,7 +380,7 @@ type CollectionPolicyConfig struct {
 func (m *CollectionPolicyConfig) Reset()                    { *m = CollectionPolicyConfig{} }
 func (m *CollectionPolicyConfig) String() string            { return proto.CompactTextString(m) }
 func (*CollectionPolicyConfig) ProtoMessage()               {}
-func (*CollectionPolicyConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*CollectionPolicyConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 type isCollectionPolicyConfig_Payload interface {
 	isCollectionPolicyConfig_Payload()
@@ -340,7 +473,7 @@ type CollectionCriteria struct {
 func (m *CollectionCriteria) Reset()                    { *m = CollectionCriteria{} }
 func (m *CollectionCriteria) String() string            { return proto.CompactTextString(m) }
 func (*CollectionCriteria) ProtoMessage()               {}
-func (*CollectionCriteria) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*CollectionCriteria) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *CollectionCriteria) GetChannel() string {
 	if m != nil {
@@ -374,6 +507,7 @@ func init() {
 	proto.RegisterType((*CollectionConfigPackage)(nil), "common.CollectionConfigPackage")
 	proto.RegisterType((*CollectionConfig)(nil), "common.CollectionConfig")
 	proto.RegisterType((*StaticCollectionConfig)(nil), "common.StaticCollectionConfig")
+	proto.RegisterType((*CollectionEndorsementPolicy)(nil), "common.CollectionEndorsementPolicy")
 	proto.RegisterType((*CollectionPolicyConfig)(nil), "common.CollectionPolicyConfig")
 	proto.RegisterType((*CollectionCriteria)(nil), "common.CollectionCriteria")
 }
@@ -381,32 +515,37 @@ func init() {
 func init() { proto.RegisterFile("common/collection.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 429 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xc1, 0x6a, 0xdb, 0x40,
-	0x10, 0x86, 0xa3, 0xc6, 0x71, 0xd0, 0xe4, 0x50, 0x77, 0x42, 0x1d, 0x51, 0x4a, 0x1a, 0x4c, 0x0f,
-	0x86, 0x16, 0xa9, 0xa4, 0x6f, 0x10, 0x53, 0x48, 0x69, 0xa0, 0x46, 0xb9, 0xe5, 0x22, 0xd6, 0xab,
-	0x89, 0xbc, 0x54, 0xda, 0x55, 0x76, 0x57, 0xc5, 0x3e, 0xf6, 0x2d, 0xfb, 0x38, 0xc1, 0xbb, 0x92,
-	0x2d, 0x1b, 0xdf, 0x3c, 0xf3, 0x7f, 0xf3, 0x7b, 0xe6, 0x5f, 0xc1, 0x15, 0x57, 0x55, 0xa5, 0x64,
-	0xc2, 0x55, 0x59, 0x12, 0xb7, 0x42, 0xc9, 0xb8, 0xd6, 0xca, 0x2a, 0x1c, 0x7a, 0xe1, 0xc3, 0xfb,
-	0x16, 0xa8, 0x55, 0x29, 0xb8, 0x20, 0xe3, 0xe5, 0xc9, 0x2f, 0xb8, 0x9a, 0x6d, 0x47, 0x66, 0x4a,
-	0x3e, 0x8b, 0x62, 0xce, 0xf8, 0x1f, 0x56, 0x10, 0x7e, 0x83, 0x21, 0x77, 0x8d, 0x28, 0xb8, 0x39,
-	0x9d, 0x5e, 0xdc, 0x46, 0xb1, 0xb7, 0x88, 0x0f, 0x07, 0xd2, 0x96, 0x9b, 0xac, 0x61, 0x74, 0xa8,
-	0xe1, 0x13, 0x44, 0xc6, 0x32, 0x2b, 0x78, 0xb6, 0x5b, 0x2d, 0xdb, 0xfa, 0x06, 0xd3, 0x8b, 0xdb,
-	0xeb, 0xce, 0xf7, 0xd1, 0x71, 0x87, 0x0e, 0xf7, 0x27, 0xe9, 0xd8, 0x1c, 0x55, 0xee, 0x42, 0x38,
-	0xaf, 0xd9, 0xba, 0x54, 0x2c, 0x9f, 0xfc, 0x0f, 0x60, 0x7c, 0x7c, 0x1e, 0x11, 0x06, 0x92, 0x55,
-	0xe4, 0xfe, 0x2d, 0x4c, 0xdd, 0x6f, 0x7c, 0x00, 0xac, 0xa8, 0x5a, 0x90, 0xce, 0x94, 0x2e, 0x4c,
-	0xe6, 0x42, 0x59, 0x47, 0x6f, 0xf6, 0xf7, 0xd9, 0x39, 0xcd, 0x9d, 0xde, 0x5e, 0x3b, 0xf2, 0x93,
-	0xbf, 0x75, 0x61, 0x7c, 0x1f, 0x63, 0xb8, 0xd4, 0xf4, 0xd2, 0x08, 0x4d, 0x79, 0x56, 0x13, 0xe9,
-	0x8c, 0xab, 0x46, 0xda, 0xe8, 0xf4, 0x26, 0x98, 0x9e, 0xa5, 0xef, 0x3a, 0x69, 0x4e, 0xa4, 0x67,
-	0x1b, 0x01, 0xbf, 0x02, 0x56, 0x6c, 0x25, 0xaa, 0xa6, 0xea, 0xe3, 0x03, 0x87, 0x8f, 0x5a, 0x65,
-	0x4b, 0x4f, 0x5e, 0x60, 0x7c, 0x7c, 0x13, 0x7c, 0x80, 0x91, 0x11, 0x85, 0x64, 0xb6, 0xd1, 0xd4,
-	0xdd, 0xe0, 0x33, 0xfd, 0xb4, 0xcd, 0xb4, 0xd3, 0xfd, 0xe0, 0x0f, 0xf9, 0x97, 0x4a, 0x55, 0xd3,
-	0xfd, 0x49, 0xfa, 0xd6, 0xec, 0x4b, 0xfd, 0x34, 0xff, 0x05, 0x80, 0xbd, 0x1c, 0xb5, 0xb0, 0xa4,
-	0x05, 0xc3, 0x08, 0xce, 0xf9, 0x92, 0x49, 0x49, 0x65, 0x1b, 0x66, 0x57, 0xe2, 0x25, 0x9c, 0xd9,
-	0x55, 0x26, 0x72, 0x17, 0x61, 0x98, 0x0e, 0xec, 0xea, 0x67, 0x8e, 0xd7, 0x00, 0xbb, 0x37, 0x77,
-	0x69, 0x84, 0x69, 0xaf, 0x83, 0x1f, 0x21, 0xdc, 0x3c, 0x86, 0xa9, 0x19, 0x27, 0x77, 0x7d, 0x98,
-	0xee, 0x1a, 0x77, 0x8f, 0xf0, 0x59, 0xe9, 0x22, 0x5e, 0xae, 0x6b, 0xd2, 0x25, 0xe5, 0x05, 0xe9,
-	0xf8, 0x99, 0x2d, 0xb4, 0xe0, 0xfe, 0xcb, 0x35, 0xed, 0x85, 0x4f, 0x5f, 0x0a, 0x61, 0x97, 0xcd,
-	0x62, 0x53, 0x26, 0x3d, 0x38, 0xf1, 0x70, 0xe2, 0xe1, 0xc4, 0xc3, 0x8b, 0xa1, 0x2b, 0xbf, 0xbf,
-	0x06, 0x00, 0x00, 0xff, 0xff, 0xa8, 0x98, 0x7f, 0x6c, 0x2f, 0x03, 0x00, 0x00,
+	// 498 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0x41, 0x6e, 0xdb, 0x3a,
+	0x10, 0x86, 0xed, 0xc4, 0x76, 0xa0, 0xc9, 0xe2, 0x39, 0x0c, 0x9e, 0x23, 0xb4, 0x41, 0x12, 0xb8,
+	0x5d, 0x18, 0x68, 0x21, 0x17, 0xe9, 0x0d, 0x62, 0x04, 0x75, 0xd1, 0x00, 0x35, 0x98, 0x5d, 0x36,
+	0x02, 0x4d, 0x8d, 0x65, 0xa2, 0x12, 0xa9, 0x50, 0x74, 0x61, 0x2f, 0x7b, 0x9f, 0xde, 0xa1, 0x57,
+	0x2b, 0x4c, 0x52, 0x96, 0xe2, 0x1a, 0x5d, 0x75, 0x27, 0xce, 0xff, 0xcd, 0xf0, 0x1f, 0xcd, 0x10,
+	0x2e, 0xb8, 0xca, 0x73, 0x25, 0xc7, 0x5c, 0x65, 0x19, 0x72, 0x23, 0x94, 0x8c, 0x0a, 0xad, 0x8c,
+	0x22, 0x3d, 0x27, 0xbc, 0xfa, 0xdf, 0x03, 0x85, 0xca, 0x04, 0x17, 0x58, 0x3a, 0x79, 0xf8, 0x05,
+	0x2e, 0x26, 0xbb, 0x94, 0x89, 0x92, 0x0b, 0x91, 0xce, 0x18, 0xff, 0xc6, 0x52, 0x24, 0x1f, 0xa0,
+	0xc7, 0x6d, 0x20, 0x6c, 0xdf, 0x1c, 0x8f, 0x4e, 0x6f, 0xc3, 0xc8, 0x95, 0x88, 0xf6, 0x13, 0xa8,
+	0xe7, 0x86, 0x1b, 0xe8, 0xef, 0x6b, 0xe4, 0x09, 0xc2, 0xd2, 0x30, 0x23, 0x78, 0x5c, 0x5b, 0x8b,
+	0x77, 0x75, 0xdb, 0xa3, 0xd3, 0xdb, 0xab, 0xaa, 0xee, 0xa3, 0xe5, 0xf6, 0x2b, 0x4c, 0x5b, 0x74,
+	0x50, 0x1e, 0x54, 0xee, 0x02, 0x38, 0x29, 0xd8, 0x26, 0x53, 0x2c, 0x19, 0xfe, 0x3c, 0x82, 0xc1,
+	0xe1, 0x7c, 0x42, 0xa0, 0x23, 0x59, 0x8e, 0xf6, 0xb6, 0x80, 0xda, 0x6f, 0xf2, 0x00, 0x24, 0xc7,
+	0x7c, 0x8e, 0x3a, 0x56, 0x3a, 0x2d, 0x63, 0xfb, 0x53, 0x36, 0xe1, 0xd1, 0x4b, 0x3f, 0x75, 0xa5,
+	0x99, 0xd5, 0x7d, 0xb7, 0x7d, 0x97, 0xf9, 0x55, 0xa7, 0xa5, 0x8b, 0x93, 0x08, 0xce, 0x35, 0x3e,
+	0xaf, 0x84, 0xc6, 0x24, 0x2e, 0x10, 0x75, 0xcc, 0xd5, 0x4a, 0x9a, 0xf0, 0xf8, 0xa6, 0x3d, 0xea,
+	0xd2, 0xb3, 0x4a, 0x9a, 0x21, 0xea, 0xc9, 0x56, 0x20, 0xef, 0x81, 0xe4, 0x6c, 0x2d, 0xf2, 0x55,
+	0xde, 0xc4, 0x3b, 0x16, 0xef, 0x7b, 0xa5, 0xa6, 0x29, 0x10, 0x94, 0x89, 0xd2, 0x25, 0xe6, 0x28,
+	0x4d, 0xe5, 0xb5, 0x6b, 0xbd, 0xbe, 0xf9, 0xd3, 0xeb, 0x7d, 0xcd, 0x3a, 0x7b, 0xf4, 0x0c, 0xf7,
+	0x43, 0xc3, 0x5f, 0x6d, 0x78, 0xfd, 0x97, 0x14, 0xf2, 0x00, 0xfd, 0x52, 0xa4, 0x92, 0x99, 0x95,
+	0xc6, 0xea, 0x46, 0x37, 0xad, 0xeb, 0xdd, 0xb4, 0x2a, 0xdd, 0xa5, 0xdc, 0xcb, 0xef, 0x98, 0xa9,
+	0x02, 0xa7, 0x2d, 0xfa, 0x5f, 0xf9, 0x52, 0x22, 0x9f, 0xe0, 0x9a, 0x2f, 0x99, 0x94, 0x98, 0xf9,
+	0xc9, 0xfb, 0x92, 0xb1, 0xc6, 0x05, 0x6a, 0x94, 0x1c, 0xed, 0xaf, 0x0f, 0xa6, 0x2d, 0x7a, 0xe9,
+	0x41, 0xbf, 0x8a, 0xae, 0x81, 0x8a, 0x6a, 0x0e, 0xfc, 0x19, 0x06, 0x87, 0xe7, 0xf3, 0x6f, 0xbd,
+	0x37, 0xaf, 0xfc, 0xd1, 0x06, 0xd2, 0xd8, 0x2e, 0x2d, 0x0c, 0x6a, 0xc1, 0x48, 0x08, 0x27, 0xde,
+	0xb4, 0x5f, 0xb1, 0xea, 0x48, 0xce, 0xa1, 0x6b, 0xd6, 0xb1, 0x48, 0x5c, 0x77, 0xb4, 0x63, 0xd6,
+	0x9f, 0x13, 0x72, 0x05, 0x50, 0xbf, 0x04, 0xbb, 0x23, 0x01, 0x6d, 0x44, 0xc8, 0x25, 0x04, 0xdb,
+	0x15, 0x2d, 0x0b, 0xc6, 0xd1, 0xee, 0x44, 0x40, 0xeb, 0xc0, 0xdd, 0x23, 0xbc, 0x55, 0x3a, 0x8d,
+	0x96, 0x9b, 0x02, 0x75, 0x86, 0x49, 0x8a, 0x3a, 0x5a, 0xb0, 0xb9, 0x16, 0xdc, 0xbd, 0xe7, 0xd2,
+	0x77, 0xf8, 0xf4, 0x2e, 0x15, 0x66, 0xb9, 0x9a, 0x6f, 0x8f, 0xe3, 0x06, 0x3c, 0x76, 0xf0, 0xd8,
+	0xc1, 0x63, 0x07, 0xcf, 0x7b, 0xf6, 0xf8, 0xf1, 0xf7, 0x00, 0xbc, 0x6d, 0xce, 0x9a, 0x45, 0x04,
+	0x00, 0x00,
 }
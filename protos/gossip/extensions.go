@@ -233,6 +233,12 @@ func (m *GossipMessage) IsLeadershipMsg() bool {
 	return m.GetLeadershipMsg() != nil
 }
 
+// IsReachabilityMsg returns whether this GossipMessage is related to
+// verifying that the external endpoint of a peer is reachable
+func (m *GossipMessage) IsReachabilityMsg() bool {
+	return m.GetReachabilityReq() != nil || m.GetReachabilityRes() != nil
+}
+
 // MsgConsumer invokes code given a SignedGossipMessage
 type MsgConsumer func(message *SignedGossipMessage)
 
@@ -252,7 +258,7 @@ func (m *GossipMessage) IsTagLegal() error {
 		return nil
 	}
 
-	if m.IsAliveMsg() || m.GetMemReq() != nil || m.GetMemRes() != nil {
+	if m.IsAliveMsg() || m.GetMemReq() != nil || m.GetMemRes() != nil || m.IsReachabilityMsg() {
 		if m.Tag != GossipMessage_EMPTY {
 			return fmt.Errorf("Tag should be %s", GossipMessage_Tag_name[int32(GossipMessage_EMPTY)])
 		}
@@ -565,6 +565,31 @@ func TestGossipMessageMembershipMessageTagType(t *testing.T) {
 	assert.NoError(t, msg.IsTagLegal())
 }
 
+func TestGossipMessageReachabilityMessageTagType(t *testing.T) {
+	var msg *SignedGossipMessage
+	channelID := "testID1"
+
+	msg = signedGossipMessage(channelID, GossipMessage_EMPTY, &GossipMessage_ReachabilityReq{
+		ReachabilityReq: &ReachabilityRequest{Endpoint: "p0:7051"},
+	})
+	assert.True(t, msg.IsReachabilityMsg())
+	assert.NoError(t, msg.IsTagLegal())
+
+	msg = signedGossipMessage(channelID, GossipMessage_EMPTY, &GossipMessage_ReachabilityRes{
+		ReachabilityRes: &ReachabilityResponse{Reachable: true},
+	})
+	assert.True(t, msg.IsReachabilityMsg())
+	assert.NoError(t, msg.IsTagLegal())
+
+	msg = signedGossipMessage(channelID, GossipMessage_ORG_ONLY, &GossipMessage_ReachabilityReq{
+		ReachabilityReq: &ReachabilityRequest{Endpoint: "p0:7051"},
+	})
+	assert.Error(t, msg.IsTagLegal())
+	assert.False(t, signedGossipMessage(channelID, GossipMessage_EMPTY, &GossipMessage_AliveMsg{
+		AliveMsg: &AliveMessage{},
+	}).IsReachabilityMsg())
+}
+
 func TestGossipMessageIdentityMessageTagType(t *testing.T) {
 	var msg *SignedGossipMessage
 	channelID := "testID1"
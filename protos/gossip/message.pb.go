@@ -42,6 +42,8 @@ It has these top-level messages:
 	PvtDataPayload
 	Acknowledgement
 	Chaincode
+	ReachabilityRequest
+	ReachabilityResponse
 */
 package gossip
 
@@ -65,6 +67,29 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// CompressionType is the algorithm the payload
+// of an Envelope is compressed with
+type CompressionType int32
+
+const (
+	CompressionType_NO_COMPRESSION CompressionType = 0
+	CompressionType_SNAPPY         CompressionType = 1
+)
+
+var CompressionType_name = map[int32]string{
+	0: "NO_COMPRESSION",
+	1: "SNAPPY",
+}
+var CompressionType_value = map[string]int32{
+	"NO_COMPRESSION": 0,
+	"SNAPPY":         1,
+}
+
+func (x CompressionType) String() string {
+	return proto.EnumName(CompressionType_name, int32(x))
+}
+func (CompressionType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
 type PullMsgType int32
 
 const (
@@ -87,7 +112,7 @@ var PullMsgType_value = map[string]int32{
 func (x PullMsgType) String() string {
 	return proto.EnumName(PullMsgType_name, int32(x))
 }
-func (PullMsgType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+func (PullMsgType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
 
 type GossipMessage_Tag int32
 
@@ -125,11 +150,14 @@ func (GossipMessage_Tag) EnumDescriptor() ([]byte, []int) { return fileDescripto
 // Envelope contains a marshalled
 // GossipMessage and a signature over it.
 // It may also contain a SecretEnvelope
-// which is a marshalled Secret
+// which is a marshalled Secret.
+// The payload may be compressed, in which case
+// the signature is over the uncompressed payload
 type Envelope struct {
 	Payload        []byte          `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
 	Signature      []byte          `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
 	SecretEnvelope *SecretEnvelope `protobuf:"bytes,3,opt,name=secret_envelope,json=secretEnvelope" json:"secret_envelope,omitempty"`
+	Compression    CompressionType `protobuf:"varint,4,opt,name=compression,enum=gossip.CompressionType" json:"compression,omitempty"`
 }
 
 func (m *Envelope) Reset()                    { *m = Envelope{} }
@@ -158,6 +186,13 @@ func (m *Envelope) GetSecretEnvelope() *SecretEnvelope {
 	return nil
 }
 
+func (m *Envelope) GetCompression() CompressionType {
+	if m != nil {
+		return m.Compression
+	}
+	return CompressionType_NO_COMPRESSION
+}
+
 // SecretEnvelope is a marshalled Secret
 // and a signature over it.
 // The signature should be validated by the peer
@@ -310,6 +345,8 @@ type GossipMessage struct {
 	//	*GossipMessage_PrivateReq
 	//	*GossipMessage_PrivateRes
 	//	*GossipMessage_PrivateData
+	//	*GossipMessage_ReachabilityReq
+	//	*GossipMessage_ReachabilityRes
 	Content isGossipMessage_Content `protobuf_oneof:"content"`
 }
 
@@ -385,6 +422,12 @@ type GossipMessage_PrivateRes struct {
 type GossipMessage_PrivateData struct {
 	PrivateData *PrivateDataMessage `protobuf:"bytes,25,opt,name=private_data,json=privateData,oneof"`
 }
+type GossipMessage_ReachabilityReq struct {
+	ReachabilityReq *ReachabilityRequest `protobuf:"bytes,26,opt,name=reachability_req,json=reachabilityReq,oneof"`
+}
+type GossipMessage_ReachabilityRes struct {
+	ReachabilityRes *ReachabilityResponse `protobuf:"bytes,27,opt,name=reachability_res,json=reachabilityRes,oneof"`
+}
 
 func (*GossipMessage_AliveMsg) isGossipMessage_Content()         {}
 func (*GossipMessage_MemReq) isGossipMessage_Content()           {}
@@ -407,6 +450,8 @@ func (*GossipMessage_Ack) isGossipMessage_Content()              {}
 func (*GossipMessage_PrivateReq) isGossipMessage_Content()       {}
 func (*GossipMessage_PrivateRes) isGossipMessage_Content()       {}
 func (*GossipMessage_PrivateData) isGossipMessage_Content()      {}
+func (*GossipMessage_ReachabilityReq) isGossipMessage_Content()  {}
+func (*GossipMessage_ReachabilityRes) isGossipMessage_Content()  {}
 
 func (m *GossipMessage) GetContent() isGossipMessage_Content {
 	if m != nil {
@@ -583,6 +628,20 @@ func (m *GossipMessage) GetPrivateData() *PrivateDataMessage {
 	return nil
 }
 
+func (m *GossipMessage) GetReachabilityReq() *ReachabilityRequest {
+	if x, ok := m.GetContent().(*GossipMessage_ReachabilityReq); ok {
+		return x.ReachabilityReq
+	}
+	return nil
+}
+
+func (m *GossipMessage) GetReachabilityRes() *ReachabilityResponse {
+	if x, ok := m.GetContent().(*GossipMessage_ReachabilityRes); ok {
+		return x.ReachabilityRes
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*GossipMessage) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _GossipMessage_OneofMarshaler, _GossipMessage_OneofUnmarshaler, _GossipMessage_OneofSizer, []interface{}{
@@ -607,6 +666,8 @@ func (*GossipMessage) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer)
 		(*GossipMessage_PrivateReq)(nil),
 		(*GossipMessage_PrivateRes)(nil),
 		(*GossipMessage_PrivateData)(nil),
+		(*GossipMessage_ReachabilityReq)(nil),
+		(*GossipMessage_ReachabilityRes)(nil),
 	}
 }
 
@@ -719,6 +780,16 @@ func _GossipMessage_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.PrivateData); err != nil {
 			return err
 		}
+	case *GossipMessage_ReachabilityReq:
+		b.EncodeVarint(26<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ReachabilityReq); err != nil {
+			return err
+		}
+	case *GossipMessage_ReachabilityRes:
+		b.EncodeVarint(27<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ReachabilityRes); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("GossipMessage.Content has unexpected type %T", x)
@@ -897,6 +968,22 @@ func _GossipMessage_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.
 		err := b.DecodeMessage(msg)
 		m.Content = &GossipMessage_PrivateData{msg}
 		return true, err
+	case 26: // content.reachability_req
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ReachabilityRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &GossipMessage_ReachabilityReq{msg}
+		return true, err
+	case 27: // content.reachability_res
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ReachabilityResponse)
+		err := b.DecodeMessage(msg)
+		m.Content = &GossipMessage_ReachabilityRes{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1011,6 +1098,16 @@ func _GossipMessage_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(25<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *GossipMessage_ReachabilityReq:
+		s := proto.Size(x.ReachabilityReq)
+		n += proto.SizeVarint(26<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *GossipMessage_ReachabilityRes:
+		s := proto.Size(x.ReachabilityRes)
+		n += proto.SizeVarint(27<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1140,6 +1237,13 @@ type ConnEstablish struct {
 	PkiId       []byte `protobuf:"bytes,1,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
 	Identity    []byte `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
 	TlsCertHash []byte `protobuf:"bytes,3,opt,name=tls_cert_hash,json=tlsCertHash,proto3" json:"tls_cert_hash,omitempty"`
+	// the compression algorithms the peer accepts,
+	// in order of preference
+	Compression []CompressionType `protobuf:"varint,4,rep,packed,name=compression,enum=gossip.CompressionType" json:"compression,omitempty"`
+	// handshake_only is set when the peer only handshakes to learn the identity of
+	// the remote peer, and closes the connection right after, so the remote peer
+	// doesn't replace its existing connection to the peer with this one
+	HandshakeOnly bool `protobuf:"varint,5,opt,name=handshake_only,json=handshakeOnly" json:"handshake_only,omitempty"`
 }
 
 func (m *ConnEstablish) Reset()                    { *m = ConnEstablish{} }
@@ -1168,6 +1272,20 @@ func (m *ConnEstablish) GetTlsCertHash() []byte {
 	return nil
 }
 
+func (m *ConnEstablish) GetCompression() []CompressionType {
+	if m != nil {
+		return m.Compression
+	}
+	return nil
+}
+
+func (m *ConnEstablish) GetHandshakeOnly() bool {
+	if m != nil {
+		return m.HandshakeOnly
+	}
+	return false
+}
+
 // PeerIdentity defines the identity of the peer
 // Used to make other peers learn of the identity
 // of a certain peer
@@ -1457,6 +1575,9 @@ type AliveMessage struct {
 	Membership *Member   `protobuf:"bytes,1,opt,name=membership" json:"membership,omitempty"`
 	Timestamp  *PeerTime `protobuf:"bytes,2,opt,name=timestamp" json:"timestamp,omitempty"`
 	Identity   []byte    `protobuf:"bytes,4,opt,name=identity,proto3" json:"identity,omitempty"`
+	// unreachable is set by peers which found out that
+	// peers of other organizations can't reach their external endpoint
+	Unreachable bool `protobuf:"varint,5,opt,name=unreachable" json:"unreachable,omitempty"`
 }
 
 func (m *AliveMessage) Reset()                    { *m = AliveMessage{} }
@@ -1485,12 +1606,22 @@ func (m *AliveMessage) GetIdentity() []byte {
 	return nil
 }
 
+func (m *AliveMessage) GetUnreachable() bool {
+	if m != nil {
+		return m.Unreachable
+	}
+	return false
+}
+
 // Leadership Message is sent during leader election to inform
 // remote peers about intent of peer to proclaim itself as leader
 type LeadershipMessage struct {
 	PkiId         []byte    `protobuf:"bytes,1,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
 	Timestamp     *PeerTime `protobuf:"bytes,2,opt,name=timestamp" json:"timestamp,omitempty"`
 	IsDeclaration bool      `protobuf:"varint,3,opt,name=is_declaration,json=isDeclaration" json:"is_declaration,omitempty"`
+	// weight of the peer as a leader candidate. Peers with greater
+	// weights are preferred, and peers which don't set it have a weight of 0
+	Weight uint32 `protobuf:"varint,4,opt,name=weight" json:"weight,omitempty"`
 }
 
 func (m *LeadershipMessage) Reset()                    { *m = LeadershipMessage{} }
@@ -1519,6 +1650,13 @@ func (m *LeadershipMessage) GetIsDeclaration() bool {
 	return false
 }
 
+func (m *LeadershipMessage) GetWeight() uint32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
 // PeerTime defines the logical time of a peer's life
 type PeerTime struct {
 	IncNum uint64 `protobuf:"varint,1,opt,name=inc_num,json=incNum" json:"inc_num,omitempty"`
@@ -1870,6 +2008,51 @@ func (m *Chaincode) GetMetadata() []byte {
 	return nil
 }
 
+// ReachabilityRequest is sent to a peer of another organization
+// in order to have it dial the external endpoint of the sender,
+// and handshake with the sender through it
+type ReachabilityRequest struct {
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint" json:"endpoint,omitempty"`
+}
+
+func (m *ReachabilityRequest) Reset()                    { *m = ReachabilityRequest{} }
+func (m *ReachabilityRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReachabilityRequest) ProtoMessage()               {}
+func (*ReachabilityRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *ReachabilityRequest) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+// ReachabilityResponse is the outcome of dialing and handshaking
+// with the endpoint of a ReachabilityRequest
+type ReachabilityResponse struct {
+	Reachable bool   `protobuf:"varint,1,opt,name=reachable" json:"reachable,omitempty"`
+	Error     string `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *ReachabilityResponse) Reset()                    { *m = ReachabilityResponse{} }
+func (m *ReachabilityResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReachabilityResponse) ProtoMessage()               {}
+func (*ReachabilityResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *ReachabilityResponse) GetReachable() bool {
+	if m != nil {
+		return m.Reachable
+	}
+	return false
+}
+
+func (m *ReachabilityResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Envelope)(nil), "gossip.Envelope")
 	proto.RegisterType((*SecretEnvelope)(nil), "gossip.SecretEnvelope")
@@ -1905,6 +2088,9 @@ func init() {
 	proto.RegisterType((*PvtDataPayload)(nil), "gossip.PvtDataPayload")
 	proto.RegisterType((*Acknowledgement)(nil), "gossip.Acknowledgement")
 	proto.RegisterType((*Chaincode)(nil), "gossip.Chaincode")
+	proto.RegisterType((*ReachabilityRequest)(nil), "gossip.ReachabilityRequest")
+	proto.RegisterType((*ReachabilityResponse)(nil), "gossip.ReachabilityResponse")
+	proto.RegisterEnum("gossip.CompressionType", CompressionType_name, CompressionType_value)
 	proto.RegisterEnum("gossip.PullMsgType", PullMsgType_name, PullMsgType_value)
 	proto.RegisterEnum("gossip.GossipMessage_Tag", GossipMessage_Tag_name, GossipMessage_Tag_value)
 }
@@ -2054,119 +2240,131 @@ var _Gossip_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("gossip/message.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1813 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdd, 0x53, 0x23, 0xc7,
-	0x11, 0xd7, 0x82, 0xbe, 0xb6, 0xf5, 0x81, 0x18, 0xb8, 0xbb, 0xf5, 0xd9, 0x71, 0xf0, 0x26, 0x67,
-	0x5f, 0x82, 0x0d, 0x17, 0x9c, 0x54, 0x5c, 0xe5, 0x24, 0x57, 0x80, 0x64, 0xa4, 0xf2, 0x89, 0x23,
-	0x03, 0x57, 0x09, 0x79, 0xd9, 0x1a, 0x76, 0x07, 0x69, 0xc3, 0xee, 0xec, 0xb2, 0x33, 0x60, 0x78,
-	0x4c, 0xe5, 0xc1, 0x55, 0x79, 0xcb, 0x9f, 0x90, 0xa7, 0xfc, 0x9b, 0xa9, 0x99, 0xd9, 0x4f, 0x09,
-	0xae, 0xea, 0x5c, 0x95, 0x37, 0xf5, 0xf7, 0x4c, 0x4f, 0xf7, 0xaf, 0x7b, 0x05, 0x9b, 0xb3, 0x88,
-	0x73, 0x3f, 0xde, 0x0d, 0x29, 0xe7, 0x64, 0x46, 0x77, 0xe2, 0x24, 0x12, 0x11, 0x6a, 0x6a, 0xae,
-	0xfd, 0x4f, 0x03, 0xda, 0x23, 0x76, 0x4b, 0x83, 0x28, 0xa6, 0xc8, 0x82, 0x56, 0x4c, 0xee, 0x83,
-	0x88, 0x78, 0x96, 0xb1, 0x65, 0xbc, 0xec, 0xe2, 0x8c, 0x44, 0x9f, 0x80, 0xc9, 0xfd, 0x19, 0x23,
-	0xe2, 0x26, 0xa1, 0xd6, 0x8a, 0x92, 0x15, 0x0c, 0xf4, 0x1a, 0xd6, 0x38, 0x75, 0x13, 0x2a, 0x1c,
-	0x9a, 0xba, 0xb2, 0x56, 0xb7, 0x8c, 0x97, 0x9d, 0xbd, 0xa7, 0x3b, 0x3a, 0xcc, 0xce, 0xa9, 0x12,
-	0x67, 0x81, 0x70, 0x9f, 0x57, 0x68, 0x7b, 0x0c, 0xfd, 0xaa, 0xc6, 0x4f, 0x3d, 0x8a, 0xbd, 0x0f,
-	0x4d, 0xed, 0x09, 0x7d, 0x09, 0x03, 0x9f, 0x09, 0x9a, 0x30, 0x12, 0x8c, 0x98, 0x17, 0x47, 0x3e,
-	0x13, 0xca, 0x95, 0x39, 0xae, 0xe1, 0x25, 0xc9, 0x81, 0x09, 0x2d, 0x37, 0x62, 0x82, 0x32, 0x61,
-	0xff, 0xd8, 0x81, 0xde, 0x91, 0x3a, 0xf6, 0x54, 0xa7, 0x0c, 0x6d, 0x42, 0x83, 0x45, 0xcc, 0xa5,
-	0xca, 0xbe, 0x8e, 0x35, 0x21, 0x8f, 0xe8, 0xce, 0x09, 0x63, 0x34, 0x48, 0x8f, 0x91, 0x91, 0x68,
-	0x1b, 0x56, 0x05, 0x99, 0xa9, 0x1c, 0xf4, 0xf7, 0x3e, 0xca, 0x72, 0x50, 0xf1, 0xb9, 0x73, 0x46,
-	0x66, 0x58, 0x6a, 0xa1, 0xaf, 0xc1, 0x24, 0x81, 0x7f, 0x4b, 0x9d, 0x90, 0xcf, 0xac, 0x86, 0x4a,
-	0xdb, 0x66, 0x66, 0xb2, 0x2f, 0x05, 0xa9, 0xc5, 0xb8, 0x86, 0xdb, 0x4a, 0x71, 0xca, 0x67, 0xe8,
-	0xb7, 0xd0, 0x0a, 0x69, 0xe8, 0x24, 0xf4, 0xda, 0x6a, 0x2a, 0x93, 0x3c, 0xca, 0x94, 0x86, 0x17,
-	0x34, 0xe1, 0x73, 0x3f, 0xc6, 0xf4, 0xfa, 0x86, 0x72, 0x31, 0xae, 0xe1, 0x66, 0x48, 0x43, 0x4c,
-	0xaf, 0xd1, 0xef, 0x32, 0x2b, 0x6e, 0xb5, 0x94, 0xd5, 0xf3, 0x87, 0xac, 0x78, 0x1c, 0x31, 0x4e,
-	0x73, 0x33, 0x8e, 0x5e, 0x41, 0xdb, 0x23, 0x82, 0xa8, 0x03, 0xb6, 0x95, 0xdd, 0x46, 0x66, 0x37,
-	0x24, 0x82, 0x14, 0xe7, 0x6b, 0x49, 0x35, 0x79, 0xbc, 0x6d, 0x68, 0xcc, 0x69, 0x10, 0x44, 0x96,
-	0x59, 0x55, 0xd7, 0x29, 0x18, 0x4b, 0xd1, 0xb8, 0x86, 0xb5, 0x0e, 0xda, 0x4d, 0xdd, 0x7b, 0xfe,
-	0xcc, 0x02, 0xa5, 0x8f, 0xca, 0xee, 0x87, 0xfe, 0x4c, 0xdf, 0x42, 0x79, 0x1f, 0xfa, 0xb3, 0xfc,
-	0x3c, 0xf2, 0xf6, 0x9d, 0xe5, 0xf3, 0x14, 0xf7, 0x56, 0x16, 0xfa, 0xe2, 0x1d, 0x65, 0x71, 0x13,
-	0x7b, 0x44, 0x50, 0xab, 0xbb, 0x1c, 0xe5, 0x9d, 0x92, 0x8c, 0x6b, 0x18, 0xbc, 0x9c, 0x42, 0x2f,
-	0xa0, 0x41, 0xc3, 0x58, 0xdc, 0x5b, 0x3d, 0x65, 0xd0, 0xcb, 0x0c, 0x46, 0x92, 0x29, 0x2f, 0xa0,
-	0xa4, 0x68, 0x1b, 0xea, 0x6e, 0xc4, 0x98, 0xd5, 0x57, 0x5a, 0x4f, 0x32, 0xad, 0xc3, 0x88, 0xb1,
-	0x11, 0x17, 0xe4, 0x22, 0xf0, 0xf9, 0x7c, 0x5c, 0xc3, 0x4a, 0x09, 0xed, 0x01, 0x70, 0x41, 0x04,
-	0x75, 0x7c, 0x76, 0x19, 0x59, 0x6b, 0xca, 0x64, 0x3d, 0x6f, 0x13, 0x29, 0x99, 0xb0, 0x4b, 0x99,
-	0x1d, 0x93, 0x67, 0x04, 0x3a, 0x80, 0xbe, 0xb6, 0xe1, 0x8c, 0xc4, 0x7c, 0x1e, 0x09, 0x6b, 0x50,
-	0x7d, 0xf4, 0xdc, 0xee, 0x34, 0x55, 0x18, 0xd7, 0x70, 0x4f, 0x99, 0x64, 0x0c, 0x34, 0x85, 0x8d,
-	0x22, 0xae, 0x13, 0xdf, 0x04, 0x81, 0xca, 0xdf, 0xba, 0x72, 0xf4, 0xc9, 0x92, 0xa3, 0x93, 0x9b,
-	0x20, 0x28, 0x12, 0x39, 0xe0, 0x0b, 0x7c, 0xb4, 0x0f, 0xda, 0xbf, 0x74, 0x22, 0x95, 0x2c, 0x54,
-	0x2d, 0x28, 0x4c, 0xc3, 0x48, 0x50, 0xe5, 0xae, 0x70, 0xd3, 0xe5, 0x25, 0x1a, 0x0d, 0xb3, 0x5b,
-	0x25, 0x69, 0xc9, 0x59, 0x1b, 0xca, 0xc7, 0xc7, 0x0f, 0xfa, 0xc8, 0xab, 0xb2, 0xc7, 0xcb, 0x0c,
-	0x99, 0x9b, 0x80, 0x12, 0x4f, 0x17, 0xaf, 0x2a, 0xd1, 0xcd, 0x6a, 0x6e, 0xde, 0xe4, 0xd2, 0xa2,
-	0x50, 0x7b, 0x85, 0x89, 0x2c, 0xd7, 0x6f, 0xa1, 0x17, 0x53, 0x9a, 0x38, 0xbe, 0x47, 0x99, 0xf0,
-	0xc5, 0xbd, 0xf5, 0xa4, 0xda, 0x86, 0x27, 0x94, 0x26, 0x93, 0x54, 0x26, 0xaf, 0x11, 0x97, 0x68,
-	0xd9, 0xec, 0xc4, 0xbd, 0xb2, 0x9e, 0x2a, 0x93, 0x67, 0x79, 0xe7, 0xba, 0x57, 0x2c, 0xfa, 0x21,
-	0xa0, 0xde, 0x8c, 0x86, 0x94, 0xc9, 0xcb, 0x4b, 0x2d, 0xf4, 0x27, 0x80, 0x38, 0xf1, 0x6f, 0x75,
-	0x16, 0xac, 0x67, 0xd5, 0xe4, 0xeb, 0xfb, 0x9e, 0xdc, 0x8a, 0x6a, 0x15, 0x97, 0x2c, 0xd0, 0xeb,
-	0x92, 0x3d, 0xb7, 0x2c, 0x65, 0xff, 0xb3, 0x47, 0xec, 0xf3, 0x8c, 0x95, 0x4c, 0xd0, 0x6b, 0xe8,
-	0xa6, 0x94, 0x23, 0x0b, 0xdd, 0xfa, 0xa8, 0xfa, 0x6c, 0x27, 0x5a, 0x56, 0x6d, 0xeb, 0x4e, 0x5c,
-	0x70, 0x6d, 0x07, 0x56, 0xcf, 0xc8, 0x0c, 0xf5, 0xc0, 0x7c, 0x77, 0x3c, 0x1c, 0x7d, 0x37, 0x39,
-	0x1e, 0x0d, 0x07, 0x35, 0x64, 0x42, 0x63, 0x34, 0x3d, 0x39, 0x3b, 0x1f, 0x18, 0xa8, 0x0b, 0xed,
-	0xb7, 0xf8, 0xc8, 0x79, 0x7b, 0xfc, 0xe6, 0x7c, 0xb0, 0x22, 0xf5, 0x0e, 0xc7, 0xfb, 0xc7, 0x9a,
-	0x5c, 0x45, 0x03, 0xe8, 0x2a, 0x72, 0xff, 0x78, 0xe8, 0xbc, 0xc5, 0x47, 0x83, 0x3a, 0x5a, 0x83,
-	0x8e, 0x56, 0xc0, 0x8a, 0xd1, 0x28, 0x23, 0xf1, 0x7f, 0x0d, 0x30, 0xf3, 0x8a, 0x44, 0x3b, 0x60,
-	0x0a, 0x3f, 0xa4, 0x5c, 0x90, 0x30, 0x56, 0x88, 0xdb, 0xd9, 0x1b, 0x94, 0x5f, 0xe8, 0xcc, 0x0f,
-	0x29, 0x2e, 0x54, 0xd0, 0x13, 0x68, 0xc6, 0x57, 0xbe, 0xe3, 0x7b, 0x0a, 0x88, 0xbb, 0xb8, 0x11,
-	0x5f, 0xf9, 0x13, 0x0f, 0xfd, 0x1c, 0x3a, 0x29, 0x4e, 0x3b, 0xd3, 0xfd, 0x43, 0xab, 0xae, 0x64,
-	0x90, 0xb2, 0xa6, 0xfb, 0x87, 0xb2, 0x43, 0xe3, 0x24, 0x8a, 0x69, 0x22, 0x7c, 0xca, 0x53, 0x44,
-	0x46, 0x45, 0x82, 0x32, 0x09, 0x2e, 0x69, 0xd9, 0x3f, 0x1a, 0x00, 0x85, 0x08, 0xfd, 0x02, 0x7a,
-	0xea, 0xe9, 0x13, 0x67, 0x4e, 0xfd, 0xd9, 0x5c, 0xa4, 0x83, 0xa3, 0xab, 0x99, 0x63, 0xc5, 0x43,
-	0x9f, 0x41, 0x37, 0xa0, 0x97, 0xc2, 0x29, 0x0f, 0x91, 0x36, 0xee, 0x48, 0xde, 0x61, 0x3a, 0x48,
-	0x7e, 0x03, 0xf2, 0x60, 0x3e, 0x73, 0x23, 0x8f, 0x72, 0x6b, 0x75, 0x6b, 0xb5, 0x0c, 0x16, 0x87,
-	0x99, 0x04, 0x97, 0x94, 0xec, 0x7d, 0x58, 0x5f, 0x42, 0x03, 0xf4, 0x25, 0xb4, 0x69, 0xa0, 0x0a,
-	0x91, 0x5b, 0x86, 0xf2, 0x92, 0x67, 0x2e, 0x9f, 0xc9, 0xb9, 0x86, 0xfd, 0x7b, 0xd8, 0x7c, 0x08,
-	0x07, 0x16, 0x33, 0x67, 0x2c, 0x66, 0xce, 0xbe, 0x84, 0x5e, 0x05, 0xf4, 0x4a, 0x4f, 0x60, 0x94,
-	0x9f, 0xe0, 0x39, 0xb4, 0xf3, 0x56, 0xd3, 0xa3, 0x33, 0xa7, 0x91, 0x0d, 0x3d, 0x11, 0x70, 0xc7,
-	0xa5, 0x89, 0x70, 0xe6, 0x84, 0xcf, 0xd3, 0xc7, 0xeb, 0x88, 0x80, 0x1f, 0xd2, 0x44, 0x8c, 0x09,
-	0x9f, 0xdb, 0xef, 0xa0, 0x5b, 0x6e, 0xc9, 0xc7, 0xc2, 0x20, 0xa8, 0x4b, 0x37, 0x69, 0x08, 0xf5,
-	0x5b, 0x86, 0x0e, 0xa9, 0x20, 0xaa, 0xf6, 0xb5, 0xe7, 0x9c, 0xb6, 0x43, 0xe8, 0x94, 0x3a, 0xef,
-	0xf1, 0xa9, 0xef, 0xa9, 0x89, 0xc4, 0xad, 0x95, 0xad, 0xd5, 0x97, 0x26, 0xce, 0x48, 0xb4, 0x03,
-	0xed, 0x90, 0xcf, 0x1c, 0x71, 0x9f, 0xae, 0x3f, 0xfd, 0x62, 0x2c, 0xc9, 0x2c, 0x4e, 0xf9, 0xec,
-	0xec, 0x3e, 0xa6, 0xb8, 0x15, 0xea, 0x1f, 0x76, 0x04, 0x9d, 0xd2, 0x3c, 0x7c, 0x24, 0x5c, 0xf9,
-	0xbc, 0x2b, 0xd5, 0xf3, 0x7e, 0x70, 0xc0, 0x3b, 0x80, 0x62, 0xd4, 0x3d, 0x12, 0xef, 0x97, 0x50,
-	0x4f, 0x63, 0x3d, 0x5c, 0x25, 0xf5, 0x9f, 0x14, 0x39, 0xd0, 0x91, 0xf5, 0x28, 0xff, 0xbf, 0x27,
-	0xf6, 0x1b, 0xfd, 0x8e, 0xd9, 0xf6, 0xf6, 0xab, 0xea, 0x2a, 0xd9, 0xd9, 0x5b, 0xcb, 0xad, 0x35,
-	0x3b, 0xdf, 0x2d, 0xed, 0xef, 0x00, 0x2d, 0x23, 0x20, 0x7a, 0xb5, 0xe8, 0xe0, 0xe9, 0x02, 0x5c,
-	0x2e, 0xf9, 0x39, 0x87, 0x56, 0xca, 0x43, 0xcf, 0xa0, 0xc5, 0xe9, 0xb5, 0xc3, 0x6e, 0xc2, 0xf4,
-	0xba, 0x4d, 0x4e, 0xaf, 0x8f, 0x6f, 0x42, 0x59, 0x9d, 0xa5, 0x57, 0xd5, 0x79, 0xfd, 0x6c, 0x01,
-	0x9d, 0x65, 0xc7, 0x77, 0xab, 0xf8, 0xfb, 0x6f, 0x03, 0xfa, 0xd5, 0xb0, 0xe8, 0x0b, 0x58, 0x73,
-	0xa3, 0x20, 0xa0, 0xae, 0xf0, 0x23, 0xe6, 0x30, 0x12, 0xea, 0xcc, 0x9a, 0xb8, 0x5f, 0xb0, 0x8f,
-	0x49, 0x48, 0xe5, 0xea, 0x2c, 0xa5, 0x3c, 0x26, 0xae, 0x5e, 0x9d, 0x4d, 0x5c, 0x30, 0xd0, 0x06,
-	0x34, 0xc4, 0x5d, 0x06, 0x97, 0x26, 0xae, 0x8b, 0xbb, 0x89, 0x27, 0x91, 0x2c, 0x3b, 0x51, 0xf2,
-	0x03, 0xa7, 0x22, 0xc5, 0xcb, 0xec, 0x98, 0x58, 0xf2, 0xec, 0x7f, 0x19, 0xd0, 0x2d, 0xaf, 0xaa,
-	0x68, 0x07, 0x20, 0xcc, 0x37, 0xca, 0x34, 0x69, 0xfd, 0xea, 0xae, 0x89, 0x4b, 0x1a, 0x1f, 0x0c,
-	0xed, 0x65, 0x00, 0xa9, 0x57, 0x01, 0xc4, 0xfe, 0x87, 0x01, 0xeb, 0x4b, 0x33, 0xff, 0x31, 0x88,
-	0xf8, 0xd0, 0xc0, 0x2f, 0xa0, 0xef, 0x73, 0xc7, 0xa3, 0x6e, 0x40, 0x12, 0x22, 0xf3, 0xaa, 0x92,
-	0xd5, 0xc6, 0x3d, 0x9f, 0x0f, 0x0b, 0xa6, 0xfd, 0x07, 0x68, 0x67, 0xd6, 0xb2, 0x00, 0x7c, 0xe6,
-	0x96, 0x0b, 0xc0, 0x67, 0xae, 0x2c, 0x80, 0x52, 0x65, 0xac, 0x94, 0x2b, 0xc3, 0xbe, 0x84, 0xf5,
-	0xa5, 0x2d, 0x1e, 0x7d, 0x0b, 0x03, 0x4e, 0x83, 0x4b, 0xb5, 0xbe, 0x25, 0xa1, 0x8e, 0x6d, 0x54,
-	0x0f, 0x9c, 0x37, 0xe9, 0x9a, 0xd4, 0x9c, 0x14, 0x8a, 0xb2, 0xe3, 0xe4, 0x3a, 0xc2, 0x54, 0x67,
-	0x75, 0xb1, 0x26, 0xec, 0x0b, 0x40, 0xcb, 0x7b, 0x3f, 0xfa, 0x1c, 0x1a, 0xea, 0x33, 0xe3, 0xd1,
-	0x41, 0xa1, 0xc5, 0x0a, 0x29, 0x28, 0xf1, 0xde, 0x83, 0x14, 0x94, 0x78, 0xf6, 0x5f, 0xa0, 0xa9,
-	0x63, 0xc8, 0x37, 0xa3, 0x95, 0xef, 0x30, 0x9c, 0xd3, 0xef, 0x45, 0xb9, 0x87, 0xc7, 0xb8, 0xdd,
-	0x82, 0x86, 0x5a, 0xc3, 0xed, 0xbf, 0x02, 0x5a, 0x5e, 0x36, 0xe5, 0x18, 0xe1, 0x82, 0x24, 0xc2,
-	0xa9, 0x36, 0x5f, 0x47, 0x31, 0x4f, 0x75, 0x07, 0x7e, 0x0a, 0x1d, 0xca, 0x3c, 0xa7, 0xfa, 0x08,
-	0x26, 0x65, 0x9e, 0x96, 0xdb, 0x07, 0xb0, 0xf1, 0xc0, 0x0a, 0x8a, 0xb6, 0xa1, 0x9d, 0xf6, 0x79,
-	0x36, 0x4c, 0x97, 0x00, 0x25, 0x57, 0xb0, 0x8f, 0x60, 0xf3, 0xa1, 0xb5, 0x0e, 0xed, 0x16, 0x68,
-	0xa7, 0x7d, 0xe4, 0x9f, 0x0d, 0xa9, 0xa2, 0xc6, 0xca, 0x1c, 0x04, 0xed, 0xff, 0x18, 0xd0, 0xab,
-	0x88, 0x8a, 0x7e, 0x35, 0x4a, 0xfd, 0xfa, 0xfe, 0x16, 0xff, 0x14, 0xa0, 0x80, 0x84, 0xb4, 0xcf,
-	0x4b, 0x1c, 0xf4, 0x31, 0x98, 0x17, 0x41, 0xe4, 0x5e, 0xc9, 0x9c, 0xa8, 0xc6, 0xaa, 0xe3, 0xb6,
-	0x62, 0x9c, 0xd2, 0x6b, 0xb4, 0x05, 0x5d, 0x99, 0x2a, 0x9f, 0x39, 0x8a, 0xa5, 0x36, 0xa3, 0x3a,
-	0x06, 0x4e, 0xaf, 0x27, 0xec, 0x40, 0x72, 0xec, 0xef, 0xe1, 0xc9, 0x83, 0x3b, 0x28, 0xda, 0x5b,
-	0xda, 0x3f, 0x9e, 0x2e, 0x5c, 0x77, 0xa4, 0xc5, 0xa5, 0x2d, 0xe4, 0x1c, 0xfa, 0x55, 0x19, 0xfa,
-	0x0a, 0x9a, 0x3a, 0x1b, 0x69, 0xe1, 0x3f, 0x92, 0xb2, 0x54, 0xa9, 0xfc, 0x17, 0x82, 0x2e, 0xfb,
-	0x1c, 0x9e, 0xff, 0x9c, 0xbb, 0xce, 0x20, 0xf4, 0x05, 0xac, 0x89, 0x3b, 0xa7, 0x72, 0xbd, 0x74,
-	0x65, 0x13, 0x77, 0xa7, 0xf9, 0x05, 0xab, 0x2e, 0xcb, 0xff, 0x4a, 0xd8, 0x5f, 0xc0, 0xda, 0xc2,
-	0xca, 0x2f, 0x9b, 0x8e, 0x26, 0x49, 0x94, 0xa4, 0xef, 0xa3, 0x09, 0xfb, 0x1d, 0x98, 0xf9, 0xe2,
-	0x26, 0x67, 0x40, 0x09, 0xae, 0xd5, 0x6f, 0x19, 0xe3, 0x96, 0x26, 0x5c, 0x3e, 0x90, 0x7e, 0xbf,
-	0x8c, 0x7c, 0xdf, 0xee, 0xf2, 0xeb, 0x3f, 0x42, 0xa7, 0x34, 0x0b, 0x17, 0xd7, 0xf3, 0x1e, 0x98,
-	0x07, 0x6f, 0xde, 0x1e, 0x7e, 0xef, 0x4c, 0x4f, 0x8f, 0x06, 0x86, 0xdc, 0xc2, 0x27, 0xc3, 0xd1,
-	0xf1, 0xd9, 0xe4, 0xec, 0x5c, 0x71, 0x56, 0xf6, 0xfe, 0x0e, 0x4d, 0xbd, 0x8b, 0xa0, 0x6f, 0xa0,
-	0xab, 0x7f, 0x9d, 0x8a, 0x84, 0x92, 0x10, 0x2d, 0x35, 0xf6, 0xf3, 0x25, 0x8e, 0x5d, 0x7b, 0x69,
-	0xbc, 0x32, 0xd0, 0xe7, 0x50, 0x3f, 0xf1, 0xd9, 0x0c, 0x55, 0x3f, 0x93, 0x9f, 0x57, 0x49, 0xbb,
-	0x76, 0xf0, 0xd5, 0xdf, 0xb6, 0x67, 0xbe, 0x98, 0xdf, 0x5c, 0xec, 0xb8, 0x51, 0xb8, 0x3b, 0xbf,
-	0x8f, 0x69, 0xa2, 0xf7, 0xe2, 0xdd, 0x4b, 0x72, 0x91, 0xf8, 0xee, 0xae, 0xfa, 0x87, 0x8a, 0xef,
-	0x6a, 0xb3, 0x8b, 0xa6, 0x22, 0xbf, 0xfe, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xea, 0xe6, 0xec,
-	0x72, 0xc8, 0x12, 0x00, 0x00,
+	// 2007 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdd, 0x52, 0xe3, 0xc8,
+	0xf5, 0xb7, 0xc0, 0x36, 0xf6, 0xf1, 0xe7, 0xf4, 0x30, 0x8c, 0x96, 0x99, 0xff, 0xfe, 0x89, 0x92,
+	0xd9, 0x25, 0xcb, 0x2e, 0xcc, 0xb0, 0x49, 0x65, 0x53, 0x9b, 0x64, 0xca, 0x80, 0x17, 0x3b, 0x3b,
+	0x36, 0x8e, 0xcc, 0x54, 0x42, 0x6e, 0x54, 0x42, 0x6a, 0x6c, 0x05, 0xa9, 0x25, 0xd4, 0x0d, 0x03,
+	0x4f, 0x90, 0xeb, 0x5c, 0xa6, 0x2a, 0x37, 0xb9, 0xca, 0x4d, 0x9e, 0x21, 0xd7, 0x79, 0xac, 0x54,
+	0x77, 0xeb, 0xa3, 0x65, 0x03, 0x95, 0xd9, 0xaa, 0xdc, 0xe9, 0x7c, 0x76, 0x9f, 0xd3, 0xa7, 0x7f,
+	0xe7, 0xb4, 0x60, 0x7d, 0x16, 0x52, 0xea, 0x45, 0x7b, 0x01, 0xa6, 0xd4, 0x9e, 0xe1, 0xdd, 0x28,
+	0x0e, 0x59, 0x88, 0xaa, 0x92, 0x6b, 0xfc, 0x4b, 0x83, 0x5a, 0x9f, 0xdc, 0x60, 0x3f, 0x8c, 0x30,
+	0xd2, 0x61, 0x2d, 0xb2, 0xef, 0xfc, 0xd0, 0x76, 0x75, 0x6d, 0x4b, 0xdb, 0x6e, 0x9a, 0x29, 0x89,
+	0x5e, 0x42, 0x9d, 0x7a, 0x33, 0x62, 0xb3, 0xeb, 0x18, 0xeb, 0x2b, 0x42, 0x96, 0x33, 0xd0, 0x5b,
+	0xe8, 0x50, 0xec, 0xc4, 0x98, 0x59, 0x38, 0x71, 0xa5, 0xaf, 0x6e, 0x69, 0xdb, 0x8d, 0xfd, 0x8d,
+	0x5d, 0xb9, 0xcc, 0xee, 0x54, 0x88, 0xd3, 0x85, 0xcc, 0x36, 0x2d, 0xd0, 0xe8, 0x97, 0xd0, 0x70,
+	0xc2, 0x20, 0x8a, 0x31, 0xa5, 0x5e, 0x48, 0xf4, 0xf2, 0x96, 0xb6, 0xdd, 0xde, 0x7f, 0x9e, 0x1a,
+	0x1f, 0xe6, 0xa2, 0xd3, 0xbb, 0x08, 0x9b, 0xaa, 0xae, 0x31, 0x80, 0x76, 0xd1, 0xf9, 0x0f, 0x8d,
+	0xc2, 0xe8, 0x41, 0x55, 0x7a, 0x42, 0x5f, 0x42, 0xd7, 0x23, 0x0c, 0xc7, 0xc4, 0xf6, 0xfb, 0xc4,
+	0x8d, 0x42, 0x8f, 0x30, 0xe1, 0xaa, 0x3e, 0x28, 0x99, 0x4b, 0x92, 0x83, 0x3a, 0xac, 0x39, 0x21,
+	0x61, 0x98, 0x30, 0xe3, 0x6f, 0x4d, 0x68, 0x1d, 0x8b, 0x4d, 0x8f, 0x64, 0xb6, 0xd1, 0x3a, 0x54,
+	0x48, 0x48, 0x1c, 0x2c, 0xec, 0xcb, 0xa6, 0x24, 0xf8, 0x16, 0x9d, 0xb9, 0x4d, 0x08, 0xf6, 0x93,
+	0x6d, 0xa4, 0x24, 0xda, 0x81, 0x55, 0x66, 0xcf, 0x44, 0xfa, 0xda, 0xfb, 0x9f, 0xa4, 0x19, 0x28,
+	0xf8, 0xdc, 0x3d, 0xb5, 0x67, 0x26, 0xd7, 0x42, 0x5f, 0x43, 0xdd, 0xf6, 0xbd, 0x1b, 0x6c, 0x05,
+	0x74, 0xa6, 0x57, 0x44, 0xc6, 0xd7, 0x53, 0x93, 0x1e, 0x17, 0x24, 0x16, 0x83, 0x92, 0x59, 0x13,
+	0x8a, 0x23, 0x3a, 0x43, 0x3f, 0x83, 0xb5, 0x00, 0x07, 0x56, 0x8c, 0xaf, 0xf4, 0xaa, 0x30, 0xc9,
+	0x56, 0x19, 0xe1, 0xe0, 0x1c, 0xc7, 0x74, 0xee, 0x45, 0x26, 0xbe, 0xba, 0xc6, 0x94, 0x0d, 0x4a,
+	0x66, 0x35, 0xc0, 0x81, 0x89, 0xaf, 0xd0, 0xcf, 0x53, 0x2b, 0xaa, 0xaf, 0x09, 0xab, 0xcd, 0xfb,
+	0xac, 0x68, 0x14, 0x12, 0x8a, 0x33, 0x33, 0x8a, 0x5e, 0x43, 0xcd, 0xb5, 0x99, 0x2d, 0x36, 0x58,
+	0x13, 0x76, 0x4f, 0x53, 0xbb, 0x23, 0x9b, 0xd9, 0xf9, 0xfe, 0xd6, 0xb8, 0x1a, 0xdf, 0xde, 0x0e,
+	0x54, 0xe6, 0xd8, 0xf7, 0x43, 0xbd, 0x5e, 0x54, 0x97, 0x29, 0x18, 0x70, 0xd1, 0xa0, 0x64, 0x4a,
+	0x1d, 0xb4, 0x97, 0xb8, 0x77, 0xbd, 0x99, 0x0e, 0x42, 0x1f, 0xa9, 0xee, 0x8f, 0xbc, 0x99, 0x8c,
+	0x42, 0x78, 0x3f, 0xf2, 0x66, 0xd9, 0x7e, 0x78, 0xf4, 0x8d, 0xe5, 0xfd, 0xe4, 0x71, 0x0b, 0x0b,
+	0x19, 0x78, 0x43, 0x58, 0x5c, 0x47, 0xae, 0xcd, 0xb0, 0xde, 0x5c, 0x5e, 0xe5, 0xbd, 0x90, 0x0c,
+	0x4a, 0x26, 0xb8, 0x19, 0x85, 0x5e, 0x41, 0x05, 0x07, 0x11, 0xbb, 0xd3, 0x5b, 0xc2, 0xa0, 0x95,
+	0x1a, 0xf4, 0x39, 0x93, 0x07, 0x20, 0xa4, 0x68, 0x07, 0xca, 0x4e, 0x48, 0x88, 0xde, 0x16, 0x5a,
+	0xcf, 0xf2, 0x8a, 0x27, 0xa4, 0x4f, 0x99, 0x7d, 0xee, 0x7b, 0x74, 0x3e, 0x28, 0x99, 0x42, 0x09,
+	0xed, 0x03, 0x50, 0x66, 0x33, 0x6c, 0x79, 0xe4, 0x22, 0xd4, 0x3b, 0xc2, 0xe4, 0x49, 0x76, 0xc3,
+	0xb8, 0x64, 0x48, 0x2e, 0x78, 0x76, 0xea, 0x34, 0x25, 0xd0, 0x01, 0xb4, 0xa5, 0x0d, 0x25, 0x76,
+	0x44, 0xe7, 0x21, 0xd3, 0xbb, 0xc5, 0x43, 0xcf, 0xec, 0xa6, 0x89, 0xc2, 0xa0, 0x64, 0xb6, 0x84,
+	0x49, 0xca, 0x40, 0x23, 0x78, 0x9a, 0xaf, 0x6b, 0x45, 0xd7, 0xbe, 0x2f, 0xf2, 0xf7, 0x44, 0x38,
+	0x7a, 0xb9, 0xe4, 0x68, 0x72, 0xed, 0xfb, 0x79, 0x22, 0xbb, 0x74, 0x81, 0x8f, 0x7a, 0x20, 0xfd,
+	0x5b, 0xb1, 0x54, 0xd2, 0x51, 0xb1, 0xa0, 0x4c, 0x1c, 0x84, 0x0c, 0x0b, 0x77, 0xb9, 0x9b, 0x26,
+	0x55, 0x68, 0x74, 0x94, 0x46, 0x15, 0x27, 0x25, 0xa7, 0x3f, 0x15, 0x3e, 0x5e, 0xdc, 0xeb, 0x23,
+	0xab, 0xca, 0x16, 0x55, 0x19, 0x3c, 0x37, 0x3e, 0xb6, 0x5d, 0x59, 0xbc, 0xa2, 0x44, 0xd7, 0x8b,
+	0xb9, 0x79, 0x97, 0x49, 0xf3, 0x42, 0x6d, 0xe5, 0x26, 0xbc, 0x5c, 0xbf, 0x85, 0x56, 0x84, 0x71,
+	0x6c, 0x79, 0x2e, 0x26, 0xcc, 0x63, 0x77, 0xfa, 0xb3, 0xe2, 0x35, 0x9c, 0x60, 0x1c, 0x0f, 0x13,
+	0x19, 0x0f, 0x23, 0x52, 0x68, 0x7e, 0xd9, 0x6d, 0xe7, 0x52, 0xdf, 0x10, 0x26, 0x19, 0xdc, 0xf5,
+	0x9c, 0x4b, 0x12, 0x7e, 0xf0, 0xb1, 0x3b, 0xc3, 0x01, 0x26, 0x3c, 0x78, 0xae, 0x85, 0x7e, 0x03,
+	0x10, 0xc5, 0xde, 0x8d, 0xcc, 0x82, 0xfe, 0xbc, 0x98, 0x7c, 0x19, 0xef, 0xe4, 0x86, 0x15, 0xab,
+	0x58, 0xb1, 0x40, 0x6f, 0x15, 0x7b, 0xaa, 0xeb, 0xc2, 0xfe, 0xff, 0x1e, 0xb0, 0xcf, 0x32, 0xa6,
+	0x98, 0xa0, 0xb7, 0xd0, 0x4c, 0x28, 0x8b, 0x17, 0xba, 0xfe, 0x49, 0xf1, 0xd8, 0x26, 0x52, 0x56,
+	0xbc, 0xd6, 0x8d, 0x28, 0xe7, 0xa2, 0x01, 0x74, 0x63, 0x6c, 0x3b, 0x73, 0xfb, 0xdc, 0xf3, 0x3d,
+	0x76, 0x27, 0x8a, 0x68, 0x73, 0xf1, 0xdc, 0x72, 0x79, 0x1e, 0x46, 0x27, 0x2e, 0xb2, 0xd1, 0x70,
+	0xc9, 0x13, 0xd5, 0x5f, 0x2c, 0x66, 0x44, 0x35, 0xc9, 0x02, 0x5a, 0x70, 0x45, 0x0d, 0x0b, 0x56,
+	0x4f, 0xed, 0x19, 0x6a, 0x41, 0xfd, 0xfd, 0xf8, 0xa8, 0xff, 0xdd, 0x70, 0xdc, 0x3f, 0xea, 0x96,
+	0x50, 0x1d, 0x2a, 0xfd, 0xd1, 0xe4, 0xf4, 0xac, 0xab, 0xa1, 0x26, 0xd4, 0x4e, 0xcc, 0x63, 0xeb,
+	0x64, 0xfc, 0xee, 0xac, 0xbb, 0xc2, 0xf5, 0x0e, 0x07, 0xbd, 0xb1, 0x24, 0x57, 0x51, 0x17, 0x9a,
+	0x82, 0xec, 0x8d, 0x8f, 0xac, 0x13, 0xf3, 0xb8, 0x5b, 0x46, 0x1d, 0x68, 0x48, 0x05, 0x53, 0x30,
+	0x2a, 0x6a, 0x7b, 0xf8, 0x87, 0x06, 0xf5, 0xec, 0x9a, 0xa0, 0x5d, 0xa8, 0x33, 0x2f, 0xc0, 0x94,
+	0xd9, 0x41, 0x24, 0xda, 0x40, 0x63, 0xbf, 0xab, 0x96, 0xcd, 0xa9, 0x17, 0x60, 0x33, 0x57, 0x41,
+	0xcf, 0xa0, 0x1a, 0x5d, 0x7a, 0x96, 0xe7, 0x8a, 0xee, 0xd0, 0x34, 0x2b, 0xd1, 0xa5, 0x37, 0x74,
+	0xd1, 0xff, 0x43, 0x23, 0x69, 0x1e, 0xd6, 0xa8, 0x77, 0x28, 0x7a, 0x67, 0xd3, 0x84, 0x84, 0x35,
+	0xea, 0x1d, 0x72, 0xd8, 0x88, 0xe2, 0x30, 0xc2, 0x31, 0xf3, 0x30, 0xd5, 0x2b, 0x45, 0x00, 0x9b,
+	0x64, 0x12, 0x53, 0xd1, 0x32, 0xfe, 0xac, 0x01, 0xe4, 0x22, 0xf4, 0x63, 0x68, 0x89, 0x7a, 0x8c,
+	0xad, 0x39, 0xf6, 0x66, 0x73, 0x96, 0x74, 0xb3, 0xa6, 0x64, 0x0e, 0x04, 0x0f, 0xfd, 0x08, 0x9a,
+	0x3e, 0xbe, 0x60, 0x96, 0xda, 0xd9, 0x6a, 0x66, 0x83, 0xf3, 0x0e, 0x25, 0x0b, 0xbd, 0x01, 0xbe,
+	0x31, 0x8f, 0x38, 0xa1, 0x8b, 0xa9, 0xbe, 0xba, 0xb5, 0xaa, 0x22, 0xd8, 0x61, 0x2a, 0x31, 0x15,
+	0x25, 0xa3, 0x07, 0x4f, 0x96, 0x20, 0x0a, 0x7d, 0x09, 0x35, 0xec, 0x8b, 0xdb, 0x41, 0x75, 0x6d,
+	0x6b, 0x55, 0xcd, 0x5c, 0x36, 0x63, 0x64, 0x1a, 0xc6, 0x2f, 0x60, 0xfd, 0x3e, 0x70, 0x5a, 0xcc,
+	0x9c, 0xb6, 0x98, 0x39, 0xe3, 0xdf, 0x1a, 0xb4, 0x0a, 0x50, 0xac, 0x9c, 0x81, 0xa6, 0x9e, 0xc1,
+	0x26, 0xd4, 0x32, 0x00, 0x90, 0x0d, 0x3d, 0xa3, 0x91, 0x01, 0x2d, 0xe6, 0x53, 0xcb, 0xc1, 0x31,
+	0xb3, 0xe6, 0x36, 0x9d, 0x27, 0xa7, 0xd7, 0x60, 0x3e, 0x3d, 0xc4, 0x31, 0x1b, 0xd8, 0x74, 0xbe,
+	0x3c, 0xff, 0xac, 0xfe, 0xb7, 0xf3, 0x0f, 0x7a, 0x05, 0xed, 0xb9, 0x4d, 0x5c, 0x3a, 0xb7, 0x2f,
+	0xb1, 0x15, 0x12, 0xff, 0x4e, 0x9c, 0x70, 0xcd, 0x6c, 0x65, 0xdc, 0x13, 0xe2, 0xdf, 0x19, 0xef,
+	0xa1, 0xa9, 0x42, 0xd1, 0x43, 0x81, 0x20, 0x28, 0xf3, 0x8d, 0x26, 0x41, 0x88, 0x6f, 0x1e, 0x5c,
+	0x80, 0x99, 0x2d, 0xee, 0xbc, 0xdc, 0x7b, 0x46, 0x1b, 0x01, 0x34, 0x14, 0xc4, 0x79, 0x78, 0xda,
+	0x71, 0x45, 0x27, 0xa6, 0xfa, 0xca, 0xd6, 0xea, 0x76, 0xdd, 0x4c, 0x49, 0xb4, 0x0b, 0xb5, 0x80,
+	0xce, 0x2c, 0x76, 0x97, 0x4c, 0x8c, 0xed, 0xbc, 0x1d, 0xf3, 0x83, 0x1a, 0xd1, 0x99, 0x08, 0x78,
+	0x2d, 0x90, 0x1f, 0x46, 0x08, 0x0d, 0x65, 0x0e, 0x78, 0x60, 0x39, 0x75, 0xbf, 0x2b, 0xc5, 0xfd,
+	0x7e, 0xf4, 0x82, 0xb7, 0x00, 0x79, 0x8b, 0x7f, 0x60, 0xbd, 0x9f, 0x40, 0x39, 0x59, 0xeb, 0xfe,
+	0x42, 0x2c, 0xff, 0xa0, 0x95, 0x7d, 0x80, 0x7c, 0x84, 0xf9, 0x9f, 0x27, 0xf6, 0x1b, 0x68, 0x28,
+	0xc0, 0x8d, 0x7e, 0x5a, 0x1c, 0xa1, 0x1b, 0xfb, 0x9d, 0xcc, 0x5a, 0xb2, 0xb3, 0x99, 0xda, 0xf8,
+	0x0e, 0xd0, 0x32, 0xf2, 0xa3, 0xd7, 0x8b, 0x0e, 0x36, 0x16, 0xda, 0xc4, 0x92, 0x9f, 0x33, 0x58,
+	0x4b, 0x78, 0xe8, 0x39, 0xac, 0x51, 0x7c, 0x65, 0x91, 0xeb, 0x20, 0x09, 0xb7, 0x4a, 0xf1, 0xd5,
+	0xf8, 0x3a, 0xe0, 0xd5, 0xa9, 0x9c, 0xaa, 0xf8, 0xe6, 0xa8, 0x53, 0xe8, 0x4a, 0x1c, 0x54, 0x9a,
+	0x85, 0xbe, 0x63, 0xfc, 0x45, 0x83, 0x76, 0x71, 0x59, 0xf4, 0x39, 0x74, 0x9c, 0xd0, 0xf7, 0xb1,
+	0xc3, 0xbc, 0x90, 0x58, 0xc4, 0x0e, 0x64, 0x66, 0xeb, 0x66, 0x3b, 0x67, 0x8f, 0xed, 0x00, 0xf3,
+	0x27, 0x03, 0x97, 0xd2, 0xc8, 0x76, 0xe4, 0x93, 0xa1, 0x6e, 0xe6, 0x0c, 0xf4, 0x14, 0x2a, 0xec,
+	0x36, 0x45, 0xe4, 0xba, 0x59, 0x66, 0xb7, 0x43, 0x97, 0x83, 0x65, 0xba, 0xa3, 0xf8, 0x03, 0xc5,
+	0x2c, 0x81, 0xe4, 0x74, 0x9b, 0x26, 0xe7, 0x19, 0xff, 0xd4, 0xa0, 0xa9, 0x8e, 0xe8, 0x68, 0x17,
+	0x20, 0xc8, 0x26, 0xe9, 0x24, 0x69, 0xed, 0xe2, 0x8c, 0x6d, 0x2a, 0x1a, 0x1f, 0xdd, 0x3d, 0x54,
+	0x88, 0x2a, 0x2f, 0x40, 0xd4, 0x16, 0x34, 0xae, 0x49, 0xd2, 0x18, 0x7d, 0x9c, 0x00, 0x88, 0xca,
+	0x32, 0xfe, 0xaa, 0xc1, 0x93, 0xa5, 0x69, 0xe8, 0x21, 0x10, 0xf9, 0xd8, 0xad, 0xbd, 0x82, 0xb6,
+	0x47, 0x2d, 0x17, 0x3b, 0xbe, 0x1d, 0xdb, 0x3c, 0xf3, 0x22, 0x9d, 0x35, 0xb3, 0xe5, 0xd1, 0xa3,
+	0x9c, 0x89, 0x36, 0xa0, 0xfa, 0x41, 0x76, 0x1f, 0xbe, 0xff, 0x96, 0x99, 0x50, 0xc6, 0xaf, 0xa0,
+	0x96, 0x7a, 0xe5, 0xa5, 0xe3, 0x11, 0x47, 0x2d, 0x1d, 0x8f, 0x38, 0xbc, 0x74, 0x94, 0x9a, 0x5a,
+	0x51, 0x6b, 0xca, 0xb8, 0x80, 0x27, 0x4b, 0xef, 0x1e, 0xf4, 0x2d, 0x74, 0x29, 0xf6, 0x2f, 0xc4,
+	0xc0, 0x1b, 0x07, 0x72, 0x4f, 0xda, 0x96, 0x76, 0xef, 0xf5, 0xee, 0x70, 0xcd, 0x61, 0xae, 0xc8,
+	0xef, 0x2a, 0x1f, 0xe0, 0x88, 0xb8, 0x93, 0x4d, 0x53, 0x12, 0xc6, 0x39, 0xa0, 0xe5, 0x97, 0x12,
+	0xfa, 0x0c, 0x2a, 0xe2, 0x61, 0xf6, 0x60, 0x17, 0x93, 0x62, 0x81, 0x31, 0xd8, 0x76, 0x1f, 0xc1,
+	0x18, 0x6c, 0xbb, 0xc6, 0xef, 0xa1, 0x2a, 0xd7, 0xe0, 0xa7, 0x8d, 0x0b, 0x2f, 0x57, 0x33, 0xa3,
+	0x1f, 0xc5, 0xc7, 0xfb, 0x67, 0x0c, 0x63, 0x0d, 0x2a, 0xe2, 0xe1, 0x62, 0xfc, 0x01, 0xd0, 0xf2,
+	0x78, 0xce, 0x5b, 0x1c, 0x65, 0x76, 0xcc, 0xac, 0xe2, 0xb5, 0x6d, 0x08, 0xe6, 0x54, 0xde, 0xdd,
+	0x4f, 0xa1, 0x81, 0x89, 0x6b, 0x15, 0x0f, 0xa1, 0x8e, 0x89, 0x2b, 0xe5, 0xc6, 0x01, 0x3c, 0xbd,
+	0x67, 0x68, 0x47, 0x3b, 0x50, 0x4b, 0x10, 0x22, 0xed, 0xf4, 0x4b, 0x50, 0x94, 0x29, 0x18, 0xc7,
+	0xb0, 0x7e, 0xdf, 0x20, 0x8c, 0xf6, 0x72, 0x9c, 0x94, 0x3e, 0xb2, 0x87, 0x56, 0xa2, 0x28, 0x51,
+	0x36, 0x83, 0x4f, 0xe3, 0xef, 0x1a, 0xb4, 0x0a, 0xa2, 0xfc, 0xa6, 0x6b, 0xca, 0x4d, 0x7f, 0x1c,
+	0x1c, 0x3e, 0x05, 0xc8, 0xc1, 0x24, 0x41, 0x08, 0x85, 0x83, 0x5e, 0x40, 0xfd, 0xdc, 0x0f, 0x9d,
+	0x4b, 0x9e, 0x13, 0x51, 0xd2, 0x65, 0xb3, 0x26, 0x18, 0x53, 0x7c, 0x85, 0xb6, 0xa0, 0xc9, 0x53,
+	0xe5, 0x11, 0x4b, 0xb0, 0xc4, 0x9d, 0x2c, 0x9b, 0x40, 0xf1, 0xd5, 0x90, 0x1c, 0x70, 0x8e, 0xf1,
+	0x3d, 0x3c, 0xbb, 0x77, 0x6a, 0x47, 0xfb, 0x4b, 0xc3, 0xd1, 0xc6, 0x42, 0xb8, 0x7d, 0x29, 0x56,
+	0x46, 0xa4, 0x33, 0x68, 0x17, 0x65, 0xe8, 0x2b, 0xa8, 0xca, 0x6c, 0x24, 0x85, 0xff, 0x40, 0xca,
+	0x12, 0x25, 0xf5, 0xa7, 0x8b, 0x2c, 0xfb, 0x94, 0x34, 0x7e, 0x97, 0xb9, 0x4e, 0xc1, 0xf7, 0x15,
+	0x74, 0xd8, 0xad, 0x55, 0x08, 0x2f, 0x99, 0x27, 0xd9, 0xed, 0x34, 0x0b, 0xb0, 0xe8, 0x52, 0xfd,
+	0x8f, 0x63, 0x7c, 0x0e, 0x9d, 0x85, 0x47, 0x12, 0xbf, 0x74, 0x38, 0x8e, 0xc3, 0x38, 0x39, 0x1f,
+	0x49, 0x18, 0xef, 0xa1, 0x9e, 0x4d, 0x95, 0xbc, 0x7b, 0x28, 0x40, 0x2f, 0xbe, 0xf9, 0x1a, 0x37,
+	0x38, 0x16, 0x43, 0x97, 0x3c, 0xbf, 0x94, 0x7c, 0x74, 0xea, 0x79, 0xc3, 0x6b, 0x75, 0xe9, 0xa1,
+	0xf2, 0xd8, 0xa5, 0x33, 0x7e, 0x0b, 0xeb, 0x45, 0x93, 0xe4, 0xb0, 0x5e, 0x42, 0x3d, 0x07, 0x5e,
+	0x4d, 0xc0, 0x5e, 0xce, 0xc8, 0xa3, 0x5a, 0x51, 0xa2, 0xfa, 0xe2, 0x0d, 0x74, 0x16, 0x46, 0x42,
+	0x84, 0xa0, 0x3d, 0x3e, 0xb1, 0x0e, 0x4f, 0x46, 0x13, 0xb3, 0x3f, 0x9d, 0x0e, 0x4f, 0xc6, 0xdd,
+	0x12, 0x02, 0xa8, 0x4e, 0xc7, 0xbd, 0xc9, 0xe4, 0xac, 0xab, 0x7d, 0xf1, 0x6b, 0x68, 0x28, 0x7d,
+	0x7f, 0xf1, 0xb5, 0xd3, 0x82, 0xfa, 0xc1, 0xbb, 0x93, 0xc3, 0xef, 0xad, 0xd1, 0xf4, 0xb8, 0xab,
+	0xf1, 0x47, 0xcd, 0xf0, 0xa8, 0x3f, 0x3e, 0x1d, 0x9e, 0x9e, 0x09, 0xce, 0xca, 0xfe, 0x9f, 0xa0,
+	0x2a, 0xe7, 0x2e, 0xf4, 0x0d, 0x34, 0xe5, 0xd7, 0x94, 0xc5, 0xd8, 0x0e, 0xd0, 0x12, 0x14, 0x6d,
+	0x2e, 0x71, 0x8c, 0xd2, 0xb6, 0xf6, 0x5a, 0x43, 0x9f, 0x41, 0x79, 0xe2, 0x91, 0x19, 0x2a, 0xfe,
+	0x0a, 0xd9, 0x2c, 0x92, 0x46, 0xe9, 0xe0, 0xab, 0x3f, 0xee, 0xcc, 0x3c, 0x36, 0xbf, 0x3e, 0xdf,
+	0x75, 0xc2, 0x60, 0x6f, 0x7e, 0x17, 0xe1, 0x58, 0x3e, 0x33, 0xf6, 0x2e, 0xec, 0xf3, 0xd8, 0x73,
+	0xf6, 0xc4, 0x0f, 0x4c, 0xba, 0x27, 0xcd, 0xce, 0xab, 0x82, 0xfc, 0xfa, 0x3f, 0x03, 0x00, 0x09,
+	0xb3, 0xfe, 0x21, 0xe7, 0x14, 0x00, 0x00,
 }
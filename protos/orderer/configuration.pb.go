@@ -12,8 +12,70 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+// State defines the orderer mode of operation, typically used for consensus-type migration.
+// NORMAL is during normal operation, when consensus-type migration is not, and can not, take place.
+// MAINTENANCE is when the consensus-type can be changed.
+type ConsensusType_State int32
+
+const (
+	ConsensusType_STATE_NORMAL      ConsensusType_State = 0
+	ConsensusType_STATE_MAINTENANCE ConsensusType_State = 1
+)
+
+var ConsensusType_State_name = map[int32]string{
+	0: "STATE_NORMAL",
+	1: "STATE_MAINTENANCE",
+}
+var ConsensusType_State_value = map[string]int32{
+	"STATE_NORMAL":      0,
+	"STATE_MAINTENANCE": 1,
+}
+
+func (x ConsensusType_State) String() string {
+	return proto.EnumName(ConsensusType_State_name, int32(x))
+}
+func (ConsensusType_State) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{0, 0} }
+
+type BatchingStrategy_Type int32
+
+const (
+	// Batches are cut only on the BatchSize limits and on the BatchTimeout
+	BatchingStrategy_DEFAULT BatchingStrategy_Type = 0
+	// The batch timeout is shortened when the ingress rate is too low to fill a batch
+	// within the BatchTimeout, down to min_batch_timeout
+	BatchingStrategy_ADAPTIVE_TIMEOUT BatchingStrategy_Type = 1
+	// PEER_RESOURCE_UPDATE messages are never batched with other messages and are cut immediately.
+	// Channel config messages are always cut in blocks of their own, whatever the strategy
+	BatchingStrategy_CONFIG_LANE BatchingStrategy_Type = 2
+	// Every batch carries messages of a single transaction (header) type
+	BatchingStrategy_TYPE_ISOLATION BatchingStrategy_Type = 3
+)
+
+var BatchingStrategy_Type_name = map[int32]string{
+	0: "DEFAULT",
+	1: "ADAPTIVE_TIMEOUT",
+	2: "CONFIG_LANE",
+	3: "TYPE_ISOLATION",
+}
+var BatchingStrategy_Type_value = map[string]int32{
+	"DEFAULT":          0,
+	"ADAPTIVE_TIMEOUT": 1,
+	"CONFIG_LANE":      2,
+	"TYPE_ISOLATION":   3,
+}
+
+func (x BatchingStrategy_Type) String() string {
+	return proto.EnumName(BatchingStrategy_Type_name, int32(x))
+}
+func (BatchingStrategy_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{3, 0} }
+
 type ConsensusType struct {
+	// The consensus type: "solo" or "kafka".
 	Type string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	// Opaque metadata, dependent on the consensus type.
+	Metadata []byte `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// The state signals the ordering service to go into maintenance mode, typically for consensus-type migration.
+	State ConsensusType_State `protobuf:"varint,3,opt,name=state,enum=orderer.ConsensusType_State" json:"state,omitempty"`
 }
 
 func (m *ConsensusType) Reset()                    { *m = ConsensusType{} }
@@ -28,6 +90,20 @@ func (m *ConsensusType) GetType() string {
 	return ""
 }
 
+func (m *ConsensusType) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *ConsensusType) GetState() ConsensusType_State {
+	if m != nil {
+		return m.State
+	}
+	return ConsensusType_STATE_NORMAL
+}
+
 type BatchSize struct {
 	// Simply specified as number of messages for now, in the future
 	// we may want to allow this to be specified by size in bytes
@@ -84,6 +160,34 @@ func (m *BatchTimeout) GetTimeout() string {
 	return ""
 }
 
+// BatchingStrategy selects how the block cutter groups ordered messages into batches.
+// When it is not set, batches are cut only on the BatchSize limits and on the BatchTimeout.
+type BatchingStrategy struct {
+	Type BatchingStrategy_Type `protobuf:"varint,1,opt,name=type,enum=orderer.BatchingStrategy_Type" json:"type,omitempty"`
+	// The lower bound of the batch timeout for the ADAPTIVE_TIMEOUT strategy, any duration string
+	// parseable by ParseDuration(). If unset, a tenth of the BatchTimeout is used.
+	MinBatchTimeout string `protobuf:"bytes,2,opt,name=min_batch_timeout,json=minBatchTimeout" json:"min_batch_timeout,omitempty"`
+}
+
+func (m *BatchingStrategy) Reset()                    { *m = BatchingStrategy{} }
+func (m *BatchingStrategy) String() string            { return proto.CompactTextString(m) }
+func (*BatchingStrategy) ProtoMessage()               {}
+func (*BatchingStrategy) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+
+func (m *BatchingStrategy) GetType() BatchingStrategy_Type {
+	if m != nil {
+		return m.Type
+	}
+	return BatchingStrategy_DEFAULT
+}
+
+func (m *BatchingStrategy) GetMinBatchTimeout() string {
+	if m != nil {
+		return m.MinBatchTimeout
+	}
+	return ""
+}
+
 // Carries a list of bootstrap brokers, i.e. this is not the exclusive set of
 // brokers an ordering service
 type KafkaBrokers struct {
@@ -95,7 +199,7 @@ type KafkaBrokers struct {
 func (m *KafkaBrokers) Reset()                    { *m = KafkaBrokers{} }
 func (m *KafkaBrokers) String() string            { return proto.CompactTextString(m) }
 func (*KafkaBrokers) ProtoMessage()               {}
-func (*KafkaBrokers) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+func (*KafkaBrokers) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
 
 func (m *KafkaBrokers) GetBrokers() []string {
 	if m != nil {
@@ -112,7 +216,7 @@ type ChannelRestrictions struct {
 func (m *ChannelRestrictions) Reset()                    { *m = ChannelRestrictions{} }
 func (m *ChannelRestrictions) String() string            { return proto.CompactTextString(m) }
 func (*ChannelRestrictions) ProtoMessage()               {}
-func (*ChannelRestrictions) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+func (*ChannelRestrictions) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
 
 func (m *ChannelRestrictions) GetMaxCount() uint64 {
 	if m != nil {
@@ -125,32 +229,48 @@ func init() {
 	proto.RegisterType((*ConsensusType)(nil), "orderer.ConsensusType")
 	proto.RegisterType((*BatchSize)(nil), "orderer.BatchSize")
 	proto.RegisterType((*BatchTimeout)(nil), "orderer.BatchTimeout")
+	proto.RegisterType((*BatchingStrategy)(nil), "orderer.BatchingStrategy")
 	proto.RegisterType((*KafkaBrokers)(nil), "orderer.KafkaBrokers")
 	proto.RegisterType((*ChannelRestrictions)(nil), "orderer.ChannelRestrictions")
+	proto.RegisterEnum("orderer.ConsensusType_State", ConsensusType_State_name, ConsensusType_State_value)
+	proto.RegisterEnum("orderer.BatchingStrategy_Type", BatchingStrategy_Type_name, BatchingStrategy_Type_value)
 }
 
 func init() { proto.RegisterFile("orderer/configuration.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 313 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0xd0, 0xcd, 0x4a, 0xc3, 0x40,
-	0x10, 0x07, 0x70, 0x62, 0x8b, 0xb5, 0x8b, 0x45, 0xbb, 0xbd, 0x04, 0x7a, 0x29, 0x11, 0xa1, 0x48,
-	0x49, 0x40, 0xdf, 0x20, 0x3d, 0x4a, 0x2f, 0xb1, 0x5e, 0xbc, 0x94, 0x4d, 0x3a, 0x49, 0x96, 0x36,
-	0x3b, 0x61, 0x76, 0x03, 0x89, 0xef, 0xe1, 0xfb, 0xca, 0x6e, 0x52, 0xed, 0x6d, 0x3e, 0x7e, 0x0b,
-	0xb3, 0x7f, 0xb6, 0x44, 0x3a, 0x02, 0x01, 0x45, 0x19, 0xaa, 0x5c, 0x16, 0x0d, 0x09, 0x23, 0x51,
-	0x85, 0x35, 0xa1, 0x41, 0x3e, 0x19, 0x96, 0xc1, 0x13, 0x9b, 0x6d, 0x51, 0x69, 0x50, 0xba, 0xd1,
-	0xfb, 0xae, 0x06, 0xce, 0xd9, 0xd8, 0x74, 0x35, 0xf8, 0xde, 0xca, 0x5b, 0x4f, 0x13, 0x57, 0x07,
-	0x3f, 0x1e, 0x9b, 0xc6, 0xc2, 0x64, 0xe5, 0x87, 0xfc, 0x06, 0xfe, 0xc2, 0xe6, 0x95, 0x68, 0x0f,
-	0x15, 0x68, 0x2d, 0x0a, 0x38, 0x64, 0xd8, 0x28, 0xe3, 0xf8, 0x2c, 0x79, 0xa8, 0x44, 0xbb, 0xeb,
-	0xe7, 0x5b, 0x3b, 0xe6, 0x1b, 0xc6, 0x45, 0xaa, 0xf1, 0xdc, 0x18, 0x38, 0xd8, 0x47, 0x69, 0x67,
-	0x40, 0xfb, 0x37, 0x0e, 0x3f, 0x5e, 0x36, 0x3b, 0xd1, 0xc6, 0x76, 0xce, 0x43, 0xb6, 0xa8, 0x09,
-	0x72, 0x20, 0x82, 0xe3, 0x15, 0x1f, 0x39, 0x3e, 0xff, 0x5b, 0x5d, 0x7c, 0xb0, 0x66, 0xf7, 0xee,
-	0xac, 0xbd, 0xac, 0x00, 0x1b, 0xc3, 0x7d, 0x36, 0x31, 0x7d, 0x39, 0x9c, 0x7f, 0x69, 0xad, 0x7c,
-	0x17, 0xf9, 0x49, 0xc4, 0x84, 0x27, 0x20, 0x6d, 0x65, 0xda, 0x97, 0xbe, 0xb7, 0x1a, 0x59, 0x39,
-	0xb4, 0xc1, 0x2b, 0x5b, 0x6c, 0x4b, 0xa1, 0x14, 0x9c, 0x13, 0xd0, 0x86, 0x64, 0x66, 0x53, 0xd3,
-	0x7c, 0xc9, 0xa6, 0xf6, 0xa0, 0xff, 0xcf, 0x8e, 0x93, 0xbb, 0x4a, 0xb4, 0xee, 0x97, 0xf1, 0x27,
-	0x7b, 0x46, 0x2a, 0xc2, 0xb2, 0xab, 0x81, 0xce, 0x70, 0x2c, 0x80, 0xc2, 0x5c, 0xa4, 0x24, 0xb3,
-	0x3e, 0x6d, 0x1d, 0x0e, 0x69, 0x7f, 0x6d, 0x0a, 0x69, 0xca, 0x26, 0x0d, 0x33, 0xac, 0xa2, 0x2b,
-	0x1d, 0xf5, 0x3a, 0xea, 0x75, 0x34, 0xe8, 0xf4, 0xd6, 0xf5, 0x6f, 0xbf, 0x01, 0x00, 0x00, 0xff,
-	0xff, 0x0c, 0x47, 0xa2, 0x55, 0xca, 0x01, 0x00, 0x00,
+	// 519 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x92, 0xed, 0x8a, 0xda, 0x4c,
+	0x14, 0xc7, 0x37, 0xeb, 0xee, 0xe3, 0x7a, 0xd6, 0x97, 0x38, 0xfb, 0x14, 0xa4, 0x5b, 0x8a, 0x04,
+	0x0a, 0x52, 0x96, 0x58, 0xec, 0x15, 0x44, 0x37, 0x5b, 0x42, 0x35, 0x2e, 0x31, 0x16, 0xda, 0x2f,
+	0x61, 0x12, 0x8f, 0x31, 0xac, 0xc9, 0xc8, 0xcc, 0x04, 0xb4, 0xf7, 0xd1, 0x4b, 0xe8, 0x8d, 0xf4,
+	0xca, 0xca, 0x24, 0xd1, 0xda, 0x7e, 0x3b, 0x2f, 0xbf, 0x99, 0x39, 0xff, 0xff, 0x19, 0xb8, 0x67,
+	0x7c, 0x85, 0x1c, 0xf9, 0x30, 0x62, 0xd9, 0x3a, 0x89, 0x73, 0x4e, 0x65, 0xc2, 0x32, 0x73, 0xc7,
+	0x99, 0x64, 0xa4, 0x5e, 0x35, 0x8d, 0x9f, 0x1a, 0xb4, 0x26, 0x2c, 0x13, 0x98, 0x89, 0x5c, 0xf8,
+	0x87, 0x1d, 0x12, 0x02, 0x57, 0xf2, 0xb0, 0xc3, 0x9e, 0xd6, 0xd7, 0x06, 0x0d, 0xaf, 0x88, 0xc9,
+	0x6b, 0xb8, 0x49, 0x51, 0xd2, 0x15, 0x95, 0xb4, 0x77, 0xd9, 0xd7, 0x06, 0x4d, 0xef, 0x94, 0x93,
+	0x11, 0x5c, 0x0b, 0x49, 0x25, 0xf6, 0x6a, 0x7d, 0x6d, 0xd0, 0x1e, 0xbd, 0x31, 0xab, 0xab, 0xcd,
+	0xbf, 0xae, 0x35, 0x17, 0x8a, 0xf1, 0x4a, 0xd4, 0xf8, 0x00, 0xd7, 0x45, 0x4e, 0x74, 0x68, 0x2e,
+	0x7c, 0xcb, 0xb7, 0x03, 0x77, 0xee, 0xcd, 0xac, 0xa9, 0x7e, 0x41, 0x5e, 0x41, 0xb7, 0xac, 0xcc,
+	0x2c, 0xc7, 0xf5, 0x6d, 0xd7, 0x72, 0x27, 0xb6, 0xae, 0x19, 0x3f, 0x34, 0x68, 0x8c, 0xa9, 0x8c,
+	0x36, 0x8b, 0xe4, 0x3b, 0x92, 0xf7, 0xd0, 0x4d, 0xe9, 0x3e, 0x48, 0x51, 0x08, 0x1a, 0x63, 0x10,
+	0xb1, 0x3c, 0x93, 0xc5, 0xc0, 0x2d, 0xaf, 0x93, 0xd2, 0xfd, 0xac, 0xac, 0x4f, 0x54, 0x99, 0x3c,
+	0x00, 0xa1, 0xa1, 0x60, 0xdb, 0x5c, 0x62, 0xa0, 0x0e, 0x85, 0x07, 0x89, 0xa2, 0x50, 0xd1, 0xf2,
+	0xf4, 0x63, 0x67, 0x46, 0xf7, 0x63, 0x55, 0x27, 0x26, 0xdc, 0xed, 0x38, 0xae, 0x91, 0x73, 0x5c,
+	0x9d, 0xe1, 0xb5, 0x02, 0xef, 0x9e, 0x5a, 0x47, 0xde, 0x18, 0x40, 0xb3, 0x18, 0xcb, 0x4f, 0x52,
+	0x64, 0xb9, 0x24, 0x3d, 0xa8, 0xcb, 0x32, 0xac, 0x0c, 0x3c, 0xa6, 0xc6, 0x2f, 0x0d, 0xf4, 0x02,
+	0x4d, 0xb2, 0x78, 0x21, 0x39, 0x95, 0x18, 0x1f, 0xc8, 0xe8, 0xcc, 0xec, 0xf6, 0xe8, 0xed, 0xc9,
+	0xbb, 0x7f, 0x41, 0x53, 0x79, 0x58, 0x2d, 0x43, 0x89, 0x4f, 0xb2, 0x20, 0x54, 0x48, 0x70, 0x7c,
+	0xec, 0xb2, 0x78, 0xac, 0x93, 0x26, 0xd9, 0xf9, 0x38, 0x86, 0x0b, 0x57, 0xc5, 0x52, 0x6f, 0xa1,
+	0xfe, 0x68, 0x3f, 0x59, 0xcb, 0xa9, 0xaf, 0x5f, 0x90, 0xff, 0x41, 0xb7, 0x1e, 0xad, 0x67, 0xdf,
+	0xf9, 0x62, 0x07, 0xbe, 0x33, 0xb3, 0xe7, 0x4b, 0x5f, 0xd7, 0x48, 0x07, 0x6e, 0x27, 0x73, 0xf7,
+	0xc9, 0xf9, 0x14, 0x4c, 0x2d, 0xd7, 0xd6, 0x2f, 0x09, 0x81, 0xb6, 0xff, 0xf5, 0xd9, 0x0e, 0x9c,
+	0xc5, 0x7c, 0x6a, 0xf9, 0xce, 0xdc, 0xd5, 0x6b, 0x4a, 0xee, 0x67, 0xba, 0x7e, 0xa1, 0x63, 0xce,
+	0x5e, 0x90, 0x0b, 0x25, 0x37, 0x2c, 0xc3, 0x9e, 0xd6, 0xaf, 0x29, 0xb9, 0x55, 0x6a, 0x8c, 0xe0,
+	0x6e, 0xb2, 0xa1, 0x59, 0x86, 0x5b, 0x0f, 0x85, 0xe4, 0x49, 0xa4, 0x7e, 0x9f, 0x20, 0xf7, 0xd0,
+	0x50, 0xae, 0xfe, 0xd9, 0xd8, 0x95, 0x77, 0x93, 0xd2, 0x7d, 0xb1, 0xaa, 0xf1, 0x12, 0xde, 0x31,
+	0x1e, 0x9b, 0x9b, 0xc3, 0x0e, 0xf9, 0x16, 0x57, 0x31, 0x72, 0x73, 0x4d, 0x43, 0x9e, 0x44, 0xe5,
+	0xaf, 0x15, 0x47, 0x7b, 0xbe, 0x3d, 0xc4, 0x89, 0xdc, 0xe4, 0xa1, 0x19, 0xb1, 0x74, 0x78, 0x46,
+	0x0f, 0x4b, 0x7a, 0x58, 0xd2, 0xc3, 0x8a, 0x0e, 0xff, 0x2b, 0xf2, 0x8f, 0xbf, 0x07, 0x00, 0x46,
+	0xa8, 0x3c, 0x87, 0x12, 0x03, 0x00, 0x00,
 }
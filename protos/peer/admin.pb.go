@@ -23,6 +23,19 @@ It has these top-level messages:
 	ServerStatus
 	LogLevelRequest
 	LogLevelResponse
+	OrdererHealthRequest
+	YieldLeadershipRequest
+	OrdererEndpointHealth
+	OrdererInvalidBlock
+	OrdererHealthResponse
+	GossipMember
+	GossipChannelPeer
+	GossipChannelState
+	LeadershipEvent
+	GossipIdentity
+	GossipConnection
+	GossipStateResponse
+	GossipReachability
 	AdminOperation
 	ChaincodeID
 	ChaincodeInput
@@ -37,6 +50,7 @@ It has these top-level messages:
 	GetStateByRange
 	GetQueryResult
 	GetHistoryForKey
+	GetStateAtHeight
 	QueryStateNext
 	QueryStateClose
 	QueryResultBytes
@@ -70,6 +84,13 @@ It has these top-level messages:
 	ChaincodeInfo
 	ChannelQueryResponse
 	ChannelInfo
+	PvtDataStatusResponse
+	BlockPvtDataStatus
+	MissingPvtData
+	IndexStatusResponse
+	IndexStatus
+	TxQueryResponse
+	IndexedTransaction
 	APIResource
 	ChaincodeIdentifier
 	ChaincodeValidation
@@ -90,6 +111,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import google_protobuf "github.com/golang/protobuf/ptypes/empty"
+import google_protobuf1 "github.com/golang/protobuf/ptypes/timestamp"
 import common "github.com/hyperledger/fabric/protos/common"
 
 import (
@@ -141,8 +163,36 @@ func (x ServerStatus_StatusCode) String() string {
 }
 func (ServerStatus_StatusCode) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0, 0} }
 
+type GossipReachability_Status int32
+
+const (
+	GossipReachability_UNKNOWN     GossipReachability_Status = 0
+	GossipReachability_REACHABLE   GossipReachability_Status = 1
+	GossipReachability_UNREACHABLE GossipReachability_Status = 2
+)
+
+var GossipReachability_Status_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "REACHABLE",
+	2: "UNREACHABLE",
+}
+var GossipReachability_Status_value = map[string]int32{
+	"UNKNOWN":     0,
+	"REACHABLE":   1,
+	"UNREACHABLE": 2,
+}
+
+func (x GossipReachability_Status) String() string {
+	return proto.EnumName(GossipReachability_Status_name, int32(x))
+}
+func (GossipReachability_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{15, 0}
+}
+
 type ServerStatus struct {
 	Status ServerStatus_StatusCode `protobuf:"varint,1,opt,name=status,enum=protos.ServerStatus_StatusCode" json:"status,omitempty"`
+	// reason explains why the status is ERROR
+	Reason string `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
 }
 
 func (m *ServerStatus) Reset()                    { *m = ServerStatus{} }
@@ -157,6 +207,13 @@ func (m *ServerStatus) GetStatus() ServerStatus_StatusCode {
 	return ServerStatus_UNDEFINED
 }
 
+func (m *ServerStatus) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 type LogLevelRequest struct {
 	LogModule string `protobuf:"bytes,1,opt,name=log_module,json=logModule" json:"log_module,omitempty"`
 	LogLevel  string `protobuf:"bytes,2,opt,name=log_level,json=logLevel" json:"log_level,omitempty"`
@@ -205,16 +262,539 @@ func (m *LogLevelResponse) GetLogLevel() string {
 	return ""
 }
 
+// OrdererHealthRequest requests the health of the ordering service
+// endpoints the peer receives the blocks of a channel from
+type OrdererHealthRequest struct {
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+}
+
+func (m *OrdererHealthRequest) Reset()                    { *m = OrdererHealthRequest{} }
+func (m *OrdererHealthRequest) String() string            { return proto.CompactTextString(m) }
+func (*OrdererHealthRequest) ProtoMessage()               {}
+func (*OrdererHealthRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *OrdererHealthRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+// YieldLeadershipRequest requests the peer to yield its
+// leadership of a channel to another peer of its organization
+type YieldLeadershipRequest struct {
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+}
+
+func (m *YieldLeadershipRequest) Reset()                    { *m = YieldLeadershipRequest{} }
+func (m *YieldLeadershipRequest) String() string            { return proto.CompactTextString(m) }
+func (*YieldLeadershipRequest) ProtoMessage()               {}
+func (*YieldLeadershipRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *YieldLeadershipRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+// OrdererEndpointHealth is the health of an ordering service endpoint,
+// as observed by the deliver service of the peer
+type OrdererEndpointHealth struct {
+	Endpoint         string               `protobuf:"bytes,1,opt,name=endpoint" json:"endpoint,omitempty"`
+	LatencyMs        uint64               `protobuf:"varint,2,opt,name=latency_ms,json=latencyMs" json:"latency_ms,omitempty"`
+	BlockLag         uint64               `protobuf:"varint,3,opt,name=block_lag,json=blockLag" json:"block_lag,omitempty"`
+	ErrorRate        float64              `protobuf:"fixed64,4,opt,name=error_rate,json=errorRate" json:"error_rate,omitempty"`
+	Score            float64              `protobuf:"fixed64,5,opt,name=score" json:"score,omitempty"`
+	Connected        bool                 `protobuf:"varint,6,opt,name=connected" json:"connected,omitempty"`
+	LastInvalidBlock *OrdererInvalidBlock `protobuf:"bytes,7,opt,name=last_invalid_block,json=lastInvalidBlock" json:"last_invalid_block,omitempty"`
+}
+
+func (m *OrdererEndpointHealth) Reset()                    { *m = OrdererEndpointHealth{} }
+func (m *OrdererEndpointHealth) String() string            { return proto.CompactTextString(m) }
+func (*OrdererEndpointHealth) ProtoMessage()               {}
+func (*OrdererEndpointHealth) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *OrdererEndpointHealth) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *OrdererEndpointHealth) GetLatencyMs() uint64 {
+	if m != nil {
+		return m.LatencyMs
+	}
+	return 0
+}
+
+func (m *OrdererEndpointHealth) GetBlockLag() uint64 {
+	if m != nil {
+		return m.BlockLag
+	}
+	return 0
+}
+
+func (m *OrdererEndpointHealth) GetErrorRate() float64 {
+	if m != nil {
+		return m.ErrorRate
+	}
+	return 0
+}
+
+func (m *OrdererEndpointHealth) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *OrdererEndpointHealth) GetConnected() bool {
+	if m != nil {
+		return m.Connected
+	}
+	return false
+}
+
+func (m *OrdererEndpointHealth) GetLastInvalidBlock() *OrdererInvalidBlock {
+	if m != nil {
+		return m.LastInvalidBlock
+	}
+	return nil
+}
+
+// OrdererInvalidBlock is a block received from an ordering service endpoint
+// which failed verification against the BlockValidation policy of the channel
+type OrdererInvalidBlock struct {
+	SeqNum uint64                      `protobuf:"varint,1,opt,name=seq_num,json=seqNum" json:"seq_num,omitempty"`
+	Reason string                      `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+	Time   *google_protobuf1.Timestamp `protobuf:"bytes,3,opt,name=time" json:"time,omitempty"`
+	Count  uint64                      `protobuf:"varint,4,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *OrdererInvalidBlock) Reset()                    { *m = OrdererInvalidBlock{} }
+func (m *OrdererInvalidBlock) String() string            { return proto.CompactTextString(m) }
+func (*OrdererInvalidBlock) ProtoMessage()               {}
+func (*OrdererInvalidBlock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *OrdererInvalidBlock) GetSeqNum() uint64 {
+	if m != nil {
+		return m.SeqNum
+	}
+	return 0
+}
+
+func (m *OrdererInvalidBlock) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *OrdererInvalidBlock) GetTime() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *OrdererInvalidBlock) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type OrdererHealthResponse struct {
+	Endpoints []*OrdererEndpointHealth `protobuf:"bytes,1,rep,name=endpoints" json:"endpoints,omitempty"`
+}
+
+func (m *OrdererHealthResponse) Reset()                    { *m = OrdererHealthResponse{} }
+func (m *OrdererHealthResponse) String() string            { return proto.CompactTextString(m) }
+func (*OrdererHealthResponse) ProtoMessage()               {}
+func (*OrdererHealthResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *OrdererHealthResponse) GetEndpoints() []*OrdererEndpointHealth {
+	if m != nil {
+		return m.Endpoints
+	}
+	return nil
+}
+
+// GossipMember is a peer known to the gossip membership of the peer
+type GossipMember struct {
+	PkiId            string `protobuf:"bytes,1,opt,name=pki_id,json=pkiId" json:"pki_id,omitempty"`
+	Endpoint         string `protobuf:"bytes,2,opt,name=endpoint" json:"endpoint,omitempty"`
+	InternalEndpoint string `protobuf:"bytes,3,opt,name=internal_endpoint,json=internalEndpoint" json:"internal_endpoint,omitempty"`
+	Unreachable      bool   `protobuf:"varint,4,opt,name=unreachable" json:"unreachable,omitempty"`
+}
+
+func (m *GossipMember) Reset()                    { *m = GossipMember{} }
+func (m *GossipMember) String() string            { return proto.CompactTextString(m) }
+func (*GossipMember) ProtoMessage()               {}
+func (*GossipMember) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *GossipMember) GetPkiId() string {
+	if m != nil {
+		return m.PkiId
+	}
+	return ""
+}
+
+func (m *GossipMember) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *GossipMember) GetInternalEndpoint() string {
+	if m != nil {
+		return m.InternalEndpoint
+	}
+	return ""
+}
+
+func (m *GossipMember) GetUnreachable() bool {
+	if m != nil {
+		return m.Unreachable
+	}
+	return false
+}
+
+// GossipChannelPeer is an alive peer of a channel, as published in gossip
+type GossipChannelPeer struct {
+	PkiId        string `protobuf:"bytes,1,opt,name=pki_id,json=pkiId" json:"pki_id,omitempty"`
+	Endpoint     string `protobuf:"bytes,2,opt,name=endpoint" json:"endpoint,omitempty"`
+	LedgerHeight uint64 `protobuf:"varint,3,opt,name=ledger_height,json=ledgerHeight" json:"ledger_height,omitempty"`
+}
+
+func (m *GossipChannelPeer) Reset()                    { *m = GossipChannelPeer{} }
+func (m *GossipChannelPeer) String() string            { return proto.CompactTextString(m) }
+func (*GossipChannelPeer) ProtoMessage()               {}
+func (*GossipChannelPeer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *GossipChannelPeer) GetPkiId() string {
+	if m != nil {
+		return m.PkiId
+	}
+	return ""
+}
+
+func (m *GossipChannelPeer) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *GossipChannelPeer) GetLedgerHeight() uint64 {
+	if m != nil {
+		return m.LedgerHeight
+	}
+	return 0
+}
+
+// GossipChannelState is the gossip state of a channel the peer joined
+type GossipChannelState struct {
+	ChannelId        string               `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	LedgerHeight     uint64               `protobuf:"varint,2,opt,name=ledger_height,json=ledgerHeight" json:"ledger_height,omitempty"`
+	LeaderPkiId      string               `protobuf:"bytes,3,opt,name=leader_pki_id,json=leaderPkiId" json:"leader_pki_id,omitempty"`
+	IsLeader         bool                 `protobuf:"varint,4,opt,name=is_leader,json=isLeader" json:"is_leader,omitempty"`
+	Peers            []*GossipChannelPeer `protobuf:"bytes,5,rep,name=peers" json:"peers,omitempty"`
+	LeadershipEvents []*LeadershipEvent   `protobuf:"bytes,6,rep,name=leadership_events,json=leadershipEvents" json:"leadership_events,omitempty"`
+}
+
+func (m *GossipChannelState) Reset()                    { *m = GossipChannelState{} }
+func (m *GossipChannelState) String() string            { return proto.CompactTextString(m) }
+func (*GossipChannelState) ProtoMessage()               {}
+func (*GossipChannelState) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *GossipChannelState) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *GossipChannelState) GetLedgerHeight() uint64 {
+	if m != nil {
+		return m.LedgerHeight
+	}
+	return 0
+}
+
+func (m *GossipChannelState) GetLeaderPkiId() string {
+	if m != nil {
+		return m.LeaderPkiId
+	}
+	return ""
+}
+
+func (m *GossipChannelState) GetIsLeader() bool {
+	if m != nil {
+		return m.IsLeader
+	}
+	return false
+}
+
+func (m *GossipChannelState) GetPeers() []*GossipChannelPeer {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+func (m *GossipChannelState) GetLeadershipEvents() []*LeadershipEvent {
+	if m != nil {
+		return m.LeadershipEvents
+	}
+	return nil
+}
+
+// LeadershipEvent is a change of the leadership status of the peer in a channel
+type LeadershipEvent struct {
+	IsLeader bool                        `protobuf:"varint,1,opt,name=is_leader,json=isLeader" json:"is_leader,omitempty"`
+	Time     *google_protobuf1.Timestamp `protobuf:"bytes,2,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *LeadershipEvent) Reset()                    { *m = LeadershipEvent{} }
+func (m *LeadershipEvent) String() string            { return proto.CompactTextString(m) }
+func (*LeadershipEvent) ProtoMessage()               {}
+func (*LeadershipEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *LeadershipEvent) GetIsLeader() bool {
+	if m != nil {
+		return m.IsLeader
+	}
+	return false
+}
+
+func (m *LeadershipEvent) GetTime() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// GossipIdentity is a peer identity known to the peer
+type GossipIdentity struct {
+	PkiId        string                      `protobuf:"bytes,1,opt,name=pki_id,json=pkiId" json:"pki_id,omitempty"`
+	Organization string                      `protobuf:"bytes,2,opt,name=organization" json:"organization,omitempty"`
+	Expiration   *google_protobuf1.Timestamp `protobuf:"bytes,3,opt,name=expiration" json:"expiration,omitempty"`
+}
+
+func (m *GossipIdentity) Reset()                    { *m = GossipIdentity{} }
+func (m *GossipIdentity) String() string            { return proto.CompactTextString(m) }
+func (*GossipIdentity) ProtoMessage()               {}
+func (*GossipIdentity) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *GossipIdentity) GetPkiId() string {
+	if m != nil {
+		return m.PkiId
+	}
+	return ""
+}
+
+func (m *GossipIdentity) GetOrganization() string {
+	if m != nil {
+		return m.Organization
+	}
+	return ""
+}
+
+func (m *GossipIdentity) GetExpiration() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Expiration
+	}
+	return nil
+}
+
+// GossipConnection is a connection of the peer to a remote peer
+type GossipConnection struct {
+	PkiId        string                      `protobuf:"bytes,1,opt,name=pki_id,json=pkiId" json:"pki_id,omitempty"`
+	Endpoint     string                      `protobuf:"bytes,2,opt,name=endpoint" json:"endpoint,omitempty"`
+	Outbound     bool                        `protobuf:"varint,3,opt,name=outbound" json:"outbound,omitempty"`
+	Established  *google_protobuf1.Timestamp `protobuf:"bytes,4,opt,name=established" json:"established,omitempty"`
+	SentMsgs     uint64                      `protobuf:"varint,5,opt,name=sent_msgs,json=sentMsgs" json:"sent_msgs,omitempty"`
+	ReceivedMsgs uint64                      `protobuf:"varint,6,opt,name=received_msgs,json=receivedMsgs" json:"received_msgs,omitempty"`
+	DroppedMsgs  uint64                      `protobuf:"varint,7,opt,name=dropped_msgs,json=droppedMsgs" json:"dropped_msgs,omitempty"`
+}
+
+func (m *GossipConnection) Reset()                    { *m = GossipConnection{} }
+func (m *GossipConnection) String() string            { return proto.CompactTextString(m) }
+func (*GossipConnection) ProtoMessage()               {}
+func (*GossipConnection) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *GossipConnection) GetPkiId() string {
+	if m != nil {
+		return m.PkiId
+	}
+	return ""
+}
+
+func (m *GossipConnection) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *GossipConnection) GetOutbound() bool {
+	if m != nil {
+		return m.Outbound
+	}
+	return false
+}
+
+func (m *GossipConnection) GetEstablished() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Established
+	}
+	return nil
+}
+
+func (m *GossipConnection) GetSentMsgs() uint64 {
+	if m != nil {
+		return m.SentMsgs
+	}
+	return 0
+}
+
+func (m *GossipConnection) GetReceivedMsgs() uint64 {
+	if m != nil {
+		return m.ReceivedMsgs
+	}
+	return 0
+}
+
+func (m *GossipConnection) GetDroppedMsgs() uint64 {
+	if m != nil {
+		return m.DroppedMsgs
+	}
+	return 0
+}
+
+// GossipStateResponse is a snapshot of the gossip membership and channel state of the peer
+type GossipStateResponse struct {
+	Self         *GossipMember         `protobuf:"bytes,1,opt,name=self" json:"self,omitempty"`
+	Alive        []*GossipMember       `protobuf:"bytes,2,rep,name=alive" json:"alive,omitempty"`
+	Dead         []*GossipMember       `protobuf:"bytes,3,rep,name=dead" json:"dead,omitempty"`
+	Channels     []*GossipChannelState `protobuf:"bytes,4,rep,name=channels" json:"channels,omitempty"`
+	Identities   []*GossipIdentity     `protobuf:"bytes,5,rep,name=identities" json:"identities,omitempty"`
+	Connections  []*GossipConnection   `protobuf:"bytes,6,rep,name=connections" json:"connections,omitempty"`
+	Reachability *GossipReachability   `protobuf:"bytes,7,opt,name=reachability" json:"reachability,omitempty"`
+}
+
+func (m *GossipStateResponse) Reset()                    { *m = GossipStateResponse{} }
+func (m *GossipStateResponse) String() string            { return proto.CompactTextString(m) }
+func (*GossipStateResponse) ProtoMessage()               {}
+func (*GossipStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *GossipStateResponse) GetSelf() *GossipMember {
+	if m != nil {
+		return m.Self
+	}
+	return nil
+}
+
+func (m *GossipStateResponse) GetAlive() []*GossipMember {
+	if m != nil {
+		return m.Alive
+	}
+	return nil
+}
+
+func (m *GossipStateResponse) GetDead() []*GossipMember {
+	if m != nil {
+		return m.Dead
+	}
+	return nil
+}
+
+func (m *GossipStateResponse) GetChannels() []*GossipChannelState {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *GossipStateResponse) GetIdentities() []*GossipIdentity {
+	if m != nil {
+		return m.Identities
+	}
+	return nil
+}
+
+func (m *GossipStateResponse) GetConnections() []*GossipConnection {
+	if m != nil {
+		return m.Connections
+	}
+	return nil
+}
+
+func (m *GossipStateResponse) GetReachability() *GossipReachability {
+	if m != nil {
+		return m.Reachability
+	}
+	return nil
+}
+
+// GossipReachability is the outcome of verifying that the external
+// endpoint of the peer is reachable from peers of other organizations
+type GossipReachability struct {
+	Endpoint     string                      `protobuf:"bytes,1,opt,name=endpoint" json:"endpoint,omitempty"`
+	Status       GossipReachability_Status   `protobuf:"varint,2,opt,name=status,enum=protos.GossipReachability_Status" json:"status,omitempty"`
+	LastVerified *google_protobuf1.Timestamp `protobuf:"bytes,3,opt,name=last_verified,json=lastVerified" json:"last_verified,omitempty"`
+	Errors       []string                    `protobuf:"bytes,4,rep,name=errors" json:"errors,omitempty"`
+}
+
+func (m *GossipReachability) Reset()                    { *m = GossipReachability{} }
+func (m *GossipReachability) String() string            { return proto.CompactTextString(m) }
+func (*GossipReachability) ProtoMessage()               {}
+func (*GossipReachability) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *GossipReachability) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *GossipReachability) GetStatus() GossipReachability_Status {
+	if m != nil {
+		return m.Status
+	}
+	return GossipReachability_UNKNOWN
+}
+
+func (m *GossipReachability) GetLastVerified() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.LastVerified
+	}
+	return nil
+}
+
+func (m *GossipReachability) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
 type AdminOperation struct {
 	// Types that are valid to be assigned to Content:
 	//	*AdminOperation_LogReq
+	//	*AdminOperation_OrdererHealthReq
+	//	*AdminOperation_YieldLeadershipReq
 	Content isAdminOperation_Content `protobuf_oneof:"content"`
 }
 
 func (m *AdminOperation) Reset()                    { *m = AdminOperation{} }
 func (m *AdminOperation) String() string            { return proto.CompactTextString(m) }
 func (*AdminOperation) ProtoMessage()               {}
-func (*AdminOperation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*AdminOperation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 type isAdminOperation_Content interface {
 	isAdminOperation_Content()
@@ -223,8 +803,16 @@ type isAdminOperation_Content interface {
 type AdminOperation_LogReq struct {
 	LogReq *LogLevelRequest `protobuf:"bytes,1,opt,name=logReq,oneof"`
 }
+type AdminOperation_OrdererHealthReq struct {
+	OrdererHealthReq *OrdererHealthRequest `protobuf:"bytes,2,opt,name=ordererHealthReq,oneof"`
+}
+type AdminOperation_YieldLeadershipReq struct {
+	YieldLeadershipReq *YieldLeadershipRequest `protobuf:"bytes,3,opt,name=yieldLeadershipReq,oneof"`
+}
 
-func (*AdminOperation_LogReq) isAdminOperation_Content() {}
+func (*AdminOperation_LogReq) isAdminOperation_Content()             {}
+func (*AdminOperation_OrdererHealthReq) isAdminOperation_Content()   {}
+func (*AdminOperation_YieldLeadershipReq) isAdminOperation_Content() {}
 
 func (m *AdminOperation) GetContent() isAdminOperation_Content {
 	if m != nil {
@@ -240,10 +828,26 @@ func (m *AdminOperation) GetLogReq() *LogLevelRequest {
 	return nil
 }
 
+func (m *AdminOperation) GetOrdererHealthReq() *OrdererHealthRequest {
+	if x, ok := m.GetContent().(*AdminOperation_OrdererHealthReq); ok {
+		return x.OrdererHealthReq
+	}
+	return nil
+}
+
+func (m *AdminOperation) GetYieldLeadershipReq() *YieldLeadershipRequest {
+	if x, ok := m.GetContent().(*AdminOperation_YieldLeadershipReq); ok {
+		return x.YieldLeadershipReq
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*AdminOperation) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _AdminOperation_OneofMarshaler, _AdminOperation_OneofUnmarshaler, _AdminOperation_OneofSizer, []interface{}{
 		(*AdminOperation_LogReq)(nil),
+		(*AdminOperation_OrdererHealthReq)(nil),
+		(*AdminOperation_YieldLeadershipReq)(nil),
 	}
 }
 
@@ -256,6 +860,16 @@ func _AdminOperation_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.LogReq); err != nil {
 			return err
 		}
+	case *AdminOperation_OrdererHealthReq:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OrdererHealthReq); err != nil {
+			return err
+		}
+	case *AdminOperation_YieldLeadershipReq:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.YieldLeadershipReq); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("AdminOperation.Content has unexpected type %T", x)
@@ -274,6 +888,22 @@ func _AdminOperation_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto
 		err := b.DecodeMessage(msg)
 		m.Content = &AdminOperation_LogReq{msg}
 		return true, err
+	case 2: // content.ordererHealthReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(OrdererHealthRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_OrdererHealthReq{msg}
+		return true, err
+	case 3: // content.yieldLeadershipReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(YieldLeadershipRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_YieldLeadershipReq{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -288,6 +918,16 @@ func _AdminOperation_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(1<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *AdminOperation_OrdererHealthReq:
+		s := proto.Size(x.OrdererHealthReq)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *AdminOperation_YieldLeadershipReq:
+		s := proto.Size(x.YieldLeadershipReq)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -299,8 +939,22 @@ func init() {
 	proto.RegisterType((*ServerStatus)(nil), "protos.ServerStatus")
 	proto.RegisterType((*LogLevelRequest)(nil), "protos.LogLevelRequest")
 	proto.RegisterType((*LogLevelResponse)(nil), "protos.LogLevelResponse")
+	proto.RegisterType((*OrdererHealthRequest)(nil), "protos.OrdererHealthRequest")
+	proto.RegisterType((*YieldLeadershipRequest)(nil), "protos.YieldLeadershipRequest")
+	proto.RegisterType((*OrdererEndpointHealth)(nil), "protos.OrdererEndpointHealth")
+	proto.RegisterType((*OrdererInvalidBlock)(nil), "protos.OrdererInvalidBlock")
+	proto.RegisterType((*OrdererHealthResponse)(nil), "protos.OrdererHealthResponse")
+	proto.RegisterType((*GossipMember)(nil), "protos.GossipMember")
+	proto.RegisterType((*GossipChannelPeer)(nil), "protos.GossipChannelPeer")
+	proto.RegisterType((*GossipChannelState)(nil), "protos.GossipChannelState")
+	proto.RegisterType((*LeadershipEvent)(nil), "protos.LeadershipEvent")
+	proto.RegisterType((*GossipIdentity)(nil), "protos.GossipIdentity")
+	proto.RegisterType((*GossipConnection)(nil), "protos.GossipConnection")
+	proto.RegisterType((*GossipStateResponse)(nil), "protos.GossipStateResponse")
+	proto.RegisterType((*GossipReachability)(nil), "protos.GossipReachability")
 	proto.RegisterType((*AdminOperation)(nil), "protos.AdminOperation")
 	proto.RegisterEnum("protos.ServerStatus_StatusCode", ServerStatus_StatusCode_name, ServerStatus_StatusCode_value)
+	proto.RegisterEnum("protos.GossipReachability_Status", GossipReachability_Status_name, GossipReachability_Status_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -319,6 +973,9 @@ type AdminClient interface {
 	GetModuleLogLevel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogLevelResponse, error)
 	SetModuleLogLevel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogLevelResponse, error)
 	RevertLogLevels(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	GetOrdererHealth(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*OrdererHealthResponse, error)
+	GetGossipState(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*GossipStateResponse, error)
+	YieldLeadership(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
 }
 
 type adminClient struct {
@@ -374,6 +1031,33 @@ func (c *adminClient) RevertLogLevels(ctx context.Context, in *common.Envelope,
 	return out, nil
 }
 
+func (c *adminClient) GetOrdererHealth(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*OrdererHealthResponse, error) {
+	out := new(OrdererHealthResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetOrdererHealth", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetGossipState(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*GossipStateResponse, error) {
+	out := new(GossipStateResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetGossipState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) YieldLeadership(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/protos.Admin/YieldLeadership", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Admin service
 
 type AdminServer interface {
@@ -382,6 +1066,9 @@ type AdminServer interface {
 	GetModuleLogLevel(context.Context, *common.Envelope) (*LogLevelResponse, error)
 	SetModuleLogLevel(context.Context, *common.Envelope) (*LogLevelResponse, error)
 	RevertLogLevels(context.Context, *common.Envelope) (*google_protobuf.Empty, error)
+	GetOrdererHealth(context.Context, *common.Envelope) (*OrdererHealthResponse, error)
+	GetGossipState(context.Context, *common.Envelope) (*GossipStateResponse, error)
+	YieldLeadership(context.Context, *common.Envelope) (*google_protobuf.Empty, error)
 }
 
 func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
@@ -478,6 +1165,60 @@ func _Admin_RevertLogLevels_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_GetOrdererHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetOrdererHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetOrdererHealth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetOrdererHealth(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetGossipState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetGossipState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetGossipState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetGossipState(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_YieldLeadership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).YieldLeadership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/YieldLeadership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).YieldLeadership(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Admin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Admin",
 	HandlerType: (*AdminServer)(nil),
@@ -502,6 +1243,18 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RevertLogLevels",
 			Handler:    _Admin_RevertLogLevels_Handler,
 		},
+		{
+			MethodName: "GetOrdererHealth",
+			Handler:    _Admin_GetOrdererHealth_Handler,
+		},
+		{
+			MethodName: "GetGossipState",
+			Handler:    _Admin_GetGossipState_Handler,
+		},
+		{
+			MethodName: "YieldLeadership",
+			Handler:    _Admin_YieldLeadership_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "peer/admin.proto",
@@ -510,35 +1263,93 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 466 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0xdd, 0x6e, 0xd3, 0x30,
-	0x14, 0x6e, 0x0b, 0xed, 0xc8, 0xe9, 0xd8, 0x82, 0x41, 0x50, 0x75, 0x42, 0xa0, 0x5c, 0xc1, 0x8d,
-	0x23, 0x8a, 0xd0, 0xae, 0xb8, 0x68, 0x49, 0x18, 0x88, 0x2d, 0xad, 0x9c, 0x55, 0x08, 0x24, 0x34,
-	0xa5, 0xed, 0x99, 0x57, 0xe1, 0xc4, 0x99, 0xe3, 0x54, 0xda, 0xeb, 0xf0, 0x1c, 0x3c, 0x1c, 0x8a,
-	0x9d, 0x68, 0x13, 0xf4, 0x06, 0xf5, 0xea, 0xf8, 0x1c, 0x7f, 0xdf, 0x67, 0x9f, 0x3f, 0x70, 0x73,
-	0x44, 0xe5, 0x27, 0xab, 0x74, 0x9d, 0xd1, 0x5c, 0x49, 0x2d, 0x49, 0xcf, 0x98, 0x62, 0x78, 0xc4,
-	0xa5, 0xe4, 0x02, 0x7d, 0xe3, 0x2e, 0xca, 0x4b, 0x1f, 0xd3, 0x5c, 0xdf, 0x58, 0xd0, 0xf0, 0xf1,
-	0x52, 0xa6, 0xa9, 0xcc, 0x7c, 0x6b, 0x6c, 0xd0, 0xfb, 0xd5, 0x86, 0xfd, 0x18, 0xd5, 0x06, 0x55,
-	0xac, 0x13, 0x5d, 0x16, 0xe4, 0x18, 0x7a, 0x85, 0x39, 0x0d, 0xda, 0x2f, 0xdb, 0xaf, 0x0e, 0x46,
-	0x2f, 0x2c, 0xb0, 0xa0, 0x77, 0x51, 0xd4, 0x9a, 0x0f, 0x72, 0x85, 0xac, 0x86, 0x7b, 0xdf, 0x00,
-	0x6e, 0xa3, 0xe4, 0x21, 0x38, 0xf3, 0x28, 0x08, 0x3f, 0x7e, 0x8e, 0xc2, 0xc0, 0x6d, 0x91, 0x3e,
-	0xec, 0xc5, 0xe7, 0x63, 0x76, 0x1e, 0x06, 0x6e, 0xdb, 0x3a, 0xd3, 0xd9, 0x2c, 0x0c, 0xdc, 0x0e,
-	0x01, 0xe8, 0xcd, 0xc6, 0xf3, 0x38, 0x0c, 0xdc, 0x7b, 0xc4, 0x81, 0x6e, 0xc8, 0xd8, 0x94, 0xb9,
-	0xf7, 0x2b, 0xcc, 0x3c, 0xfa, 0x12, 0x4d, 0xbf, 0x46, 0x6e, 0xd7, 0x3b, 0x83, 0xc3, 0x53, 0xc9,
-	0x4f, 0x71, 0x83, 0x82, 0xe1, 0x75, 0x89, 0x85, 0x26, 0xcf, 0x01, 0x84, 0xe4, 0x17, 0xa9, 0x5c,
-	0x95, 0x02, 0xcd, 0x57, 0x1d, 0xe6, 0x08, 0xc9, 0xcf, 0x4c, 0x80, 0x1c, 0x41, 0xe5, 0x5c, 0x88,
-	0x8a, 0x32, 0xe8, 0x98, 0xdb, 0x07, 0xa2, 0x96, 0xf0, 0x22, 0x70, 0x6f, 0xe5, 0x8a, 0x5c, 0x66,
-	0x05, 0xee, 0xa8, 0x77, 0x30, 0xae, 0x9a, 0x31, 0xcd, 0x51, 0x25, 0x7a, 0x2d, 0x33, 0xf2, 0x06,
-	0x7a, 0x42, 0x72, 0x86, 0xd7, 0x46, 0xa9, 0x3f, 0x7a, 0xd6, 0x14, 0xf1, 0xaf, 0x34, 0x3e, 0xb5,
-	0x58, 0x0d, 0x9c, 0x38, 0xb0, 0xb7, 0x94, 0x99, 0xc6, 0x4c, 0x8f, 0x7e, 0x77, 0xa0, 0x6b, 0x04,
-	0xc9, 0x3b, 0x70, 0x4e, 0x50, 0xd7, 0x9d, 0x71, 0x69, 0xdd, 0xb9, 0x30, 0xdb, 0xa0, 0x90, 0x39,
-	0x0e, 0x9f, 0x6c, 0xeb, 0x8d, 0xd7, 0x22, 0xc7, 0xd0, 0x8f, 0x75, 0xa2, 0xb4, 0x0d, 0xff, 0x07,
-	0x71, 0x0c, 0x8f, 0x4e, 0x50, 0xdb, 0x9c, 0x9b, 0xaf, 0x6e, 0xa1, 0x0f, 0xfe, 0x4d, 0xc7, 0x96,
-	0xd1, 0x4a, 0xc4, 0x3b, 0x4a, 0xbc, 0x87, 0x43, 0x86, 0x1b, 0x54, 0xba, 0xb9, 0xdb, 0x96, 0xfb,
-	0x53, 0x6a, 0x67, 0x9d, 0x36, 0xb3, 0x4e, 0xc3, 0x6a, 0xd6, 0xbd, 0xd6, 0xe4, 0x07, 0x78, 0x52,
-	0x71, 0x7a, 0x75, 0x93, 0xa3, 0x12, 0xb8, 0xe2, 0xa8, 0xe8, 0x65, 0xb2, 0x50, 0xeb, 0x65, 0xf3,
-	0x64, 0xb5, 0x3e, 0x93, 0x7d, 0x53, 0xe1, 0x59, 0xb2, 0xfc, 0x99, 0x70, 0xfc, 0xfe, 0x9a, 0xaf,
-	0xf5, 0x55, 0xb9, 0xa8, 0x5e, 0xf1, 0xef, 0x10, 0x7d, 0x4b, 0xb4, 0xfb, 0x54, 0xf8, 0x15, 0x71,
-	0x61, 0x77, 0xed, 0xed, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xaf, 0x20, 0x26, 0x0b, 0x86, 0x03,
-	0x00, 0x00,
+	// 1396 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0x5f, 0x4f, 0x23, 0x47,
+	0x12, 0x67, 0x8c, 0x6d, 0xec, 0xb2, 0x17, 0x86, 0x5e, 0x96, 0xf5, 0xc1, 0xee, 0x2d, 0x3b, 0xf7,
+	0xc2, 0xdd, 0x49, 0xb6, 0x8e, 0xd3, 0xee, 0xea, 0xf6, 0xfe, 0x09, 0x16, 0x07, 0x48, 0xc0, 0xa0,
+	0x06, 0x12, 0x6d, 0xa4, 0x64, 0x34, 0xf6, 0x14, 0xe3, 0x16, 0xe3, 0x99, 0xa1, 0xbb, 0x6d, 0x85,
+	0xbc, 0x45, 0x4a, 0x94, 0x97, 0x44, 0xda, 0xcf, 0x93, 0xcf, 0x94, 0x6f, 0x90, 0x97, 0xa8, 0xbb,
+	0x67, 0xfc, 0x9f, 0xec, 0xbf, 0x27, 0xbb, 0xab, 0x7f, 0x55, 0x5d, 0x55, 0xfd, 0xab, 0xea, 0x1a,
+	0xb0, 0x13, 0x44, 0xde, 0xf0, 0xfc, 0x1e, 0x8b, 0xea, 0x09, 0x8f, 0x65, 0x4c, 0x8a, 0xfa, 0x47,
+	0x6c, 0x6c, 0x06, 0x71, 0x1c, 0x84, 0xd8, 0xd0, 0xcb, 0x76, 0xff, 0xaa, 0x81, 0xbd, 0x44, 0xde,
+	0x1a, 0xd0, 0xc6, 0x93, 0xe9, 0x4d, 0xc9, 0x7a, 0x28, 0xa4, 0xd7, 0x4b, 0x52, 0xc0, 0xfd, 0x4e,
+	0xdc, 0xeb, 0xc5, 0x51, 0xc3, 0xfc, 0x18, 0xa1, 0xf3, 0x8b, 0x05, 0xd5, 0x73, 0xe4, 0x03, 0xe4,
+	0xe7, 0xd2, 0x93, 0x7d, 0x41, 0x5e, 0x40, 0x51, 0xe8, 0x7f, 0x35, 0x6b, 0xcb, 0xda, 0x5e, 0xde,
+	0x79, 0x62, 0x80, 0xa2, 0x3e, 0x8e, 0xaa, 0x9b, 0x9f, 0x57, 0xb1, 0x8f, 0x34, 0x85, 0x93, 0x75,
+	0x28, 0x72, 0xf4, 0x44, 0x1c, 0xd5, 0x72, 0x5b, 0xd6, 0x76, 0x99, 0xa6, 0x2b, 0xe7, 0x35, 0xc0,
+	0x08, 0x4d, 0xee, 0x41, 0xf9, 0xb2, 0xb5, 0xdf, 0xfc, 0xe4, 0xa8, 0xd5, 0xdc, 0xb7, 0x17, 0x48,
+	0x05, 0x96, 0xce, 0x2f, 0x76, 0xe9, 0x45, 0x73, 0xdf, 0xb6, 0xcc, 0xe2, 0xf4, 0xec, 0xac, 0xb9,
+	0x6f, 0xe7, 0x08, 0x40, 0xf1, 0x6c, 0xf7, 0xf2, 0xbc, 0xb9, 0x6f, 0x2f, 0x92, 0x32, 0x14, 0x9a,
+	0x94, 0x9e, 0x52, 0x3b, 0xaf, 0x30, 0x97, 0xad, 0xcf, 0x5a, 0xa7, 0x5f, 0xb4, 0xec, 0x82, 0x73,
+	0x02, 0x2b, 0xc7, 0x71, 0x70, 0x8c, 0x03, 0x0c, 0x29, 0xde, 0xf4, 0x51, 0x48, 0xf2, 0x18, 0x20,
+	0x8c, 0x03, 0xb7, 0x17, 0xfb, 0xfd, 0x10, 0x75, 0x08, 0x65, 0x5a, 0x0e, 0xe3, 0xe0, 0x44, 0x0b,
+	0xc8, 0x26, 0xa8, 0x85, 0x1b, 0x2a, 0x95, 0xd4, 0xcf, 0x52, 0x98, 0x9a, 0x70, 0x5a, 0x60, 0x8f,
+	0xcc, 0x89, 0x24, 0x8e, 0x04, 0x7e, 0x94, 0xbd, 0x67, 0xb0, 0x76, 0xca, 0x7d, 0xe4, 0xc8, 0x0f,
+	0xd1, 0x0b, 0x65, 0x77, 0xcc, 0xc7, 0x4e, 0xd7, 0x8b, 0x22, 0x0c, 0x5d, 0xe6, 0x67, 0x36, 0x53,
+	0xc9, 0x91, 0xef, 0xbc, 0x80, 0xf5, 0xd7, 0x0c, 0x43, 0xff, 0x18, 0x3d, 0x1f, 0xb9, 0xe8, 0xb2,
+	0xe4, 0x1d, 0x15, 0x7f, 0xce, 0xc1, 0x83, 0xf4, 0xc0, 0x66, 0xe4, 0x27, 0x31, 0x8b, 0xa4, 0x39,
+	0x98, 0x6c, 0x40, 0x09, 0x53, 0x49, 0xaa, 0x36, 0x5c, 0xeb, 0x08, 0x3d, 0x89, 0x51, 0xe7, 0xd6,
+	0xed, 0x09, 0x1d, 0x43, 0x9e, 0x96, 0x53, 0xc9, 0x89, 0x50, 0x11, 0xb6, 0xc3, 0xb8, 0x73, 0xed,
+	0x86, 0x5e, 0x50, 0x5b, 0xd4, 0xbb, 0x25, 0x2d, 0x38, 0xf6, 0x02, 0xa5, 0x8b, 0x9c, 0xc7, 0xdc,
+	0xe5, 0x9e, 0xc4, 0x5a, 0x7e, 0xcb, 0xda, 0xb6, 0x68, 0x59, 0x4b, 0xa8, 0x27, 0x91, 0xac, 0x41,
+	0x41, 0x74, 0x62, 0x8e, 0xb5, 0x82, 0xde, 0x31, 0x0b, 0xf2, 0x08, 0xca, 0x9d, 0x38, 0x8a, 0xb0,
+	0x23, 0xd1, 0xaf, 0x15, 0xb7, 0xac, 0xed, 0x12, 0x1d, 0x09, 0xc8, 0x11, 0x90, 0xd0, 0x13, 0xd2,
+	0x65, 0xd1, 0xc0, 0x0b, 0x99, 0xef, 0xea, 0xb3, 0x6a, 0x4b, 0x5b, 0xd6, 0x76, 0x65, 0x67, 0x33,
+	0xe3, 0x62, 0x1a, 0xe5, 0x91, 0xc1, 0xec, 0x29, 0x08, 0xb5, 0x95, 0xda, 0xb8, 0xc4, 0xf9, 0xc9,
+	0x82, 0xfb, 0x73, 0x90, 0xe4, 0x21, 0x2c, 0x09, 0xbc, 0x71, 0xa3, 0x7e, 0x4f, 0x27, 0x23, 0x4f,
+	0x8b, 0x02, 0x6f, 0x5a, 0xfd, 0xde, 0x5d, 0x14, 0x26, 0x75, 0xc8, 0xab, 0x62, 0xd2, 0xe1, 0x57,
+	0x76, 0x36, 0xea, 0xa6, 0xd2, 0xea, 0x59, 0xa5, 0xd5, 0x2f, 0xb2, 0x4a, 0xa3, 0x1a, 0xa7, 0xe2,
+	0xee, 0xc4, 0xfd, 0x48, 0xea, 0x8c, 0xe4, 0xa9, 0x59, 0x38, 0x17, 0xc3, 0xdb, 0xc9, 0xe8, 0x90,
+	0x72, 0xec, 0xdf, 0x50, 0xce, 0x6e, 0x43, 0x55, 0xdd, 0xe2, 0x76, 0x65, 0xe7, 0xf1, 0x54, 0xa4,
+	0x93, 0xf7, 0x49, 0x47, 0x78, 0xe7, 0x8d, 0x05, 0xd5, 0x83, 0x58, 0x08, 0x96, 0x9c, 0x60, 0xaf,
+	0x8d, 0x9c, 0x3c, 0x80, 0x62, 0x72, 0xcd, 0x46, 0x04, 0x29, 0x24, 0xd7, 0xec, 0xc8, 0x9f, 0xa0,
+	0x40, 0x6e, 0x8a, 0x02, 0x7f, 0x87, 0x55, 0x16, 0x49, 0xe4, 0x91, 0x17, 0xba, 0x43, 0xd0, 0xa2,
+	0x06, 0xd9, 0xd9, 0x46, 0xe6, 0x01, 0xd9, 0x82, 0x4a, 0x3f, 0xe2, 0xe8, 0x75, 0xba, 0x5e, 0x3b,
+	0x34, 0x97, 0x5e, 0xa2, 0xe3, 0x22, 0xe7, 0x1a, 0x56, 0x8d, 0x47, 0xaf, 0x0c, 0x35, 0xcf, 0xf0,
+	0xc3, 0xdc, 0xfa, 0x0b, 0xdc, 0x0b, 0xd1, 0x0f, 0x90, 0xbb, 0x5d, 0x64, 0x41, 0x57, 0xa6, 0xf4,
+	0xab, 0x1a, 0xe1, 0xa1, 0x96, 0x39, 0x6f, 0x72, 0x40, 0x26, 0x4e, 0x53, 0xcd, 0x06, 0xdf, 0x52,
+	0x2a, 0xb3, 0xa6, 0x73, 0xb3, 0xa6, 0x89, 0xa3, 0x40, 0xaa, 0x06, 0xdd, 0xd4, 0x73, 0x93, 0x92,
+	0x8a, 0x11, 0x9e, 0x69, 0xff, 0x37, 0xa1, 0xcc, 0x84, 0x6b, 0x24, 0x69, 0x2e, 0x4a, 0x4c, 0x98,
+	0xd2, 0x25, 0x0d, 0x28, 0x24, 0x88, 0x5c, 0xd4, 0x0a, 0xfa, 0x52, 0xff, 0x94, 0x5d, 0xea, 0x4c,
+	0x76, 0xa8, 0xc1, 0x91, 0x7d, 0x58, 0x0d, 0x87, 0x55, 0xef, 0xe2, 0x00, 0x15, 0x23, 0x8a, 0x5a,
+	0xf9, 0x61, 0xa6, 0x3c, 0x6a, 0x0b, 0x4d, 0xb5, 0x4f, 0xed, 0x70, 0x52, 0x20, 0x9c, 0xaf, 0x61,
+	0x65, 0x0a, 0x34, 0xe9, 0xa6, 0x35, 0xe5, 0x66, 0x46, 0xef, 0xdc, 0xbb, 0xd1, 0xdb, 0xf9, 0xd1,
+	0x82, 0x65, 0x13, 0xc2, 0x91, 0x8f, 0x91, 0x64, 0xf2, 0xf6, 0xae, 0xdb, 0x75, 0xa0, 0x1a, 0xf3,
+	0xc0, 0x8b, 0xd8, 0xb7, 0x9e, 0x64, 0xc3, 0xb2, 0x9a, 0x90, 0x91, 0x97, 0x00, 0xf8, 0x4d, 0xc2,
+	0xb8, 0x41, 0xbc, 0xbd, 0xc4, 0xc6, 0xd0, 0xce, 0xf7, 0x39, 0xb0, 0xd3, 0x64, 0x9a, 0x06, 0xa2,
+	0x0c, 0x7e, 0x00, 0xd3, 0x36, 0xa0, 0x14, 0xf7, 0x65, 0x3b, 0xee, 0x47, 0xe6, 0x92, 0x4b, 0x74,
+	0xb8, 0x26, 0xff, 0x81, 0x8a, 0x3a, 0xb9, 0x1d, 0x32, 0xd1, 0x45, 0xbf, 0x96, 0x7f, 0xab, 0x83,
+	0xe3, 0x70, 0x95, 0x78, 0x81, 0x91, 0x74, 0x7b, 0x22, 0x10, 0xba, 0x0d, 0xe6, 0x69, 0x49, 0x09,
+	0x4e, 0x44, 0x20, 0x14, 0x0b, 0x39, 0x76, 0x90, 0x0d, 0xd0, 0x37, 0x80, 0xa2, 0x61, 0x61, 0x26,
+	0xd4, 0xa0, 0xa7, 0x50, 0xf5, 0x79, 0x9c, 0x24, 0x19, 0x66, 0x49, 0x63, 0x2a, 0xa9, 0x4c, 0x41,
+	0x9c, 0xef, 0x16, 0xe1, 0xbe, 0x49, 0x83, 0x26, 0xff, 0xb0, 0xb1, 0x6c, 0x43, 0x5e, 0x60, 0x78,
+	0xa5, 0xf3, 0x50, 0xd9, 0x59, 0x9b, 0xa4, 0x9f, 0x69, 0x17, 0x54, 0x23, 0xc8, 0xdf, 0xa0, 0xe0,
+	0x85, 0x6c, 0xa0, 0x38, 0xb0, 0x78, 0x27, 0xd4, 0x40, 0x94, 0x55, 0x1f, 0x3d, 0x95, 0xa8, 0xbb,
+	0xa1, 0x1a, 0x41, 0x9e, 0x43, 0x29, 0x2d, 0x39, 0x51, 0xcb, 0x6b, 0xf4, 0xc6, 0xdc, 0x12, 0x30,
+	0x5e, 0x0f, 0xb1, 0xe4, 0x39, 0x00, 0x33, 0xcc, 0x62, 0x98, 0x15, 0xcf, 0xfa, 0xa4, 0x66, 0xc6,
+	0x3c, 0x3a, 0x86, 0x24, 0x2f, 0xa1, 0xd2, 0x19, 0xf2, 0x20, 0x2b, 0x9c, 0xda, 0xd4, 0x91, 0x43,
+	0x00, 0x1d, 0x07, 0x93, 0xff, 0x41, 0x35, 0xed, 0x60, 0x2c, 0x64, 0xf2, 0x36, 0x7d, 0x71, 0xa6,
+	0xfc, 0xa5, 0x63, 0x08, 0x3a, 0x81, 0x77, 0x7e, 0xb3, 0x80, 0xcc, 0x82, 0xfe, 0xf0, 0xe5, 0xfd,
+	0xd7, 0x70, 0xd4, 0xca, 0xe9, 0x51, 0xeb, 0xe9, 0xdd, 0x87, 0xa5, 0x03, 0xd7, 0x70, 0xd8, 0xfa,
+	0x3f, 0xdc, 0xd3, 0xaf, 0xe4, 0x00, 0x39, 0xbb, 0x62, 0xe8, 0xbf, 0x43, 0xdd, 0x54, 0x95, 0xc2,
+	0xe7, 0x29, 0x5e, 0x3d, 0x75, 0xfa, 0x9d, 0x36, 0x17, 0x53, 0xa6, 0xe9, 0xca, 0x79, 0x06, 0x45,
+	0x73, 0xd4, 0xf8, 0xa4, 0xb5, 0xa0, 0xc6, 0x36, 0xda, 0xdc, 0x7d, 0x75, 0xb8, 0xbb, 0x77, 0xdc,
+	0xb4, 0x2d, 0xb2, 0x02, 0x95, 0xcb, 0xd6, 0x48, 0x90, 0x73, 0x7e, 0xb5, 0x60, 0x79, 0x57, 0x4d,
+	0xac, 0xa7, 0x09, 0x9a, 0xda, 0x24, 0xff, 0x80, 0x62, 0x18, 0x07, 0x14, 0x6f, 0x52, 0xfa, 0x8d,
+	0x1a, 0xd8, 0xe4, 0xc8, 0x76, 0xb8, 0x40, 0x53, 0x20, 0xf9, 0x14, 0xec, 0x78, 0x6a, 0x60, 0x4a,
+	0x9b, 0xd2, 0xa3, 0xa9, 0xf7, 0x70, 0x62, 0xa0, 0x3a, 0x5c, 0xa0, 0x33, 0x7a, 0xe4, 0x0c, 0xc8,
+	0xed, 0xcc, 0x14, 0x95, 0xa6, 0xe9, 0xcf, 0x99, 0xb5, 0xf9, 0x73, 0xd6, 0xe1, 0x02, 0x9d, 0xa3,
+	0xbb, 0x57, 0x86, 0xa5, 0x4e, 0x1c, 0x49, 0x8c, 0xe4, 0xce, 0x0f, 0x79, 0x28, 0xe8, 0x70, 0xc9,
+	0x33, 0x28, 0x1f, 0xa0, 0x4c, 0x53, 0x66, 0xd7, 0xd3, 0xd9, 0xba, 0x19, 0x0d, 0x30, 0x8c, 0x13,
+	0xdc, 0x58, 0x9b, 0x37, 0x3d, 0x3b, 0x0b, 0xe4, 0x05, 0x54, 0xce, 0xa5, 0xc7, 0xa5, 0x11, 0xbf,
+	0x87, 0xe2, 0x2e, 0xac, 0x1e, 0xa0, 0x34, 0xd3, 0x67, 0x96, 0xc8, 0x39, 0xea, 0xb5, 0xd9, 0x64,
+	0x9b, 0x9e, 0x60, 0x4c, 0x9c, 0x7f, 0xa4, 0x89, 0xff, 0xc2, 0x0a, 0xc5, 0x01, 0x72, 0x99, 0xed,
+	0xcd, 0x8b, 0x7d, 0x7d, 0x86, 0x8c, 0x4d, 0xf5, 0xb9, 0xe2, 0x2c, 0x90, 0x26, 0xd8, 0x07, 0x28,
+	0x27, 0xae, 0x72, 0x8e, 0xfe, 0xe3, 0x3b, 0xee, 0x7c, 0x2c, 0x90, 0xe5, 0x03, 0x94, 0x63, 0x8d,
+	0x6f, 0x8e, 0x91, 0xcd, 0xc9, 0x9a, 0x9a, 0xe8, 0x8f, 0x26, 0x90, 0x29, 0x0e, 0xbc, 0x4f, 0x20,
+	0x7b, 0x5f, 0x81, 0x13, 0xf3, 0xa0, 0xde, 0xbd, 0x4d, 0x90, 0x9b, 0xd1, 0xa1, 0x7e, 0xe5, 0xb5,
+	0x39, 0xeb, 0x64, 0xa7, 0x26, 0x88, 0x7c, 0xaf, 0xaa, 0xa9, 0x72, 0xe6, 0x75, 0xae, 0xbd, 0x00,
+	0xbf, 0xfc, 0x6b, 0xc0, 0x64, 0xb7, 0xdf, 0x56, 0xa7, 0x34, 0xc6, 0x14, 0x1b, 0x46, 0xd1, 0x7c,
+	0xbe, 0x89, 0x86, 0x52, 0x6c, 0x9b, 0xef, 0xbe, 0x7f, 0xfe, 0x3e, 0x00, 0x11, 0x44, 0xff, 0xe4,
+	0x12, 0x0e, 0x00, 0x00,
 }
@@ -40,6 +40,7 @@ const (
 	ChaincodeMessage_QUERY_STATE_CLOSE   ChaincodeMessage_Type = 17
 	ChaincodeMessage_KEEPALIVE           ChaincodeMessage_Type = 18
 	ChaincodeMessage_GET_HISTORY_FOR_KEY ChaincodeMessage_Type = 19
+	ChaincodeMessage_GET_STATE_AT_HEIGHT ChaincodeMessage_Type = 20
 )
 
 var ChaincodeMessage_Type_name = map[int32]string{
@@ -62,6 +63,7 @@ var ChaincodeMessage_Type_name = map[int32]string{
 	17: "QUERY_STATE_CLOSE",
 	18: "KEEPALIVE",
 	19: "GET_HISTORY_FOR_KEY",
+	20: "GET_STATE_AT_HEIGHT",
 }
 var ChaincodeMessage_Type_value = map[string]int32{
 	"UNDEFINED":           0,
@@ -83,6 +85,7 @@ var ChaincodeMessage_Type_value = map[string]int32{
 	"QUERY_STATE_CLOSE":   17,
 	"KEEPALIVE":           18,
 	"GET_HISTORY_FOR_KEY": 19,
+	"GET_STATE_AT_HEIGHT": 20,
 }
 
 func (x ChaincodeMessage_Type) String() string {
@@ -294,8 +297,16 @@ func (m *GetQueryResult) GetCollection() string {
 	return ""
 }
 
+// GetHistoryForKey is the payload of a ChaincodeMessage. It contains a key
+// whose history is queried, optionally restricted to the blocks from start_block
+// to end_block (0 means no upper bound), from the newest to the oldest modification
+// if newest_first is set, and to limit modifications (0 means no limit).
 type GetHistoryForKey struct {
-	Key string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Key         string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	StartBlock  uint64 `protobuf:"varint,2,opt,name=start_block,json=startBlock" json:"start_block,omitempty"`
+	EndBlock    uint64 `protobuf:"varint,3,opt,name=end_block,json=endBlock" json:"end_block,omitempty"`
+	NewestFirst bool   `protobuf:"varint,4,opt,name=newest_first,json=newestFirst" json:"newest_first,omitempty"`
+	Limit       uint32 `protobuf:"varint,5,opt,name=limit" json:"limit,omitempty"`
 }
 
 func (m *GetHistoryForKey) Reset()                    { *m = GetHistoryForKey{} }
@@ -310,6 +321,61 @@ func (m *GetHistoryForKey) GetKey() string {
 	return ""
 }
 
+func (m *GetHistoryForKey) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *GetHistoryForKey) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+func (m *GetHistoryForKey) GetNewestFirst() bool {
+	if m != nil {
+		return m.NewestFirst
+	}
+	return false
+}
+
+func (m *GetHistoryForKey) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// GetStateAtHeight is the payload of a ChaincodeMessage. It contains a key
+// whose value is read as of the block block_num, i.e. after the transactions
+// of that block were committed.
+type GetStateAtHeight struct {
+	Key      string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	BlockNum uint64 `protobuf:"varint,2,opt,name=block_num,json=blockNum" json:"block_num,omitempty"`
+}
+
+func (m *GetStateAtHeight) Reset()                    { *m = GetStateAtHeight{} }
+func (m *GetStateAtHeight) String() string            { return proto.CompactTextString(m) }
+func (*GetStateAtHeight) ProtoMessage()               {}
+func (*GetStateAtHeight) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{7} }
+
+func (m *GetStateAtHeight) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *GetStateAtHeight) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
 type QueryStateNext struct {
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 }
@@ -317,7 +383,7 @@ type QueryStateNext struct {
 func (m *QueryStateNext) Reset()                    { *m = QueryStateNext{} }
 func (m *QueryStateNext) String() string            { return proto.CompactTextString(m) }
 func (*QueryStateNext) ProtoMessage()               {}
-func (*QueryStateNext) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{7} }
+func (*QueryStateNext) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{8} }
 
 func (m *QueryStateNext) GetId() string {
 	if m != nil {
@@ -333,7 +399,7 @@ type QueryStateClose struct {
 func (m *QueryStateClose) Reset()                    { *m = QueryStateClose{} }
 func (m *QueryStateClose) String() string            { return proto.CompactTextString(m) }
 func (*QueryStateClose) ProtoMessage()               {}
-func (*QueryStateClose) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{8} }
+func (*QueryStateClose) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{9} }
 
 func (m *QueryStateClose) GetId() string {
 	if m != nil {
@@ -349,7 +415,7 @@ type QueryResultBytes struct {
 func (m *QueryResultBytes) Reset()                    { *m = QueryResultBytes{} }
 func (m *QueryResultBytes) String() string            { return proto.CompactTextString(m) }
 func (*QueryResultBytes) ProtoMessage()               {}
-func (*QueryResultBytes) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{9} }
+func (*QueryResultBytes) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{10} }
 
 func (m *QueryResultBytes) GetResultBytes() []byte {
 	if m != nil {
@@ -367,7 +433,7 @@ type QueryResponse struct {
 func (m *QueryResponse) Reset()                    { *m = QueryResponse{} }
 func (m *QueryResponse) String() string            { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()               {}
-func (*QueryResponse) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{10} }
+func (*QueryResponse) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{11} }
 
 func (m *QueryResponse) GetResults() []*QueryResultBytes {
 	if m != nil {
@@ -398,6 +464,7 @@ func init() {
 	proto.RegisterType((*GetStateByRange)(nil), "protos.GetStateByRange")
 	proto.RegisterType((*GetQueryResult)(nil), "protos.GetQueryResult")
 	proto.RegisterType((*GetHistoryForKey)(nil), "protos.GetHistoryForKey")
+	proto.RegisterType((*GetStateAtHeight)(nil), "protos.GetStateAtHeight")
 	proto.RegisterType((*QueryStateNext)(nil), "protos.QueryStateNext")
 	proto.RegisterType((*QueryStateClose)(nil), "protos.QueryStateClose")
 	proto.RegisterType((*QueryResultBytes)(nil), "protos.QueryResultBytes")
@@ -512,57 +579,63 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 825 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x95, 0x51, 0x6f, 0xe2, 0x46,
-	0x10, 0xc7, 0x8f, 0x00, 0xc1, 0x0c, 0x09, 0xec, 0x6d, 0xae, 0xa9, 0x0f, 0xe9, 0x5a, 0x8a, 0xfa,
-	0x40, 0x5f, 0xa0, 0xa5, 0x7d, 0xe8, 0xc3, 0x49, 0x15, 0xc1, 0x1b, 0x62, 0x85, 0xd8, 0xdc, 0xda,
-	0x39, 0x1d, 0x7d, 0xb1, 0x1c, 0xbc, 0x67, 0xac, 0x1a, 0xaf, 0x6b, 0x2f, 0xa7, 0xf3, 0x67, 0xe8,
-	0x07, 0xeb, 0xd7, 0xaa, 0xd6, 0xc6, 0x84, 0x23, 0x8a, 0x4e, 0xea, 0x13, 0xfe, 0xcf, 0xfc, 0x66,
-	0xe6, 0x3f, 0xd6, 0xb2, 0x86, 0xd7, 0x31, 0x63, 0xc9, 0x68, 0xb5, 0x76, 0x83, 0x68, 0xc5, 0x3d,
-	0xe6, 0xa4, 0xeb, 0x60, 0x33, 0x8c, 0x13, 0x2e, 0x38, 0x3e, 0xcd, 0x7f, 0xd2, 0x6e, 0xf7, 0x08,
-	0x61, 0x9f, 0x58, 0x24, 0x0a, 0xa6, 0x7b, 0x91, 0xe7, 0xe2, 0x84, 0xc7, 0x3c, 0x75, 0xc3, 0x5d,
-	0xf0, 0x7b, 0x9f, 0x73, 0x3f, 0x64, 0xa3, 0x5c, 0x3d, 0x6c, 0x3f, 0x8e, 0x44, 0xb0, 0x61, 0xa9,
-	0x70, 0x37, 0x71, 0x01, 0xf4, 0xff, 0xa9, 0x03, 0x9a, 0x96, 0xfd, 0xee, 0x58, 0x9a, 0xba, 0x3e,
-	0xc3, 0xbf, 0x40, 0x4d, 0x64, 0x31, 0x53, 0x2b, 0xbd, 0xca, 0xa0, 0x3d, 0x7e, 0x53, 0xa0, 0xe9,
-	0xf0, 0x98, 0x1b, 0xda, 0x59, 0xcc, 0x68, 0x8e, 0xe2, 0xdf, 0xa1, 0xb9, 0x6f, 0xad, 0x9e, 0xf4,
-	0x2a, 0x83, 0xd6, 0xb8, 0x3b, 0x2c, 0x86, 0x0f, 0xcb, 0xe1, 0x43, 0xbb, 0x24, 0xe8, 0x23, 0x8c,
-	0x55, 0x68, 0xc4, 0x6e, 0x16, 0x72, 0xd7, 0x53, 0xab, 0xbd, 0xca, 0xe0, 0x8c, 0x96, 0x12, 0x63,
-	0xa8, 0x89, 0xcf, 0x81, 0xa7, 0xd6, 0x7a, 0x95, 0x41, 0x93, 0xe6, 0xcf, 0x78, 0x0c, 0x4a, 0xb9,
-	0xa2, 0x5a, 0xcf, 0xc7, 0x5c, 0x96, 0xf6, 0xac, 0xc0, 0x8f, 0x98, 0xb7, 0xd8, 0x65, 0xe9, 0x9e,
-	0xc3, 0x7f, 0x40, 0xe7, 0xe8, 0x95, 0xa9, 0xa7, 0x5f, 0x96, 0xee, 0x37, 0x23, 0x32, 0x4b, 0xdb,
-	0xab, 0x2f, 0x34, 0x7e, 0x03, 0xb0, 0x5a, 0xbb, 0x51, 0xc4, 0x42, 0x27, 0xf0, 0xd4, 0x46, 0x6e,
-	0xa7, 0xb9, 0x8b, 0xe8, 0x5e, 0xff, 0xdf, 0x13, 0xa8, 0xc9, 0x57, 0x81, 0xcf, 0xa1, 0x79, 0x6f,
-	0x68, 0xe4, 0x5a, 0x37, 0x88, 0x86, 0x5e, 0xe0, 0x33, 0x50, 0x28, 0x99, 0xe9, 0x96, 0x4d, 0x28,
-	0xaa, 0xe0, 0x36, 0x40, 0xa9, 0x88, 0x86, 0x4e, 0xb0, 0x02, 0x35, 0xdd, 0xd0, 0x6d, 0x54, 0xc5,
-	0x4d, 0xa8, 0x53, 0x32, 0xd1, 0x96, 0xa8, 0x86, 0x3b, 0xd0, 0xb2, 0xe9, 0xc4, 0xb0, 0x26, 0x53,
-	0x5b, 0x37, 0x0d, 0x54, 0x97, 0x2d, 0xa7, 0xe6, 0xdd, 0x62, 0x4e, 0x6c, 0xa2, 0xa1, 0x53, 0x89,
-	0x12, 0x4a, 0x4d, 0x8a, 0x1a, 0x32, 0x33, 0x23, 0xb6, 0x63, 0xd9, 0x13, 0x9b, 0x20, 0x45, 0xca,
-	0xc5, 0x7d, 0x29, 0x9b, 0x52, 0x6a, 0x64, 0xbe, 0x93, 0x80, 0x5f, 0x01, 0xd2, 0x8d, 0xf7, 0xe6,
-	0x2d, 0x71, 0xa6, 0x37, 0x13, 0xdd, 0x98, 0x9a, 0x1a, 0x41, 0xad, 0xc2, 0xa0, 0xb5, 0x30, 0x0d,
-	0x8b, 0xa0, 0x73, 0x7c, 0x09, 0x78, 0xdf, 0xd0, 0xb9, 0x5a, 0x3a, 0x74, 0x62, 0xcc, 0x08, 0x6a,
-	0xcb, 0x5a, 0x19, 0x7f, 0x77, 0x4f, 0xe8, 0xd2, 0xa1, 0xc4, 0xba, 0x9f, 0xdb, 0xa8, 0x23, 0xa3,
-	0x45, 0xa4, 0xe0, 0x0d, 0xf2, 0xc1, 0x46, 0x08, 0x7f, 0x03, 0x2f, 0x0f, 0xa3, 0xd3, 0xb9, 0x69,
-	0x11, 0xf4, 0x52, 0xba, 0xb9, 0x25, 0x64, 0x31, 0x99, 0xeb, 0xef, 0x09, 0xc2, 0xf8, 0x5b, 0xb8,
-	0x90, 0x1d, 0x6f, 0x74, 0xcb, 0x36, 0xe9, 0xd2, 0xb9, 0x36, 0xa9, 0x73, 0x4b, 0x96, 0xe8, 0xa2,
-	0xff, 0x16, 0x94, 0x19, 0x13, 0x96, 0x70, 0x05, 0xc3, 0x08, 0xaa, 0x7f, 0xb1, 0x2c, 0x3f, 0x83,
-	0x4d, 0x2a, 0x1f, 0xf1, 0x77, 0x00, 0x2b, 0x1e, 0x86, 0x6c, 0x25, 0x02, 0x1e, 0xe5, 0x87, 0xac,
-	0x49, 0x0f, 0x22, 0x7d, 0x0a, 0xca, 0x62, 0xfb, 0x6c, 0xf5, 0x2b, 0xa8, 0x7f, 0x72, 0xc3, 0x2d,
-	0xcb, 0x0b, 0xcf, 0x68, 0x21, 0x8e, 0x7a, 0x56, 0x9f, 0xf4, 0x7c, 0x0b, 0x8a, 0xc6, 0xc2, 0xff,
-	0xeb, 0x88, 0x41, 0xa7, 0xdc, 0xe7, 0x2a, 0xa3, 0x6e, 0xe4, 0x33, 0xdc, 0x05, 0x25, 0x15, 0x6e,
-	0x22, 0x6e, 0xf7, 0x9d, 0xf6, 0x1a, 0x5f, 0xc2, 0x29, 0x8b, 0x3c, 0x99, 0x29, 0x5a, 0xed, 0xd4,
-	0x57, 0x4d, 0x5e, 0x43, 0x7b, 0xc6, 0xc4, 0xbb, 0x2d, 0x4b, 0x32, 0xca, 0xd2, 0x6d, 0x28, 0xe4,
-	0xb2, 0x7f, 0x4b, 0xb9, 0x1b, 0x51, 0x88, 0xaf, 0xda, 0xfd, 0x11, 0xd0, 0x8c, 0x89, 0x9b, 0x20,
-	0x15, 0x3c, 0xc9, 0xae, 0x79, 0x22, 0x67, 0x3f, 0x59, 0xba, 0xdf, 0x83, 0x76, 0x3e, 0x2a, 0x5f,
-	0xcb, 0x60, 0x9f, 0x05, 0x6e, 0xc3, 0x49, 0xe0, 0xed, 0x90, 0x93, 0xc0, 0xeb, 0xff, 0x00, 0x9d,
-	0x47, 0x62, 0x1a, 0xf2, 0x94, 0x3d, 0x41, 0x7e, 0x03, 0x74, 0xe0, 0xf7, 0x2a, 0x13, 0x2c, 0xc5,
-	0x3d, 0x68, 0x25, 0x8f, 0x32, 0x87, 0xcf, 0xe8, 0x61, 0xa8, 0x1f, 0xc1, 0x79, 0x59, 0x15, 0xf3,
-	0x28, 0x65, 0x78, 0x0c, 0x8d, 0x22, 0x2f, 0xf1, 0xea, 0xa0, 0x35, 0x56, 0xcb, 0xbf, 0xf4, 0x71,
-	0x77, 0x5a, 0x82, 0xf8, 0x35, 0x28, 0x6b, 0x37, 0x75, 0x36, 0x3c, 0x29, 0xce, 0x82, 0x42, 0x1b,
-	0x6b, 0x37, 0xbd, 0xe3, 0x49, 0xe9, 0xb2, 0x5a, 0xba, 0x1c, 0x7f, 0x38, 0xb8, 0x1c, 0xad, 0x6d,
-	0x1c, 0xf3, 0x44, 0x60, 0x0d, 0x14, 0xca, 0xfc, 0x20, 0x15, 0x2c, 0xc1, 0xea, 0x73, 0x57, 0x63,
-	0xf7, 0xd9, 0x4c, 0xff, 0xc5, 0xa0, 0xf2, 0x73, 0xe5, 0xca, 0x84, 0x3e, 0x4f, 0xfc, 0xe1, 0x3a,
-	0x8b, 0x59, 0x12, 0x32, 0xcf, 0x67, 0xc9, 0xf0, 0xa3, 0xfb, 0x90, 0x04, 0xab, 0xb2, 0x4e, 0xde,
-	0xe6, 0x7f, 0xfe, 0xe4, 0x07, 0x62, 0xbd, 0x7d, 0x18, 0xae, 0xf8, 0x66, 0x74, 0x80, 0x8e, 0x0a,
-	0xb4, 0xb8, 0xd5, 0xd3, 0x91, 0x44, 0x1f, 0x8a, 0x4f, 0xc4, 0xaf, 0xff, 0x05, 0x00, 0x00, 0xff,
-	0xff, 0x18, 0x6a, 0x79, 0xe7, 0x46, 0x06, 0x00, 0x00,
+	// 928 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xd1, 0x72, 0xda, 0x46,
+	0x14, 0x0d, 0x06, 0xdb, 0xe2, 0x62, 0xe3, 0xcd, 0xda, 0x75, 0x09, 0x99, 0x34, 0x84, 0x27, 0xfa,
+	0x02, 0x2d, 0xed, 0x43, 0x1f, 0x32, 0xd3, 0xc1, 0xb0, 0x06, 0x8d, 0x6d, 0x41, 0x56, 0x72, 0x26,
+	0xee, 0x8b, 0x46, 0x46, 0xd7, 0xa0, 0x89, 0xd0, 0xaa, 0xd2, 0x92, 0x86, 0x6f, 0xe9, 0x6b, 0x3f,
+	0xac, 0x9f, 0xd2, 0xd9, 0x15, 0xc2, 0xc4, 0xae, 0x27, 0x33, 0x79, 0x82, 0x73, 0xee, 0xb9, 0xe7,
+	0x9e, 0xdd, 0x59, 0xed, 0xc2, 0x8b, 0x18, 0x31, 0xe9, 0x4c, 0xe7, 0x5e, 0x10, 0x4d, 0x85, 0x8f,
+	0x6e, 0x3a, 0x0f, 0x16, 0xed, 0x38, 0x11, 0x52, 0xd0, 0x3d, 0xfd, 0x93, 0xd6, 0xeb, 0x0f, 0x24,
+	0xf8, 0x09, 0x23, 0x99, 0x69, 0xea, 0xc7, 0xba, 0x16, 0x27, 0x22, 0x16, 0xa9, 0x17, 0xae, 0xc9,
+	0xd7, 0x33, 0x21, 0x66, 0x21, 0x76, 0x34, 0xba, 0x5d, 0xde, 0x75, 0x64, 0xb0, 0xc0, 0x54, 0x7a,
+	0x8b, 0x38, 0x13, 0x34, 0xff, 0xd9, 0x05, 0xd2, 0xcf, 0xfd, 0xae, 0x30, 0x4d, 0xbd, 0x19, 0xd2,
+	0x9f, 0xa1, 0x24, 0x57, 0x31, 0xd6, 0x0a, 0x8d, 0x42, 0xab, 0xda, 0x7d, 0x95, 0x49, 0xd3, 0xf6,
+	0x43, 0x5d, 0xdb, 0x59, 0xc5, 0xc8, 0xb5, 0x94, 0xfe, 0x06, 0xe5, 0x8d, 0x75, 0x6d, 0xa7, 0x51,
+	0x68, 0x55, 0xba, 0xf5, 0x76, 0x36, 0xbc, 0x9d, 0x0f, 0x6f, 0x3b, 0xb9, 0x82, 0xdf, 0x8b, 0x69,
+	0x0d, 0xf6, 0x63, 0x6f, 0x15, 0x0a, 0xcf, 0xaf, 0x15, 0x1b, 0x85, 0xd6, 0x01, 0xcf, 0x21, 0xa5,
+	0x50, 0x92, 0x9f, 0x03, 0xbf, 0x56, 0x6a, 0x14, 0x5a, 0x65, 0xae, 0xff, 0xd3, 0x2e, 0x18, 0xf9,
+	0x12, 0x6b, 0xbb, 0x7a, 0xcc, 0x69, 0x1e, 0xcf, 0x0e, 0x66, 0x11, 0xfa, 0x93, 0x75, 0x95, 0x6f,
+	0x74, 0xf4, 0x77, 0x38, 0x7a, 0xb0, 0x65, 0xb5, 0xbd, 0x2f, 0x5b, 0x37, 0x2b, 0x63, 0xaa, 0xca,
+	0xab, 0xd3, 0x2f, 0x30, 0x7d, 0x05, 0x30, 0x9d, 0x7b, 0x51, 0x84, 0xa1, 0x1b, 0xf8, 0xb5, 0x7d,
+	0x1d, 0xa7, 0xbc, 0x66, 0x4c, 0xbf, 0xf9, 0xef, 0x0e, 0x94, 0xd4, 0x56, 0xd0, 0x43, 0x28, 0x5f,
+	0x5b, 0x03, 0x76, 0x6e, 0x5a, 0x6c, 0x40, 0x9e, 0xd1, 0x03, 0x30, 0x38, 0x1b, 0x9a, 0xb6, 0xc3,
+	0x38, 0x29, 0xd0, 0x2a, 0x40, 0x8e, 0xd8, 0x80, 0xec, 0x50, 0x03, 0x4a, 0xa6, 0x65, 0x3a, 0xa4,
+	0x48, 0xcb, 0xb0, 0xcb, 0x59, 0x6f, 0x70, 0x43, 0x4a, 0xf4, 0x08, 0x2a, 0x0e, 0xef, 0x59, 0x76,
+	0xaf, 0xef, 0x98, 0x63, 0x8b, 0xec, 0x2a, 0xcb, 0xfe, 0xf8, 0x6a, 0x72, 0xc9, 0x1c, 0x36, 0x20,
+	0x7b, 0x4a, 0xca, 0x38, 0x1f, 0x73, 0xb2, 0xaf, 0x2a, 0x43, 0xe6, 0xb8, 0xb6, 0xd3, 0x73, 0x18,
+	0x31, 0x14, 0x9c, 0x5c, 0xe7, 0xb0, 0xac, 0xe0, 0x80, 0x5d, 0xae, 0x21, 0xd0, 0x13, 0x20, 0xa6,
+	0xf5, 0x7e, 0x7c, 0xc1, 0xdc, 0xfe, 0xa8, 0x67, 0x5a, 0xfd, 0xf1, 0x80, 0x91, 0x4a, 0x16, 0xd0,
+	0x9e, 0x8c, 0x2d, 0x9b, 0x91, 0x43, 0x7a, 0x0a, 0x74, 0x63, 0xe8, 0x9e, 0xdd, 0xb8, 0xbc, 0x67,
+	0x0d, 0x19, 0xa9, 0xaa, 0x5e, 0xc5, 0xbf, 0xbb, 0x66, 0xfc, 0xc6, 0xe5, 0xcc, 0xbe, 0xbe, 0x74,
+	0xc8, 0x91, 0x62, 0x33, 0x26, 0xd3, 0x5b, 0xec, 0x83, 0x43, 0x08, 0xfd, 0x0e, 0x9e, 0x6f, 0xb3,
+	0xfd, 0xcb, 0xb1, 0xcd, 0xc8, 0x73, 0x95, 0xe6, 0x82, 0xb1, 0x49, 0xef, 0xd2, 0x7c, 0xcf, 0x08,
+	0xa5, 0xdf, 0xc3, 0xb1, 0x72, 0x1c, 0x99, 0xb6, 0x33, 0xe6, 0x37, 0xee, 0xf9, 0x98, 0xbb, 0x17,
+	0xec, 0x86, 0x1c, 0xe7, 0x85, 0xac, 0xb9, 0xe7, 0xb8, 0x23, 0x66, 0x0e, 0x47, 0x0e, 0x39, 0x69,
+	0xbe, 0x05, 0x63, 0x88, 0xd2, 0x96, 0x9e, 0x44, 0x4a, 0xa0, 0xf8, 0x11, 0x57, 0xfa, 0x70, 0x96,
+	0xb9, 0xfa, 0x4b, 0x7f, 0x00, 0x98, 0x8a, 0x30, 0xc4, 0xa9, 0x0c, 0x44, 0xa4, 0x4f, 0x5f, 0x99,
+	0x6f, 0x31, 0x4d, 0x0e, 0xc6, 0x64, 0xf9, 0x64, 0xf7, 0x09, 0xec, 0x7e, 0xf2, 0xc2, 0x25, 0xea,
+	0xc6, 0x03, 0x9e, 0x81, 0x07, 0x9e, 0xc5, 0x47, 0x9e, 0x6f, 0xc1, 0x18, 0x60, 0xf8, 0xad, 0x89,
+	0x10, 0x8e, 0xf2, 0xf5, 0x9c, 0xad, 0xb8, 0x17, 0xcd, 0x90, 0xd6, 0xc1, 0x48, 0xa5, 0x97, 0xc8,
+	0x8b, 0x8d, 0xd3, 0x06, 0xd3, 0x53, 0xd8, 0xc3, 0xc8, 0x57, 0x95, 0xcc, 0x6a, 0x8d, 0xbe, 0x1a,
+	0xf2, 0x1c, 0xaa, 0x43, 0x94, 0xef, 0x96, 0x98, 0xac, 0x38, 0xa6, 0xcb, 0x50, 0xaa, 0xc5, 0xfe,
+	0xa9, 0xe0, 0x7a, 0x44, 0x06, 0xbe, 0x1a, 0xf7, 0xef, 0x02, 0x90, 0x21, 0xca, 0x51, 0x90, 0x4a,
+	0x91, 0xac, 0xce, 0x45, 0xa2, 0x86, 0x3f, 0x5e, 0xf5, 0x6b, 0xa8, 0xe8, 0xc8, 0xee, 0x6d, 0x28,
+	0xa6, 0x1f, 0xb5, 0x4f, 0x89, 0x83, 0xa6, 0xce, 0x14, 0x43, 0x5f, 0x42, 0x19, 0x23, 0x7f, 0x5d,
+	0x2e, 0xea, 0xb2, 0x81, 0x91, 0x9f, 0x15, 0xdf, 0xc0, 0x41, 0x84, 0x7f, 0x61, 0x2a, 0xdd, 0xbb,
+	0x20, 0x49, 0xa5, 0xfe, 0xec, 0x0d, 0x5e, 0xc9, 0xb8, 0x73, 0x45, 0xa9, 0xf4, 0x61, 0xb0, 0x08,
+	0xa4, 0xfe, 0xf4, 0x0f, 0x79, 0x06, 0x9a, 0x3d, 0x20, 0xf9, 0x66, 0xf6, 0xe4, 0x08, 0x83, 0xd9,
+	0x5c, 0xfe, 0x4f, 0xb8, 0x97, 0x50, 0xd6, 0x73, 0xdd, 0x68, 0xb9, 0x58, 0x47, 0x33, 0x34, 0x61,
+	0x2d, 0x17, 0xcd, 0x06, 0x54, 0xf5, 0x2e, 0x69, 0x13, 0x0b, 0x3f, 0x4b, 0x5a, 0x85, 0x9d, 0xc0,
+	0x5f, 0xf7, 0xef, 0x04, 0x7e, 0xf3, 0x0d, 0x1c, 0xdd, 0x2b, 0xfa, 0xa1, 0x48, 0xf1, 0x91, 0xe4,
+	0x57, 0x20, 0x5b, 0x5b, 0x7d, 0xb6, 0x92, 0x98, 0xd2, 0x06, 0x54, 0x92, 0x7b, 0xa8, 0xc5, 0x07,
+	0x7c, 0x9b, 0x6a, 0x46, 0x70, 0x98, 0x77, 0xc5, 0x22, 0x4a, 0x91, 0x76, 0x61, 0x3f, 0xab, 0x2b,
+	0x79, 0xb1, 0x55, 0xe9, 0xd6, 0xf2, 0x6b, 0xea, 0xa1, 0x3b, 0xcf, 0x85, 0xf4, 0x05, 0x18, 0x73,
+	0x2f, 0x75, 0x17, 0x22, 0xc9, 0x8e, 0xb1, 0xc1, 0xf7, 0xe7, 0x5e, 0x7a, 0x25, 0x92, 0x3c, 0x65,
+	0x31, 0x4f, 0xd9, 0xfd, 0xb0, 0x75, 0xe1, 0xdb, 0xcb, 0x38, 0x16, 0x89, 0xa4, 0x03, 0x30, 0x38,
+	0xce, 0x82, 0x54, 0x62, 0x42, 0x6b, 0x4f, 0x5d, 0xf7, 0xf5, 0x27, 0x2b, 0xcd, 0x67, 0xad, 0xc2,
+	0x4f, 0x85, 0xb3, 0x31, 0x34, 0x45, 0x32, 0x6b, 0xcf, 0x57, 0x31, 0x26, 0x21, 0xfa, 0x33, 0x4c,
+	0xda, 0x77, 0xde, 0x6d, 0x12, 0x4c, 0xf3, 0x3e, 0xf5, 0x42, 0xfd, 0xf1, 0xe3, 0x2c, 0x90, 0xf3,
+	0xe5, 0x6d, 0x7b, 0x2a, 0x16, 0x9d, 0x2d, 0x69, 0x27, 0x93, 0x66, 0x2f, 0x55, 0xda, 0x51, 0xd2,
+	0xdb, 0xec, 0xd9, 0xfb, 0xe5, 0xbf, 0x01, 0x00, 0x57, 0xbe, 0x57, 0x4b, 0x1a, 0x07, 0x00, 0x00,
 }
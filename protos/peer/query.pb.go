@@ -6,12 +6,51 @@ package peer
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import google_protobuf1 "github.com/golang/protobuf/ptypes/timestamp"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+type IndexStatus_Status int32
+
+const (
+	IndexStatus_UNKNOWN IndexStatus_Status = 0
+	// created from its definition in the chaincode package
+	IndexStatus_CREATED IndexStatus_Status = 1
+	// recreated since its definition changed in the chaincode package
+	IndexStatus_UPDATED IndexStatus_Status = 2
+	// already matching its definition in the chaincode package
+	IndexStatus_UNCHANGED IndexStatus_Status = 3
+	// deleted since its definition was removed from the chaincode package
+	IndexStatus_DELETED IndexStatus_Status = 4
+	// could not be created, updated or deleted
+	IndexStatus_FAILED IndexStatus_Status = 5
+)
+
+var IndexStatus_Status_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "CREATED",
+	2: "UPDATED",
+	3: "UNCHANGED",
+	4: "DELETED",
+	5: "FAILED",
+}
+var IndexStatus_Status_value = map[string]int32{
+	"UNKNOWN":   0,
+	"CREATED":   1,
+	"UPDATED":   2,
+	"UNCHANGED": 3,
+	"DELETED":   4,
+	"FAILED":    5,
+}
+
+func (x IndexStatus_Status) String() string {
+	return proto.EnumName(IndexStatus_Status_name, int32(x))
+}
+func (IndexStatus_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor9, []int{8, 0} }
+
 // ChaincodeQueryResponse returns information about each chaincode that pertains
 // to a query in lscc.go, such as GetChaincodes (returns all chaincodes
 // instantiated on a channel), and GetInstalledChaincodes (returns all chaincodes
@@ -146,34 +185,356 @@ func (m *ChannelInfo) GetChannelId() string {
 	return ""
 }
 
+// PvtDataStatusResponse returns information about the private data of the
+// blocks that pertain to a query in qscc.go, such as GetPvtDataStatus
+// (returns the collections with missing private data of a range of blocks)
+type PvtDataStatusResponse struct {
+	Blocks []*BlockPvtDataStatus `protobuf:"bytes,1,rep,name=blocks" json:"blocks,omitempty"`
+}
+
+func (m *PvtDataStatusResponse) Reset()                    { *m = PvtDataStatusResponse{} }
+func (m *PvtDataStatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*PvtDataStatusResponse) ProtoMessage()               {}
+func (*PvtDataStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{4} }
+
+func (m *PvtDataStatusResponse) GetBlocks() []*BlockPvtDataStatus {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+// BlockPvtDataStatus describes the private data of a block
+type BlockPvtDataStatus struct {
+	BlockNum uint64 `protobuf:"varint,1,opt,name=block_num,json=blockNum" json:"block_num,omitempty"`
+	// whether the private data of the block was purged from the private data store
+	Purged bool `protobuf:"varint,2,opt,name=purged" json:"purged,omitempty"`
+	// the collections of the valid transactions of the block whose private data is missing
+	Missing []*MissingPvtData `protobuf:"bytes,3,rep,name=missing" json:"missing,omitempty"`
+}
+
+func (m *BlockPvtDataStatus) Reset()                    { *m = BlockPvtDataStatus{} }
+func (m *BlockPvtDataStatus) String() string            { return proto.CompactTextString(m) }
+func (*BlockPvtDataStatus) ProtoMessage()               {}
+func (*BlockPvtDataStatus) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{5} }
+
+func (m *BlockPvtDataStatus) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+func (m *BlockPvtDataStatus) GetPurged() bool {
+	if m != nil {
+		return m.Purged
+	}
+	return false
+}
+
+func (m *BlockPvtDataStatus) GetMissing() []*MissingPvtData {
+	if m != nil {
+		return m.Missing
+	}
+	return nil
+}
+
+// MissingPvtData describes the missing private data of a collection of a transaction
+type MissingPvtData struct {
+	TxNum      uint64 `protobuf:"varint,1,opt,name=tx_num,json=txNum" json:"tx_num,omitempty"`
+	TxId       string `protobuf:"bytes,2,opt,name=tx_id,json=txId" json:"tx_id,omitempty"`
+	Namespace  string `protobuf:"bytes,3,opt,name=namespace" json:"namespace,omitempty"`
+	Collection string `protobuf:"bytes,4,opt,name=collection" json:"collection,omitempty"`
+	// whether the peer is eligible to receive the private data of the collection;
+	// peers which aren't eligible never receive it
+	Eligible bool `protobuf:"varint,5,opt,name=eligible" json:"eligible,omitempty"`
+}
+
+func (m *MissingPvtData) Reset()                    { *m = MissingPvtData{} }
+func (m *MissingPvtData) String() string            { return proto.CompactTextString(m) }
+func (*MissingPvtData) ProtoMessage()               {}
+func (*MissingPvtData) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{6} }
+
+func (m *MissingPvtData) GetTxNum() uint64 {
+	if m != nil {
+		return m.TxNum
+	}
+	return 0
+}
+
+func (m *MissingPvtData) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *MissingPvtData) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *MissingPvtData) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *MissingPvtData) GetEligible() bool {
+	if m != nil {
+		return m.Eligible
+	}
+	return false
+}
+
+// IndexStatusResponse returns the state database indexes of a chaincode, as
+// reconciled with the index definitions of its chaincode package when it was
+// last deployed or installed (GetIndexStatus in qscc.go)
+type IndexStatusResponse struct {
+	Indexes []*IndexStatus `protobuf:"bytes,1,rep,name=indexes" json:"indexes,omitempty"`
+}
+
+func (m *IndexStatusResponse) Reset()                    { *m = IndexStatusResponse{} }
+func (m *IndexStatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*IndexStatusResponse) ProtoMessage()               {}
+func (*IndexStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{7} }
+
+func (m *IndexStatusResponse) GetIndexes() []*IndexStatus {
+	if m != nil {
+		return m.Indexes
+	}
+	return nil
+}
+
+// IndexStatus describes an index of the state database of a chaincode
+type IndexStatus struct {
+	DesignDoc string `protobuf:"bytes,1,opt,name=design_doc,json=designDoc" json:"design_doc,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// the file of the chaincode package which defines the index
+	File       string             `protobuf:"bytes,3,opt,name=file" json:"file,omitempty"`
+	Definition string             `protobuf:"bytes,4,opt,name=definition" json:"definition,omitempty"`
+	Status     IndexStatus_Status `protobuf:"varint,5,opt,name=status,enum=protos.IndexStatus_Status" json:"status,omitempty"`
+	// the reason of the failure
+	Error string `protobuf:"bytes,6,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *IndexStatus) Reset()                    { *m = IndexStatus{} }
+func (m *IndexStatus) String() string            { return proto.CompactTextString(m) }
+func (*IndexStatus) ProtoMessage()               {}
+func (*IndexStatus) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{8} }
+
+func (m *IndexStatus) GetDesignDoc() string {
+	if m != nil {
+		return m.DesignDoc
+	}
+	return ""
+}
+
+func (m *IndexStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *IndexStatus) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *IndexStatus) GetDefinition() string {
+	if m != nil {
+		return m.Definition
+	}
+	return ""
+}
+
+func (m *IndexStatus) GetStatus() IndexStatus_Status {
+	if m != nil {
+		return m.Status
+	}
+	return IndexStatus_UNKNOWN
+}
+
+func (m *IndexStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// TxQueryResponse returns the transactions of a channel whose timestamp falls
+// in a time range, optionally restricted to a creator, as indexed in the block
+// store (GetTransactionsByTime and GetTransactionsByCreator in qscc.go)
+type TxQueryResponse struct {
+	Transactions []*IndexedTransaction `protobuf:"bytes,1,rep,name=transactions" json:"transactions,omitempty"`
+	// the bookmark to query the next page of transactions with, empty if the
+	// returned transactions are the last ones of the time range
+	Bookmark string `protobuf:"bytes,2,opt,name=bookmark" json:"bookmark,omitempty"`
+	// the number of the first block whose transactions are indexed; the
+	// transactions of older blocks are not returned until the index is backfilled
+	IndexedFromBlock uint64 `protobuf:"varint,3,opt,name=indexed_from_block,json=indexedFromBlock" json:"indexed_from_block,omitempty"`
+}
+
+func (m *TxQueryResponse) Reset()                    { *m = TxQueryResponse{} }
+func (m *TxQueryResponse) String() string            { return proto.CompactTextString(m) }
+func (*TxQueryResponse) ProtoMessage()               {}
+func (*TxQueryResponse) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{9} }
+
+func (m *TxQueryResponse) GetTransactions() []*IndexedTransaction {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+func (m *TxQueryResponse) GetBookmark() string {
+	if m != nil {
+		return m.Bookmark
+	}
+	return ""
+}
+
+func (m *TxQueryResponse) GetIndexedFromBlock() uint64 {
+	if m != nil {
+		return m.IndexedFromBlock
+	}
+	return 0
+}
+
+// IndexedTransaction describes a transaction indexed by creator and time
+type IndexedTransaction struct {
+	TxId     string `protobuf:"bytes,1,opt,name=tx_id,json=txId" json:"tx_id,omitempty"`
+	BlockNum uint64 `protobuf:"varint,2,opt,name=block_num,json=blockNum" json:"block_num,omitempty"`
+	TxNum    uint64 `protobuf:"varint,3,opt,name=tx_num,json=txNum" json:"tx_num,omitempty"`
+	// the timestamp of the channel header of the transaction
+	Timestamp *google_protobuf1.Timestamp `protobuf:"bytes,4,opt,name=timestamp" json:"timestamp,omitempty"`
+	// the SHA-256 hash of the serialized identity of the creator of the transaction
+	CreatorHash    []byte           `protobuf:"bytes,5,opt,name=creator_hash,json=creatorHash,proto3" json:"creator_hash,omitempty"`
+	ValidationCode TxValidationCode `protobuf:"varint,6,opt,name=validation_code,json=validationCode,enum=protos.TxValidationCode" json:"validation_code,omitempty"`
+}
+
+func (m *IndexedTransaction) Reset()                    { *m = IndexedTransaction{} }
+func (m *IndexedTransaction) String() string            { return proto.CompactTextString(m) }
+func (*IndexedTransaction) ProtoMessage()               {}
+func (*IndexedTransaction) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{10} }
+
+func (m *IndexedTransaction) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *IndexedTransaction) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+func (m *IndexedTransaction) GetTxNum() uint64 {
+	if m != nil {
+		return m.TxNum
+	}
+	return 0
+}
+
+func (m *IndexedTransaction) GetTimestamp() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *IndexedTransaction) GetCreatorHash() []byte {
+	if m != nil {
+		return m.CreatorHash
+	}
+	return nil
+}
+
+func (m *IndexedTransaction) GetValidationCode() TxValidationCode {
+	if m != nil {
+		return m.ValidationCode
+	}
+	return TxValidationCode_VALID
+}
+
 func init() {
 	proto.RegisterType((*ChaincodeQueryResponse)(nil), "protos.ChaincodeQueryResponse")
 	proto.RegisterType((*ChaincodeInfo)(nil), "protos.ChaincodeInfo")
 	proto.RegisterType((*ChannelQueryResponse)(nil), "protos.ChannelQueryResponse")
 	proto.RegisterType((*ChannelInfo)(nil), "protos.ChannelInfo")
+	proto.RegisterType((*PvtDataStatusResponse)(nil), "protos.PvtDataStatusResponse")
+	proto.RegisterType((*BlockPvtDataStatus)(nil), "protos.BlockPvtDataStatus")
+	proto.RegisterType((*MissingPvtData)(nil), "protos.MissingPvtData")
+	proto.RegisterType((*IndexStatusResponse)(nil), "protos.IndexStatusResponse")
+	proto.RegisterType((*IndexStatus)(nil), "protos.IndexStatus")
+	proto.RegisterType((*TxQueryResponse)(nil), "protos.TxQueryResponse")
+	proto.RegisterType((*IndexedTransaction)(nil), "protos.IndexedTransaction")
+	proto.RegisterEnum("protos.IndexStatus_Status", IndexStatus_Status_name, IndexStatus_Status_value)
 }
 
 func init() { proto.RegisterFile("peer/query.proto", fileDescriptor9) }
 
 var fileDescriptor9 = []byte{
-	// 296 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x91, 0xdf, 0x4a, 0xc3, 0x30,
-	0x14, 0xc6, 0xe9, 0xfe, 0xba, 0x33, 0x15, 0x89, 0x53, 0x72, 0x23, 0x8c, 0x5e, 0x4d, 0x90, 0x16,
-	0x14, 0x5f, 0xc0, 0x5d, 0xc8, 0xae, 0x86, 0xbd, 0xf4, 0x46, 0xba, 0xe4, 0x6c, 0x0d, 0x6c, 0x49,
-	0x4c, 0xba, 0xc1, 0x9e, 0xc6, 0x57, 0x95, 0x93, 0xac, 0xa3, 0xbb, 0xea, 0x39, 0xbf, 0xef, 0x17,
-	0xca, 0x97, 0xc0, 0x9d, 0x45, 0x74, 0xf9, 0xef, 0x1e, 0xdd, 0x31, 0xb3, 0xce, 0xd4, 0x86, 0x0d,
-	0xc2, 0xc7, 0xa7, 0x4b, 0x78, 0x9c, 0x57, 0xa5, 0xd2, 0xc2, 0x48, 0xfc, 0xa2, 0xbc, 0x40, 0x6f,
-	0x8d, 0xf6, 0xc8, 0xde, 0x01, 0x44, 0x93, 0x78, 0x9e, 0x4c, 0xbb, 0xb3, 0xf1, 0xeb, 0x43, 0x3c,
-	0xed, 0xb3, 0xf3, 0x99, 0x85, 0x5e, 0x9b, 0xa2, 0x25, 0xa6, 0x7f, 0x09, 0xdc, 0x5c, 0xa4, 0x8c,
-	0x41, 0x4f, 0x97, 0x3b, 0xe4, 0xc9, 0x34, 0x99, 0x8d, 0x8a, 0x30, 0x33, 0x0e, 0xc3, 0x03, 0x3a,
-	0xaf, 0x8c, 0xe6, 0x9d, 0x80, 0x9b, 0x95, 0x6c, 0x5b, 0xd6, 0x15, 0xef, 0x46, 0x9b, 0x66, 0x36,
-	0x81, 0xbe, 0xd2, 0x76, 0x5f, 0xf3, 0x5e, 0x80, 0x71, 0x21, 0x13, 0xbd, 0x10, 0xbc, 0x1f, 0x4d,
-	0x9a, 0x89, 0x1d, 0x88, 0x0d, 0x22, 0xa3, 0x99, 0xdd, 0x42, 0x47, 0x49, 0x3e, 0x9c, 0x26, 0xb3,
-	0xeb, 0xa2, 0xa3, 0x64, 0xfa, 0x09, 0x93, 0x79, 0x55, 0x6a, 0x8d, 0xdb, 0xcb, 0xc2, 0x39, 0x5c,
-	0x89, 0xc8, 0x9b, 0xba, 0xf7, 0xad, 0xba, 0xc4, 0x43, 0xd9, 0xb3, 0x94, 0xbe, 0xc0, 0xb8, 0x15,
-	0xb0, 0xa7, 0x70, 0x61, 0xb4, 0xfe, 0x28, 0x79, 0x6a, 0x3b, 0x3a, 0x91, 0x85, 0xfc, 0x58, 0x42,
-	0x6a, 0xdc, 0x26, 0xab, 0x8e, 0x16, 0xdd, 0x16, 0xe5, 0x06, 0x5d, 0xb6, 0x2e, 0x57, 0x4e, 0x89,
-	0xe6, 0x27, 0xf4, 0x46, 0xdf, 0xcf, 0x1b, 0x55, 0x57, 0xfb, 0x55, 0x26, 0xcc, 0x2e, 0x6f, 0xa9,
-	0x79, 0x54, 0xf3, 0xa8, 0xe6, 0xa4, 0xae, 0xe2, 0x13, 0xbe, 0xfd, 0x07, 0x00, 0x00, 0xff, 0xff,
-	0x79, 0x94, 0xb3, 0xbd, 0xdd, 0x01, 0x00, 0x00,
+	// 817 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x55, 0x5d, 0x6f, 0xdb, 0x36,
+	0x14, 0x9d, 0xfc, 0xed, 0xeb, 0xd4, 0x35, 0x98, 0x26, 0x10, 0xb2, 0xaf, 0x4c, 0x4f, 0x19, 0xd0,
+	0xd9, 0x43, 0x86, 0x01, 0x7b, 0x1a, 0x90, 0xda, 0x6e, 0x6b, 0xb4, 0x73, 0x3a, 0xcd, 0x5d, 0x81,
+	0xbd, 0x18, 0xb4, 0x48, 0xcb, 0x44, 0x24, 0x51, 0x23, 0x29, 0xc3, 0xc5, 0xfe, 0xc7, 0xf6, 0xb0,
+	0x87, 0xfd, 0xd2, 0x01, 0x05, 0x49, 0x49, 0x96, 0xe2, 0x27, 0xf3, 0x9e, 0x7b, 0xe8, 0xcb, 0x7b,
+	0xcf, 0x21, 0x05, 0xa3, 0x94, 0x52, 0x31, 0xf9, 0x33, 0xa3, 0xe2, 0xe3, 0x38, 0x15, 0x5c, 0x71,
+	0xd4, 0x31, 0x3f, 0xf2, 0xea, 0xeb, 0x90, 0xf3, 0x30, 0xa2, 0x13, 0x13, 0x6e, 0xb2, 0xed, 0x44,
+	0xb1, 0x98, 0x4a, 0x85, 0xe3, 0xd4, 0x12, 0xaf, 0x2e, 0xcd, 0x56, 0x25, 0x70, 0x22, 0x71, 0xa0,
+	0x18, 0x4f, 0x2c, 0xee, 0xdd, 0xc3, 0xe5, 0x74, 0x87, 0x59, 0x12, 0x70, 0x42, 0x7f, 0xd5, 0x7f,
+	0xec, 0x53, 0x99, 0xf2, 0x44, 0x52, 0xf4, 0x23, 0x40, 0x50, 0x64, 0xa4, 0xeb, 0x5c, 0x37, 0x6f,
+	0x06, 0xb7, 0x17, 0x76, 0x97, 0x1c, 0x97, 0x7b, 0x16, 0xc9, 0x96, 0xfb, 0x15, 0xa2, 0xf7, 0x9f,
+	0x03, 0x4f, 0x6a, 0x59, 0x84, 0xa0, 0x95, 0xe0, 0x98, 0xba, 0xce, 0xb5, 0x73, 0xd3, 0xf7, 0xcd,
+	0x1a, 0xb9, 0xd0, 0xdd, 0x53, 0x21, 0x19, 0x4f, 0xdc, 0x86, 0x81, 0x8b, 0x50, 0xb3, 0x53, 0xac,
+	0x76, 0x6e, 0xd3, 0xb2, 0xf5, 0x1a, 0x3d, 0x83, 0x36, 0x4b, 0xd2, 0x4c, 0xb9, 0x2d, 0x03, 0xda,
+	0x40, 0x33, 0xa9, 0x0c, 0x02, 0xb7, 0x6d, 0x99, 0x7a, 0xad, 0xb1, 0xbd, 0xc6, 0x3a, 0x16, 0xd3,
+	0x6b, 0x34, 0x84, 0x06, 0x23, 0x6e, 0xf7, 0xda, 0xb9, 0x39, 0xf3, 0x1b, 0x8c, 0x78, 0xaf, 0xe0,
+	0xd9, 0x74, 0x87, 0x93, 0x84, 0x46, 0xf5, 0x86, 0x27, 0xd0, 0x0b, 0x2c, 0x5e, 0xb4, 0x7b, 0x5e,
+	0x69, 0x57, 0xe3, 0xa6, 0xd9, 0x92, 0xe4, 0x3d, 0x87, 0x41, 0x25, 0x81, 0xbe, 0x34, 0x03, 0xd3,
+	0xe1, 0x9a, 0x91, 0xbc, 0xdb, 0x7e, 0x8e, 0x2c, 0x88, 0xf7, 0x06, 0x2e, 0xde, 0xed, 0xd5, 0x0c,
+	0x2b, 0xfc, 0x9b, 0xc2, 0x2a, 0x93, 0x65, 0xdd, 0x5b, 0xe8, 0x6c, 0x22, 0x1e, 0x3c, 0x14, 0x55,
+	0xaf, 0x8a, 0xaa, 0x2f, 0x34, 0x5a, 0xdf, 0x93, 0x33, 0xbd, 0xbf, 0x00, 0x9d, 0x66, 0xd1, 0xe7,
+	0xd0, 0x37, 0xf9, 0x75, 0x92, 0xc5, 0xe6, 0x00, 0x2d, 0xbf, 0x67, 0x80, 0x65, 0x16, 0xa3, 0x4b,
+	0xe8, 0xa4, 0x99, 0x08, 0x29, 0x31, 0x13, 0xef, 0xf9, 0x79, 0x84, 0xbe, 0x87, 0x6e, 0xcc, 0xa4,
+	0x64, 0x49, 0xe8, 0x36, 0x4d, 0xfd, 0xcb, 0xa2, 0xfe, 0x2f, 0x16, 0xce, 0x6b, 0xf8, 0x05, 0xcd,
+	0xfb, 0xdb, 0x81, 0x61, 0x3d, 0x87, 0x2e, 0xa0, 0xa3, 0x0e, 0x95, 0xb2, 0x6d, 0x75, 0xd0, 0x35,
+	0xcf, 0xa1, 0xad, 0x0e, 0x6b, 0x66, 0x4b, 0xf6, 0xfd, 0x96, 0x3a, 0x2c, 0x08, 0xfa, 0x02, 0xfa,
+	0xda, 0x03, 0x32, 0xc5, 0x01, 0xcd, 0x65, 0x3e, 0x02, 0xe8, 0x2b, 0x80, 0x80, 0x47, 0x11, 0x35,
+	0x26, 0xcd, 0x05, 0xaf, 0x20, 0xe8, 0x0a, 0x7a, 0x34, 0x62, 0x21, 0xdb, 0x44, 0xd4, 0x28, 0xdf,
+	0xf3, 0xcb, 0xd8, 0x9b, 0xc1, 0xf9, 0x22, 0x21, 0xf4, 0xf0, 0x68, 0xc0, 0xdf, 0x41, 0x97, 0x69,
+	0x98, 0x9e, 0xe8, 0x5a, 0x65, 0x17, 0x1c, 0xef, 0x9f, 0x06, 0x0c, 0x2a, 0x09, 0xad, 0x2b, 0xa1,
+	0x92, 0x85, 0xc9, 0x9a, 0xf0, 0xa0, 0xd0, 0xd5, 0x22, 0x33, 0x1e, 0x94, 0xf6, 0x6e, 0x54, 0xec,
+	0x8d, 0xa0, 0xb5, 0x65, 0x51, 0xd1, 0x9d, 0x59, 0xeb, 0xc6, 0x08, 0xdd, 0xb2, 0x84, 0x55, 0x1b,
+	0x3b, 0x22, 0xda, 0x06, 0xd2, 0x14, 0x34, 0x6d, 0x0d, 0x8f, 0x36, 0xa8, 0x9c, 0x65, 0x5c, 0xd8,
+	0xc0, 0x32, 0xf5, 0xc5, 0xa0, 0x42, 0x70, 0x91, 0xfb, 0xdd, 0x06, 0xde, 0x07, 0xe8, 0xe4, 0x47,
+	0x1f, 0x40, 0xf7, 0xfd, 0xf2, 0xcd, 0xf2, 0xfe, 0xc3, 0x72, 0xf4, 0x99, 0x0e, 0xa6, 0xfe, 0xfc,
+	0x6e, 0x35, 0x9f, 0x8d, 0x1c, 0x93, 0x79, 0x37, 0x33, 0x41, 0x03, 0x3d, 0x81, 0xfe, 0xfb, 0xe5,
+	0xf4, 0xf5, 0xdd, 0xf2, 0xd5, 0x7c, 0x36, 0x6a, 0xea, 0xdc, 0x6c, 0xfe, 0x76, 0xae, 0x73, 0x2d,
+	0x04, 0xd0, 0x79, 0x79, 0xb7, 0x78, 0x3b, 0x9f, 0x8d, 0xda, 0xde, 0xbf, 0x0e, 0x3c, 0x5d, 0x1d,
+	0xea, 0xb7, 0xe6, 0x67, 0x38, 0xab, 0xbc, 0x2a, 0x27, 0x1e, 0x36, 0x87, 0xa7, 0x64, 0x75, 0xa4,
+	0xf8, 0x35, 0xbe, 0xd6, 0x73, 0xc3, 0xf9, 0x43, 0x8c, 0xc5, 0x43, 0x3e, 0xc2, 0x32, 0x46, 0xcf,
+	0x01, 0x59, 0x51, 0xc8, 0x7a, 0x2b, 0x78, 0xbc, 0x36, 0x5e, 0x36, 0x43, 0x6d, 0xf9, 0xa3, 0x3c,
+	0xf3, 0x52, 0xf0, 0xd8, 0x5c, 0x05, 0xef, 0x7f, 0x07, 0xd0, 0x69, 0xb9, 0xa3, 0x07, 0x9d, 0x8a,
+	0x07, 0x6b, 0x37, 0xa5, 0xf1, 0xe8, 0xa6, 0x1c, 0xcd, 0xdc, 0xac, 0x9a, 0xf9, 0x27, 0xe8, 0x97,
+	0xaf, 0xaa, 0xd1, 0x4f, 0xb7, 0x69, 0xdf, 0xdd, 0x71, 0xf1, 0xee, 0x8e, 0x57, 0x05, 0xc3, 0x3f,
+	0x92, 0xd1, 0x37, 0x70, 0x16, 0x08, 0x8a, 0x15, 0x17, 0xeb, 0x1d, 0x96, 0x3b, 0x23, 0xf0, 0x99,
+	0x3f, 0xc8, 0xb1, 0xd7, 0x58, 0xee, 0xd0, 0x1d, 0x3c, 0xdd, 0xe3, 0x88, 0x11, 0xac, 0xcf, 0xbc,
+	0xd6, 0x6f, 0xa7, 0xd1, 0x74, 0x78, 0xeb, 0x16, 0x93, 0x5c, 0x1d, 0x7e, 0x2f, 0x09, 0x53, 0x4e,
+	0xa8, 0x3f, 0xdc, 0xd7, 0xe2, 0x17, 0xf7, 0xe0, 0x71, 0x11, 0x8e, 0x77, 0x1f, 0x53, 0x2a, 0x22,
+	0x4a, 0x42, 0x2a, 0xc6, 0x5b, 0xbc, 0x11, 0x2c, 0x28, 0xfe, 0x41, 0x7f, 0x02, 0xfe, 0xf8, 0x36,
+	0x64, 0x6a, 0x97, 0x6d, 0xc6, 0x01, 0x8f, 0x27, 0x15, 0xea, 0xc4, 0x52, 0xed, 0x07, 0x44, 0x4e,
+	0x34, 0x75, 0x63, 0x3f, 0x2e, 0x3f, 0x7c, 0x1a, 0x00, 0x03, 0x62, 0x67, 0x98, 0x77, 0x06, 0x00,
+	0x00,
 }